@@ -1,4 +1,11 @@
-import type { BatchEvalContext, BatchEvalResult, EvalContext, EvalResult } from './types'
+import type {
+  BatchEvalContext,
+  BatchEvalResult,
+  EvalContext,
+  EvalPreviewRequest,
+  EvalPreviewResult,
+  EvalResult,
+} from './types'
 import type { ApiResult } from './result'
 import { requestJson } from './http'
 
@@ -8,4 +15,9 @@ export const postEvaluation = (body: EvalContext): Promise<ApiResult<EvalResult>
 export const postEvaluationBatch = (
   body: BatchEvalContext,
 ): Promise<ApiResult<BatchEvalResult>> =>
-  requestJson<BatchEvalResult>({ method: 'POST', path: '/evaluation/batch', body })
\ No newline at end of file
+  requestJson<BatchEvalResult>({ method: 'POST', path: '/evaluation/batch', body })
+
+export const postEvaluationPreview = (
+  body: EvalPreviewRequest,
+): Promise<ApiResult<EvalPreviewResult>> =>
+  requestJson<EvalPreviewResult>({ method: 'POST', path: '/evaluation/preview', body })
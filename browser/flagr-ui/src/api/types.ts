@@ -36,9 +36,17 @@ export interface Segment {
   rolloutPercent: number
   rank?: number
   constraints?: Constraint[]
+  constraintGroups?: ConstraintGroup[]
   distributions?: Distribution[]
 }
 
+/** swagger: constraintGroup */
+export interface ConstraintGroup {
+  operator: 'AND' | 'OR' | 'NOT'
+  constraints?: Constraint[]
+  groups?: ConstraintGroup[]
+}
+
 export interface IdentifiedSegment extends Segment {
   id: number
 }
@@ -164,12 +172,67 @@ export interface BatchEvalContext {
   flagIDs?: number[]
 }
 
+/** swagger: evaluationPreviewRequest */
+export interface EvalPreviewRequest {
+  flag: Flag
+  evalContexts: EvalContext[]
+}
+
+/** swagger: evaluationPreviewResponse */
+export interface EvalPreviewResult {
+  evalResults: EvalResult[]
+  warnings?: string[]
+}
+
 export type EvalRecordSource = 'evaluation' | 'exposure'
 
+/** swagger: constraintDebugLog */
+export interface ConstraintDebugLog {
+  constraintID?: number
+  property?: string
+  operator?: string
+  expected?: unknown
+  actual?: unknown
+  matched: boolean
+  error?: string
+}
+
+/** swagger: constraintGroupDebugLog */
+export interface ConstraintGroupDebugLog {
+  operator?: string
+  matched: boolean
+  constraints?: ConstraintDebugLog[]
+  groups?: ConstraintGroupDebugLog[]
+}
+
+/** swagger: distributionDebugLog */
+export interface DistributionDebugLog {
+  variantID?: number
+  variantKey?: string
+  percent: number
+  bucketStart: number
+  bucketEnd: number
+}
+
+/** swagger: rolloutDebugLog */
+export interface RolloutDebugLog {
+  rolledOut: boolean
+  reason?: string
+  bucketNum: number
+  rolloutPercent: number
+  variantID?: number
+  variantKey?: string
+  distributions?: DistributionDebugLog[]
+}
+
 /** swagger: segmentDebugLog */
 export interface SegmentDebugLog {
   segmentID?: number
   msg?: string
+  matched?: boolean
+  constraints?: ConstraintDebugLog[]
+  groups?: ConstraintGroupDebugLog[]
+  rollout?: RolloutDebugLog
 }
 
 /** swagger: evalDebugLog */
@@ -192,11 +255,14 @@ export interface EvalResult {
   evalContext?: EvalContext
   timestamp?: string
   evalDebugLog?: EvalDebugLog
+  /** set on batch results not evaluated before the batch deadline */
+  error?: string
 }
 
 /** swagger: evaluationBatchResponse */
 export interface BatchEvalResult {
   evaluationResults: EvalResult[]
+  partial?: boolean
 }
 
 export interface EvalSummaryConstraint {
@@ -204,7 +270,9 @@ export interface EvalSummaryConstraint {
   constraintProperty?: string
   constraintOperator?: string
   constraintValue?: string
+  actualValue?: string
   matched?: boolean
+  error?: string
 }
 
 /** UI table derived from evalDebugLog.segmentDebugLogs. */
@@ -215,6 +283,7 @@ export interface EvalSummarySegment {
   matched?: boolean
   msg?: string
   constraints: EvalSummaryConstraint[]
+  rollout?: RolloutDebugLog
 }
 
 export interface EvalSummary {
@@ -226,6 +295,8 @@ export interface EvalSummary {
 export type OperatorValue =
   | 'EQ' | 'NEQ' | 'LT' | 'LTE' | 'GT' | 'GTE'
   | 'EREG' | 'NEREG' | 'IN' | 'NOTIN' | 'CONTAINS' | 'NOTCONTAINS'
+  | 'ANY_IN' | 'ALL_IN' | 'NONE_IN'
+  | 'STARTS_WITH' | 'ENDS_WITH' | 'EQ_CI' | 'IN_CI' | 'CONTAINS_CI'
 
 /** Editable segment fields in SegmentsSection. */
 export type SegmentFieldKey = 'description' | 'rolloutPercent'
@@ -6,10 +6,10 @@ import {
 } from './constraintOperators'
 
 describe('constraintOperators', () => {
-  it('exposes 12 API operators plus 2 UI sugar options from operators.json', () => {
-    expect(OPERATOR_UI_OPTIONS).toHaveLength(14)
+  it('exposes 20 API operators plus 2 UI sugar options from operators.json', () => {
+    expect(OPERATOR_UI_OPTIONS).toHaveLength(22)
     const apiCount = OPERATOR_UI_OPTIONS.filter((o) => !o.uiOnly).length
-    expect(apiCount).toBe(12)
+    expect(apiCount).toBe(20)
     expect(OPERATOR_UI_OPTIONS.find((o) => o.value === 'EQ')?.exprToken).toBe('==')
     expect(OPERATOR_UI_OPTIONS.find((o) => o.value === 'UI_STRING_CONTAINS')?.persistAs).toBe('EREG')
   })
@@ -21,6 +21,13 @@ describe('constraintOperators', () => {
     expect(op?.description).toMatch(/Not substring/i)
   })
 
+  it('findOperatorUi resolves native string operators', () => {
+    expect(findOperatorUi('ENDS_WITH')?.group).toBe('Text (simple)')
+    expect(findOperatorUi('ENDS_WITH')?.description).toMatch(/no regex/i)
+    expect(findOperatorUi('EQ_CI')?.group).toBe('Compare')
+    expect(findOperatorUi('IN_CI')?.valuePlaceholder).toBe('["us","ca"]')
+  })
+
   it('findOperatorUi resolves text includes sugar', () => {
     const op = findOperatorUi('UI_STRING_CONTAINS')
     expect(op?.label).toBe('Text includes')
@@ -0,0 +1,85 @@
+import { describe, expect, it } from 'vitest'
+import { debugValueText, evalSummaryFromResult } from './evaluation'
+
+describe('evalSummaryFromResult', () => {
+  it('returns null without a flag', () => {
+    expect(evalSummaryFromResult({})).toBeNull()
+  })
+
+  it('maps structured segment debug logs', () => {
+    const summary = evalSummaryFromResult({
+      flagID: 1,
+      variantKey: 'control',
+      evalDebugLog: {
+        segmentDebugLogs: [
+          {
+            segmentID: 1,
+            msg: 'constraint not match.',
+            matched: false,
+            constraints: [
+              { constraintID: 3, property: 'country', operator: 'EQ', expected: 'US', actual: 'CA', matched: false },
+              { constraintID: 4, property: 'tier', operator: 'IN', expected: ['gold'], matched: false },
+            ],
+          },
+          {
+            segmentID: 2,
+            matched: true,
+            constraints: [],
+            rollout: { rolledOut: true, bucketNum: 42, rolloutPercent: 20, variantKey: 'control' },
+          },
+        ],
+      },
+    })
+
+    expect(summary?.variantKey).toBe('control')
+    const [first, second] = summary?.segments ?? []
+    expect(first.matched).toBe(false)
+    expect(first.msg).toBe('constraint not match.')
+    expect(first.constraints).toEqual([
+      {
+        constraintID: 3,
+        constraintProperty: 'country',
+        constraintOperator: 'EQ',
+        constraintValue: '"US"',
+        actualValue: '"CA"',
+        matched: false,
+        error: undefined,
+      },
+      {
+        constraintID: 4,
+        constraintProperty: 'tier',
+        constraintOperator: 'IN',
+        constraintValue: '["gold"]',
+        actualValue: '(missing)',
+        matched: false,
+        error: undefined,
+      },
+    ])
+    expect(second.matched).toBe(true)
+    expect(second.rolloutPercent).toBe(20)
+    expect(second.rollout?.bucketNum).toBe(42)
+  })
+
+  it('keeps message-only logs from older servers', () => {
+    const summary = evalSummaryFromResult({
+      flagKey: 'k',
+      evalDebugLog: { segmentDebugLogs: [{ segmentID: 1, msg: 'matched all constraints.' }] },
+    })
+    expect(summary?.segments[0]).toEqual({
+      segmentID: 1,
+      rolloutPercent: undefined,
+      matched: undefined,
+      msg: 'matched all constraints.',
+      constraints: [],
+      rollout: undefined,
+    })
+  })
+})
+
+describe('debugValueText', () => {
+  it('formats values as JSON', () => {
+    expect(debugValueText(undefined)).toBe('(missing)')
+    expect(debugValueText(null)).toBe('null')
+    expect(debugValueText(30)).toBe('30')
+  })
+})
@@ -40,6 +40,11 @@ export function parseBatchEvalResultJson(text: string): BatchEvalResult | null {
   }
 }
 
+/** Compact JSON for a constraint value in the summary; absent values show as "(missing)". */
+export function debugValueText(value: unknown): string {
+  return value === undefined ? '(missing)' : JSON.stringify(value)
+}
+
 /** Parse evaluation debug payload from POST /evaluation (swagger evalDebugLog). */
 export function evalSummaryFromResult(result: EvalResult): EvalSummary | null {
   if (result.flagID == null && result.flagKey == null) return null
@@ -47,8 +52,19 @@ export function evalSummaryFromResult(result: EvalResult): EvalSummary | null {
   const log: EvalDebugLog | undefined = result.evalDebugLog
   const segments: EvalSummarySegment[] = (log?.segmentDebugLogs ?? []).map((seg) => ({
     segmentID: seg.segmentID,
+    rolloutPercent: seg.rollout?.rolloutPercent,
+    matched: seg.matched,
     msg: seg.msg,
-    constraints: [],
+    constraints: (seg.constraints ?? []).map((c) => ({
+      constraintID: c.constraintID,
+      constraintProperty: c.property,
+      constraintOperator: c.operator,
+      constraintValue: debugValueText(c.expected),
+      actualValue: debugValueText(c.actual),
+      matched: c.matched,
+      error: c.error,
+    })),
+    rollout: seg.rollout,
   }))
 
   return {
@@ -1,4 +1,5 @@
 import constants from '@/helpers/constants'
+import { materializeConstraintForApi } from '@/helpers/constraintOperatorSugar'
 import type { Flag, FlagView, Segment, Variant, VariantAttachment } from '@/api/types'
 
 export interface EntityTypeOption {
@@ -69,3 +70,23 @@ export function normalizeFlag(flag: Flag): FlagView {
   }
   return f as FlagView
 }
+
+/**
+ * The flag as currently edited on the flag page, in the shape POST /evaluation/preview
+ * accepts: UI operator sugar is materialized and attachments that are not valid JSON
+ * yet are left out.
+ */
+export function previewFlagFromView(flag: FlagView): Flag {
+  return {
+    ...flag,
+    variants: flag.variants.map((v) => ({
+      id: v.id,
+      key: v.key,
+      attachment: typeof v.attachment === 'string' ? undefined : v.attachment,
+    })),
+    segments: flag.segments.map((s) => ({
+      ...s,
+      constraints: (s.constraints ?? []).map((c) => materializeConstraintForApi(c)),
+    })),
+  }
+}
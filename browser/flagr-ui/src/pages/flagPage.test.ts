@@ -3,8 +3,10 @@ import type { FlagPageVm } from './flagPage'
 import {
   applyDeepLink,
   mountFlagPage,
+  previewEvaluation,
   scrollToSnapshot,
 } from './flagPage'
+import * as evalApi from '@/api/eval'
 import { SNAPSHOT_HIGHLIGHT_MS } from '@/helpers/copyText'
 import { FLAG_TAB_CONFIG, FLAG_TAB_HISTORY, snapshotElementId } from '@/helpers/shareLinks'
 
@@ -13,6 +15,10 @@ vi.mock('@/api/crud', () => ({
   listFlagSnapshots: vi.fn(() => new Promise(() => {})),
 }))
 
+vi.mock('@/api/eval', () => ({
+  postEvaluationPreview: vi.fn(),
+}))
+
 function minimalVm(overrides: Partial<FlagPageVm> = {}): FlagPageVm {
   return {
     flagId: '42',
@@ -139,3 +145,55 @@ describe('scrollToSnapshot', () => {
     expect(scrollToSnapshot(999)).toBe(false)
   })
 })
+
+describe('previewEvaluation', () => {
+  beforeEach(() => {
+    vi.clearAllMocks()
+  })
+
+  it('posts the edited flag and shows the first result', async () => {
+    const result = { flagKey: 'draft', variantKey: 'on', evalDebugLog: { msg: '' } }
+    const post = vi.mocked(evalApi.postEvaluationPreview)
+    post.mockResolvedValue({ ok: true, value: { evalResults: [result] } })
+    const vm = minimalVm({
+      flag: {
+        key: 'draft',
+        description: '',
+        tags: [],
+        variants: [
+          { id: 1, key: 'on', attachment: { color: 'blue' }, attachmentValid: true },
+          { id: 2, key: 'off', attachment: '{"half', attachmentValid: false },
+        ],
+        segments: [],
+      },
+    })
+    const ctx = { entityID: 'e1', entityContext: { state: 'CA' } }
+
+    previewEvaluation(vm, ctx)
+    await vi.waitFor(() => expect(vm.$message.success).toHaveBeenCalled())
+
+    expect(post).toHaveBeenCalledWith({
+      flag: expect.objectContaining({
+        key: 'draft',
+        variants: [
+          { id: 1, key: 'on', attachment: { color: 'blue' } },
+          { id: 2, key: 'off', attachment: undefined },
+        ],
+      }),
+      evalContexts: [ctx],
+    })
+    expect(vm.evalResult).toEqual(result)
+  })
+
+  it('shows warnings', async () => {
+    vi.mocked(evalApi.postEvaluationPreview).mockResolvedValue({
+      ok: true,
+      value: { evalResults: [{}], warnings: ['flag "draft": no segments defined'] },
+    })
+    const vm = minimalVm()
+
+    previewEvaluation(vm, {})
+    await vi.waitFor(() => expect(vm.$message.warning).toHaveBeenCalled())
+    expect(vm.$message.success).not.toHaveBeenCalled()
+  })
+})
@@ -2,7 +2,12 @@ import { nextTick } from 'vue'
 import type { Router } from 'vue-router'
 import * as evalApi from '@/api/eval'
 import * as crudApi from '@/api/crud'
-import { variantUsedInDistribution, normalizeFlag, normalizeSegment } from '@/helpers/flagModel'
+import {
+  variantUsedInDistribution,
+  normalizeFlag,
+  normalizeSegment,
+  previewFlagFromView,
+} from '@/helpers/flagModel'
 import { evalSummaryFromResult } from '@/helpers/evaluation'
 import type { EntityTypeOption } from '@/helpers/flagModel'
 import type {
@@ -540,6 +545,23 @@ export function postEvaluation(vm: FlagPageVm, evalContext: EvalContext): void {
   })
 }
 
+/** Evaluates the flag as edited on the page, saved or not, via POST /evaluation/preview. */
+export function previewEvaluation(vm: FlagPageVm, evalContext: EvalContext): void {
+  const body = { flag: previewFlagFromView(vm.flag), evalContexts: [evalContext] }
+  runApi(vm, evalApi.postEvaluationPreview(body), {
+    onSuccess: (response) => {
+      const result = response.evalResults[0] ?? {}
+      vm.evalResult = result
+      vm.evalSummary = evalSummaryFromResult(result)
+      if (response.warnings?.length) {
+        vm.$message.warning(`preview: ${response.warnings.join('; ')}`)
+      } else {
+        vm.$message.success('preview success')
+      }
+    },
+  })
+}
+
 export function postEvaluationBatch(vm: FlagPageVm, batchEvalContext: BatchEvalContext): void {
   runApi(vm, evalApi.postEvaluationBatch(batchEvalContext), {
     successMessage: 'evaluation success',
@@ -0,0 +1,266 @@
+// flagr-simulate predicts how a flag change moves traffic between variants.
+// It evaluates a flag, stored or proposed, against a corpus of eval contexts
+// and reports per-segment match counts, per-variant counts with deltas
+// against the current config, and the entities that would switch variants.
+//
+// The current config comes from a Flagr server (POST /evaluation/simulation)
+// or, offline, from a JSON flag file or file_dir directory.
+//
+// Usage:
+//
+//	flagr-simulate -server http://localhost:18000 -flag checkout -proposed checkout.json -contexts sample.jsonl
+//	flagr-simulate -flags flags.json -flag checkout -proposed checkout.json -random 10000
+//	flagr-simulate --help
+//
+// Exit codes:
+//
+//	0 — simulated
+//	1 — errors found
+//	2 — usage error
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/openflagr/flagr/pkg/config"
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/pkg/evaluator"
+	"github.com/openflagr/flagr/pkg/handler"
+	"github.com/openflagr/flagr/swagger_gen/models"
+)
+
+func main() {
+	fs := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	server := fs.String("server", "", "Flagr base URL, e.g. http://localhost:18000, to simulate against its stored flags")
+	flagsPath := fs.String("flags", "", "JSON flag file or file_dir directory to simulate against offline")
+	flagRef := fs.String("flag", "", "key or ID of the stored flag")
+	proposedPath := fs.String("proposed", "", "JSON file with the proposed flag definition")
+	contextsPath := fs.String("contexts", "", `JSONL file with one evalContext per line ("-" for stdin)`)
+	random := fs.Int64("random", 0, "simulate this many random entity IDs instead of -contexts")
+	seed := fs.Int64("seed", 0, "seed for -random")
+	entityType := fs.String("entity-type", "", "entityType for -random")
+	entityContext := fs.String("entity-context", "", "entityContext JSON object for -random")
+	switchedLimit := fs.Int64("switched-limit", 100, "maximum number of switched entities to list")
+	jsonOutput := fs.Bool("json", false, "print the evaluationSimulationResponse JSON")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s (-server URL | -flags path) [-flag key|id] [-proposed flag.json] (-contexts corpus.jsonl | -random N)\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\nSimulates a stored or proposed flag over a corpus of eval contexts and\n")
+		fmt.Fprintf(os.Stderr, "compares the variant distribution with the stored flag.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		os.Exit(2)
+	}
+	if fs.NArg() != 0 || (*server == "") == (*flagsPath == "") || (*contextsPath == "") == (*random == 0) ||
+		(*flagRef == "" && *proposedPath == "") {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	req := &models.EvaluationSimulationRequest{SwitchedLimit: switchedLimit}
+	if id, err := strconv.ParseInt(*flagRef, 10, 64); err == nil {
+		req.FlagID = id
+	} else {
+		req.FlagKey = *flagRef
+	}
+	if *proposedPath != "" {
+		b, err := os.ReadFile(*proposedPath)
+		if err != nil {
+			fail("%v", err)
+		}
+		if err := json.Unmarshal(b, &req.Flag); err != nil {
+			fail("%s: invalid JSON: %v", *proposedPath, err)
+		}
+	}
+	if *contextsPath != "" {
+		contexts, err := readContexts(*contextsPath)
+		if err != nil {
+			fail("%v", err)
+		}
+		req.Contexts = contexts
+	} else {
+		req.Synthetic = &models.SimulationSyntheticCorpus{Count: random, Seed: *seed, EntityType: *entityType}
+		if *entityContext != "" {
+			if err := json.Unmarshal([]byte(*entityContext), &req.Synthetic.EntityContext); err != nil {
+				fail("-entity-context: invalid JSON: %v", err)
+			}
+		}
+	}
+
+	var resp *models.EvaluationSimulationResponse
+	var err error
+	if *server != "" {
+		resp, err = simulateOnServer(*server, req)
+	} else {
+		resp, err = simulateOffline(*flagsPath, req)
+	}
+	if err != nil {
+		fail("%v", err)
+	}
+
+	if *jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(resp); err != nil {
+			fail("%v", err)
+		}
+		return
+	}
+	printReport(os.Stdout, resp)
+}
+
+func fail(format string, a ...any) {
+	fmt.Fprintf(os.Stderr, "ERROR: "+format+"\n", a...)
+	os.Exit(1)
+}
+
+// readContexts reads a JSONL corpus, skipping blank lines.
+func readContexts(path string) ([]*models.EvalContext, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var contexts []*models.EvalContext
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; s.Scan(); line++ {
+		b := bytes.TrimSpace(s.Bytes())
+		if len(b) == 0 {
+			continue
+		}
+		c := &models.EvalContext{}
+		if err := json.Unmarshal(b, c); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid evalContext: %v", path, line, err)
+		}
+		contexts = append(contexts, c)
+	}
+	return contexts, s.Err()
+}
+
+func simulateOnServer(server string, req *models.EvaluationSimulationRequest) (*models.EvaluationSimulationResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	url := strings.TrimSuffix(server, "/") + "/api/v1/evaluation/simulation"
+	res, err := http.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		var e models.Error
+		if err := json.NewDecoder(res.Body).Decode(&e); err == nil && e.Message != nil {
+			return nil, fmt.Errorf("%s: %s", res.Status, *e.Message)
+		}
+		return nil, fmt.Errorf("%s from %s", res.Status, url)
+	}
+	resp := &models.EvaluationSimulationResponse{}
+	if err := json.NewDecoder(res.Body).Decode(resp); err != nil {
+		return nil, fmt.Errorf("invalid response from %s: %v", url, err)
+	}
+	return resp, nil
+}
+
+func simulateOffline(path string, req *models.EvaluationSimulationRequest) (*models.EvaluationSimulationResponse, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	var flags []entity.Flag
+	if info.IsDir() {
+		if flags, _, err = handler.ReadFlagsDir(path); err != nil {
+			return nil, err
+		}
+		evaluator.NormalizeIDs(flags)
+	} else {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if flags, err = evaluator.ParseEvalCacheJSON(b); err != nil {
+			return nil, err
+		}
+	}
+	flagSet, err := evaluator.NewFlagSet(flags)
+	if err != nil {
+		return nil, err
+	}
+
+	// the corpus is local, so no server-side limit applies
+	config.Config.EvalSimulationMaxContexts = 0
+	resp, errPayload := handler.SimulateEvaluation(req, flagSet.Get)
+	if errPayload != nil {
+		return nil, fmt.Errorf("%s", *errPayload.Message)
+	}
+	return resp, nil
+}
+
+func printReport(out io.Writer, resp *models.EvaluationSimulationResponse) {
+	compare := resp.CurrentSegments != nil
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%d eval contexts\n\n", *resp.Total)
+
+	fmt.Fprintln(w, "SEGMENT\tDESCRIPTION\tROLLOUT\tMATCHED\t%")
+	for _, s := range resp.Segments {
+		fmt.Fprintf(w, "%d\t%s\t%d%%\t%d\t%.2f%%\n", s.SegmentID, s.Description, s.RolloutPercent, s.Matched, s.Percent)
+	}
+	fmt.Fprintln(w)
+
+	variants := append(resp.Variants, resp.Unassigned)
+	if compare {
+		fmt.Fprintln(w, "VARIANT\tCOUNT\t%\tCURRENT\tCURRENT %\tDELTA\tDELTA %")
+		for _, v := range variants {
+			fmt.Fprintf(w, "%s\t%d\t%.2f%%\t%d\t%.2f%%\t%+d\t%+.2f%%\n",
+				variantName(v.VariantKey), v.Count, v.Percent, v.CurrentCount, v.CurrentPercent, v.Delta, v.PercentDelta)
+		}
+	} else {
+		fmt.Fprintln(w, "VARIANT\tCOUNT\t%")
+		for _, v := range variants {
+			fmt.Fprintf(w, "%s\t%d\t%.2f%%\n", variantName(v.VariantKey), v.Count, v.Percent)
+		}
+	}
+
+	if compare {
+		fmt.Fprintf(w, "\n%d entities switch variants", resp.SwitchedTotal)
+		if int64(len(resp.Switched)) < resp.SwitchedTotal {
+			fmt.Fprintf(w, " (first %d listed)", len(resp.Switched))
+		}
+		fmt.Fprintln(w)
+		if len(resp.Switched) > 0 {
+			fmt.Fprintln(w, "\nENTITY\tFROM\tTO")
+			for _, s := range resp.Switched {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", s.EntityID, variantName(s.CurrentVariantKey), variantName(s.VariantKey))
+			}
+		}
+	}
+
+	for _, warning := range resp.Warnings {
+		fmt.Fprintf(os.Stderr, "WARNING: %s\n", warning)
+	}
+	w.Flush()
+}
+
+func variantName(key string) string {
+	if key == "" {
+		return "(none)"
+	}
+	return key
+}
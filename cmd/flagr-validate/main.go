@@ -1,8 +1,10 @@
-// flagr-validate validates a Flagr JSON flag definition file.
+// flagr-validate validates a Flagr JSON flag definition file, or a directory
+// of JSON/YAML flag files as served by the file_dir driver.
 //
 // Usage:
 //
 //	flagr-validate <flags.json>
+//	flagr-validate <flags-dir/>
 //	flagr-validate --help
 //
 // Exit codes:
@@ -22,27 +24,43 @@ import (
 
 func main() {
 	if len(os.Args) != 2 || os.Args[1] == "--help" || os.Args[1] == "-h" {
-		fmt.Fprintf(os.Stderr, "Usage: %s <flags.json>\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "\nValidates a Flagr JSON flag definition file.\n")
+		fmt.Fprintf(os.Stderr, "Usage: %s <flags.json | flags-dir/>\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\nValidates a Flagr JSON flag definition file, or every\n")
+		fmt.Fprintf(os.Stderr, "*.json/*.yaml/*.yml file under a directory (merged).\n")
 		fmt.Fprintf(os.Stderr, "Checks: valid JSON, required fields, key uniqueness,\n")
 		fmt.Fprintf(os.Stderr, "distribution sums, variant references.\n")
 		os.Exit(2)
 	}
 
 	path := os.Args[1]
-	b, err := os.ReadFile(path)
+	info, err := os.Stat(path)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
 		os.Exit(1)
 	}
 
-	var ecj handler.EvalCacheJSON
-	if err := json.Unmarshal(b, &ecj); err != nil {
-		fmt.Fprintf(os.Stderr, "ERROR: invalid JSON: %v\n", err)
-		os.Exit(1)
-	}
+	var result handler.ValidationResult
+	if info.IsDir() {
+		flags, sources, err := handler.ReadFlagsDir(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+			os.Exit(1)
+		}
+		result = handler.ValidateFlagsWithSources(flags, sources)
+	} else {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+			os.Exit(1)
+		}
 
-	result := handler.ValidateFlags(ecj.Flags)
+		var ecj handler.EvalCacheJSON
+		if err := json.Unmarshal(b, &ecj); err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: invalid JSON: %v\n", err)
+			os.Exit(1)
+		}
+		result = handler.ValidateFlags(ecj.Flags)
+	}
 
 	for _, w := range result.Warnings {
 		fmt.Fprintf(os.Stderr, "WARNING: %s\n", w)
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/DataDog/datadog-go/statsd"
 	"github.com/caarlos0/env"
@@ -18,6 +19,9 @@ import (
 var EvalOnlyModeDBDrivers = map[string]struct{}{
 	"json_file": {},
 	"json_http": {},
+	"file_dir":  {},
+	"json_git":  {},
+	"json_s3":   {},
 }
 
 // Global is the global dependency we can use, such as the new relic app instance
@@ -112,6 +116,13 @@ type prometheusMetrics struct {
 	EvalCounter      *prometheus.CounterVec
 	RequestCounter   *prometheus.CounterVec
 	RequestHistogram *prometheus.HistogramVec
+
+	SpoolRecords          *prometheus.GaugeVec
+	SpoolBytes            *prometheus.GaugeVec
+	SpoolDropped          *prometheus.CounterVec
+	SpoolDeliveryFailures *prometheus.CounterVec
+
+	RecorderDropped *prometheus.CounterVec
 }
 
 func setupPrometheus() {
@@ -126,6 +137,32 @@ func setupPrometheus() {
 			Help: "The total http requests received",
 		}, []string{"status", "path", "method"})
 
+		if len(Config.RecorderSpoolTypes) > 0 {
+			Global.Prometheus.SpoolRecords = promauto.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "flagr_recorder_spool_records",
+				Help: "Records in the spool of a data recorder, not delivered yet",
+			}, []string{"recorder"})
+			Global.Prometheus.SpoolBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "flagr_recorder_spool_bytes",
+				Help: "Bytes of the records in the spool of a data recorder, not delivered yet",
+			}, []string{"recorder"})
+			Global.Prometheus.SpoolDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "flagr_recorder_spool_dropped_total",
+				Help: "Records dropped from the spool of a data recorder before they were delivered",
+			}, []string{"recorder", "reason"})
+			Global.Prometheus.SpoolDeliveryFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "flagr_recorder_spool_delivery_failures_total",
+				Help: "Failed deliveries of batches from the spool of a data recorder, retried later",
+			}, []string{"recorder"})
+		}
+
+		if slices.Contains(Config.RecorderType, "nats") {
+			Global.Prometheus.RecorderDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: "flagr_recorder_dropped_total",
+				Help: "Records a data recorder dropped rather than block the evaluation, e.g. while its broker is slow",
+			}, []string{"recorder"})
+		}
+
 		if Config.PrometheusIncludeLatencyHistogram {
 			Global.Prometheus.RequestHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
 				Name: "flagr_requests_buckets",
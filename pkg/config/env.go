@@ -56,10 +56,31 @@ var Config = struct {
 	// - With 2 entities and 2 tags (~100 flags each): 2 * 100 = 200 evaluations
 	// A reasonable limit might be 500-1000 for typical use cases.
 	EvalBatchSize int `env:"FLAGR_EVAL_BATCH_SIZE" envDefault:"0"`
+	// EvalBatchWorkers - number of workers evaluating the entity and flag pairs of a single batch request
+	// in parallel, unless the request sets its own "workers". 1 evaluates them one after the other in the
+	// request goroutine (default).
+	EvalBatchWorkers int `env:"FLAGR_EVAL_BATCH_WORKERS" envDefault:"1"`
+	// EvalBatchMaxWorkers - maximum number of extra worker goroutines of all batch requests together. A request
+	// that cannot get more workers under the cap evaluates with fewer, down to its own goroutine.
+	EvalBatchMaxWorkers int `env:"FLAGR_EVAL_BATCH_MAX_WORKERS" envDefault:"64"`
+	// EvalBatchTimeout - deadline of a batch request. Pairs not evaluated by then are returned with an error,
+	// and the response is marked partial. Set to 0 to disable (default).
+	EvalBatchTimeout time.Duration `env:"FLAGR_EVAL_BATCH_TIMEOUT" envDefault:"0"`
+	// EvalSimulationMaxContexts - maximum number of eval contexts, given or synthetic, in a single
+	// POST /evaluation/simulation request. Set to 0 to disable the limit.
+	EvalSimulationMaxContexts int `env:"FLAGR_EVAL_SIMULATION_MAX_CONTEXTS" envDefault:"100000"`
 	// EvalGetMaxURLBytes - maximum length of the raw query string on GET /evaluation and GET /evaluation/batch.
 	// Set to 0 to disable (default 8192). Exceeding the limit returns 400; use POST when payloads are large.
 	EvalGetMaxURLBytes int `env:"FLAGR_EVAL_GET_MAX_URL_BYTES" envDefault:"8192"`
 
+	// GRPCEnabled - serve the flagr.v1.EvaluationService gRPC service (with the gRPC health and reflection services)
+	// on its own port, next to the REST API.
+	GRPCEnabled bool `env:"FLAGR_GRPC_ENABLED" envDefault:"false"`
+	// GRPCHost - gRPC server host. Empty binds on the same host as the REST API (HOST).
+	GRPCHost string `env:"FLAGR_GRPC_HOST" envDefault:""`
+	// GRPCPort - gRPC server port
+	GRPCPort int `env:"FLAGR_GRPC_PORT" envDefault:"18001"`
+
 	// InjectedContextEnabled - enables built-in context injection into entityContext.
 	// When true, @ts, @ts_hour, @ts_weekday, @ts_month are always injected.
 	// HTTP headers listed in InjectedContextHTTPHeaders are injected as @http_* keys.
@@ -77,10 +98,14 @@ var Config = struct {
 	// ExposureBatchSize - maximum exposures per POST /exposures request.
 	ExposureBatchSize int `env:"FLAGR_EXPOSURE_BATCH_SIZE" envDefault:"100"`
 
+	// MetricEventBatchSize - maximum metric events per POST /metrics/events request.
+	MetricEventBatchSize int `env:"FLAGR_METRIC_EVENT_BATCH_SIZE" envDefault:"1000"`
+
 	/**
 	DBDriver and DBConnectionStr define how we can write and read flags data.
 	For databases, flagr supports sqlite3, mysql and postgres.
-	For read-only evaluation, flagr supports file and http.
+	For read-only evaluation, flagr supports file, http, a directory of JSON/YAML files, a git repository
+	and S3-compatible object storage.
 
 	Examples:
 
@@ -93,6 +118,9 @@ var Config = struct {
 
 	"json_file"           "/tmp/flags.json"                    # (it automatically sets EvalOnlyMode=true)
 	"json_http"           "https://example.com/flags.json"     # (it automatically sets EvalOnlyMode=true)
+	"file_dir"            "/etc/flagr/flags/"                  # (it automatically sets EvalOnlyMode=true)
+	"json_git"            "https://github.com/org/flags.git"   # (it automatically sets EvalOnlyMode=true, see DBGit* below)
+	"json_s3"             "s3://bucket/path/flags.json"        # (it automatically sets EvalOnlyMode=true, see DBS3* below)
 
 	*/
 	DBDriver        string `env:"FLAGR_DB_DBDRIVER" envDefault:"sqlite3"`
@@ -104,6 +132,26 @@ var Config = struct {
 	DBConnectionRetryAttempts uint          `env:"FLAGR_DB_DBCONNECTION_RETRY_ATTEMPTS" envDefault:"9"`
 	DBConnectionRetryDelay    time.Duration `env:"FLAGR_DB_DBCONNECTION_RETRY_DELAY" envDefault:"100ms"`
 
+	// DBGitRef - branch, tag or commit SHA the json_git driver loads flags from
+	DBGitRef string `env:"FLAGR_DB_GIT_REF" envDefault:"main"`
+	// DBGitPath - path of the flags inside the json_git repository. A file is read as a single
+	// JSON/YAML flag file, a directory is read like the file_dir driver.
+	DBGitPath string `env:"FLAGR_DB_GIT_PATH" envDefault:"flags.json"`
+	// DBGitCloneDir - where the json_git driver keeps its local bare clone.
+	// Defaults to a new temporary directory on every start, removed on shutdown. Set it to
+	// reuse the clone across restarts.
+	DBGitCloneDir string `env:"FLAGR_DB_GIT_CLONE_DIR" envDefault:""`
+
+	// DBS3Endpoint - custom endpoint of the json_s3 driver for S3-compatible stores such as MinIO,
+	// e.g. "http://minio:9000". Empty uses AWS S3. Credentials use the standard AWS configuration
+	// (AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY, shared config files, web identity, instance roles).
+	DBS3Endpoint string `env:"FLAGR_DB_S3_ENDPOINT" envDefault:""`
+	// DBS3Region - region of the json_s3 bucket. Empty falls back to AWS_REGION and the shared config.
+	DBS3Region string `env:"FLAGR_DB_S3_REGION" envDefault:""`
+	// DBS3UsePathStyle - address the json_s3 bucket as endpoint/bucket/key instead of
+	// bucket.endpoint/key. Most S3-compatible stores need this.
+	DBS3UsePathStyle bool `env:"FLAGR_DB_S3_USE_PATH_STYLE" envDefault:"false"`
+
 	// CORSEnabled - enable CORS
 	CORSEnabled          bool     `env:"FLAGR_CORS_ENABLED" envDefault:"true"`
 	CORSAllowCredentials bool     `env:"FLAGR_CORS_ALLOW_CREDENTIALS" envDefault:"true"`
@@ -202,8 +250,70 @@ var Config = struct {
 	RecorderPubsubVerbose              bool          `env:"FLAGR_RECORDER_PUBSUB_VERBOSE" envDefault:"false"`
 	RecorderPubsubVerboseCancelTimeout time.Duration `env:"FLAGR_RECORDER_PUBSUB_VERBOSE_CANCEL_TIMEOUT" envDefault:"5s"`
 
+	// NATS JetStream related configurations for data records logging (Flagr Metrics)
+	// RecorderNATSSubject is a template: {flagKey}, {flagID} and {recordSource} are replaced per record
+	RecorderNATSURL             string        `env:"FLAGR_RECORDER_NATS_URL" envDefault:"nats://127.0.0.1:4222"`
+	RecorderNATSSubject         string        `env:"FLAGR_RECORDER_NATS_SUBJECT" envDefault:"flagr.records.{flagKey}"`
+	RecorderNATSStream          string        `env:"FLAGR_RECORDER_NATS_STREAM" envDefault:""` // if set, publishes fail unless they land in this stream
+	RecorderNATSCredsFile       string        `env:"FLAGR_RECORDER_NATS_CREDSFILE" envDefault:""`
+	RecorderNATSCertFile        string        `env:"FLAGR_RECORDER_NATS_CERTFILE" envDefault:""`
+	RecorderNATSKeyFile         string        `env:"FLAGR_RECORDER_NATS_KEYFILE" envDefault:""`
+	RecorderNATSCAFile          string        `env:"FLAGR_RECORDER_NATS_CAFILE" envDefault:""`
+	RecorderNATSVerifySSL       bool          `env:"FLAGR_RECORDER_NATS_VERIFYSSL" envDefault:"false"`
+	RecorderNATSSimpleSSL       bool          `env:"FLAGR_RECORDER_NATS_SIMPLE_SSL" envDefault:"false"`
+	RecorderNATSMaxPending      int           `env:"FLAGR_RECORDER_NATS_MAX_PENDING" envDefault:"4000"`
+	RecorderNATSAckTimeout      time.Duration `env:"FLAGR_RECORDER_NATS_ACK_TIMEOUT" envDefault:"5s"`
+	RecorderNATSShutdownTimeout time.Duration `env:"FLAGR_RECORDER_NATS_SHUTDOWN_TIMEOUT" envDefault:"5s"`
+	RecorderNATSEncrypted       bool          `env:"FLAGR_RECORDER_NATS_ENCRYPTED" envDefault:"false"`
+	RecorderNATSEncryptionKey   string        `env:"FLAGR_RECORDER_NATS_ENCRYPTION_KEY" envDefault:""`
+
+	// RecorderSpoolTypes - comma-separated recorder types whose records are written ahead to a spool on disk and delivered from it in order, e.g. "kafka"
+	RecorderSpoolTypes []string `env:"FLAGR_RECORDER_SPOOL_TYPES" envDefault:"" envSeparator:","`
+	// RecorderSpoolDir - directory of the spools, with a subdirectory per recorder type
+	RecorderSpoolDir string `env:"FLAGR_RECORDER_SPOOL_DIR" envDefault:"/tmp/flagr_spool"`
+	// RecorderSpoolSegmentBytes - size of a spool segment file before the next one is started
+	RecorderSpoolSegmentBytes int64 `env:"FLAGR_RECORDER_SPOOL_SEGMENT_BYTES" envDefault:"16777216"`
+	// RecorderSpoolMaxBytes - size of a spool beyond which its oldest segments are dropped. 0 disables the cap
+	RecorderSpoolMaxBytes int64 `env:"FLAGR_RECORDER_SPOOL_MAX_BYTES" envDefault:"1073741824"`
+	// RecorderSpoolMaxAge - age after which undelivered segments are dropped. 0 disables the cap
+	RecorderSpoolMaxAge time.Duration `env:"FLAGR_RECORDER_SPOOL_MAX_AGE" envDefault:"24h"`
+	// RecorderSpoolSyncInterval - how often spooled records are synced to disk. 0 syncs every record
+	RecorderSpoolSyncInterval time.Duration `env:"FLAGR_RECORDER_SPOOL_SYNC_INTERVAL" envDefault:"1s"`
+	// RecorderSpoolBatchSize - records delivered from a spool at a time
+	RecorderSpoolBatchSize int `env:"FLAGR_RECORDER_SPOOL_BATCH_SIZE" envDefault:"500"`
+	// RecorderSpoolDeliveryTimeout - how long a batch may take to be acknowledged by the sink before it is retried
+	RecorderSpoolDeliveryTimeout time.Duration `env:"FLAGR_RECORDER_SPOOL_DELIVERY_TIMEOUT" envDefault:"30s"`
+	// RecorderSpoolShutdownTimeout - how long shutdown waits for spooled records to be delivered. The rest are delivered after the restart
+	RecorderSpoolShutdownTimeout time.Duration `env:"FLAGR_RECORDER_SPOOL_SHUTDOWN_TIMEOUT" envDefault:"10s"`
+
 	// RecorderDatarFlushInterval - how often to flush in-memory aggregates to DB
 	RecorderDatarFlushInterval time.Duration `env:"FLAGR_RECORDER_DATAR_FLUSH_INTERVAL" envDefault:"60s"`
+	// RecorderDatarAssignmentSource - which records assign entities to variants for metric attribution.
+	// Options: "all" (evaluations and exposures), "exposure" (exposures only, for clients that log impressions)
+	RecorderDatarAssignmentSource string `env:"FLAGR_RECORDER_DATAR_ASSIGNMENT_SOURCE" envDefault:"all"`
+	// RecorderDatarDimensions - comma-separated optional dimensions Datar also counts records by, for queries by tag.
+	// Options: "record_source" (evaluations and exposures, which are counted only with it), "entity_type"
+	RecorderDatarDimensions []string `env:"FLAGR_RECORDER_DATAR_DIMENSIONS" envDefault:"" envSeparator:","`
+	// RecorderDatarSRMAlpha - p-value below which a flag's split of evaluations is a sample ratio mismatch
+	RecorderDatarSRMAlpha float64 `env:"FLAGR_RECORDER_DATAR_SRM_ALPHA" envDefault:"0.001"`
+	// RecorderDatarSRMMinCount - evaluations a segment needs before its split is checked
+	RecorderDatarSRMMinCount int64 `env:"FLAGR_RECORDER_DATAR_SRM_MIN_COUNT" envDefault:"1000"`
+	// RecorderDatarSRMCheckInterval - how often to check all flags and notify on new mismatches. 0 disables it
+	RecorderDatarSRMCheckInterval time.Duration `env:"FLAGR_RECORDER_DATAR_SRM_CHECK_INTERVAL" envDefault:"1h"`
+	// RecorderDatarSRMLookback - time range of the periodic checks
+	RecorderDatarSRMLookback time.Duration `env:"FLAGR_RECORDER_DATAR_SRM_LOOKBACK" envDefault:"24h"`
+	// RecorderDatarHourlyRetentionDays - days hourly counts are kept before they are rolled up into daily counts. 0 keeps them
+	RecorderDatarHourlyRetentionDays int `env:"FLAGR_RECORDER_DATAR_HOURLY_RETENTION_DAYS" envDefault:"0"`
+	// RecorderDatarDailyRetentionDays - days after which hourly and daily counts, assignments and conversions are dropped. 0 keeps them
+	RecorderDatarDailyRetentionDays int `env:"FLAGR_RECORDER_DATAR_DAILY_RETENTION_DAYS" envDefault:"0"`
+	// RecorderDatarCompactionInterval - how often one of the Flagr instances applies the retention
+	RecorderDatarCompactionInterval time.Duration `env:"FLAGR_RECORDER_DATAR_COMPACTION_INTERVAL" envDefault:"1h"`
+	// RecorderDatarPrometheusEnabled - expose the evaluations of each flag in a rolling window as flagr_datar_flag_evaluations. Needs FLAGR_PROMETHEUS_ENABLED
+	RecorderDatarPrometheusEnabled bool `env:"FLAGR_RECORDER_DATAR_PROMETHEUS_ENABLED" envDefault:"false"`
+	// RecorderDatarPrometheusWindow - rolling window of flagr_datar_flag_evaluations, in whole hours
+	RecorderDatarPrometheusWindow time.Duration `env:"FLAGR_RECORDER_DATAR_PROMETHEUS_WINDOW" envDefault:"24h"`
+	// RecorderDatarPrometheusMaxFlags - flags evaluated most that flagr_datar_flag_evaluations has a series of
+	RecorderDatarPrometheusMaxFlags int `env:"FLAGR_RECORDER_DATAR_PROMETHEUS_MAX_FLAGS" envDefault:"100"`
 
 	/**
 	JWTAuthEnabled enables the JWT Auth
@@ -0,0 +1,165 @@
+package datar
+
+import (
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openflagr/flagr/pkg/entity"
+)
+
+// Collector exposes the evaluations of the busiest flags over a rolling window
+// as Prometheus gauges, read from the buffer at each scrape. Unlike
+// flagr_eval_results it has a series per flag only, at most maxFlags of them.
+//
+// The counts in the DB, flushed by every instance, are added by one instance
+// only: the holder of a lock in datar_locks renewed by its scrapes. So the
+// gauges of all instances sum up to the evaluations of the flag, give or
+// take the maxFlags cut of each instance.
+type Collector struct {
+	e           *Engine
+	window      time.Duration
+	maxFlags    int
+	evaluations *prometheus.Desc
+}
+
+// NewCollector returns a Collector of the evaluations of the maxFlags flags
+// evaluated most since the start of the hour window ago.
+func NewCollector(e *Engine, window time.Duration, maxFlags int) *Collector {
+	return &Collector{
+		e:        e,
+		window:   window,
+		maxFlags: maxFlags,
+		evaluations: prometheus.NewDesc(
+			"flagr_datar_flag_evaluations",
+			"Evaluations of the flag in the rolling window of Datar, flushed and buffered",
+			[]string{"FlagID", "FlagKey"},
+			prometheus.Labels{"window": window.String()},
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.evaluations
+}
+
+const (
+	collectorLock = "collector"
+	// collectorLockTTL is how long the instance that last added the flushed
+	// counts keeps adding them without being scraped.
+	collectorLockTTL = 2 * time.Minute
+)
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	flushed, err := c.e.acquireLock(collectorLock, collectorLockTTL)
+	if err != nil {
+		logrus.WithError(err).Warn("Datar: failed to take the collector lock, collecting buffered counts only")
+	}
+	totals, err := c.e.flagTotals(time.Now().Add(-c.window), c.maxFlags, flushed)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.evaluations, err)
+		return
+	}
+	for _, t := range totals {
+		ch <- prometheus.MustNewConstMetric(c.evaluations, prometheus.GaugeValue, float64(t.Count),
+			strconv.FormatInt(t.FlagID, 10), t.FlagKey)
+	}
+}
+
+// flagTotal is the evaluations of a flag in the window of a Collector.
+type flagTotal struct {
+	FlagID  int64
+	FlagKey string
+	Count   int64
+}
+
+// flagTotals returns the buffered evaluations, and the flushed ones too if
+// flushed, since the start of the hour of from of the limit existing flags
+// evaluated most, by count descending.
+func (e *Engine) flagTotals(from time.Time, limit int, flushed bool) ([]flagTotal, error) {
+	if e == nil {
+		return nil, errNilEngine
+	}
+	from = from.Truncate(time.Hour)
+	counts, err := e.bufferedAndFlushed(from, flushed)
+	if err != nil {
+		logrus.WithError(err).Error("Datar: flagTotals failed")
+		return nil, err
+	}
+	if len(counts) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, 0, len(counts))
+	for id := range counts {
+		ids = append(ids, id)
+	}
+	totals, err := e.flagKeys(ids)
+	if err != nil {
+		logrus.WithError(err).Error("Datar: flagTotals flag keys failed")
+		return nil, err
+	}
+	for i := range totals {
+		totals[i].Count = counts[totals[i].FlagID]
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Count != totals[j].Count {
+			return totals[i].Count > totals[j].Count
+		}
+		return totals[i].FlagID < totals[j].FlagID
+	})
+	if limit > 0 && len(totals) > limit {
+		totals = totals[:limit]
+	}
+	return totals, nil
+}
+
+// bufferedAndFlushed returns the evaluations per flag since from in the
+// buffer, and in the DB if flushed. A flush waits for both to be read, so
+// that no count is read in both or in neither.
+func (e *Engine) bufferedAndFlushed(from time.Time, flushed bool) (map[int64]int64, error) {
+	e.flushMu.RLock()
+	defer e.flushMu.RUnlock()
+	counts := make(map[int64]int64)
+	e.buffer.Range(func(k, v any) bool {
+		if key := k.(FlushKey); !key.Hour.Before(from) {
+			counts[key.FlagID] += int64(atomic.LoadInt32(v.(*int32)))
+		}
+		return true
+	})
+	if !flushed {
+		return counts, nil
+	}
+
+	var rows []struct {
+		FlagID int64
+		Count  int64
+	}
+	if err := e.events(from, time.Now().Add(time.Hour)).
+		Select("flag_id, SUM(eval_count) AS count").
+		Group("flag_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		counts[r.FlagID] += r.Count
+	}
+	return counts, nil
+}
+
+// flagKeys returns the flags of ids that exist, without counts.
+func (e *Engine) flagKeys(ids []int64) ([]flagTotal, error) {
+	var totals []flagTotal
+	// MySQL reserves key, but not after a table name.
+	err := e.db.Model(&entity.Flag{}).
+		Select("flags.id AS flag_id, flags.key AS flag_key").
+		Where("flags.id IN ?", ids).
+		Scan(&totals).Error
+	return totals, err
+}
@@ -0,0 +1,122 @@
+package datar
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestCollector(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	createFlag(t, db, 1, "checkout", "flag", true)
+	createFlag(t, db, 2, "search", "flag", true)
+	createFlag(t, db, 3, "banner", "flag", true)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	hour := time.Now().Truncate(time.Hour)
+	require.NoError(t, db.Create(&[]entity.HourlyEvent{
+		{FlagID: 1, VariantID: 1, BucketHour: hour.Add(-time.Hour), EvalCount: 5},
+		{FlagID: 2, VariantID: 1, BucketHour: hour, EvalCount: 2},
+		{FlagID: 2, VariantID: 1, BucketHour: hour.Add(-48 * time.Hour), EvalCount: 1000},
+		{FlagID: 4, VariantID: 1, BucketHour: hour, EvalCount: 50}, // no such flag
+	}).Error)
+	for range 4 {
+		e.Record(2, 1, 0)
+	}
+	e.Record(3, 1, 0)
+
+	// Flushed and buffered evaluations add up.
+	assert.Equal(t, map[string]float64{"checkout": 5, "search": 6, "banner": 1},
+		gatherEvaluations(t, NewCollector(e, 24*time.Hour, 10)))
+	assert.Equal(t, map[string]float64{"search": 6, "checkout": 5},
+		gatherEvaluations(t, NewCollector(e, 24*time.Hour, 2)), "the flags evaluated most")
+
+	e.flush()
+	assert.Equal(t, map[string]float64{"checkout": 5, "search": 6, "banner": 1},
+		gatherEvaluations(t, NewCollector(e, 24*time.Hour, 10)))
+
+	// Other instances add their buffered evaluations only, until the
+	// instance adding the flushed ones shuts down.
+	other := New(db, true, time.Hour)
+	require.NotNil(t, other)
+	defer other.Shutdown()
+	other.Record(3, 1, 0)
+	assert.Equal(t, map[string]float64{"banner": 1},
+		gatherEvaluations(t, NewCollector(other, 24*time.Hour, 10)))
+	require.NoError(t, e.Shutdown())
+	assert.Equal(t, map[string]float64{"checkout": 5, "search": 6, "banner": 2},
+		gatherEvaluations(t, NewCollector(other, 24*time.Hour, 10)))
+}
+
+func TestCollector_ConcurrentFlush(t *testing.T) {
+	t.Parallel()
+	// On disk, so that scrapes and flushes can use connections of their own.
+	db := entity.NewSQLiteDB(filepath.Join(t.TempDir(), "datar.sqlite"))
+	require.NoError(t, db.AutoMigrate(entity.AutoMigrateTables...))
+	createFlag(t, db, 1, "checkout", "flag", true)
+	// Scrapes run while flushes are writing the drained buffer.
+	writing := make(chan struct{})
+	require.NoError(t, db.Callback().Create().Before("gorm:create").Register("writing", func(tx *gorm.DB) {
+		if tx.Statement.Table == "datar_hourly_events" {
+			writing <- struct{}{}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}))
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	c := NewCollector(e, 24*time.Hour, 10)
+	for i := 1; i <= 5; i++ {
+		for range 10 {
+			e.Record(1, 1, 0)
+		}
+		flushed := make(chan struct{})
+		go func() {
+			defer close(flushed)
+			e.flush()
+		}()
+		<-writing
+		assert.Equal(t, map[string]float64{"checkout": float64(10 * i)}, gatherEvaluations(t, c))
+		<-flushed
+	}
+}
+
+func gatherEvaluations(t *testing.T, c prometheus.Collector) map[string]float64 {
+	t.Helper()
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(c))
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	values := map[string]float64{}
+	for _, f := range families {
+		assert.Equal(t, "flagr_datar_flag_evaluations", f.GetName())
+		for _, m := range f.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			assert.Equal(t, "24h0m0s", labels["window"])
+			values[labels["FlagKey"]] = m.GetGauge().GetValue()
+		}
+	}
+	return values
+}
+
+func TestFlagTotals_MySQL(t *testing.T) {
+	t.Parallel()
+	db, queries := newMySQLDryRunDB(t)
+	e := &Engine{db: db}
+	_, _ = e.flagKeys([]int64{1, 2})
+	require.Len(t, *queries, 1)
+	assert.Contains(t, (*queries)[0], "SELECT flags.id AS flag_id, flags.key AS flag_key FROM `flags`")
+}
@@ -0,0 +1,179 @@
+package datar
+
+import (
+	"slices"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openflagr/flagr/pkg/entity"
+)
+
+// Besides the evaluation counters, records can be counted by optional
+// dimensions: the record source, which tells evaluations from exposures, and
+// the entity type. Dimension counts go to their own table, so that the
+// evaluation counters and everything computed from them keep counting
+// evaluations only. Queries by tag aggregate them across flags.
+
+// maxDimensionLength is the longest dimension value kept; longer values are
+// cut.
+const maxDimensionLength = 64
+
+// dimensionKey identifies a count of the dimension buffer.
+type dimensionKey struct {
+	FlagID       int64
+	VariantID    int64
+	SegmentID    int64
+	RecordSource string
+	EntityType   string
+	Hour         time.Time
+}
+
+// DimensionCount is the records of one value of a dimension.
+type DimensionCount struct {
+	Value string
+	Count int64
+}
+
+// TagFlagCount is the records of one flag of a tag.
+type TagFlagCount struct {
+	FlagID  int64
+	FlagKey string
+	Count   int64
+}
+
+// TagSummaryFilter narrows a tag summary to records of a record source
+// and/or entity type. Empty fields match all records.
+type TagSummaryFilter struct {
+	RecordSource string
+	EntityType   string
+}
+
+// TagSummary is the records of the flags of a tag by dimension.
+type TagSummary struct {
+	Tag           string
+	Total         int64
+	Flags         []TagFlagCount   // by count descending
+	RecordSources []DimensionCount // by value, without untracked records
+	EntityTypes   []DimensionCount // by value, without untracked records
+}
+
+// RecordDimensions counts a record by its dimensions. Dimensions that are
+// not tracked are empty. Safe on nil receiver.
+func (e *Engine) RecordDimensions(flagID, variantID, segmentID int64, recordSource, entityType string) {
+	if e == nil || e.closed.Load() {
+		return
+	}
+	if len(entityType) > maxDimensionLength {
+		entityType = entityType[:maxDimensionLength]
+	}
+	key := dimensionKey{
+		FlagID:       flagID,
+		VariantID:    variantID,
+		SegmentID:    segmentID,
+		RecordSource: recordSource,
+		EntityType:   entityType,
+		Hour:         time.Now().Truncate(time.Hour),
+	}
+	actual, _ := e.dimensions.LoadOrStore(key, new(int64))
+	atomic.AddInt64(actual.(*int64), 1)
+}
+
+// flushDimensions writes the buffered dimension counts using additive UPSERT.
+func (e *Engine) flushDimensions() error {
+	var rows []entity.DimensionHourlyEvent
+	now := time.Now()
+	e.dimensions.Range(func(k, v any) bool {
+		key := k.(dimensionKey)
+		rows = append(rows, entity.DimensionHourlyEvent{
+			FlagID:       key.FlagID,
+			BucketHour:   key.Hour,
+			VariantID:    key.VariantID,
+			SegmentID:    key.SegmentID,
+			RecordSource: key.RecordSource,
+			EntityType:   key.EntityType,
+			RecordCount:  atomic.LoadInt64(v.(*int64)),
+			UpdatedAt:    now,
+		})
+		e.dimensions.Delete(k)
+		return true
+	})
+	if len(rows) == 0 {
+		return nil
+	}
+	return withRetry(func() error {
+		return e.db.Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "flag_id"},
+				{Name: "bucket_hour"},
+				{Name: "variant_id"},
+				{Name: "segment_id"},
+				{Name: "record_source"},
+				{Name: "entity_type"},
+			},
+			DoUpdates: clause.Set{
+				{Column: clause.Column{Name: "record_count"}, Value: gorm.Expr(e.addExpr("datar_dimension_hourly_events", "record_count"))},
+				{Column: clause.Column{Name: "updated_at"}, Value: gorm.Expr("CURRENT_TIMESTAMP")},
+			},
+		}).CreateInBatches(&rows, attributionBatchSize).Error
+	})
+}
+
+// QueryTagSummary returns the dimension counts of the flags tagged with tag
+// in the given time range: by flag, record source and entity type.
+func (e *Engine) QueryTagSummary(tag string, from, to time.Time, f TagSummaryFilter) (*TagSummary, error) {
+	if e == nil {
+		return nil, errNilEngine
+	}
+	tagged := e.db.Table("flags_tags").
+		Select("flags_tags.flag_id").
+		Joins("JOIN tags ON tags.id = flags_tags.tag_id").
+		Joins("JOIN flags ON flags.id = flags_tags.flag_id").
+		Where("tags.value = ? AND tags.deleted_at IS NULL AND flags.deleted_at IS NULL", tag)
+	counts := func() *gorm.DB {
+		q := e.db.Model(&entity.DimensionHourlyEvent{}).
+			Where("flag_id IN (?) AND bucket_hour >= ? AND bucket_hour < ?", tagged, from, to)
+		if f.RecordSource != "" {
+			q = q.Where("record_source = ?", f.RecordSource)
+		}
+		if f.EntityType != "" {
+			q = q.Where("entity_type = ?", f.EntityType)
+		}
+		return q
+	}
+
+	summary := &TagSummary{Tag: tag}
+	if err := e.db.Model(&entity.Flag{}).
+		Select("flags.id AS flag_id, flags.key AS flag_key, agg.count").
+		Joins("JOIN (?) AS agg ON agg.flag_id = flags.id",
+			counts().Select("flag_id, SUM(record_count) AS count").Group("flag_id")).
+		Order("agg.count DESC, flags.id").
+		Scan(&summary.Flags).Error; err != nil {
+		logrus.WithError(err).Error("Datar: QueryTagSummary flags failed")
+		return nil, err
+	}
+	for _, fc := range summary.Flags {
+		summary.Total += fc.Count
+	}
+
+	for column, dst := range map[string]*[]DimensionCount{
+		"record_source": &summary.RecordSources,
+		"entity_type":   &summary.EntityTypes,
+	} {
+		if err := counts().
+			Select(column + " AS value, SUM(record_count) AS count").
+			Group(column).
+			Scan(dst).Error; err != nil {
+			logrus.WithError(err).Error("Datar: QueryTagSummary dimensions failed")
+			return nil, err
+		}
+		// Records without the dimension were counted while it was not tracked.
+		*dst = slices.DeleteFunc(*dst, func(c DimensionCount) bool { return c.Value == "" })
+		sort.Slice(*dst, func(i, j int) bool { return (*dst)[i].Value < (*dst)[j].Value })
+	}
+	return summary, nil
+}
@@ -0,0 +1,102 @@
+package datar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryTagSummary(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	checkout, other := entity.Tag{Value: "checkout"}, entity.Tag{Value: "other"}
+	require.NoError(t, db.Create(&checkout).Error)
+	require.NoError(t, db.Create(&other).Error)
+	for _, f := range []*entity.Flag{
+		{Key: "cart", Tags: []entity.Tag{checkout}},
+		{Key: "payment", Tags: []entity.Tag{checkout, other}},
+		{Key: "search", Tags: []entity.Tag{other}},
+		{Key: "deleted", Tags: []entity.Tag{checkout}},
+	} {
+		require.NoError(t, db.Create(f).Error)
+	}
+	require.NoError(t, db.Delete(&entity.Flag{}, 4).Error)
+
+	for range 3 {
+		e.RecordDimensions(1, 1, 1, "evaluation", "user")
+	}
+	for range 2 {
+		e.RecordDimensions(1, 1, 1, "exposure", "user")
+	}
+	e.RecordDimensions(1, 1, 0, "", "") // before the dimensions were tracked
+	e.RecordDimensions(2, 3, 2, "evaluation", "device")
+	e.RecordDimensions(3, 5, 0, "evaluation", "user")
+	e.RecordDimensions(4, 7, 0, "evaluation", "user")
+	e.flush()
+
+	from, to := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	summary, err := e.QueryTagSummary("checkout", from, to, TagSummaryFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, &TagSummary{
+		Tag:   "checkout",
+		Total: 7,
+		Flags: []TagFlagCount{
+			{FlagID: 1, FlagKey: "cart", Count: 6},
+			{FlagID: 2, FlagKey: "payment", Count: 1},
+		},
+		RecordSources: []DimensionCount{{Value: "evaluation", Count: 4}, {Value: "exposure", Count: 2}},
+		EntityTypes:   []DimensionCount{{Value: "device", Count: 1}, {Value: "user", Count: 5}},
+	}, summary)
+
+	summary, err = e.QueryTagSummary("checkout", from, to, TagSummaryFilter{RecordSource: "exposure"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), summary.Total)
+	assert.Equal(t, []TagFlagCount{{FlagID: 1, FlagKey: "cart", Count: 2}}, summary.Flags)
+
+	summary, err = e.QueryTagSummary("checkout", from, to, TagSummaryFilter{EntityType: "device"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), summary.Total)
+
+	// Counts add up across flushes.
+	e.RecordDimensions(3, 5, 0, "evaluation", "user")
+	e.flush()
+	summary, err = e.QueryTagSummary("other", from, to, TagSummaryFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, []TagFlagCount{
+		{FlagID: 3, FlagKey: "search", Count: 2},
+		{FlagID: 2, FlagKey: "payment", Count: 1},
+	}, summary.Flags)
+
+	summary, err = e.QueryTagSummary("missing", from, to, TagSummaryFilter{})
+	require.NoError(t, err)
+	assert.Zero(t, summary.Total)
+	assert.Empty(t, summary.Flags)
+}
+
+func TestRecordDimensions_LongEntityType(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	e.RecordDimensions(1, 1, 1, "evaluation", strings.Repeat("x", 100))
+	require.NoError(t, e.flushDimensions())
+	var row entity.DimensionHourlyEvent
+	require.NoError(t, db.First(&row).Error)
+	assert.Len(t, row.EntityType, maxDimensionLength)
+	assert.Equal(t, int64(1), row.RecordCount)
+
+	var nilEngine *Engine
+	nilEngine.RecordDimensions(1, 1, 1, "evaluation", "user")
+	_, err := nilEngine.QueryTagSummary("t", time.Now(), time.Now(), TagSummaryFilter{})
+	assert.Error(t, err)
+}
@@ -10,6 +10,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/openflagr/flagr/pkg/datar/hll"
 	"github.com/openflagr/flagr/pkg/entity"
 )
 
@@ -32,43 +33,62 @@ type SummaryRow struct {
 	Enabled        bool
 	Description    string
 	TotalEvalCount int64
+	UniqueEntities int64 // estimated distinct entities, see sketch.go
 	LastEvaluated  string
 }
 
 // VariantEntry is one variant's aggregated count.
 type VariantEntry struct {
-	VariantID int64
-	Count     int64
+	VariantID      int64
+	Count          int64
+	UniqueEntities int64
 }
 
 // SegmentEntry is one segment's aggregated count.
 type SegmentEntry struct {
-	SegmentID int64
-	Count     int64
+	SegmentID      int64
+	Count          int64
+	UniqueEntities int64
 }
 
 // DayEntry is one calendar day's aggregated count.
 type DayEntry struct {
-	Day   string // YYYY-MM-DD
-	Count int64
+	Day            string // YYYY-MM-DD
+	Count          int64
+	UniqueEntities int64
 }
 
 // FlagSummaryBreakdown is the pre-aggregated breakdown for a single flag.
 type FlagSummaryBreakdown struct {
-	FlagID   int64
-	Variants []VariantEntry
-	Segments []SegmentEntry
-	Days     []DayEntry
+	FlagID         int64
+	UniqueEntities int64
+	Variants       []VariantEntry
+	Segments       []SegmentEntry
+	Days           []DayEntry
 }
 
 // Engine is the complete Datar analytics engine.
 // It aggregates evaluation counts in-memory, periodically flushes to the DB,
 // and serves aggregate queries — all in one self-contained struct.
 type Engine struct {
-	buffer sync.Map // FlushKey → *int32
+	buffer   sync.Map // FlushKey → *int32
+	sketches sync.Map // FlushKey → *entitySketch, see sketch.go
+	// flushMu is held while the buffer is drained into the DB, and read
+	// locked while both are read, see flagTotals.
+	flushMu sync.RWMutex
+
+	dimensions sync.Map // dimensionKey → *int64, see dimensions.go
+
+	// Metric attribution buffers, see metrics.go.
+	assignments  sync.Map // assignmentKey → assignment
+	metricMu     sync.Mutex
+	metricEvents []MetricEvent
+	// droppedMetricEvents since the last flush, guarded by metricMu.
+	droppedMetricEvents int64
 
 	db          *gorm.DB
 	addEvalExpr string
+	owner       string // of locks, see retention.go
 
 	flushInterval time.Duration
 	closeCh       chan struct{}
@@ -92,6 +112,7 @@ func New(db *gorm.DB, enabled bool, flushInterval time.Duration) *Engine {
 	e := &Engine{
 		db:            db,
 		addEvalExpr:   addEvalExpr,
+		owner:         instanceID(),
 		flushInterval: flushInterval,
 		closeCh:       make(chan struct{}),
 	}
@@ -150,13 +171,13 @@ func (e *Engine) Len() int {
 
 // QuerySummary returns all flags with traffic totals in the given time range.
 // Only includes flags that have actual evaluation traffic in the window.
+// Hourly and rolled-up daily counts are both read.
 func (e *Engine) QuerySummary(from, to time.Time, limit, offset int) ([]SummaryRow, error) {
 	if e == nil {
 		return nil, errNilEngine
 	}
-	sub := e.db.Model(&entity.HourlyEvent{}).
+	sub := e.events(from, to).
 		Select("flag_id, SUM(eval_count) AS total_count, MAX(updated_at) AS last_evaluated_at").
-		Where("bucket_hour >= ? AND bucket_hour < ?", from, to).
 		Group("flag_id")
 
 	var rows []SummaryRow
@@ -171,23 +192,26 @@ func (e *Engine) QuerySummary(from, to time.Time, limit, offset int) ([]SummaryR
 		logrus.WithError(err).Error("Datar: QuerySummary failed")
 		return nil, err
 	}
+	if err := e.addUniqueEntities(rows, from, to); err != nil {
+		logrus.WithError(err).Error("Datar: QuerySummary unique entities failed")
+		return nil, err
+	}
 	return rows, nil
 }
 
 // QueryFlagSummaryBreakdown returns the pre-aggregated breakdown for a single flag.
 // Uses SQL GROUP BY for each dimension instead of loading raw rows into Go.
+// Hourly and rolled-up daily counts are both read.
 func (e *Engine) QueryFlagSummaryBreakdown(flagID int64, from, to time.Time) (*FlagSummaryBreakdown, error) {
 	if e == nil {
 		return nil, errNilEngine
 	}
-	where := "flag_id = ? AND bucket_hour >= ? AND bucket_hour < ?"
-	args := []any{flagID, from, to}
 
 	// Variants, sorted by count descending.
 	var variants []VariantEntry
-	if err := e.db.Model(&entity.HourlyEvent{}).
+	if err := e.events(from, to).
 		Select("variant_id, SUM(eval_count) AS count").
-		Where(where, args...).Group("variant_id").Order("SUM(eval_count) DESC").
+		Where("flag_id = ?", flagID).Group("variant_id").Order("SUM(eval_count) DESC").
 		Scan(&variants).Error; err != nil {
 		logrus.WithError(err).Error("Datar: QueryFlagSummaryBreakdown variants failed")
 		return nil, err
@@ -195,9 +219,9 @@ func (e *Engine) QueryFlagSummaryBreakdown(flagID int64, from, to time.Time) (*F
 
 	// Segments (exclude segment_id = 0), sorted by count descending.
 	var segs []SegmentEntry
-	if err := e.db.Model(&entity.HourlyEvent{}).
+	if err := e.events(from, to).
 		Select("segment_id, SUM(eval_count) AS count").
-		Where(where+" AND segment_id > 0", args...).Group("segment_id").Order("SUM(eval_count) DESC").
+		Where("flag_id = ? AND segment_id > 0", flagID).Group("segment_id").Order("SUM(eval_count) DESC").
 		Scan(&segs).Error; err != nil {
 		logrus.WithError(err).Error("Datar: QueryFlagSummaryBreakdown segments failed")
 		return nil, err
@@ -205,20 +229,25 @@ func (e *Engine) QueryFlagSummaryBreakdown(flagID int64, from, to time.Time) (*F
 
 	daySelect, dayGroup := dayBucketExpr(e.db)
 	var days []DayEntry
-	if err := e.db.Model(&entity.HourlyEvent{}).
+	if err := e.events(from, to).
 		Select(daySelect+", SUM(eval_count) AS count").
-		Where(where, args...).Group(dayGroup).Order(dayGroup + " ASC").
+		Where("flag_id = ?", flagID).Group(dayGroup).Order(dayGroup + " ASC").
 		Scan(&days).Error; err != nil {
 		logrus.WithError(err).Error("Datar: QueryFlagSummaryBreakdown days failed")
 		return nil, err
 	}
 
-	return &FlagSummaryBreakdown{
+	breakdown := &FlagSummaryBreakdown{
 		FlagID:   flagID,
 		Variants: variants,
 		Segments: segs,
 		Days:     days,
-	}, nil
+	}
+	if err := e.addBreakdownUniqueEntities(breakdown, from, to); err != nil {
+		logrus.WithError(err).Error("Datar: QueryFlagSummaryBreakdown unique entities failed")
+		return nil, err
+	}
+	return breakdown, nil
 }
 
 // dayBucketExpr returns SQL to bucket bucket_hour by calendar day for the active dialect.
@@ -246,15 +275,23 @@ func (e *Engine) Shutdown() error {
 		e.closed.Store(true)
 		close(e.closeCh)
 		e.wg.Wait()
+		// Another instance's scrapes add the flushed counts from now on.
+		e.releaseLock(collectorLock)
 
-		agg := e.SnapshotAndReset()
-		if len(agg) > 0 {
-			logrus.WithField("keys", len(agg)).Info("Datar: flushing remaining aggregates on shutdown")
-			if err := e.flushWithRetry(agg); err != nil {
-				logrus.WithError(err).Error("Datar: shutdown flush failed, data may be lost")
-				shutdownErr = err
-				return
-			}
+		if err := e.flushBuffer(); err != nil {
+			logrus.WithError(err).Error("Datar: shutdown flush failed, data may be lost")
+			shutdownErr = err
+			return
+		}
+		if err := e.flushDimensions(); err != nil {
+			logrus.WithError(err).Error("Datar: shutdown flush of dimension counts failed, data may be lost")
+			shutdownErr = err
+			return
+		}
+		if err := e.flushMetrics(); err != nil {
+			logrus.WithError(err).Error("Datar: shutdown flush of metric events failed, data may be lost")
+			shutdownErr = err
+			return
 		}
 		logrus.Info("Datar: shutdown complete")
 	})
@@ -278,23 +315,40 @@ func (e *Engine) flushLoop() {
 }
 
 func (e *Engine) flush() {
-	agg := e.SnapshotAndReset()
-	if len(agg) == 0 {
-		return
-	}
-	logrus.WithField("keys", len(agg)).Debug("Datar: flushing aggregates")
-	if err := e.flushWithRetry(agg); err != nil {
+	if err := e.flushBuffer(); err != nil {
 		logrus.WithError(err).Error("Datar: flush failed after retries, data in this cycle is lost")
 	}
+	if err := e.flushDimensions(); err != nil {
+		logrus.WithError(err).Error("Datar: dimension flush failed after retries, data in this cycle is lost")
+	}
+	if err := e.flushMetrics(); err != nil {
+		logrus.WithError(err).Error("Datar: metric flush failed after retries, data in this cycle is lost")
+	}
+}
+
+// flushBuffer drains the buffered counts and sketches into the DB.
+func (e *Engine) flushBuffer() error {
+	e.flushMu.Lock()
+	defer e.flushMu.Unlock()
+	agg, sketches := e.SnapshotAndReset(), e.snapshotSketches()
+	if len(agg) == 0 && len(sketches) == 0 {
+		return nil
+	}
+	logrus.WithField("keys", len(agg)).Debug("Datar: flushing aggregates")
+	return e.flushWithRetry(agg, sketches)
 }
 
 // flushWithRetry attempts to flush aggregates up to flushRetries times
 // before giving up. This is best-effort: if the container restarts,
 // in-flight aggregates are lost regardless.
-func (e *Engine) flushWithRetry(agg map[FlushKey]int32) error {
+func (e *Engine) flushWithRetry(agg map[FlushKey]int32, sketches map[FlushKey]*hll.Sketch) error {
+	return withRetry(func() error { return e.flushAggregates(agg, sketches) })
+}
+
+func withRetry(fn func() error) error {
 	var err error
 	for attempt := 1; attempt <= flushRetries; attempt++ {
-		if err = e.flushAggregates(agg); err == nil {
+		if err = fn(); err == nil {
 			return nil
 		}
 		logrus.WithError(err).WithField("attempt", attempt).Warn("Datar: flush attempt failed")
@@ -302,14 +356,15 @@ func (e *Engine) flushWithRetry(agg map[FlushKey]int32) error {
 	return err
 }
 
-// flushAggregates writes the snapshot to the DB using additive UPSERT.
-func (e *Engine) flushAggregates(agg map[FlushKey]int32) error {
-	if len(agg) == 0 {
+// flushAggregates writes the snapshot to the DB using additive UPSERT, and
+// merges the entity sketches into the upserted rows.
+func (e *Engine) flushAggregates(agg map[FlushKey]int32, sketches map[FlushKey]*hll.Sketch) error {
+	if len(agg) == 0 && len(sketches) == 0 {
 		return nil
 	}
 	now := time.Now()
 	records := make([]entity.HourlyEvent, 0, len(agg))
-	for k, count := range agg {
+	record := func(k FlushKey, count int32) {
 		records = append(records, entity.HourlyEvent{
 			FlagID:     k.FlagID,
 			VariantID:  k.VariantID,
@@ -319,19 +374,42 @@ func (e *Engine) flushAggregates(agg map[FlushKey]int32) error {
 			UpdatedAt:  now,
 		})
 	}
-	return e.db.Clauses(clause.OnConflict{
-		Columns: []clause.Column{
-			{Name: "flag_id"},
-			{Name: "variant_id"},
-			{Name: "segment_id"},
-			{Name: "bucket_hour"},
-		},
-		DoUpdates: clause.Set{{
-			Column: clause.Column{Name: "eval_count"},
-			Value:  gorm.Expr(e.addEvalExpr),
-		}, {
-			Column: clause.Column{Name: "updated_at"},
-			Value:  gorm.Expr("CURRENT_TIMESTAMP"),
-		}},
-	}).Create(&records).Error
+	for k, count := range agg {
+		record(k, count)
+	}
+	// A sketch can outrun its count across a snapshot; its row still has to
+	// exist to merge into.
+	for k := range sketches {
+		if _, ok := agg[k]; !ok {
+			record(k, 0)
+		}
+	}
+
+	upsert := func(tx *gorm.DB) error {
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "flag_id"},
+				{Name: "variant_id"},
+				{Name: "segment_id"},
+				{Name: "bucket_hour"},
+			},
+			DoUpdates: clause.Set{{
+				Column: clause.Column{Name: "eval_count"},
+				Value:  gorm.Expr(e.addEvalExpr),
+			}, {
+				Column: clause.Column{Name: "updated_at"},
+				Value:  gorm.Expr("CURRENT_TIMESTAMP"),
+			}},
+		}).Create(&records).Error
+	}
+	if len(sketches) == 0 {
+		return upsert(e.db)
+	}
+	// The upsert locks the rows until the sketches are merged into them.
+	return e.db.Transaction(func(tx *gorm.DB) error {
+		if err := upsert(tx); err != nil {
+			return err
+		}
+		return mergeSketches(tx, sketches)
+	})
 }
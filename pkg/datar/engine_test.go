@@ -8,6 +8,8 @@ import (
 
 	"github.com/openflagr/flagr/pkg/entity"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
 
@@ -20,6 +22,22 @@ func newTestDB(t *testing.T) *gorm.DB {
 	return db
 }
 
+// newMySQLDryRunDB returns a DB of the mysql dialect that runs no queries,
+// and the queries and scans it was asked to run.
+func newMySQLDryRunDB(t *testing.T) (*gorm.DB, *[]string) {
+	t.Helper()
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		DSN:                       "flagr:flagr@tcp(127.0.0.1:3306)/flagr",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+	var queries []string
+	capture := func(tx *gorm.DB) { queries = append(queries, tx.Statement.SQL.String()) }
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("capture", capture))
+	require.NoError(t, db.Callback().Row().After("gorm:row").Register("capture", capture))
+	return db, &queries
+}
+
 func createFlag(t *testing.T, db *gorm.DB, id int64, key, desc string, enabled bool) {
 	t.Helper()
 	e := 0
@@ -498,8 +516,8 @@ func TestFlushAggregates_Empty(t *testing.T) {
 	}
 	defer e.Shutdown()
 
-	assert.NoError(t, e.flushAggregates(nil))
-	assert.NoError(t, e.flushAggregates(map[FlushKey]int32{}))
+	assert.NoError(t, e.flushAggregates(nil, nil))
+	assert.NoError(t, e.flushAggregates(map[FlushKey]int32{}, nil))
 }
 
 func TestQueryFlagSummaryBreakdown_MultipleSegments(t *testing.T) {
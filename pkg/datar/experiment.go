@@ -0,0 +1,284 @@
+package datar
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/openflagr/flagr/pkg/datar/stats"
+	"github.com/openflagr/flagr/pkg/entity"
+)
+
+// Denominators of experiment readouts: the trials of each variant.
+const (
+	// DenominatorEvaluations counts the evaluations of each variant.
+	DenominatorEvaluations = "evaluations"
+	// DenominatorAssignments counts the entities first assigned each variant,
+	// see metrics.go.
+	DenominatorAssignments = "assignments"
+)
+
+// Tests of experiment readouts, by metric kind.
+const (
+	TestTwoProportionZ = "two_proportion_z"
+	TestWelchT         = "welch_t"
+)
+
+// ErrInvalidExperiment is wrapped by the errors of QueryExperiment caused by
+// the query rather than the DB.
+var ErrInvalidExperiment = errors.New("invalid experiment")
+
+// ExperimentQuery selects the outcomes and the analysis of an experiment
+// readout. Outcomes are either the recorded events of MetricKey, or posted
+// per variant in Outcomes.
+type ExperimentQuery struct {
+	ControlVariantID int64
+	MetricKey        string
+	Kind             string // metric kind of Outcomes; from the metric for MetricKey
+	Outcomes         []VariantOutcome
+	Denominator      string // default assignments for MetricKey, evaluations for Outcomes
+	Alpha            float64
+	Bayesian         bool
+	Sequential       bool
+}
+
+// VariantOutcome is the outcome of a metric for a variant: the trials with a
+// success for conversion metrics, the sums of values for value metrics.
+type VariantOutcome struct {
+	VariantID       int64
+	Successes       int64
+	ValueSum        float64
+	ValueSumSquares float64
+}
+
+// VariantReadout is the result of a variant. Comparison and the Bayesian
+// fields are against the control, and unset for the control itself.
+type VariantReadout struct {
+	VariantID int64
+	Control   bool
+	Trials    int64
+	Successes int64
+	Mean      float64 // success rate, or mean value per trial
+	MeanCI    stats.Interval
+
+	Comparison               *stats.Comparison
+	ProbabilityToBeatControl float64 // NaN unless Bayesian
+	CredibleInterval         *stats.Interval
+
+	Error string // why the variant could not be compared
+}
+
+// Experiment is a readout of a flag's variants against a control variant.
+type Experiment struct {
+	FlagID           int64
+	ControlVariantID int64
+	MetricKey        string
+	Kind             string
+	Denominator      string
+	Test             string
+	Alpha            float64
+	Bayesian         bool
+	Sequential       bool
+	Variants         []VariantReadout // control first, then by variant ID
+}
+
+// QueryExperiment compares the variants of a flag with the control variant
+// over the given time range, using Datar's per-variant counts as trials.
+func (e *Engine) QueryExperiment(flagID int64, from, to time.Time, q ExperimentQuery) (*Experiment, error) {
+	if e == nil {
+		return nil, errNilEngine
+	}
+	if err := e.resolveExperimentQuery(&q); err != nil {
+		return nil, err
+	}
+
+	trials := make(map[int64]int64)
+	var metrics *FlagMetrics
+	if q.Denominator == DenominatorAssignments || q.MetricKey != "" {
+		var err error
+		if metrics, err = e.QueryFlagMetrics(flagID, from, to); err != nil {
+			return nil, err
+		}
+	}
+	if q.Denominator == DenominatorAssignments {
+		for _, v := range metrics.Variants {
+			trials[v.VariantID] = v.Exposures
+		}
+	} else {
+		summary, err := e.QueryFlagSummaryBreakdown(flagID, from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range summary.Variants {
+			trials[v.VariantID] = v.Count
+		}
+	}
+	if q.MetricKey != "" {
+		for _, v := range metrics.Variants {
+			for _, m := range v.Metrics {
+				if m.MetricKey == q.MetricKey {
+					q.Outcomes = append(q.Outcomes, VariantOutcome{
+						VariantID:       v.VariantID,
+						Successes:       m.Conversions,
+						ValueSum:        m.ValueSum,
+						ValueSumSquares: m.ValueSumSquares,
+					})
+				}
+			}
+		}
+	}
+	return analyzeExperiment(flagID, q, trials), nil
+}
+
+// resolveExperimentQuery validates q and fills in its defaults.
+func (e *Engine) resolveExperimentQuery(q *ExperimentQuery) error {
+	if q.ControlVariantID <= 0 {
+		return fmt.Errorf("%w: controlVariantID is required", ErrInvalidExperiment)
+	}
+	if (q.MetricKey == "") == (len(q.Outcomes) == 0) {
+		return fmt.Errorf("%w: exactly one of metricKey and variants is required", ErrInvalidExperiment)
+	}
+	if q.Alpha == 0 {
+		q.Alpha = 0.05
+	}
+	if q.Alpha <= 0 || q.Alpha > 0.5 {
+		return fmt.Errorf("%w: alpha must be in (0, 0.5], got %v", ErrInvalidExperiment, q.Alpha)
+	}
+
+	if q.MetricKey != "" {
+		m := entity.Metric{}
+		// A struct condition quotes key, a reserved word of MySQL.
+		err := e.db.Where(&entity.Metric{Key: q.MetricKey}).First(&m).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: metric %q is not defined", ErrInvalidExperiment, q.MetricKey)
+		}
+		if err != nil {
+			return err
+		}
+		q.Kind = m.Kind
+		if q.Denominator == "" {
+			q.Denominator = DenominatorAssignments
+		}
+	}
+	if q.Kind == "" {
+		q.Kind = entity.MetricKindConversion
+	}
+	if q.Kind != entity.MetricKindConversion && q.Kind != entity.MetricKindValue {
+		return fmt.Errorf("%w: unknown metric kind %q", ErrInvalidExperiment, q.Kind)
+	}
+	if q.Denominator == "" {
+		q.Denominator = DenominatorEvaluations
+	}
+	if q.Denominator != DenominatorEvaluations && q.Denominator != DenominatorAssignments {
+		return fmt.Errorf("%w: unknown denominator %q", ErrInvalidExperiment, q.Denominator)
+	}
+	return nil
+}
+
+// analyzeExperiment compares the outcomes of every variant with trials or
+// outcomes to the control. Variant 0, evaluations without a variant, is not
+// part of the experiment.
+func analyzeExperiment(flagID int64, q ExperimentQuery, trials map[int64]int64) *Experiment {
+	exp := &Experiment{
+		FlagID:           flagID,
+		ControlVariantID: q.ControlVariantID,
+		MetricKey:        q.MetricKey,
+		Kind:             q.Kind,
+		Denominator:      q.Denominator,
+		Test:             TestTwoProportionZ,
+		Alpha:            q.Alpha,
+		Bayesian:         q.Bayesian,
+		Sequential:       q.Sequential,
+	}
+	if q.Kind == entity.MetricKindValue {
+		exp.Test = TestWelchT
+	}
+
+	outcomes := make(map[int64]VariantOutcome)
+	ids := []int64{q.ControlVariantID}
+	seen := map[int64]bool{q.ControlVariantID: true}
+	add := func(id int64) {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, o := range q.Outcomes {
+		outcomes[o.VariantID] = o
+		add(o.VariantID)
+	}
+	for id := range trials {
+		add(id)
+	}
+	sort.Slice(ids[1:], func(i, j int) bool { return ids[1+i] < ids[1+j] })
+
+	readout := func(id int64) VariantReadout {
+		o := outcomes[id]
+		r := VariantReadout{
+			VariantID:                id,
+			Control:                  id == q.ControlVariantID,
+			Trials:                   trials[id],
+			Successes:                o.Successes,
+			ProbabilityToBeatControl: math.NaN(),
+		}
+		if exp.Test == TestTwoProportionZ {
+			p := stats.Proportion{Successes: o.Successes, Trials: r.Trials}
+			r.Mean = p.Rate()
+			r.MeanCI = stats.WilsonInterval(p, q.Alpha)
+			if o.Successes > r.Trials {
+				r.Error = fmt.Sprintf("successes %d exceed the %d %s", o.Successes, r.Trials, q.Denominator)
+			}
+		} else {
+			s := stats.SampleFromSums(r.Trials, o.ValueSum, o.ValueSumSquares)
+			r.Mean = s.Mean
+			r.MeanCI = stats.MeanInterval(s, q.Alpha)
+		}
+		return r
+	}
+
+	control := readout(q.ControlVariantID)
+	co := outcomes[q.ControlVariantID]
+	exp.Variants = append(exp.Variants, control)
+	for _, id := range ids[1:] {
+		r := readout(id)
+		if r.Error == "" && control.Error != "" {
+			r.Error = "control: " + control.Error
+		}
+		if r.Error == "" {
+			o := outcomes[id]
+			var c stats.Comparison
+			var err error
+			if exp.Test == TestTwoProportionZ {
+				cp := stats.Proportion{Successes: co.Successes, Trials: control.Trials}
+				tp := stats.Proportion{Successes: o.Successes, Trials: r.Trials}
+				c, err = stats.TwoProportionZTest(cp, tp, q.Alpha)
+				if q.Bayesian {
+					r.ProbabilityToBeatControl = stats.ProbabilityToBeatBeta(cp, tp)
+					ci := stats.BetaCredibleInterval(tp, q.Alpha)
+					r.CredibleInterval = &ci
+				}
+			} else {
+				cs := stats.SampleFromSums(control.Trials, co.ValueSum, co.ValueSumSquares)
+				ts := stats.SampleFromSums(r.Trials, o.ValueSum, o.ValueSumSquares)
+				c, err = stats.WelchTTest(cs, ts, q.Alpha)
+				if q.Bayesian {
+					r.ProbabilityToBeatControl = stats.ProbabilityToBeatNormal(cs, ts)
+				}
+			}
+			if err != nil {
+				r.Error = err.Error()
+			} else {
+				if q.Sequential {
+					c.Sequential(q.Alpha)
+				}
+				r.Comparison = &c
+			}
+		}
+		exp.Variants = append(exp.Variants, r)
+	}
+	return exp
+}
@@ -0,0 +1,189 @@
+package datar
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/openflagr/flagr/pkg/datar/stats"
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryExperiment_PostedConversions(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	now := time.Now().UTC().Truncate(time.Hour)
+	for _, row := range [][3]int64{{0, 10, 70}, {1, 10, 600}, {1, 20, 400}, {2, 10, 1000}, {3, 10, 10}} {
+		require.NoError(t, db.Exec(
+			`INSERT INTO datar_hourly_events (flag_id, variant_id, segment_id, bucket_hour, eval_count) VALUES (1, ?, ?, ?, ?)`,
+			row[0], row[1], now, row[2],
+		).Error)
+	}
+
+	exp, err := e.QueryExperiment(1, now.Add(-24*time.Hour), now.Add(time.Hour), ExperimentQuery{
+		ControlVariantID: 1,
+		Outcomes: []VariantOutcome{
+			{VariantID: 1, Successes: 200},
+			{VariantID: 2, Successes: 250},
+			{VariantID: 3, Successes: 20},
+			{VariantID: 4}, // no evaluations
+		},
+		Bayesian: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, DenominatorEvaluations, exp.Denominator)
+	assert.Equal(t, TestTwoProportionZ, exp.Test)
+	assert.Equal(t, 0.05, exp.Alpha)
+	require.Len(t, exp.Variants, 4, "variant 0 is left out")
+
+	control := exp.Variants[0]
+	assert.True(t, control.Control)
+	assert.Equal(t, int64(1000), control.Trials)
+	assert.InDelta(t, 0.2, control.Mean, 1e-12)
+	assert.Nil(t, control.Comparison)
+
+	treatment := exp.Variants[1]
+	assert.Equal(t, int64(2), treatment.VariantID)
+	require.NotNil(t, treatment.Comparison)
+	assert.InDelta(t, 0.25, treatment.Comparison.Lift, 1e-12)
+	assert.InDelta(t, 0.007419649261025674, treatment.Comparison.PValue, 1e-9)
+	assert.InDelta(t, 0.9962833291305507, treatment.ProbabilityToBeatControl, 1e-6)
+	assert.NotNil(t, treatment.CredibleInterval)
+
+	assert.Equal(t, "successes 20 exceed the 10 evaluations", exp.Variants[2].Error)
+	assert.Equal(t, int64(4), exp.Variants[3].VariantID)
+	assert.Equal(t, "not enough data", exp.Variants[3].Error)
+
+	// Sequential results are more conservative.
+	seq, err := e.QueryExperiment(1, now.Add(-24*time.Hour), now.Add(time.Hour), ExperimentQuery{
+		ControlVariantID: 1,
+		Outcomes:         []VariantOutcome{{VariantID: 1, Successes: 200}, {VariantID: 2, Successes: 250}},
+		Sequential:       true,
+	})
+	require.NoError(t, err)
+	assert.True(t, math.IsNaN(seq.Variants[1].ProbabilityToBeatControl))
+	assert.InDelta(t, 0.1222457172633802, seq.Variants[1].Comparison.PValue, 1e-9)
+}
+
+func TestQueryExperiment_RecordedMetric(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+	require.NoError(t, db.Create(&entity.Metric{Key: "revenue", Kind: entity.MetricKindValue}).Error)
+
+	now := time.Now().UTC().Truncate(time.Hour)
+	for i, v := range []float64{1, 2, 3, 4, 5, 6} {
+		id := string(rune('a' + i))
+		variant := int64(1 + i%2)
+		e.RecordAssignment(1, variant, id, now)
+		e.RecordMetricEvent(MetricEvent{EntityID: id, MetricKey: "revenue", Value: v * float64(variant), Time: now})
+	}
+	require.NoError(t, e.flushMetrics())
+
+	exp, err := e.QueryExperiment(1, now.Add(-time.Hour), now.Add(time.Hour), ExperimentQuery{
+		ControlVariantID: 1,
+		MetricKey:        "revenue",
+		Kind:             entity.MetricKindConversion, // ignored, the metric's kind wins
+		Bayesian:         true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.MetricKindValue, exp.Kind)
+	assert.Equal(t, DenominatorAssignments, exp.Denominator)
+	assert.Equal(t, TestWelchT, exp.Test)
+	require.Len(t, exp.Variants, 2)
+
+	// Control: 1, 3, 5. Treatment: 4, 8, 12.
+	assert.Equal(t, int64(3), exp.Variants[0].Trials)
+	assert.InDelta(t, 3, exp.Variants[0].Mean, 1e-12)
+	treatment := exp.Variants[1]
+	assert.InDelta(t, 8, treatment.Mean, 1e-12)
+	require.NotNil(t, treatment.Comparison)
+	assert.InDelta(t, 5, treatment.Comparison.Difference, 1e-12)
+	assert.InDelta(t, 5.0/3, treatment.Comparison.Lift, 1e-12)
+	assert.Less(t, treatment.Comparison.PValue, 0.2)
+	assert.Greater(t, treatment.ProbabilityToBeatControl, 0.9)
+}
+
+func TestQueryExperiment_ValuesPerEntity(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+	require.NoError(t, db.Create(&entity.Metric{Key: "revenue", Kind: entity.MetricKindValue}).Error)
+
+	// Totals per entity: control 2, 4, 6, and treatment 3, 6, 9, each of
+	// three events in two flushes.
+	now := time.Now().UTC().Truncate(time.Hour)
+	for i, id := range []string{"a", "b", "c", "d", "e", "f"} {
+		variant := int64(1 + i/3)
+		total := float64(i%3+1) * float64(variant+1)
+		e.RecordAssignment(1, variant, id, now)
+		e.RecordMetricEvent(MetricEvent{EntityID: id, MetricKey: "revenue", Value: total / 2, Time: now})
+		e.RecordMetricEvent(MetricEvent{EntityID: id, MetricKey: "revenue", Value: total / 4, Time: now})
+	}
+	require.NoError(t, e.flushMetrics())
+	for i, id := range []string{"a", "b", "c", "d", "e", "f"} {
+		variant := int64(1 + i/3)
+		total := float64(i%3+1) * float64(variant+1)
+		e.RecordMetricEvent(MetricEvent{EntityID: id, MetricKey: "revenue", Value: total / 4, Time: now.Add(time.Minute)})
+	}
+	require.NoError(t, e.flushMetrics())
+
+	exp, err := e.QueryExperiment(1, now.Add(-time.Hour), now.Add(time.Hour), ExperimentQuery{
+		ControlVariantID: 1,
+		MetricKey:        "revenue",
+	})
+	require.NoError(t, err)
+	require.Len(t, exp.Variants, 2)
+
+	// The variances are of the totals, 4 and 9, not of the events.
+	control, treatment := exp.Variants[0], exp.Variants[1]
+	assert.InDelta(t, 4, control.Mean, 1e-12)
+	assert.InDelta(t, stats.MeanInterval(stats.Sample{N: 3, Mean: 4, Variance: 4}, 0.05).Upper, control.MeanCI.Upper, 1e-9)
+	assert.InDelta(t, 6, treatment.Mean, 1e-12)
+	assert.InDelta(t, stats.MeanInterval(stats.Sample{N: 3, Mean: 6, Variance: 9}, 0.05).Upper, treatment.MeanCI.Upper, 1e-9)
+}
+
+func TestQueryExperiment_Invalid(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	outcomes := []VariantOutcome{{VariantID: 1}}
+	for _, q := range []ExperimentQuery{
+		{Outcomes: outcomes},
+		{ControlVariantID: 1},
+		{ControlVariantID: 1, Outcomes: outcomes, MetricKey: "m"},
+		{ControlVariantID: 1, MetricKey: "undefined"},
+		{ControlVariantID: 1, Outcomes: outcomes, Alpha: 0.6},
+		{ControlVariantID: 1, Outcomes: outcomes, Kind: "sum"},
+		{ControlVariantID: 1, Outcomes: outcomes, Denominator: "sessions"},
+	} {
+		_, err := e.QueryExperiment(1, time.Now(), time.Now(), q)
+		assert.ErrorIs(t, err, ErrInvalidExperiment, "%+v", q)
+	}
+
+	var nilEngine *Engine
+	_, err := nilEngine.QueryExperiment(1, time.Now(), time.Now(), ExperimentQuery{})
+	assert.Error(t, err)
+}
+
+func TestResolveExperimentQuery_MySQL(t *testing.T) {
+	t.Parallel()
+	db, queries := newMySQLDryRunDB(t)
+	e := &Engine{db: db}
+	_ = e.resolveExperimentQuery(&ExperimentQuery{ControlVariantID: 1, MetricKey: "revenue"})
+	require.NotEmpty(t, *queries)
+	assert.Contains(t, (*queries)[0], "WHERE `metrics`.`key` = ?")
+}
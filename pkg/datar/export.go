@@ -0,0 +1,106 @@
+package datar
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Formats of exports.
+const (
+	ExportCSV    = "csv"
+	ExportNDJSON = "ndjson"
+)
+
+// ErrInvalidExport is wrapped by the errors of Export caused by the request
+// rather than the DB.
+var ErrInvalidExport = errors.New("invalid export")
+
+// ExportRow is the evaluation count of a flag, variant and segment in a
+// bucket, with the keys of the flag and variant. The keys are empty when the
+// flag or variant no longer exists.
+type ExportRow struct {
+	Bucket      time.Time `json:"bucket"`      // start of the bucket, in UTC
+	Granularity string    `json:"granularity"` // GranularityHour, or GranularityDay for days rolled up by the retention
+	FlagID      int64     `json:"flag_id"`
+	FlagKey     string    `json:"flag_key"`
+	VariantID   int64     `json:"variant_id"`
+	VariantKey  string    `json:"variant_key"`
+	SegmentID   int64     `json:"segment_id"`
+	EvalCount   int64     `json:"eval_count"`
+}
+
+// exportColumns is the header of CSV exports, the JSON names of ExportRow.
+var exportColumns = []string{
+	"bucket", "granularity", "flag_id", "flag_key", "variant_id", "variant_key", "segment_id", "eval_count",
+}
+
+// Export writes the evaluation counts in the given time range to w as CSV,
+// with a header, or as one JSON object per line. Rows are ordered by bucket,
+// then flag, variant and segment, and streamed from the DB, so an error can
+// leave w with part of the rows.
+func (e *Engine) Export(w io.Writer, format string, from, to time.Time) error {
+	if e == nil {
+		return errNilEngine
+	}
+	switch format {
+	case ExportCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(exportColumns); err != nil {
+			return err
+		}
+		err := e.exportRows(from, to, func(r ExportRow) error {
+			return cw.Write([]string{
+				r.Bucket.Format(time.RFC3339),
+				r.Granularity,
+				strconv.FormatInt(r.FlagID, 10),
+				r.FlagKey,
+				strconv.FormatInt(r.VariantID, 10),
+				r.VariantKey,
+				strconv.FormatInt(r.SegmentID, 10),
+				strconv.FormatInt(r.EvalCount, 10),
+			})
+		})
+		cw.Flush()
+		return errors.Join(err, cw.Error())
+	case ExportNDJSON:
+		enc := json.NewEncoder(w)
+		return e.exportRows(from, to, func(r ExportRow) error { return enc.Encode(r) })
+	}
+	return fmt.Errorf("%w: unknown format %q", ErrInvalidExport, format)
+}
+
+// exportRows calls fn with each row of an export, until fn fails.
+func (e *Engine) exportRows(from, to time.Time, fn func(ExportRow) error) error {
+	rows, err := e.events(from, to).
+		Select("datar_events.bucket_hour AS bucket, datar_events.granularity, datar_events.flag_id, " +
+			"COALESCE(flags.key, '') AS flag_key, datar_events.variant_id, " +
+			"COALESCE(variants.key, '') AS variant_key, datar_events.segment_id, datar_events.eval_count").
+		Joins("LEFT JOIN flags ON flags.id = datar_events.flag_id").
+		Joins("LEFT JOIN variants ON variants.id = datar_events.variant_id").
+		Order("datar_events.bucket_hour, datar_events.flag_id, datar_events.variant_id, datar_events.segment_id").
+		Rows()
+	if err != nil {
+		logrus.WithError(err).Error("Datar: Export failed")
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var r ExportRow
+		if err := e.db.ScanRows(rows, &r); err != nil {
+			logrus.WithError(err).Error("Datar: Export failed")
+			return err
+		}
+		r.Bucket = r.Bucket.UTC()
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
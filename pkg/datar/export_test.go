@@ -0,0 +1,69 @@
+package datar
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	createFlag(t, db, 1, "checkout", "flag", true)
+	require.NoError(t, db.Create(&entity.Variant{Model: gorm.Model{ID: 3}, FlagID: 1, Key: "treatment"}).Error)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	d := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, db.Create(&[]entity.HourlyEvent{
+		{FlagID: 1, VariantID: 3, SegmentID: 10, BucketHour: d.Add(2 * time.Hour), EvalCount: 7},
+		{FlagID: 2, VariantID: 9, SegmentID: 0, BucketHour: d.Add(time.Hour), EvalCount: 4},
+		{FlagID: 1, VariantID: 3, SegmentID: 10, BucketHour: d.Add(48 * time.Hour), EvalCount: 100},
+	}).Error)
+	require.NoError(t, db.Create(&entity.DailyEvent{FlagID: 1, VariantID: 3, SegmentID: 10, BucketDay: d.AddDate(0, 0, -1), EvalCount: 20}).Error)
+	from, to := d.AddDate(0, 0, -1), d.Add(day)
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, e.Export(&buf, ExportCSV, from, to))
+		assert.Equal(t, strings.Join([]string{
+			"bucket,granularity,flag_id,flag_key,variant_id,variant_key,segment_id,eval_count",
+			"2026-04-30T00:00:00Z,day,1,checkout,3,treatment,10,20",
+			"2026-05-01T01:00:00Z,hour,2,,9,,0,4",
+			"2026-05-01T02:00:00Z,hour,1,checkout,3,treatment,10,7",
+		}, "\n")+"\n", buf.String())
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, e.Export(&buf, ExportNDJSON, d, to))
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		var r ExportRow
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &r))
+		assert.Equal(t, ExportRow{
+			Bucket: d.Add(2 * time.Hour), Granularity: GranularityHour,
+			FlagID: 1, FlagKey: "checkout", VariantID: 3, VariantKey: "treatment", SegmentID: 10, EvalCount: 7,
+		}, r)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, e.Export(&buf, ExportNDJSON, d.AddDate(-1, 0, 0), d.AddDate(0, -6, 0)))
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.ErrorIs(t, e.Export(&buf, "xlsx", from, to), ErrInvalidExport)
+		assert.Empty(t, buf.String())
+	})
+}
@@ -0,0 +1,275 @@
+// Package hll implements the HyperLogLog sketches of Datar's distinct entity
+// counts. Sketches are mergeable, so a sketch per hour can be combined into
+// the count of any time range, and serializable for storage next to the
+// hourly counters.
+//
+// Registers are kept sparse until a sketch has seen enough entities for the
+// dense array to be smaller. Estimates use the improved raw estimator of Ertl
+// ("New cardinality estimation algorithms for HyperLogLog sketches", 2017),
+// which needs neither bias correction tables nor a switch to linear counting.
+package hll
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+// Precision is the number of hash bits indexing the registers. 2^14 registers
+// give a standard error of about 0.81%.
+const Precision = 14
+
+const (
+	registers = 1 << Precision
+	// maxRank is the largest register value: the leading zeros of the
+	// remaining 64-Precision bits plus one.
+	maxRank = 64 - Precision + 1
+	// sparseLimit is the number of sparse registers above which a sketch
+	// switches to dense registers.
+	sparseLimit = registers / 16
+)
+
+const (
+	encodingVersion = 1
+	formatSparse    = 0
+	formatDense     = 1
+)
+
+// ErrInvalidSketch is returned by UnmarshalBinary for data that is not a
+// serialized sketch.
+var ErrInvalidSketch = errors.New("hll: invalid sketch")
+
+// Sketch is a HyperLogLog sketch. It is not safe for concurrent use.
+type Sketch struct {
+	sparse map[uint16]uint8 // register index → value, until dense is set
+	dense  []uint8
+}
+
+// New returns an empty sketch.
+func New() *Sketch {
+	return &Sketch{sparse: make(map[uint16]uint8)}
+}
+
+// Hash is the hash of an entity ID inserted into sketches. It is stable
+// across processes, so sketches of different Flagr instances can be merged.
+func Hash(entityID string) uint64 {
+	return xxhash.Sum64String(entityID)
+}
+
+// Insert adds an entity by its Hash.
+func (s *Sketch) Insert(hash uint64) {
+	index := uint16(hash >> (64 - Precision))
+	rank := uint8(min(bits.LeadingZeros64(hash<<Precision)+1, maxRank))
+	s.set(index, rank)
+}
+
+// set raises the register at index to rank.
+func (s *Sketch) set(index uint16, rank uint8) {
+	if s.dense != nil {
+		if rank > s.dense[index] {
+			s.dense[index] = rank
+		}
+		return
+	}
+	if rank > s.sparse[index] {
+		s.sparse[index] = rank
+		if len(s.sparse) > sparseLimit {
+			s.toDense()
+		}
+	}
+}
+
+func (s *Sketch) toDense() {
+	s.dense = make([]uint8, registers)
+	for index, rank := range s.sparse {
+		s.dense[index] = rank
+	}
+	s.sparse = nil
+}
+
+// Clone returns a copy of s.
+func (s *Sketch) Clone() *Sketch {
+	c := &Sketch{}
+	if s.dense != nil {
+		c.dense = append([]uint8(nil), s.dense...)
+		return c
+	}
+	c.sparse = make(map[uint16]uint8, len(s.sparse))
+	for index, rank := range s.sparse {
+		c.sparse[index] = rank
+	}
+	return c
+}
+
+// Merge adds the entities of o to s, so that s estimates the union.
+func (s *Sketch) Merge(o *Sketch) {
+	if o.dense != nil {
+		if s.dense == nil {
+			s.toDense()
+		}
+		for index, rank := range o.dense {
+			if rank > s.dense[index] {
+				s.dense[index] = rank
+			}
+		}
+		return
+	}
+	for index, rank := range o.sparse {
+		s.set(index, rank)
+	}
+}
+
+// Estimate is the estimated number of distinct entities inserted.
+func (s *Sketch) Estimate() uint64 {
+	var histogram [maxRank + 1]float64
+	if s.dense != nil {
+		for _, rank := range s.dense {
+			histogram[rank]++
+		}
+	} else {
+		histogram[0] = registers - float64(len(s.sparse))
+		for _, rank := range s.sparse {
+			histogram[rank]++
+		}
+	}
+
+	const m = float64(registers)
+	z := m * tau((m-histogram[maxRank])/m)
+	for k := maxRank - 1; k >= 1; k-- {
+		z = (z + histogram[k]) * 0.5
+	}
+	z += m * sigma(histogram[0]/m)
+	return uint64(math.Round(m * m / (2 * math.Ln2) / z))
+}
+
+// sigma is σ(x) of Ertl's estimator, for the registers that are 0.
+func sigma(x float64) float64 {
+	if x == 1 {
+		return math.Inf(1)
+	}
+	y, z := 1.0, x
+	for {
+		x *= x
+		previous := z
+		z += x * y
+		y += y
+		if z == previous {
+			return z
+		}
+	}
+}
+
+// tau is τ(x) of Ertl's estimator, for the registers at the maximum rank.
+func tau(x float64) float64 {
+	if x == 0 || x == 1 {
+		return 0
+	}
+	y, z := 1.0, 1-x
+	for {
+		x = math.Sqrt(x)
+		previous := z
+		y *= 0.5
+		z -= (1 - x) * (1 - x) * y
+		if z == previous {
+			return z / 3
+		}
+	}
+}
+
+// MarshalBinary serializes the sketch. Sparse sketches are delta-encoded
+// varints of their registers, dense ones pack four 6-bit registers into three
+// bytes.
+func (s *Sketch) MarshalBinary() ([]byte, error) {
+	if s.dense != nil {
+		b := make([]byte, 3, 3+registers*3/4)
+		b[0], b[1], b[2] = encodingVersion, Precision, formatDense
+		for i := 0; i < registers; i += 4 {
+			r := s.dense[i : i+4]
+			b = append(b,
+				r[0]<<2|r[1]>>4,
+				r[1]<<4|r[2]>>2,
+				r[2]<<6|r[3],
+			)
+		}
+		return b, nil
+	}
+
+	entries := make([]uint32, 0, len(s.sparse))
+	for index, rank := range s.sparse {
+		entries = append(entries, uint32(index)<<6|uint32(rank))
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i] < entries[j] })
+	b := []byte{encodingVersion, Precision, formatSparse}
+	b = binary.AppendUvarint(b, uint64(len(entries)))
+	previous := uint32(0)
+	for _, e := range entries {
+		b = binary.AppendUvarint(b, uint64(e-previous))
+		previous = e
+	}
+	return b, nil
+}
+
+// UnmarshalBinary replaces s with a sketch serialized by MarshalBinary.
+func (s *Sketch) UnmarshalBinary(b []byte) error {
+	if len(b) < 3 || b[0] != encodingVersion {
+		return ErrInvalidSketch
+	}
+	if b[1] != Precision {
+		return fmt.Errorf("%w: precision %d, want %d", ErrInvalidSketch, b[1], Precision)
+	}
+	data := b[3:]
+
+	switch b[2] {
+	case formatDense:
+		if len(data) != registers*3/4 {
+			return fmt.Errorf("%w: %d bytes of dense registers", ErrInvalidSketch, len(data))
+		}
+		dense := make([]uint8, registers)
+		for i, j := 0, 0; i < registers; i, j = i+4, j+3 {
+			dense[i] = data[j] >> 2
+			dense[i+1] = (data[j]&0x03)<<4 | data[j+1]>>4
+			dense[i+2] = (data[j+1]&0x0f)<<2 | data[j+2]>>6
+			dense[i+3] = data[j+2] & 0x3f
+		}
+		for _, rank := range dense {
+			if rank > maxRank {
+				return fmt.Errorf("%w: register value %d", ErrInvalidSketch, rank)
+			}
+		}
+		s.sparse, s.dense = nil, dense
+		return nil
+
+	case formatSparse:
+		n, read := binary.Uvarint(data)
+		if read <= 0 || n > sparseLimit {
+			return fmt.Errorf("%w: sparse register count", ErrInvalidSketch)
+		}
+		data = data[read:]
+		sparse := make(map[uint16]uint8, n)
+		entry := uint64(0)
+		for range n {
+			delta, read := binary.Uvarint(data)
+			if read <= 0 {
+				return fmt.Errorf("%w: truncated sparse registers", ErrInvalidSketch)
+			}
+			data = data[read:]
+			entry += delta
+			index, rank := entry>>6, uint8(entry&0x3f)
+			if index >= registers || rank == 0 || rank > maxRank {
+				return fmt.Errorf("%w: sparse register %d", ErrInvalidSketch, entry)
+			}
+			sparse[uint16(index)] = rank
+		}
+		if len(data) != 0 {
+			return fmt.Errorf("%w: trailing bytes", ErrInvalidSketch)
+		}
+		s.sparse, s.dense = sparse, nil
+		return nil
+	}
+	return fmt.Errorf("%w: format %d", ErrInvalidSketch, b[2])
+}
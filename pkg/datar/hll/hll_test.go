@@ -0,0 +1,98 @@
+package hll
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sketchOf(from, to int) *Sketch {
+	s := New()
+	for i := from; i < to; i++ {
+		s.Insert(Hash(fmt.Sprintf("entity-%d", i)))
+	}
+	return s
+}
+
+func TestEstimate(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, uint64(0), New().Estimate())
+
+	for _, n := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		s := sketchOf(0, n)
+		// Four standard errors of 0.81%, and exact for small counts.
+		assert.InDelta(t, n, s.Estimate(), 0.0325*float64(n)+0.5, "n=%d", n)
+	}
+
+	// Repeated entities count once.
+	s := sketchOf(0, 500)
+	s.Merge(sketchOf(0, 500))
+	for range 3 {
+		s.Insert(Hash("entity-1"))
+	}
+	assert.Equal(t, sketchOf(0, 500).Estimate(), s.Estimate())
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+	for _, n := range []int{100, 50000} {
+		a, b := sketchOf(0, n), sketchOf(n/2, n+n/2)
+		union := sketchOf(0, n+n/2)
+		a.Merge(b)
+		assert.Equal(t, union.Estimate(), a.Estimate(), "n=%d", n)
+
+		// Merging dense into sparse gives the same registers.
+		small := sketchOf(0, 10)
+		small.Merge(sketchOf(0, n))
+		assert.Equal(t, sketchOf(0, n).Estimate(), small.Estimate(), "n=%d", n)
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	t.Parallel()
+	for _, n := range []int{0, 1, 300, 100000} {
+		s := sketchOf(0, n)
+		b, err := s.MarshalBinary()
+		require.NoError(t, err)
+
+		decoded := New()
+		require.NoError(t, decoded.UnmarshalBinary(b))
+		assert.Equal(t, s.Estimate(), decoded.Estimate(), "n=%d", n)
+		assert.Equal(t, s.dense == nil, decoded.dense == nil, "n=%d", n)
+		if s.dense != nil {
+			assert.Equal(t, s.dense, decoded.dense)
+			assert.Len(t, b, 3+registers*3/4)
+		} else {
+			assert.Equal(t, s.sparse, decoded.sparse)
+		}
+	}
+
+	// Sparse sketches stay small.
+	b, err := sketchOf(0, 300).MarshalBinary()
+	require.NoError(t, err)
+	assert.Less(t, len(b), 1000)
+}
+
+func TestUnmarshalBinary_Invalid(t *testing.T) {
+	t.Parallel()
+	sparse, err := sketchOf(0, 10).MarshalBinary()
+	require.NoError(t, err)
+	dense, err := sketchOf(0, 100000).MarshalBinary()
+	require.NoError(t, err)
+
+	for name, b := range map[string][]byte{
+		"empty":       nil,
+		"version":     {2, Precision, formatSparse, 0},
+		"precision":   {encodingVersion, 12, formatSparse, 0},
+		"format":      {encodingVersion, Precision, 7, 0},
+		"truncated":   sparse[:len(sparse)-1],
+		"trailing":    append(append([]byte{}, sparse...), 1),
+		"zero rank":   {encodingVersion, Precision, formatSparse, 1, 64},
+		"short dense": dense[:len(dense)-1],
+		"dense rank":  append([]byte{encodingVersion, Precision, formatDense, 0xff}, dense[4:]...),
+	} {
+		assert.ErrorIs(t, New().UnmarshalBinary(b), ErrInvalidSketch, name)
+	}
+}
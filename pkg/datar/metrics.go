@@ -0,0 +1,315 @@
+package datar
+
+import (
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openflagr/flagr/pkg/entity"
+)
+
+// Metric events are attributed to flags through assignments: the variant each
+// entity was first assigned for a flag. An event of an entity counts for
+// every flag the entity was assigned for in or before the hour of the event,
+// under the variant it was assigned. Events of entities without an
+// assignment, or with one recorded only after the event, are not counted.
+
+// attributionBatchSize is how many entity IDs are looked up per query, and
+// how many rows are written per insert.
+const attributionBatchSize = 500
+
+// maxMetricEvents is how many metric events are buffered between flushes.
+// Events are dropped while the buffer is full, e.g. when the database is down
+// for several flushes.
+const maxMetricEvents = 100_000
+
+// MaxEntityIDLength is the length in bytes of the longest entity ID stored
+// in assignments and conversions. Longer IDs are skipped with a warning, as
+// they would fail the whole batch insert they are in.
+const MaxEntityIDLength = 255
+
+// MetricEvent is an outcome of an entity, e.g. a purchase, sent to
+// POST /metrics/events. Value is 0 for conversion metrics without a value.
+type MetricEvent struct {
+	EntityID  string
+	MetricKey string
+	Value     float64
+	Time      time.Time
+}
+
+type assignmentKey struct {
+	FlagID   int64
+	EntityID string
+}
+
+type assignment struct {
+	VariantID int64
+	Hour      time.Time
+}
+
+// MetricAggregate is the aggregate of one metric's events for a variant in a
+// time range. ValueSumSquares sums the squares of each converting entity's
+// total value in the range, since an entity rather than an event is a trial
+// of an experiment.
+type MetricAggregate struct {
+	MetricKey       string
+	Events          int64
+	Conversions     int64 // entities with at least one event
+	ValueSum        float64
+	ValueSumSquares float64
+}
+
+// VariantMetrics is one variant's assigned entities and metric aggregates.
+type VariantMetrics struct {
+	VariantID int64
+	Exposures int64 // entities first assigned the variant in the time range
+	Metrics   []MetricAggregate
+}
+
+// FlagMetrics is the metric breakdown of a single flag by variant.
+type FlagMetrics struct {
+	FlagID   int64
+	Variants []VariantMetrics
+}
+
+// RecordAssignment records that the entity was assigned the variant of the
+// flag at the given time. Only the first assignment of an entity for a flag
+// is kept. Safe on nil receiver.
+func (e *Engine) RecordAssignment(flagID, variantID int64, entityID string, at time.Time) {
+	if e == nil || e.closed.Load() || variantID == 0 || entityID == "" {
+		return
+	}
+	if len(entityID) > MaxEntityIDLength {
+		logrus.WithField("flagID", flagID).Warn("Datar: skipping assignment of an entity ID longer than 255 bytes")
+		return
+	}
+	e.assignments.LoadOrStore(
+		assignmentKey{FlagID: flagID, EntityID: entityID},
+		assignment{VariantID: variantID, Hour: at.Truncate(time.Hour)},
+	)
+}
+
+// RecordMetricEvent buffers a metric event for attribution on the next
+// flush and reports whether it did. It drops the event when maxMetricEvents
+// are buffered, its entity ID is too long, or the engine is shut down. Safe
+// on nil receiver.
+func (e *Engine) RecordMetricEvent(ev MetricEvent) bool {
+	if e == nil || e.closed.Load() {
+		return false
+	}
+	if len(ev.EntityID) > MaxEntityIDLength {
+		logrus.WithField("metricKey", ev.MetricKey).Warn("Datar: skipping metric event of an entity ID longer than 255 bytes")
+		return false
+	}
+	e.metricMu.Lock()
+	defer e.metricMu.Unlock()
+	if len(e.metricEvents) >= maxMetricEvents {
+		e.droppedMetricEvents++
+		return false
+	}
+	e.metricEvents = append(e.metricEvents, ev)
+	return true
+}
+
+// flushMetrics writes the buffered assignments, then attributes the buffered
+// metric events, so that events can be attributed to assignments recorded in
+// the same flush interval.
+func (e *Engine) flushMetrics() error {
+	var assignments []entity.Assignment
+	e.assignments.Range(func(k, v any) bool {
+		key, a := k.(assignmentKey), v.(assignment)
+		assignments = append(assignments, entity.Assignment{
+			FlagID:     key.FlagID,
+			EntityID:   key.EntityID,
+			VariantID:  a.VariantID,
+			BucketHour: a.Hour,
+		})
+		e.assignments.Delete(k)
+		return true
+	})
+	if len(assignments) > 0 {
+		err := withRetry(func() error {
+			return e.db.Clauses(clause.OnConflict{DoNothing: true}).
+				CreateInBatches(&assignments, attributionBatchSize).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	e.metricMu.Lock()
+	events, dropped := e.metricEvents, e.droppedMetricEvents
+	e.metricEvents, e.droppedMetricEvents = nil, 0
+	e.metricMu.Unlock()
+	if dropped > 0 {
+		logrus.WithField("dropped", dropped).Warn("Datar: metric event buffer full, dropped metric events")
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	logrus.WithField("events", len(events)).Debug("Datar: attributing metric events")
+	return withRetry(func() error { return e.attributeMetricEvents(events) })
+}
+
+type conversionKey struct {
+	FlagID    int64
+	MetricKey string
+	EntityID  string
+	Hour      time.Time
+}
+
+// attributeMetricEvents looks up the assignments of the events' entities
+// and adds the events to the hourly conversions of their variants.
+func (e *Engine) attributeMetricEvents(events []MetricEvent) error {
+	entityIDs := make([]string, 0, len(events))
+	seen := make(map[string]struct{}, len(events))
+	for _, ev := range events {
+		if _, ok := seen[ev.EntityID]; !ok {
+			seen[ev.EntityID] = struct{}{}
+			entityIDs = append(entityIDs, ev.EntityID)
+		}
+	}
+	byEntity := make(map[string][]entity.Assignment)
+	for start := 0; start < len(entityIDs); start += attributionBatchSize {
+		chunk := entityIDs[start:min(start+attributionBatchSize, len(entityIDs))]
+		var rows []entity.Assignment
+		if err := e.db.Where("entity_id IN ?", chunk).Find(&rows).Error; err != nil {
+			return err
+		}
+		for _, a := range rows {
+			byEntity[a.EntityID] = append(byEntity[a.EntityID], a)
+		}
+	}
+
+	conversions := make(map[conversionKey]*entity.Conversion)
+	for _, ev := range events {
+		hour := ev.Time.Truncate(time.Hour)
+		for _, a := range byEntity[ev.EntityID] {
+			if hour.Before(a.BucketHour) {
+				continue
+			}
+			k := conversionKey{FlagID: a.FlagID, MetricKey: ev.MetricKey, EntityID: ev.EntityID, Hour: hour}
+			c, ok := conversions[k]
+			if !ok {
+				c = &entity.Conversion{
+					FlagID:     a.FlagID,
+					MetricKey:  ev.MetricKey,
+					EntityID:   ev.EntityID,
+					BucketHour: hour,
+					VariantID:  a.VariantID,
+				}
+				conversions[k] = c
+			}
+			c.EventCount++
+			c.Value += ev.Value
+		}
+	}
+	if len(conversions) == 0 {
+		return nil
+	}
+
+	rows := make([]entity.Conversion, 0, len(conversions))
+	for _, c := range conversions {
+		rows = append(rows, *c)
+	}
+	return e.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "flag_id"},
+			{Name: "metric_key"},
+			{Name: "entity_id"},
+			{Name: "bucket_hour"},
+		},
+		DoUpdates: clause.Set{
+			{Column: clause.Column{Name: "event_count"}, Value: gorm.Expr(e.addExpr("datar_conversions", "event_count"))},
+			{Column: clause.Column{Name: "value"}, Value: gorm.Expr(e.addExpr("datar_conversions", "value"))},
+		},
+	}).CreateInBatches(&rows, attributionBatchSize).Error
+}
+
+// addExpr returns the additive UPSERT expression of a column for the active
+// dialect, like addEvalExpr.
+func (e *Engine) addExpr(table, column string) string {
+	if e.db.Name() == "mysql" {
+		return table + "." + column + " + VALUES(" + column + ")"
+	}
+	return table + "." + column + " + EXCLUDED." + column
+}
+
+// QueryFlagMetrics returns the assigned entities and metric aggregates of
+// every variant of a flag in the given time range.
+func (e *Engine) QueryFlagMetrics(flagID int64, from, to time.Time) (*FlagMetrics, error) {
+	if e == nil {
+		return nil, errNilEngine
+	}
+	where := "flag_id = ? AND bucket_hour >= ? AND bucket_hour < ?"
+	args := []any{flagID, from, to}
+
+	var exposures []struct {
+		VariantID int64
+		Count     int64
+	}
+	if err := e.db.Model(&entity.Assignment{}).
+		Select("variant_id, COUNT(*) AS count").
+		Where(where, args...).Group("variant_id").
+		Scan(&exposures).Error; err != nil {
+		logrus.WithError(err).Error("Datar: QueryFlagMetrics exposures failed")
+		return nil, err
+	}
+
+	// The totals of each entity in the time range, then their sums. Like the
+	// exposures, only entities first assigned in the range count.
+	perEntity := e.db.Table("datar_conversions AS c").
+		Joins("JOIN datar_assignments AS a ON a.flag_id = c.flag_id AND a.entity_id = c.entity_id").
+		Select("c.variant_id, c.metric_key, c.entity_id, SUM(c.event_count) AS events, SUM(c.value) AS total").
+		Where("c.flag_id = ? AND c.bucket_hour >= ? AND c.bucket_hour < ? AND a.bucket_hour >= ? AND a.bucket_hour < ?",
+			flagID, from, to, from, to).
+		Group("c.variant_id, c.metric_key, c.entity_id")
+	var metrics []struct {
+		VariantID int64
+		MetricAggregate
+	}
+	if err := e.db.Table("(?) AS per_entity", perEntity).
+		Select("variant_id, metric_key, SUM(events) AS events, COUNT(*) AS conversions, " +
+			"SUM(total) AS value_sum, SUM(total * total) AS value_sum_squares").
+		Group("variant_id, metric_key").
+		Scan(&metrics).Error; err != nil {
+		logrus.WithError(err).Error("Datar: QueryFlagMetrics conversions failed")
+		return nil, err
+	}
+
+	variants := make(map[int64]*VariantMetrics)
+	variant := func(id int64) *VariantMetrics {
+		v, ok := variants[id]
+		if !ok {
+			v = &VariantMetrics{VariantID: id}
+			variants[id] = v
+		}
+		return v
+	}
+	metric := func(v *VariantMetrics, key string) *MetricAggregate {
+		for i := range v.Metrics {
+			if v.Metrics[i].MetricKey == key {
+				return &v.Metrics[i]
+			}
+		}
+		v.Metrics = append(v.Metrics, MetricAggregate{MetricKey: key})
+		return &v.Metrics[len(v.Metrics)-1]
+	}
+	for _, x := range exposures {
+		variant(x.VariantID).Exposures = x.Count
+	}
+	for _, x := range metrics {
+		*metric(variant(x.VariantID), x.MetricKey) = x.MetricAggregate
+	}
+
+	fm := &FlagMetrics{FlagID: flagID, Variants: make([]VariantMetrics, 0, len(variants))}
+	for _, v := range variants {
+		sort.Slice(v.Metrics, func(i, j int) bool { return v.Metrics[i].MetricKey < v.Metrics[j].MetricKey })
+		fm.Variants = append(fm.Variants, *v)
+	}
+	sort.Slice(fm.Variants, func(i, j int) bool { return fm.Variants[i].VariantID < fm.Variants[j].VariantID })
+	return fm, nil
+}
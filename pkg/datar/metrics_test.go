@@ -0,0 +1,255 @@
+package datar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openflagr/flagr/pkg/entity"
+)
+
+func TestRecordAssignment_FirstWins(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	now := time.Now().UTC().Truncate(time.Hour)
+	e.RecordAssignment(1, 10, "u1", now)
+	e.RecordAssignment(1, 20, "u1", now) // buffered: ignored
+	e.RecordAssignment(1, 0, "u2", now)  // no variant: ignored
+	e.RecordAssignment(1, 10, "", now)   // no entity: ignored
+	require.NoError(t, e.flushMetrics())
+	e.RecordAssignment(1, 20, "u1", now) // stored: ignored
+	require.NoError(t, e.flushMetrics())
+
+	fm, err := e.QueryFlagMetrics(1, now.Add(-time.Hour), now.Add(time.Hour))
+	require.NoError(t, err)
+	if assert.Len(t, fm.Variants, 1) {
+		assert.Equal(t, int64(10), fm.Variants[0].VariantID)
+		assert.Equal(t, int64(1), fm.Variants[0].Exposures)
+	}
+}
+
+func TestRecordMetrics_LongEntityID(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	now := time.Now()
+	long := strings.Repeat("x", MaxEntityIDLength+1)
+	e.RecordAssignment(1, 10, long, now)
+	e.RecordAssignment(1, 10, "u1", now)
+	assert.False(t, e.RecordMetricEvent(MetricEvent{EntityID: long, MetricKey: "purchase", Time: now}))
+	assert.True(t, e.RecordMetricEvent(MetricEvent{EntityID: "u1", MetricKey: "purchase", Time: now}))
+	require.NoError(t, e.flushMetrics())
+
+	var assignments, conversions int64
+	require.NoError(t, db.Model(&entity.Assignment{}).Count(&assignments).Error)
+	require.NoError(t, db.Model(&entity.Conversion{}).Count(&conversions).Error)
+	assert.Equal(t, int64(1), assignments)
+	assert.Equal(t, int64(1), conversions)
+}
+
+func TestFlushMetrics_Attribution(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	now := time.Now().UTC().Truncate(time.Hour)
+	e.RecordAssignment(1, 10, "u1", now)
+	e.RecordAssignment(1, 10, "u2", now)
+	e.RecordAssignment(1, 20, "u3", now)
+	e.RecordAssignment(2, 30, "u1", now)
+	e.RecordAssignment(1, 20, "late", now.Add(time.Hour))
+
+	e.RecordMetricEvent(MetricEvent{EntityID: "u1", MetricKey: "purchase", Value: 10, Time: now})
+	e.RecordMetricEvent(MetricEvent{EntityID: "u1", MetricKey: "purchase", Value: 5, Time: now.Add(time.Minute)})
+	e.RecordMetricEvent(MetricEvent{EntityID: "u3", MetricKey: "purchase", Value: 2, Time: now})
+	e.RecordMetricEvent(MetricEvent{EntityID: "u3", MetricKey: "signup", Time: now})
+	e.RecordMetricEvent(MetricEvent{EntityID: "unknown", MetricKey: "purchase", Value: 1, Time: now})
+	e.RecordMetricEvent(MetricEvent{EntityID: "late", MetricKey: "purchase", Value: 1, Time: now})
+	require.NoError(t, e.flushMetrics())
+
+	// Events of a later flush add to the same hourly rows and to the total
+	// value of the entity, and converting entities are counted once.
+	e.RecordMetricEvent(MetricEvent{EntityID: "u1", MetricKey: "purchase", Value: 1, Time: now})
+	require.NoError(t, e.flushMetrics())
+
+	fm, err := e.QueryFlagMetrics(1, now.Add(-time.Hour), now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), fm.FlagID)
+	require.Len(t, fm.Variants, 2)
+
+	v10 := fm.Variants[0]
+	assert.Equal(t, int64(10), v10.VariantID)
+	assert.Equal(t, int64(2), v10.Exposures)
+	assert.Equal(t, []MetricAggregate{
+		{MetricKey: "purchase", Events: 3, Conversions: 1, ValueSum: 16, ValueSumSquares: 256},
+	}, v10.Metrics)
+
+	v20 := fm.Variants[1]
+	assert.Equal(t, int64(20), v20.VariantID)
+	assert.Equal(t, int64(2), v20.Exposures)
+	assert.Equal(t, []MetricAggregate{
+		{MetricKey: "purchase", Events: 1, Conversions: 1, ValueSum: 2, ValueSumSquares: 4},
+		{MetricKey: "signup", Events: 1, Conversions: 1},
+	}, v20.Metrics)
+
+	fm, err = e.QueryFlagMetrics(2, now.Add(-time.Hour), now.Add(time.Hour))
+	require.NoError(t, err)
+	if assert.Len(t, fm.Variants, 1) {
+		assert.Equal(t, []MetricAggregate{
+			{MetricKey: "purchase", Events: 3, Conversions: 1, ValueSum: 16, ValueSumSquares: 256},
+		}, fm.Variants[0].Metrics)
+	}
+}
+
+func TestQueryFlagMetrics_TotalsInRange(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	now := time.Now().UTC().Truncate(time.Hour)
+	e.RecordAssignment(1, 10, "u1", now)
+	e.RecordAssignment(1, 10, "u2", now)
+	e.RecordMetricEvent(MetricEvent{EntityID: "u1", MetricKey: "revenue", Value: 10, Time: now})
+	e.RecordMetricEvent(MetricEvent{EntityID: "u1", MetricKey: "revenue", Value: 5, Time: now.Add(time.Hour)})
+	e.RecordMetricEvent(MetricEvent{EntityID: "u2", MetricKey: "revenue", Value: 2, Time: now.Add(time.Hour)})
+	require.NoError(t, e.flushMetrics())
+
+	for _, tc := range []struct {
+		to   time.Time
+		want MetricAggregate
+	}{
+		{now.Add(time.Hour), MetricAggregate{MetricKey: "revenue", Events: 1, Conversions: 1, ValueSum: 10, ValueSumSquares: 100}},
+		{now.Add(2 * time.Hour), MetricAggregate{MetricKey: "revenue", Events: 3, Conversions: 2, ValueSum: 17, ValueSumSquares: 229}},
+	} {
+		fm, err := e.QueryFlagMetrics(1, now, tc.to)
+		require.NoError(t, err)
+		if assert.Len(t, fm.Variants, 1) {
+			assert.Equal(t, []MetricAggregate{tc.want}, fm.Variants[0].Metrics, "to %v", tc.to)
+		}
+	}
+}
+
+func TestQueryFlagMetrics_AssignedInRange(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	now := time.Now().UTC().Truncate(time.Hour)
+	e.RecordAssignment(1, 10, "early", now.Add(-time.Hour))
+	e.RecordAssignment(1, 10, "u1", now)
+	e.RecordMetricEvent(MetricEvent{EntityID: "early", MetricKey: "purchase", Value: 3, Time: now})
+	e.RecordMetricEvent(MetricEvent{EntityID: "u1", MetricKey: "purchase", Value: 1, Time: now})
+	require.NoError(t, e.flushMetrics())
+
+	// The entity assigned before the range is neither exposed nor converted.
+	fm, err := e.QueryFlagMetrics(1, now, now.Add(time.Hour))
+	require.NoError(t, err)
+	if assert.Len(t, fm.Variants, 1) {
+		assert.Equal(t, int64(1), fm.Variants[0].Exposures)
+		assert.Equal(t, []MetricAggregate{{MetricKey: "purchase", Events: 1, Conversions: 1, ValueSum: 1, ValueSumSquares: 1}},
+			fm.Variants[0].Metrics)
+	}
+}
+
+func TestShutdown_FlushesMetrics(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+
+	now := time.Now()
+	e.RecordAssignment(1, 10, "u1", now)
+	e.RecordMetricEvent(MetricEvent{EntityID: "u1", MetricKey: "purchase", Time: now})
+	require.NoError(t, e.Shutdown())
+
+	// Recording after shutdown is a no-op.
+	e.RecordAssignment(1, 10, "u2", now)
+	e.RecordMetricEvent(MetricEvent{EntityID: "u1", MetricKey: "purchase", Time: now})
+	assert.Len(t, e.metricEvents, 0)
+
+	fm, err := e.QueryFlagMetrics(1, now.Add(-time.Hour), now.Add(time.Hour))
+	require.NoError(t, err)
+	if assert.Len(t, fm.Variants, 1) {
+		assert.Equal(t, int64(1), fm.Variants[0].Exposures)
+		assert.Equal(t, int64(1), fm.Variants[0].Metrics[0].Conversions)
+	}
+}
+
+func TestQueryFlagMetrics_NilEngine(t *testing.T) {
+	t.Parallel()
+	var e *Engine
+	assert.NotPanics(t, func() {
+		e.RecordAssignment(1, 1, "u1", time.Now())
+		e.RecordMetricEvent(MetricEvent{EntityID: "u1", MetricKey: "m"})
+	})
+	fm, err := e.QueryFlagMetrics(1, time.Now(), time.Now())
+	assert.Error(t, err)
+	assert.Nil(t, fm)
+}
+
+func TestQueryFlagMetrics_DBError(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	require.NoError(t, db.Exec("DROP TABLE datar_conversions").Error)
+
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	_, err := e.QueryFlagMetrics(1, time.Now(), time.Now())
+	assert.Error(t, err, "should fail with missing conversions table")
+}
+
+func TestFlushMetrics_DBError(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+
+	now := time.Now()
+	e.RecordAssignment(1, 10, "u1", now)
+	e.RecordMetricEvent(MetricEvent{EntityID: "u1", MetricKey: "purchase", Time: now})
+	require.NoError(t, db.Exec("DROP TABLE datar_conversions").Error)
+
+	assert.Error(t, e.Shutdown(), "should fail flushing to dropped table")
+}
+
+func TestRecordMetricEvent_BufferFull(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	now := time.Now()
+	for i := range maxMetricEvents + 10 {
+		ok := e.RecordMetricEvent(MetricEvent{EntityID: "u1", MetricKey: "purchase", Time: now})
+		assert.Equal(t, i < maxMetricEvents, ok, "event %d", i)
+	}
+	e.metricMu.Lock()
+	assert.Len(t, e.metricEvents, maxMetricEvents)
+	assert.Equal(t, int64(10), e.droppedMetricEvents)
+	e.metricMu.Unlock()
+
+	require.NoError(t, e.flushMetrics())
+	e.metricMu.Lock()
+	assert.Empty(t, e.metricEvents)
+	assert.Zero(t, e.droppedMetricEvents)
+	e.metricMu.Unlock()
+}
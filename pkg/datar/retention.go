@@ -0,0 +1,283 @@
+package datar
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openflagr/flagr/pkg/datar/hll"
+	"github.com/openflagr/flagr/pkg/entity"
+)
+
+// Hourly rows older than the hourly retention are rolled up into a row per
+// UTC day in datar_daily_events, and rows of both tables older than the daily
+// retention are dropped. Queries read both tables, so a rolled-up day counts
+// in a time range when its midnight (UTC) is in the range. Dimension counts
+// are not rolled up; they are kept hourly until the daily retention.
+
+// RetentionOptions configures how long evaluation counts are kept. Zero keeps
+// them forever.
+type RetentionOptions struct {
+	HourlyDays int // days hourly rows are kept before they are rolled up
+	DailyDays  int // days after which rows of both tables and dimension counts are dropped
+}
+
+const (
+	compactionLock = "compaction"
+	// compactionLockTTL is how long the lock outlives an instance that died
+	// while compacting. It is renewed for every day of a flag rolled up.
+	compactionLockTTL = 10 * time.Minute
+	day               = 24 * time.Hour
+)
+
+var errLockLost = errors.New("datar: compaction lock lost")
+
+// eventsSQL is the rows of both tables, daily rows as rows of their first
+// hour. The granularity column tells them apart.
+const eventsSQL = `SELECT id, flag_id, variant_id, segment_id, bucket_hour, eval_count, sketch, updated_at, 'hour' AS granularity
+FROM datar_hourly_events WHERE bucket_hour >= ? AND bucket_hour < ?
+UNION ALL
+SELECT id, flag_id, variant_id, segment_id, bucket_day AS bucket_hour, eval_count, sketch, last_evaluated_at AS updated_at, 'day' AS granularity
+FROM datar_daily_events WHERE bucket_day >= ? AND bucket_day < ?`
+
+// events is the evaluation counts of both tables in the given time range, as
+// the table datar_events with the columns of datar_hourly_events and
+// granularity.
+func (e *Engine) events(from, to time.Time) *gorm.DB {
+	return e.db.Table("(?) AS datar_events", e.db.Raw(eventsSQL, from, to, from, to))
+}
+
+// instanceID identifies the engine as the owner of locks.
+func instanceID() string {
+	host, _ := os.Hostname()
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(b))
+}
+
+// acquireLock takes or renews the named lock for ttl. It reports false when
+// another instance holds the lock.
+func (e *Engine) acquireLock(name string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	res := e.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&entity.DatarLock{Name: name, Owner: e.owner, ExpiresAt: now.Add(ttl)})
+	if res.Error != nil || res.RowsAffected == 1 {
+		return res.Error == nil, res.Error
+	}
+	res = e.db.Model(&entity.DatarLock{}).
+		Where("name = ? AND (owner = ? OR expires_at < ?)", name, e.owner, now).
+		Updates(map[string]any{"owner": e.owner, "expires_at": now.Add(ttl)})
+	return res.RowsAffected == 1, res.Error
+}
+
+func (e *Engine) releaseLock(name string) {
+	if err := e.db.Where("name = ? AND owner = ?", name, e.owner).Delete(&entity.DatarLock{}).Error; err != nil {
+		logrus.WithError(err).WithField("lock", name).Warn("Datar: failed to release lock")
+	}
+}
+
+// StartCompaction applies the retention every interval. Instances sharing the
+// database take turns through a lock in datar_locks, so the rollup runs on
+// one of them at a time. Compaction stops on Shutdown.
+func (e *Engine) StartCompaction(interval time.Duration, opts RetentionOptions) {
+	if e == nil || interval <= 0 || (opts.HourlyDays <= 0 && opts.DailyDays <= 0) {
+		return
+	}
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-e.closeCh:
+				return
+			case <-ticker.C:
+				if err := e.compact(time.Now(), opts); err != nil {
+					logrus.WithError(err).Error("Datar: compaction failed")
+				}
+			}
+		}
+	}()
+}
+
+// compact runs one round of compaction at now, unless another instance holds
+// the lock.
+func (e *Engine) compact(now time.Time, opts RetentionOptions) error {
+	ok, err := e.acquireLock(compactionLock, compactionLockTTL)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		logrus.Debug("Datar: compaction is running on another instance")
+		return nil
+	}
+	defer e.releaseLock(compactionLock)
+
+	today := now.UTC().Truncate(day)
+	if opts.HourlyDays > 0 {
+		if err := e.rollup(today.AddDate(0, 0, -opts.HourlyDays)); err != nil {
+			return err
+		}
+	}
+	if opts.DailyDays > 0 {
+		return e.dropBefore(today.AddDate(0, 0, -opts.DailyDays))
+	}
+	return nil
+}
+
+// rollup rolls up the hourly rows of the days before cutoff, a UTC midnight,
+// oldest day first.
+func (e *Engine) rollup(cutoff time.Time) error {
+	for {
+		var oldest []time.Time
+		if err := e.db.Model(&entity.HourlyEvent{}).
+			Where("bucket_hour < ?", cutoff).
+			Order("bucket_hour").Limit(1).
+			Pluck("bucket_hour", &oldest).Error; err != nil {
+			return err
+		}
+		if len(oldest) == 0 {
+			return nil
+		}
+		d := oldest[0].UTC().Truncate(day)
+
+		var flagIDs []int64
+		if err := e.db.Model(&entity.HourlyEvent{}).
+			Where("bucket_hour >= ? AND bucket_hour < ?", d, d.Add(day)).
+			Distinct().Pluck("flag_id", &flagIDs).Error; err != nil {
+			return err
+		}
+		for _, flagID := range flagIDs {
+			ok, err := e.acquireLock(compactionLock, compactionLockTTL)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return errLockLost
+			}
+			if err := e.rollupDay(flagID, d); err != nil {
+				return fmt.Errorf("rolling up flag %d on %s: %w", flagID, d.Format("2006-01-02"), err)
+			}
+		}
+	}
+}
+
+// rollupKey is the dimensions of a daily row of a flag.
+type rollupKey struct {
+	VariantID int64
+	SegmentID int64
+}
+
+// rollupDay moves the hourly rows of a flag on the UTC day d into its daily
+// rows, adding to the rows of hours rolled up before.
+func (e *Engine) rollupDay(flagID int64, d time.Time) error {
+	type row struct {
+		ID        uint
+		VariantID int64
+		SegmentID int64
+		EvalCount int64
+		Sketch    []byte
+		UpdatedAt time.Time
+	}
+	return e.db.Transaction(func(tx *gorm.DB) error {
+		where := "flag_id = ? AND bucket_hour >= ? AND bucket_hour < ?"
+		var hourly []row
+		if err := tx.Model(&entity.HourlyEvent{}).
+			Select("id, variant_id, segment_id, eval_count, sketch, updated_at").
+			Where(where, flagID, d, d.Add(day)).
+			Scan(&hourly).Error; err != nil {
+			return err
+		}
+		var existing []entity.DailyEvent
+		if err := tx.Where("flag_id = ? AND bucket_day = ?", flagID, d).Find(&existing).Error; err != nil {
+			return err
+		}
+
+		daily := make(map[rollupKey]*entity.DailyEvent, len(existing))
+		sketches := sketchMerger[rollupKey]{}
+		addSketch := func(k rollupKey, b []byte) {
+			if b == nil {
+				return
+			}
+			s := hll.New()
+			if err := s.UnmarshalBinary(b); err != nil {
+				logrus.WithError(err).WithField("flagID", flagID).Warn("Datar: dropping unreadable entity sketch")
+				return
+			}
+			sketches.add(k, s)
+		}
+		for i := range existing {
+			k := rollupKey{existing[i].VariantID, existing[i].SegmentID}
+			daily[k] = &existing[i]
+			addSketch(k, existing[i].Sketch)
+		}
+		for _, r := range hourly {
+			k := rollupKey{r.VariantID, r.SegmentID}
+			dr, ok := daily[k]
+			if !ok {
+				dr = &entity.DailyEvent{FlagID: flagID, BucketDay: d, VariantID: r.VariantID, SegmentID: r.SegmentID}
+				daily[k] = dr
+			}
+			dr.EvalCount += r.EvalCount
+			if r.UpdatedAt.After(dr.LastEvaluatedAt) {
+				dr.LastEvaluatedAt = r.UpdatedAt
+			}
+			addSketch(k, r.Sketch)
+		}
+
+		for k, dr := range daily {
+			if s, ok := sketches[k]; ok {
+				b, err := s.MarshalBinary()
+				if err != nil {
+					return err
+				}
+				dr.Sketch = b
+			}
+			if err := tx.Save(dr).Error; err != nil {
+				return err
+			}
+		}
+
+		// Rows flushed or rolled up by someone else since they were read
+		// would be lost or counted twice.
+		res := tx.Where(where, flagID, d, d.Add(day)).Delete(&entity.HourlyEvent{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != int64(len(hourly)) {
+			return fmt.Errorf("read %d hourly rows, deleted %d", len(hourly), res.RowsAffected)
+		}
+		return nil
+	})
+}
+
+// dropBefore deletes the rows of both tables, and the dimension counts, before
+// cutoff.
+func (e *Engine) dropBefore(cutoff time.Time) error {
+	res := e.db.Where("bucket_day < ?", cutoff).Delete(&entity.DailyEvent{})
+	if res.Error != nil {
+		return res.Error
+	}
+	dropped := res.RowsAffected
+	for _, model := range []any{
+		&entity.HourlyEvent{}, &entity.DimensionHourlyEvent{}, &entity.Assignment{}, &entity.Conversion{},
+	} {
+		res = e.db.Where("bucket_hour < ?", cutoff).Delete(model)
+		if res.Error != nil {
+			return res.Error
+		}
+		dropped += res.RowsAffected
+	}
+	if dropped > 0 {
+		logrus.WithFields(logrus.Fields{"rows": dropped, "before": cutoff}).Info("Datar: dropped expired counts and metrics")
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package datar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openflagr/flagr/pkg/datar/hll"
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func marshalSketch(t *testing.T, entityIDs ...string) []byte {
+	t.Helper()
+	s := hll.New()
+	for _, id := range entityIDs {
+		s.Insert(hll.Hash(id))
+	}
+	b, err := s.MarshalBinary()
+	require.NoError(t, err)
+	return b
+}
+
+func countRows(t *testing.T, db *gorm.DB, model any) int64 {
+	t.Helper()
+	var n int64
+	require.NoError(t, db.Model(model).Count(&n).Error)
+	return n
+}
+
+func TestCompact_Rollup(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	createFlag(t, db, 1, "test", "flag", true)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	now := time.Now().UTC()
+	old := now.Truncate(day).AddDate(0, 0, -10)
+	recent := now.Truncate(time.Hour)
+	require.NoError(t, db.Create(&[]entity.HourlyEvent{
+		{FlagID: 1, VariantID: 1, SegmentID: 10, BucketHour: old.Add(time.Hour), EvalCount: 3, Sketch: marshalSketch(t, "a", "b")},
+		{FlagID: 1, VariantID: 1, SegmentID: 10, BucketHour: old.Add(5 * time.Hour), EvalCount: 4, Sketch: marshalSketch(t, "b", "c")},
+		{FlagID: 1, VariantID: 2, SegmentID: 0, BucketHour: old.Add(5 * time.Hour), EvalCount: 5},
+		{FlagID: 1, VariantID: 1, SegmentID: 10, BucketHour: recent, EvalCount: 7, Sketch: marshalSketch(t, "a", "d")},
+	}).Error)
+
+	from, to := now.AddDate(0, 0, -30), now.Add(time.Hour)
+	before, err := e.QueryFlagSummaryBreakdown(1, from, to)
+	require.NoError(t, err)
+	beforeRows, err := e.QuerySummary(from, to, 10, 0)
+	require.NoError(t, err)
+
+	require.NoError(t, e.compact(now, RetentionOptions{HourlyDays: 7}))
+	assert.Equal(t, int64(1), countRows(t, db, &entity.HourlyEvent{}), "only the recent hour is left")
+	var daily []entity.DailyEvent
+	require.NoError(t, db.Order("variant_id").Find(&daily).Error)
+	require.Len(t, daily, 2)
+	assert.Equal(t, int64(7), daily[0].EvalCount)
+	assert.True(t, daily[0].BucketDay.Equal(old))
+	assert.Equal(t, int64(5), daily[1].EvalCount)
+	assert.Nil(t, daily[1].Sketch)
+
+	// Queries read both tables.
+	after, err := e.QueryFlagSummaryBreakdown(1, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+	assert.Equal(t, int64(4), after.UniqueEntities)
+	assert.Equal(t, []VariantEntry{
+		{VariantID: 1, Count: 14, UniqueEntities: 4},
+		{VariantID: 2, Count: 5},
+	}, after.Variants)
+	afterRows, err := e.QuerySummary(from, to, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, afterRows, 1)
+	assert.Equal(t, beforeRows[0].TotalEvalCount, afterRows[0].TotalEvalCount)
+	assert.Equal(t, int64(19), afterRows[0].TotalEvalCount)
+	assert.Equal(t, int64(4), afterRows[0].UniqueEntities)
+
+	// Hours flushed late add to the day.
+	require.NoError(t, db.Create(&entity.HourlyEvent{
+		FlagID: 1, VariantID: 1, SegmentID: 10, BucketHour: old.Add(23 * time.Hour), EvalCount: 1, Sketch: marshalSketch(t, "e"),
+	}).Error)
+	require.NoError(t, e.compact(now, RetentionOptions{HourlyDays: 7}))
+	require.NoError(t, db.Order("variant_id").Find(&daily).Error)
+	require.Len(t, daily, 2)
+	assert.Equal(t, int64(8), daily[0].EvalCount)
+	after, err = e.QueryFlagSummaryBreakdown(1, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), after.UniqueEntities)
+
+	// Nothing left to roll up.
+	require.NoError(t, e.compact(now, RetentionOptions{HourlyDays: 7}))
+	assert.Equal(t, int64(1), countRows(t, db, &entity.HourlyEvent{}))
+}
+
+func TestCompact_Drop(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	now := time.Now().UTC()
+	today := now.Truncate(day)
+	require.NoError(t, db.Create(&[]entity.HourlyEvent{
+		{FlagID: 1, VariantID: 1, BucketHour: today.AddDate(0, 0, -40), EvalCount: 1},
+		{FlagID: 1, VariantID: 1, BucketHour: today.AddDate(0, 0, -20), EvalCount: 1},
+		{FlagID: 1, VariantID: 1, BucketHour: today.AddDate(0, 0, -2), EvalCount: 1},
+	}).Error)
+	require.NoError(t, db.Create(&entity.DailyEvent{FlagID: 1, VariantID: 1, BucketDay: today.AddDate(0, 0, -31), EvalCount: 1}).Error)
+	require.NoError(t, db.Create(&[]entity.Assignment{
+		{FlagID: 1, VariantID: 1, EntityID: "u1", BucketHour: today.AddDate(0, 0, -40)},
+		{FlagID: 1, VariantID: 1, EntityID: "u2", BucketHour: today.AddDate(0, 0, -2)},
+	}).Error)
+	require.NoError(t, db.Create(&[]entity.Conversion{
+		{FlagID: 1, MetricKey: "purchase", EntityID: "u1", BucketHour: today.AddDate(0, 0, -40), EventCount: 1},
+		{FlagID: 1, MetricKey: "purchase", EntityID: "u1", BucketHour: today.AddDate(0, 0, -2), EventCount: 1},
+	}).Error)
+
+	// Without an hourly retention, hourly rows are dropped after the daily
+	// retention.
+	require.NoError(t, e.compact(now, RetentionOptions{DailyDays: 30}))
+	assert.Equal(t, int64(2), countRows(t, db, &entity.HourlyEvent{}))
+	assert.Equal(t, int64(0), countRows(t, db, &entity.DailyEvent{}))
+	assert.Equal(t, int64(1), countRows(t, db, &entity.Assignment{}))
+	assert.Equal(t, int64(1), countRows(t, db, &entity.Conversion{}))
+
+	require.NoError(t, e.compact(now, RetentionOptions{HourlyDays: 7, DailyDays: 30}))
+	assert.Equal(t, int64(1), countRows(t, db, &entity.HourlyEvent{}))
+	assert.Equal(t, int64(1), countRows(t, db, &entity.DailyEvent{}))
+}
+
+func TestCompact_Lock(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e1 := New(db, true, time.Hour)
+	e2 := New(db, true, time.Hour)
+	require.NotNil(t, e1)
+	require.NotNil(t, e2)
+	defer e1.Shutdown()
+	defer e2.Shutdown()
+	assert.NotEqual(t, e1.owner, e2.owner)
+
+	ok, err := e1.acquireLock(compactionLock, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = e2.acquireLock(compactionLock, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, ok, "held by e1")
+	ok, err = e1.acquireLock(compactionLock, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok, "renewed by e1")
+
+	// Another instance compacting leaves the rows alone.
+	now := time.Now().UTC()
+	require.NoError(t, db.Create(&entity.HourlyEvent{FlagID: 1, VariantID: 1, BucketHour: now.AddDate(0, 0, -10), EvalCount: 1}).Error)
+	require.NoError(t, e2.compact(now, RetentionOptions{HourlyDays: 7}))
+	assert.Equal(t, int64(1), countRows(t, db, &entity.HourlyEvent{}))
+
+	e1.releaseLock(compactionLock)
+	require.NoError(t, e2.compact(now, RetentionOptions{HourlyDays: 7}))
+	assert.Equal(t, int64(0), countRows(t, db, &entity.HourlyEvent{}))
+	assert.Equal(t, int64(0), countRows(t, db, &entity.DatarLock{}), "released after compaction")
+
+	// An expired lock is taken over.
+	require.NoError(t, db.Create(&entity.DatarLock{Name: compactionLock, Owner: "gone", ExpiresAt: now.Add(-time.Minute)}).Error)
+	ok, err = e1.acquireLock(compactionLock, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
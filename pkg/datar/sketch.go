@@ -0,0 +1,236 @@
+package datar
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/openflagr/flagr/pkg/datar/hll"
+	"github.com/openflagr/flagr/pkg/entity"
+)
+
+// Distinct entities are counted with a HyperLogLog sketch per FlushKey,
+// stored with the hourly counters. Sketches of several hours, variants or
+// segments merge into the sketch of their union, so an entity evaluated in
+// every hour of a week counts once for the week. Estimates are within about
+// 1% of the true count; evaluations without an entity ID, including those
+// the recorder passes without their generated ID, are not counted.
+
+// entitySketch is a sketch of the in-memory buffer.
+type entitySketch struct {
+	mu     sync.Mutex
+	sketch *hll.Sketch
+}
+
+// RecordEntity is Record that also counts the entity towards the distinct
+// entities of the flag, variant and segment. Safe on nil receiver.
+func (e *Engine) RecordEntity(flagID, variantID, segmentID int64, entityID string) {
+	if e == nil || e.closed.Load() {
+		return
+	}
+	e.Record(flagID, variantID, segmentID)
+	if entityID == "" {
+		return
+	}
+
+	key := FlushKey{
+		FlagID:    flagID,
+		VariantID: variantID,
+		SegmentID: segmentID,
+		Hour:      time.Now().Truncate(time.Hour),
+	}
+	hash := hll.Hash(entityID)
+	v, ok := e.sketches.Load(key)
+	if !ok {
+		v, _ = e.sketches.LoadOrStore(key, &entitySketch{sketch: hll.New()})
+	}
+	s := v.(*entitySketch)
+	s.mu.Lock()
+	s.sketch.Insert(hash)
+	s.mu.Unlock()
+}
+
+// snapshotSketches drains the buffered sketches, like SnapshotAndReset.
+func (e *Engine) snapshotSketches() map[FlushKey]*hll.Sketch {
+	result := make(map[FlushKey]*hll.Sketch)
+	e.sketches.Range(func(k, v any) bool {
+		e.sketches.Delete(k)
+		s := v.(*entitySketch)
+		s.mu.Lock()
+		result[k.(FlushKey)] = s.sketch
+		s.sketch = hll.New() // late inserts go nowhere, like late increments
+		s.mu.Unlock()
+		return true
+	})
+	return result
+}
+
+// sketchKey matches FlushKeys to rows regardless of the time zone of the hour.
+type sketchKey struct {
+	FlagID    int64
+	VariantID int64
+	SegmentID int64
+	Hour      int64 // Unix seconds
+}
+
+// sketchRow is an hourly row with its sketch.
+type sketchRow struct {
+	ID         uint
+	FlagID     int64
+	VariantID  int64
+	SegmentID  int64
+	BucketHour time.Time
+	Sketch     []byte
+}
+
+// mergeSketches merges the flushed sketches into the sketches of their rows,
+// which must exist.
+func mergeSketches(tx *gorm.DB, sketches map[FlushKey]*hll.Sketch) error {
+	pending := make(map[sketchKey]*hll.Sketch, len(sketches))
+	flagIDs := make(map[int64]bool)
+	var from, to time.Time
+	for k, s := range sketches {
+		pending[sketchKey{k.FlagID, k.VariantID, k.SegmentID, k.Hour.Unix()}] = s
+		flagIDs[k.FlagID] = true
+		if from.IsZero() || k.Hour.Before(from) {
+			from = k.Hour
+		}
+		if k.Hour.After(to) {
+			to = k.Hour
+		}
+	}
+	ids := make([]int64, 0, len(flagIDs))
+	for id := range flagIDs {
+		ids = append(ids, id)
+	}
+
+	var rows []sketchRow
+	if err := tx.Model(&entity.HourlyEvent{}).
+		Select("id, flag_id, variant_id, segment_id, bucket_hour, sketch").
+		Where("flag_id IN ? AND bucket_hour >= ? AND bucket_hour <= ?", ids, from, to).
+		Scan(&rows).Error; err != nil {
+		return err
+	}
+	for _, r := range rows {
+		s, ok := pending[sketchKey{r.FlagID, r.VariantID, r.SegmentID, r.BucketHour.Unix()}]
+		if !ok {
+			continue
+		}
+		if r.Sketch != nil {
+			stored := hll.New()
+			if err := stored.UnmarshalBinary(r.Sketch); err != nil {
+				logrus.WithError(err).WithField("id", r.ID).Warn("Datar: replacing unreadable entity sketch")
+			} else {
+				s.Merge(stored)
+			}
+		}
+		b, err := s.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.HourlyEvent{}).Where("id = ?", r.ID).Update("sketch", b).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sketchMerger merges the sketches of rows by a dimension. Added sketches
+// are owned by the merger.
+type sketchMerger[K comparable] map[K]*hll.Sketch
+
+func (m sketchMerger[K]) add(k K, s *hll.Sketch) {
+	if merged, ok := m[k]; ok {
+		merged.Merge(s)
+		return
+	}
+	m[k] = s
+}
+
+func (m sketchMerger[K]) estimate(k K) int64 {
+	if s, ok := m[k]; ok {
+		return int64(s.Estimate())
+	}
+	return 0
+}
+
+// scanSketches calls fn with the decoded sketch of every row of the query
+// that has one, one row at a time.
+func (e *Engine) scanSketches(query *gorm.DB, fn func(r sketchRow, s *hll.Sketch)) error {
+	rows, err := query.Select("id, flag_id, variant_id, segment_id, bucket_hour, sketch").
+		Where("sketch IS NOT NULL").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var r sketchRow
+		if err := e.db.ScanRows(rows, &r); err != nil {
+			return err
+		}
+		s := hll.New()
+		if err := s.UnmarshalBinary(r.Sketch); err != nil {
+			logrus.WithError(err).WithField("id", r.ID).Warn("Datar: skipping unreadable entity sketch")
+			continue
+		}
+		fn(r, s)
+	}
+	return rows.Err()
+}
+
+// addUniqueEntities sets the distinct entities of the summary rows.
+func (e *Engine) addUniqueEntities(rows []SummaryRow, from, to time.Time) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(rows))
+	for i, r := range rows {
+		ids[i] = r.FlagID
+	}
+	flags := sketchMerger[int64]{}
+	if err := e.scanSketches(
+		e.events(from, to).Where("flag_id IN ?", ids),
+		func(r sketchRow, s *hll.Sketch) { flags.add(r.FlagID, s) },
+	); err != nil {
+		return err
+	}
+	for i := range rows {
+		rows[i].UniqueEntities = flags.estimate(rows[i].FlagID)
+	}
+	return nil
+}
+
+// addBreakdownUniqueEntities sets the distinct entities of a flag summary
+// breakdown.
+func (e *Engine) addBreakdownUniqueEntities(b *FlagSummaryBreakdown, from, to time.Time) error {
+	total := sketchMerger[int64]{}
+	variants := sketchMerger[int64]{}
+	segments := sketchMerger[int64]{}
+	days := sketchMerger[string]{}
+	if err := e.scanSketches(
+		e.events(from, to).Where("flag_id = ?", b.FlagID),
+		func(r sketchRow, s *hll.Sketch) {
+			total.add(r.FlagID, s.Clone())
+			variants.add(r.VariantID, s.Clone())
+			if r.SegmentID > 0 {
+				segments.add(r.SegmentID, s.Clone())
+			}
+			days.add(r.BucketHour.UTC().Format("2006-01-02"), s)
+		},
+	); err != nil {
+		return err
+	}
+	b.UniqueEntities = total.estimate(b.FlagID)
+	for i := range b.Variants {
+		b.Variants[i].UniqueEntities = variants.estimate(b.Variants[i].VariantID)
+	}
+	for i := range b.Segments {
+		b.Segments[i].UniqueEntities = segments.estimate(b.Segments[i].SegmentID)
+	}
+	for i := range b.Days {
+		b.Days[i].UniqueEntities = days.estimate(b.Days[i].Day)
+	}
+	return nil
+}
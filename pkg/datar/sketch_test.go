@@ -0,0 +1,133 @@
+package datar
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openflagr/flagr/pkg/datar/hll"
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordEntity_UniqueEntities(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	createFlag(t, db, 1, "test", "flag", true)
+	createFlag(t, db, 2, "other", "flag", true)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	// Variant 1 sees entities 0-99 in two flushes, variant 2 entities 50-149.
+	for range 2 {
+		for i := range 100 {
+			e.RecordEntity(1, 1, 10, fmt.Sprintf("user-%d", i))
+			e.RecordEntity(1, 2, 10, fmt.Sprintf("user-%d", i+50))
+		}
+		e.flush()
+	}
+	e.RecordEntity(1, 0, 0, "user-0")
+	e.RecordEntity(1, 0, 0, "") // counted, not sketched
+	e.RecordEntity(2, 1, 20, "user-0")
+	e.flush()
+
+	// Estimates are within a few entities of the true counts.
+	from, to := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	summary, err := e.QueryFlagSummaryBreakdown(1, from, to)
+	require.NoError(t, err)
+	assert.InDelta(t, 150, summary.UniqueEntities, 3)
+	require.Len(t, summary.Variants, 3)
+	for _, v := range summary.Variants {
+		switch v.VariantID {
+		case 0:
+			assert.Equal(t, int64(2), v.Count)
+			assert.Equal(t, int64(1), v.UniqueEntities)
+		default:
+			assert.Equal(t, int64(200), v.Count)
+			assert.InDelta(t, 100, v.UniqueEntities, 3)
+		}
+	}
+	require.Len(t, summary.Segments, 1)
+	assert.InDelta(t, 150, summary.Segments[0].UniqueEntities, 3)
+	require.Len(t, summary.Days, 1)
+	assert.InDelta(t, 150, summary.Days[0].UniqueEntities, 3)
+
+	rows, err := e.QuerySummary(from, to, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.InDelta(t, 150, rows[0].UniqueEntities, 3)
+	assert.Equal(t, int64(1), rows[1].UniqueEntities)
+}
+
+func TestRecordEntity_AfterCloseIsNoop(t *testing.T) {
+	t.Parallel()
+	e := New(newTestDB(t), true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	e.closed.Store(true)
+	e.RecordEntity(1, 1, 1, "a")
+	assert.Equal(t, 0, e.Len())
+	assert.Empty(t, e.snapshotSketches())
+
+	var nilEngine *Engine
+	nilEngine.RecordEntity(1, 1, 1, "a")
+}
+
+func TestFlushAggregates_SketchWithoutCount(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	s := hll.New()
+	s.Insert(hll.Hash("a"))
+	hour := time.Now().Truncate(time.Hour)
+	require.NoError(t, e.flushAggregates(nil, map[FlushKey]*hll.Sketch{{FlagID: 1, VariantID: 1, SegmentID: 1, Hour: hour}: s}))
+
+	var row entity.HourlyEvent
+	require.NoError(t, db.First(&row).Error)
+	assert.Equal(t, int32(0), row.EvalCount)
+	assert.NotEmpty(t, row.Sketch)
+}
+
+func TestUniqueEntities_UnreadableSketch(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	hour := time.Now().Truncate(time.Hour)
+	require.NoError(t, db.Create(&entity.HourlyEvent{
+		FlagID: 1, VariantID: 1, SegmentID: 1, BucketHour: hour, EvalCount: 3, Sketch: []byte{0xff},
+	}).Error)
+
+	from, to := hour.Add(-time.Hour), hour.Add(time.Hour)
+	summary, err := e.QueryFlagSummaryBreakdown(1, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), summary.UniqueEntities, "unreadable sketches are skipped")
+
+	// The next flush replaces it.
+	e.RecordEntity(1, 1, 1, "a")
+	e.flush()
+	summary, err = e.QueryFlagSummaryBreakdown(1, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), summary.UniqueEntities)
+	assert.Equal(t, int64(4), summary.Variants[0].Count)
+}
+
+func TestUniqueEntities_QueryError(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	e.RecordEntity(1, 1, 1, "a")
+	require.NoError(t, db.Exec("DROP TABLE datar_hourly_events").Error)
+	assert.Error(t, e.flushAggregates(nil, e.snapshotSketches()))
+}
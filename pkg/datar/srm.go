@@ -0,0 +1,326 @@
+package datar
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openflagr/flagr/pkg/datar/stats"
+	"github.com/openflagr/flagr/pkg/entity"
+)
+
+// SRMOptions configures sample ratio mismatch checks.
+type SRMOptions struct {
+	Alpha    float64 // p-value below which a split is a mismatch
+	MinCount int64   // evaluations a segment needs to be tested
+}
+
+// minSRMExpectedCount is the smallest expected count of a variant for which
+// the chi-squared approximation of the test holds.
+const minSRMExpectedCount = 5
+
+// SRMVariant is the observed and expected evaluations of a variant in a
+// segment.
+type SRMVariant struct {
+	VariantID     int64
+	Observed      int64
+	Expected      float64
+	ExpectedShare float64 // of the evaluations of the segment
+}
+
+// SRMSegment is the sample ratio check of a segment: its evaluations by
+// variant against its distribution.
+type SRMSegment struct {
+	SegmentID      int64
+	RolloutPercent uint
+	Total          int64
+	Variants       []SRMVariant // by variant ID
+	Statistic      float64      // NaN unless tested
+	DF             int
+	PValue         float64 // NaN unless tested
+	Mismatch       bool
+
+	Error string // why the segment was not tested
+}
+
+// SampleRatioMismatch is the sample ratio check of a flag. Evaluations of each
+// segment are tested separately against its distribution; the flag's result
+// is the sum of the chi-squared statistics of the tested segments.
+type SampleRatioMismatch struct {
+	FlagID    int64
+	FlagKey   string
+	Alpha     float64
+	Statistic float64 // NaN unless a segment was tested
+	DF        int
+	PValue    float64 // NaN unless a segment was tested
+	Mismatch  bool
+	Segments  []SRMSegment // in evaluation order
+}
+
+// QuerySampleRatioMismatch checks the evaluations of a flag's variants in the
+// given time range against the split configured by its segments'
+// distributions and rollout percents.
+//
+// Only evaluations with a variant carry their segment, so each segment is
+// tested on the evaluations it rolled out, against the buckets its rollout
+// assigns to each variant. The current configuration is assumed for the whole
+// time range; a segment with evaluations of a variant it no longer
+// distributes to is not tested.
+func (e *Engine) QuerySampleRatioMismatch(flagID int64, from, to time.Time, opts SRMOptions) (*SampleRatioMismatch, error) {
+	if e == nil {
+		return nil, errNilEngine
+	}
+
+	flag := entity.Flag{}
+	if err := e.db.Preload("Segments", func(db *gorm.DB) *gorm.DB {
+		return entity.PreloadConstraintsDistribution(db).Order("segments.rank").Order("segments.id")
+	}).First(&flag, flagID).Error; err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		SegmentID int64
+		VariantID int64
+		Count     int64
+	}
+	if err := e.events(from, to).
+		Select("segment_id, variant_id, SUM(eval_count) AS count").
+		Where("flag_id = ? AND segment_id > 0", flagID).
+		Group("segment_id, variant_id").
+		Scan(&rows).Error; err != nil {
+		logrus.WithError(err).Error("Datar: QuerySampleRatioMismatch failed")
+		return nil, err
+	}
+	observed := make(map[int64]map[int64]int64)
+	for _, r := range rows {
+		if observed[r.SegmentID] == nil {
+			observed[r.SegmentID] = make(map[int64]int64)
+		}
+		observed[r.SegmentID][r.VariantID] += r.Count
+	}
+
+	srm := &SampleRatioMismatch{
+		FlagID:    flagID,
+		FlagKey:   flag.Key,
+		Alpha:     opts.Alpha,
+		Statistic: math.NaN(),
+		PValue:    math.NaN(),
+	}
+	var statistic float64
+	for _, s := range flag.Segments {
+		var seg SRMSegment
+		if err := s.PrepareEvaluation(); err != nil {
+			seg = SRMSegment{
+				SegmentID:      int64(s.ID),
+				RolloutPercent: s.RolloutPercent,
+				Statistic:      math.NaN(),
+				PValue:         math.NaN(),
+				Error:          err.Error(),
+			}
+		} else {
+			seg = checkSegmentRatio(s, observed[int64(s.ID)], opts)
+		}
+		if seg.Error == "" {
+			statistic += seg.Statistic
+			srm.DF += seg.DF
+		}
+		srm.Segments = append(srm.Segments, seg)
+	}
+	if srm.DF > 0 {
+		srm.Statistic = statistic
+		srm.PValue = stats.ChiSquaredSurvival(statistic, float64(srm.DF))
+		srm.Mismatch = srm.PValue < opts.Alpha
+	}
+	return srm, nil
+}
+
+// checkSegmentRatio tests the evaluations of a segment by variant against the
+// rollout shares of its distribution.
+func checkSegmentRatio(s entity.Segment, observed map[int64]int64, opts SRMOptions) SRMSegment {
+	seg := SRMSegment{
+		SegmentID:      int64(s.ID),
+		RolloutPercent: s.RolloutPercent,
+		Statistic:      math.NaN(),
+		PValue:         math.NaN(),
+	}
+
+	da := s.SegmentEvaluation.DistributionArray
+	shares := make(map[int64]float64)
+	var shareSum float64
+	for i, share := range da.RolloutShares(s.RolloutPercent) {
+		shares[int64(da.VariantIDs[i])] += share
+		shareSum += share
+	}
+	ids := make([]int64, 0, len(shares))
+	for id := range shares {
+		ids = append(ids, id)
+	}
+	for id, count := range observed {
+		seg.Total += count
+		if _, ok := shares[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	counts := make([]int64, len(ids))
+	weights := make([]float64, len(ids))
+	for i, id := range ids {
+		v := SRMVariant{VariantID: id, Observed: observed[id]}
+		if shareSum > 0 {
+			v.ExpectedShare = shares[id] / shareSum
+			v.Expected = float64(seg.Total) * v.ExpectedShare
+		}
+		counts[i], weights[i] = v.Observed, v.ExpectedShare
+		seg.Variants = append(seg.Variants, v)
+	}
+	for _, v := range seg.Variants {
+		if v.ExpectedShare == 0 && v.Observed > 0 {
+			seg.Error = fmt.Sprintf("variant %d is not in the distribution of the segment", v.VariantID)
+			return seg
+		}
+	}
+	if seg.Total < opts.MinCount {
+		seg.Error = fmt.Sprintf("%d evaluations, %d required", seg.Total, opts.MinCount)
+		return seg
+	}
+	for _, v := range seg.Variants {
+		if v.ExpectedShare > 0 && v.Expected < minSRMExpectedCount {
+			seg.Error = fmt.Sprintf("expected evaluations of variant %d below %d", v.VariantID, minSRMExpectedCount)
+			return seg
+		}
+	}
+
+	g, err := stats.ChiSquaredGoodnessOfFit(counts, weights)
+	if err != nil {
+		seg.Error = err.Error()
+		return seg
+	}
+	seg.Statistic, seg.DF, seg.PValue = g.Statistic, g.DF, g.PValue
+	seg.Mismatch = g.PValue < opts.Alpha
+	return seg
+}
+
+const (
+	srmLock = "srm"
+	// srmLockTTL is how long the lock outlives an instance that died while
+	// checking.
+	srmLockTTL = 10 * time.Minute
+)
+
+// srmMonitor is the configuration of StartSRMMonitor.
+type srmMonitor struct {
+	opts       SRMOptions
+	lookback   time.Duration
+	onMismatch func(*SampleRatioMismatch)
+}
+
+// StartSRMMonitor checks the sample ratio of every flag evaluated within
+// lookback each interval, and calls onMismatch when a flag's check turns into
+// a mismatch. onMismatch is called again for the flag only after a check
+// without a mismatch. Instances sharing the database take turns through a
+// lock in datar_locks, and keep the alerted flags in datar_srm_alerts, so a
+// mismatch is alerted once. The monitor stops on Shutdown.
+func (e *Engine) StartSRMMonitor(interval, lookback time.Duration, opts SRMOptions, onMismatch func(*SampleRatioMismatch)) {
+	if e == nil || interval <= 0 {
+		return
+	}
+	m := &srmMonitor{opts: opts, lookback: lookback, onMismatch: onMismatch}
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-e.closeCh:
+				return
+			case <-ticker.C:
+				e.checkSampleRatios(m, time.Now())
+			}
+		}
+	}()
+}
+
+// checkSampleRatios runs one round of the SRM monitor m at now, unless
+// another instance holds the lock. Flags without evaluations within the
+// lookback have no mismatch.
+func (e *Engine) checkSampleRatios(m *srmMonitor, now time.Time) {
+	ok, err := e.acquireLock(srmLock, srmLockTTL)
+	if err != nil {
+		logrus.WithError(err).Error("Datar: sample ratio check failed")
+		return
+	}
+	if !ok {
+		logrus.Debug("Datar: sample ratio check is running on another instance")
+		return
+	}
+	defer e.releaseLock(srmLock)
+
+	var alerted []int64
+	if err := e.db.Model(&entity.SRMAlert{}).Pluck("flag_id", &alerted).Error; err != nil {
+		logrus.WithError(err).Error("Datar: sample ratio check failed")
+		return
+	}
+	wasAlerted := make(map[int64]bool, len(alerted))
+	for _, flagID := range alerted {
+		wasAlerted[flagID] = true
+	}
+
+	from := now.Add(-m.lookback)
+	var flagIDs []int64
+	if err := e.db.Model(&entity.HourlyEvent{}).
+		Where("bucket_hour >= ? AND bucket_hour < ? AND segment_id > 0", from, now).
+		Distinct().Pluck("flag_id", &flagIDs).Error; err != nil {
+		logrus.WithError(err).Error("Datar: sample ratio check failed")
+		return
+	}
+
+	mismatched := make(map[int64]bool)
+	for _, flagID := range flagIDs {
+		srm, err := e.QuerySampleRatioMismatch(flagID, from, now, m.opts)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			logrus.WithError(err).WithField("flagID", flagID).Error("Datar: sample ratio check failed")
+			mismatched[flagID] = wasAlerted[flagID]
+			continue
+		}
+		if !srm.Mismatch {
+			continue
+		}
+		mismatched[flagID] = true
+		if wasAlerted[flagID] {
+			continue
+		}
+		if err := e.db.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&entity.SRMAlert{FlagID: flagID, AlertedAt: now}).Error; err != nil {
+			logrus.WithError(err).WithField("flagID", flagID).Error("Datar: failed to save sample ratio alert")
+			continue
+		}
+		logrus.WithFields(logrus.Fields{
+			"flagID": flagID,
+			"pValue": srm.PValue,
+		}).Warn("Datar: sample ratio mismatch")
+		m.onMismatch(srm)
+	}
+
+	var over []int64
+	for _, flagID := range alerted {
+		if !mismatched[flagID] {
+			over = append(over, flagID)
+		}
+	}
+	if len(over) > 0 {
+		if err := e.db.Where("flag_id IN ?", over).Delete(&entity.SRMAlert{}).Error; err != nil {
+			logrus.WithError(err).Error("Datar: failed to clear sample ratio alerts")
+		}
+	}
+}
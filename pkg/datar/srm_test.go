@@ -0,0 +1,191 @@
+package datar
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// createSRMFlag creates flag 1 with segment 1 splitting variants 1 and 2
+// 50/50 at 100% rollout, and segment 2 splitting them 20/80 at 50% rollout.
+func createSRMFlag(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	require.NoError(t, db.Create(&entity.Flag{
+		Key: "srm",
+		Segments: []entity.Segment{
+			{Rank: 0, RolloutPercent: 100, Distributions: []entity.Distribution{
+				{VariantID: 1, Percent: 50}, {VariantID: 2, Percent: 50},
+			}},
+			{Rank: 1, RolloutPercent: 50, Distributions: []entity.Distribution{
+				{VariantID: 1, Percent: 20}, {VariantID: 2, Percent: 80},
+			}},
+		},
+	}).Error)
+}
+
+func insertSRMCounts(t *testing.T, db *gorm.DB, hour time.Time, rows [][3]int64) {
+	t.Helper()
+	for _, row := range rows {
+		require.NoError(t, db.Exec(
+			`INSERT INTO datar_hourly_events (flag_id, variant_id, segment_id, bucket_hour, eval_count) VALUES (1, ?, ?, ?, ?)`,
+			row[0], row[1], hour, row[2],
+		).Error)
+	}
+}
+
+func TestQuerySampleRatioMismatch(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+	createSRMFlag(t, db)
+
+	now := time.Now().UTC().Truncate(time.Hour)
+	from, to := now.Add(-24*time.Hour), now.Add(time.Hour)
+	// Variant 0 carries no segment and is not part of the check.
+	insertSRMCounts(t, db, now, [][3]int64{{0, 0, 9000}, {1, 1, 5000}, {2, 1, 5200}, {1, 2, 1000}, {2, 2, 4000}})
+
+	srm, err := e.QuerySampleRatioMismatch(1, from, to, SRMOptions{Alpha: 0.001, MinCount: 100})
+	require.NoError(t, err)
+	assert.Equal(t, "srm", srm.FlagKey)
+	require.Len(t, srm.Segments, 2)
+
+	s1 := srm.Segments[0]
+	assert.Equal(t, int64(1), s1.SegmentID)
+	assert.Equal(t, int64(10200), s1.Total)
+	assert.Equal(t, []SRMVariant{
+		{VariantID: 1, Observed: 5000, Expected: 5100, ExpectedShare: 0.5},
+		{VariantID: 2, Observed: 5200, Expected: 5100, ExpectedShare: 0.5},
+	}, s1.Variants)
+	assert.InDelta(t, 2*100.0*100/5100, s1.Statistic, 1e-9)
+	assert.Equal(t, 1, s1.DF)
+	assert.InDelta(t, 0.04767, s1.PValue, 1e-5)
+	assert.False(t, s1.Mismatch)
+
+	// The rollout keeps the 20/80 split.
+	s2 := srm.Segments[1]
+	assert.Equal(t, uint(50), s2.RolloutPercent)
+	assert.InDelta(t, 0.2, s2.Variants[0].ExpectedShare, 1e-12)
+	assert.InDelta(t, 0, s2.Statistic, 1e-9)
+
+	assert.Equal(t, 2, srm.DF)
+	assert.InDelta(t, s1.Statistic, srm.Statistic, 1e-9)
+	assert.InDelta(t, math.Exp(-s1.Statistic/2), srm.PValue, 1e-9)
+	assert.False(t, srm.Mismatch)
+
+	// A looser alpha flags the first segment, but not the flag: the second
+	// segment matches its split.
+	srm, err = e.QuerySampleRatioMismatch(1, from, to, SRMOptions{Alpha: 0.05, MinCount: 100})
+	require.NoError(t, err)
+	assert.True(t, srm.Segments[0].Mismatch)
+	assert.False(t, srm.Segments[1].Mismatch)
+	assert.False(t, srm.Mismatch)
+
+	t.Run("untested segments", func(t *testing.T) {
+		srm, err := e.QuerySampleRatioMismatch(1, from, to, SRMOptions{Alpha: 0.001, MinCount: 6000})
+		require.NoError(t, err)
+		assert.Empty(t, srm.Segments[0].Error)
+		assert.Equal(t, "5000 evaluations, 6000 required", srm.Segments[1].Error)
+		assert.True(t, math.IsNaN(srm.Segments[1].PValue))
+		assert.Equal(t, 1, srm.DF)
+
+		srm, err = e.QuerySampleRatioMismatch(1, from, to, SRMOptions{Alpha: 0.001, MinCount: 20000})
+		require.NoError(t, err)
+		assert.Equal(t, 0, srm.DF)
+		assert.True(t, math.IsNaN(srm.PValue))
+		assert.False(t, srm.Mismatch)
+	})
+
+	t.Run("variant outside the distribution", func(t *testing.T) {
+		insertSRMCounts(t, db, now.Add(-time.Hour), [][3]int64{{3, 2, 10}})
+		srm, err := e.QuerySampleRatioMismatch(1, from, to, SRMOptions{Alpha: 0.001, MinCount: 100})
+		require.NoError(t, err)
+		assert.Equal(t, "variant 3 is not in the distribution of the segment", srm.Segments[1].Error)
+		assert.Len(t, srm.Segments[1].Variants, 3)
+	})
+
+	_, err = e.QuerySampleRatioMismatch(2, from, to, SRMOptions{Alpha: 0.001})
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestCheckSampleRatios(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+	createSRMFlag(t, db)
+
+	hour := time.Now().UTC().Truncate(time.Hour).Add(-48 * time.Hour)
+	insertSRMCounts(t, db, hour, [][3]int64{{1, 1, 5000}, {2, 1, 6000}})
+
+	var alerts []*SampleRatioMismatch
+	m := &srmMonitor{
+		opts:       SRMOptions{Alpha: 0.001, MinCount: 100},
+		lookback:   2 * time.Hour,
+		onMismatch: func(srm *SampleRatioMismatch) { alerts = append(alerts, srm) },
+	}
+	e.checkSampleRatios(m, hour.Add(time.Hour))
+	require.Len(t, alerts, 1)
+	assert.Equal(t, int64(1), alerts[0].FlagID)
+	assert.True(t, alerts[0].Mismatch)
+
+	// A flag is alerted once per mismatch.
+	e.checkSampleRatios(m, hour.Add(time.Hour))
+	assert.Len(t, alerts, 1)
+
+	// Without evaluations in the lookback the mismatch is over.
+	e.checkSampleRatios(m, hour.Add(10*time.Hour))
+	assert.Len(t, alerts, 1)
+	e.checkSampleRatios(m, hour.Add(time.Hour))
+	assert.Len(t, alerts, 2)
+
+	require.NoError(t, db.Exec("DROP TABLE datar_hourly_events").Error)
+	e.checkSampleRatios(m, hour.Add(time.Hour))
+	assert.Len(t, alerts, 2)
+	assert.Equal(t, int64(1), countRows(t, db, &entity.SRMAlert{}), "a failed check keeps the state")
+}
+
+func TestCheckSampleRatios_Instances(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e1 := New(db, true, time.Hour)
+	e2 := New(db, true, time.Hour)
+	require.NotNil(t, e1)
+	require.NotNil(t, e2)
+	defer e1.Shutdown()
+	defer e2.Shutdown()
+	createSRMFlag(t, db)
+
+	hour := time.Now().UTC().Truncate(time.Hour).Add(-48 * time.Hour)
+	insertSRMCounts(t, db, hour, [][3]int64{{1, 1, 5000}, {2, 1, 6000}})
+
+	var alerts []*SampleRatioMismatch
+	m := &srmMonitor{
+		opts:       SRMOptions{Alpha: 0.001, MinCount: 100},
+		lookback:   2 * time.Hour,
+		onMismatch: func(srm *SampleRatioMismatch) { alerts = append(alerts, srm) },
+	}
+
+	// An instance skips the round while another one checks.
+	ok, err := e1.acquireLock(srmLock, time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+	e2.checkSampleRatios(m, hour.Add(time.Hour))
+	assert.Empty(t, alerts)
+	e1.releaseLock(srmLock)
+
+	// The alert state is shared, so the mismatch is alerted once.
+	e1.checkSampleRatios(m, hour.Add(time.Hour))
+	e2.checkSampleRatios(m, hour.Add(time.Hour))
+	assert.Len(t, alerts, 1)
+
+	// The lock is released after each round.
+	assert.Equal(t, int64(0), countRows(t, db, &entity.DatarLock{}))
+}
@@ -0,0 +1,75 @@
+package stats
+
+import "math"
+
+// bayesIntegrationSteps is the number of Simpson steps integrating over the
+// posterior of the control.
+const bayesIntegrationSteps = 2000
+
+// ProbabilityToBeatBeta is the posterior probability that the success rate of
+// the treatment is greater than that of the control, with uniform Beta(1, 1)
+// priors on both rates.
+func ProbabilityToBeatBeta(control, treatment Proportion) float64 {
+	ac, bc := betaPosterior(control)
+	at, bt := betaPosterior(treatment)
+
+	// P(T > C) = ∫ f_C(x) (1 - I_x(at, bt)) dx over the bulk of f_C.
+	mean := ac / (ac + bc)
+	sd := math.Sqrt(ac * bc / ((ac + bc) * (ac + bc) * (ac + bc + 1)))
+	lo, hi := math.Max(0, mean-12*sd), math.Min(1, mean+12*sd)
+	lb := logBeta(ac, bc)
+	f := func(x float64) float64 {
+		density := math.Exp(xlogy(ac-1, x) + xlogy(bc-1, 1-x) - lb)
+		return density * (1 - RegularizedIncompleteBeta(x, at, bt))
+	}
+	h := (hi - lo) / bayesIntegrationSteps
+	sum := f(lo) + f(hi)
+	for i := 1; i < bayesIntegrationSteps; i++ {
+		w := 2.0
+		if i%2 == 1 {
+			w = 4
+		}
+		sum += w * f(lo+float64(i)*h)
+	}
+	return math.Min(1, math.Max(0, sum*h/3))
+}
+
+// BetaCredibleInterval is the equal-tailed credible interval at level 1-alpha
+// of a success rate, with a uniform Beta(1, 1) prior.
+func BetaCredibleInterval(p Proportion, alpha float64) Interval {
+	a, b := betaPosterior(p)
+	return Interval{BetaQuantile(alpha/2, a, b), BetaQuantile(1-alpha/2, a, b)}
+}
+
+// xlogy is x*log(y), 0 for x = 0 even at y = 0.
+func xlogy(x, y float64) float64 {
+	if x == 0 {
+		return 0
+	}
+	return x * math.Log(y)
+}
+
+func betaPosterior(p Proportion) (a, b float64) {
+	return 1 + float64(p.Successes), 1 + float64(p.Trials-p.Successes)
+}
+
+// ProbabilityToBeatNormal is the posterior probability that the mean of the
+// treatment is greater than that of the control, with flat priors and normal
+// approximations of the posteriors of the means.
+func ProbabilityToBeatNormal(control, treatment Sample) float64 {
+	if control.N == 0 || treatment.N == 0 {
+		return math.NaN()
+	}
+	se := math.Sqrt(control.Variance/control.N + treatment.Variance/treatment.N)
+	diff := treatment.Mean - control.Mean
+	if se == 0 {
+		switch {
+		case diff > 0:
+			return 1
+		case diff < 0:
+			return 0
+		}
+		return 0.5
+	}
+	return NormalCDF(diff / se)
+}
@@ -0,0 +1,47 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Vectors are from the exact formula for integer beta parameters (Evan
+// Miller, "Formulas for Bayesian A/B Testing").
+
+func TestProbabilityToBeatBeta(t *testing.T) {
+	t.Parallel()
+	for _, v := range []struct {
+		control, treatment Proportion
+		p                  float64
+	}{
+		{Proportion{200, 1000}, Proportion{250, 1000}, 0.9962833291305507},
+		{Proportion{10, 100}, Proportion{8, 100}, 0.31540801885787434},
+		{Proportion{3, 10}, Proportion{5, 12}, 0.6998923139049646},
+		{Proportion{0, 0}, Proportion{0, 0}, 0.5},
+	} {
+		assert.InDelta(t, v.p, ProbabilityToBeatBeta(v.control, v.treatment), 1e-6, "%v vs %v", v.control, v.treatment)
+	}
+
+	// Large counts stay accurate.
+	p := ProbabilityToBeatBeta(Proportion{100000, 1000000}, Proportion{100000, 1000000})
+	assert.InDelta(t, 0.5, p, 1e-6)
+}
+
+func TestBetaCredibleInterval(t *testing.T) {
+	t.Parallel()
+	i := BetaCredibleInterval(Proportion{200, 1000}, 0.05)
+	assert.InDelta(t, 0.17639237749914205, i.Lower, 1e-7)
+	assert.InDelta(t, 0.22593716107238093, i.Upper, 1e-7)
+}
+
+func TestProbabilityToBeatNormal(t *testing.T) {
+	t.Parallel()
+	control := Sample{N: 100, Mean: 10, Variance: 25}
+	treatment := Sample{N: 100, Mean: 11, Variance: 25}
+	assert.InDelta(t, NormalCDF(1/math.Sqrt(0.5)), ProbabilityToBeatNormal(control, treatment), 1e-12)
+	assert.InDelta(t, 0.5, ProbabilityToBeatNormal(control, control), 1e-12)
+	assert.Equal(t, 1.0, ProbabilityToBeatNormal(Sample{N: 5, Mean: 1}, Sample{N: 5, Mean: 2}))
+	assert.True(t, math.IsNaN(ProbabilityToBeatNormal(Sample{}, treatment)))
+}
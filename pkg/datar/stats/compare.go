@@ -0,0 +1,149 @@
+package stats
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrInsufficientData is returned when a test cannot be computed, e.g. for a
+// variant without trials or two samples without variance.
+var ErrInsufficientData = errors.New("not enough data")
+
+// Proportion is the number of trials of a variant with a success, e.g. the
+// entities that converted, out of all its trials.
+type Proportion struct {
+	Successes int64
+	Trials    int64
+}
+
+// Rate is the success rate, 0 without trials.
+func (p Proportion) Rate() float64 {
+	if p.Trials == 0 {
+		return 0
+	}
+	return float64(p.Successes) / float64(p.Trials)
+}
+
+// Sample summarizes a numeric metric of a variant.
+type Sample struct {
+	N        float64
+	Mean     float64
+	Variance float64 // sample variance, with N-1 degrees of freedom
+}
+
+// SampleFromSums summarizes n values from their sum and sum of squares.
+// Values not included in the sums count as 0.
+func SampleFromSums(n int64, sum, sumSquares float64) Sample {
+	s := Sample{N: float64(n)}
+	if n == 0 {
+		return s
+	}
+	s.Mean = sum / s.N
+	if n > 1 {
+		s.Variance = math.Max(0, (sumSquares-sum*s.Mean)/(s.N-1))
+	}
+	return s
+}
+
+// Comparison is the result of testing a treatment against a control.
+type Comparison struct {
+	Difference   float64 // treatment minus control
+	DifferenceCI Interval
+	Lift         float64 // relative difference to the control; NaN when the control is 0
+	LiftCI       Interval
+	Statistic    float64 // z or t
+	DF           float64 // degrees of freedom of t; +Inf for z
+	PValue       float64 // two-sided
+
+	se         float64 // standard error of Difference
+	liftSE     float64
+	effectiveN float64
+}
+
+// TwoProportionZTest compares two success rates with the pooled two-proportion
+// z-test. Intervals are at level 1-alpha, from the unpooled standard error and
+// the delta method for the lift.
+func TwoProportionZTest(control, treatment Proportion, alpha float64) (Comparison, error) {
+	if control.Trials == 0 || treatment.Trials == 0 {
+		return Comparison{}, ErrInsufficientData
+	}
+	nc, nt := float64(control.Trials), float64(treatment.Trials)
+	pc, pt := control.Rate(), treatment.Rate()
+	pooled := float64(control.Successes+treatment.Successes) / (nc + nt)
+	pooledSE := math.Sqrt(pooled * (1 - pooled) * (1/nc + 1/nt))
+	if pooledSE == 0 {
+		return Comparison{}, ErrInsufficientData
+	}
+
+	c := Comparison{
+		Difference: pt - pc,
+		Statistic:  (pt - pc) / pooledSE,
+		DF:         math.Inf(1),
+	}
+	c.PValue = 2 * NormalCDF(-math.Abs(c.Statistic))
+	c.finish(pc, pt, pc*(1-pc)/nc, pt*(1-pt)/nt, nc, nt, NormalQuantile(1-alpha/2))
+	return c, nil
+}
+
+// WelchTTest compares two means with Welch's unequal variances t-test, with
+// Welch–Satterthwaite degrees of freedom. Intervals are at level 1-alpha.
+func WelchTTest(control, treatment Sample, alpha float64) (Comparison, error) {
+	if control.N < 2 || treatment.N < 2 {
+		return Comparison{}, ErrInsufficientData
+	}
+	vc, vt := control.Variance/control.N, treatment.Variance/treatment.N
+	se := math.Sqrt(vc + vt)
+	if se == 0 {
+		return Comparison{}, ErrInsufficientData
+	}
+
+	c := Comparison{
+		Difference: treatment.Mean - control.Mean,
+		Statistic:  (treatment.Mean - control.Mean) / se,
+		DF:         (vc + vt) * (vc + vt) / (vc*vc/(control.N-1) + vt*vt/(treatment.N-1)),
+	}
+	c.PValue = 2 * StudentTCDF(-math.Abs(c.Statistic), c.DF)
+	c.finish(control.Mean, treatment.Mean, vc, vt, control.N, treatment.N, StudentTQuantile(1-alpha/2, c.DF))
+	return c, nil
+}
+
+// finish sets the intervals of c from the control and treatment means, the
+// variances of the means and the quantile of the interval level.
+func (c *Comparison) finish(mc, mt, vc, vt, nc, nt, q float64) {
+	c.se = math.Sqrt(vc + vt)
+	c.effectiveN = 1 / (1/nc + 1/nt)
+	c.DifferenceCI = Interval{c.Difference - q*c.se, c.Difference + q*c.se}
+
+	if mc == 0 {
+		c.Lift = math.NaN()
+		c.LiftCI = Interval{math.NaN(), math.NaN()}
+		return
+	}
+	// Delta method for the ratio of the means.
+	c.Lift = mt/mc - 1
+	c.liftSE = math.Sqrt(vt/(mc*mc) + mt*mt*vc/(mc*mc*mc*mc))
+	c.LiftCI = Interval{c.Lift - q*c.liftSE, c.Lift + q*c.liftSE}
+}
+
+// WilsonInterval is the Wilson score interval of a success rate at level
+// 1-alpha.
+func WilsonInterval(p Proportion, alpha float64) Interval {
+	if p.Trials == 0 {
+		return Interval{0, 1}
+	}
+	n, rate := float64(p.Trials), p.Rate()
+	z := NormalQuantile(1 - alpha/2)
+	denominator := 1 + z*z/n
+	center := (rate + z*z/(2*n)) / denominator
+	half := z / denominator * math.Sqrt(rate*(1-rate)/n+z*z/(4*n*n))
+	return Interval{math.Max(0, center-half), math.Min(1, center+half)}
+}
+
+// MeanInterval is the t interval of a mean at level 1-alpha.
+func MeanInterval(s Sample, alpha float64) Interval {
+	if s.N < 2 {
+		return Interval{math.Inf(-1), math.Inf(1)}
+	}
+	half := StudentTQuantile(1-alpha/2, s.N-1) * math.Sqrt(s.Variance/s.N)
+	return Interval{s.Mean - half, s.Mean + half}
+}
@@ -0,0 +1,84 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwoProportionZTest(t *testing.T) {
+	t.Parallel()
+	c, err := TwoProportionZTest(Proportion{200, 1000}, Proportion{250, 1000}, 0.05)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.05, c.Difference, 1e-12)
+	assert.InDelta(t, 2.677397763008329, c.Statistic, 1e-9)
+	assert.InDelta(t, 0.007419649261025674, c.PValue, 1e-9)
+	assert.True(t, math.IsInf(c.DF, 1))
+	assert.InDelta(t, 0.01346362168753984, c.DifferenceCI.Lower, 1e-9)
+	assert.InDelta(t, 0.08653637831246014, c.DifferenceCI.Upper, 1e-9)
+	assert.InDelta(t, 0.25, c.Lift, 1e-12)
+	assert.InDelta(t, 0.04502206008611073, c.LiftCI.Lower, 1e-9)
+
+	// Swapping the variants mirrors the result.
+	m, err := TwoProportionZTest(Proportion{250, 1000}, Proportion{200, 1000}, 0.05)
+	require.NoError(t, err)
+	assert.InDelta(t, -c.Statistic, m.Statistic, 1e-12)
+	assert.InDelta(t, c.PValue, m.PValue, 1e-12)
+
+	_, err = TwoProportionZTest(Proportion{0, 0}, Proportion{1, 10}, 0.05)
+	assert.ErrorIs(t, err, ErrInsufficientData)
+	_, err = TwoProportionZTest(Proportion{0, 10}, Proportion{0, 10}, 0.05)
+	assert.ErrorIs(t, err, ErrInsufficientData)
+
+	// Without control successes the lift is undefined, the test is not.
+	c, err = TwoProportionZTest(Proportion{0, 100}, Proportion{5, 100}, 0.05)
+	require.NoError(t, err)
+	assert.True(t, math.IsNaN(c.Lift))
+	assert.Less(t, c.PValue, 0.05)
+}
+
+func TestWelchTTest(t *testing.T) {
+	t.Parallel()
+	// Example 1 of Welch's t-test on Wikipedia, from sums as Datar stores them.
+	control := SampleFromSums(15, 312.3, 6612.23)
+	treatment := SampleFromSums(15, 344.8, 7979.18)
+	c, err := WelchTTest(control, treatment, 0.05)
+	require.NoError(t, err)
+	assert.InDelta(t, 2.1666666666666714, c.Difference, 1e-9)
+	assert.InDelta(t, 2.455356398286005, c.Statistic, 1e-8)
+	assert.InDelta(t, 24.988529290230943, c.DF, 1e-8)
+	assert.InDelta(t, 0.02137800146285196, c.PValue, 1e-8)
+	q := StudentTQuantile(0.975, c.DF)
+	assert.InDelta(t, 2.1666666666666714-q*0.8824245100137571, c.DifferenceCI.Lower, 1e-8)
+	assert.InDelta(t, c.Difference/control.Mean, c.Lift, 1e-12)
+	assert.Less(t, c.LiftCI.Lower, c.Lift)
+
+	_, err = WelchTTest(Sample{N: 1, Mean: 1}, treatment, 0.05)
+	assert.ErrorIs(t, err, ErrInsufficientData)
+	_, err = WelchTTest(SampleFromSums(10, 10, 10), SampleFromSums(10, 10, 10), 0.05)
+	assert.ErrorIs(t, err, ErrInsufficientData)
+}
+
+func TestSampleFromSums(t *testing.T) {
+	t.Parallel()
+	// 1, 2, 3 and two zeros.
+	s := SampleFromSums(5, 6, 14)
+	assert.Equal(t, 5.0, s.N)
+	assert.InDelta(t, 1.2, s.Mean, 1e-12)
+	assert.InDelta(t, 1.7, s.Variance, 1e-12)
+	assert.Equal(t, Sample{}, SampleFromSums(0, 0, 0))
+}
+
+func TestIntervals(t *testing.T) {
+	t.Parallel()
+	w := WilsonInterval(Proportion{200, 1000}, 0.05)
+	assert.InDelta(t, 0.1763770904431985, w.Lower, 1e-9)
+	assert.InDelta(t, 0.22591896464813466, w.Upper, 1e-9)
+	assert.Equal(t, Interval{0, 1}, WilsonInterval(Proportion{}, 0.05))
+
+	m := MeanInterval(Sample{N: 11, Mean: 5, Variance: 11}, 0.05)
+	assert.InDelta(t, 5-2.2281388519861585, m.Lower, 1e-8)
+	assert.InDelta(t, 5+2.2281388519861585, m.Upper, 1e-8)
+}
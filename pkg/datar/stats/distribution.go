@@ -0,0 +1,211 @@
+// Package stats implements the statistics of Datar experiment readouts:
+// distribution functions, frequentist tests of a treatment against a control,
+// Bayesian probabilities to beat the control, an always-valid sequential
+// correction, and the goodness-of-fit test of sample ratio checks. It has no dependencies besides the standard library.
+package stats
+
+import "math"
+
+// Interval is a confidence or credible interval.
+type Interval struct {
+	Lower float64
+	Upper float64
+}
+
+// NormalCDF is the cumulative distribution function of the standard normal
+// distribution.
+func NormalCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// NormalQuantile is the inverse of NormalCDF, for p in (0, 1).
+func NormalQuantile(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
+// StudentTCDF is the cumulative distribution function of Student's t
+// distribution with df degrees of freedom. df need not be an integer.
+func StudentTCDF(t, df float64) float64 {
+	tail := 0.5 * RegularizedIncompleteBeta(df/(df+t*t), df/2, 0.5)
+	if t > 0 {
+		return 1 - tail
+	}
+	return tail
+}
+
+// StudentTQuantile is the inverse of StudentTCDF, for p in (0, 1).
+func StudentTQuantile(p, df float64) float64 {
+	return invert(func(t float64) float64 { return StudentTCDF(t, df) }, p, NormalQuantile(p))
+}
+
+// BetaCDF is the cumulative distribution function of the beta distribution.
+func BetaCDF(x, a, b float64) float64 {
+	return RegularizedIncompleteBeta(x, a, b)
+}
+
+// BetaQuantile is the inverse of BetaCDF, for p in (0, 1).
+func BetaQuantile(p, a, b float64) float64 {
+	lo, hi := 0.0, 1.0
+	for range 100 {
+		mid := (lo + hi) / 2
+		if BetaCDF(mid, a, b) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// invert finds x with cdf(x) = p for an increasing cdf on the real line,
+// bracketing from guess and bisecting.
+func invert(cdf func(float64) float64, p, guess float64) float64 {
+	lo, hi := guess-1, guess+1
+	for step := 1.0; cdf(lo) > p; step *= 2 {
+		lo -= step
+	}
+	for step := 1.0; cdf(hi) < p; step *= 2 {
+		hi += step
+	}
+	for range 200 {
+		mid := (lo + hi) / 2
+		if mid == lo || mid == hi {
+			break
+		}
+		if cdf(mid) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// logBeta is the logarithm of the beta function.
+func logBeta(a, b float64) float64 {
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	return la + lb - lab
+}
+
+// RegularizedIncompleteBeta is I_x(a, b), evaluated with the continued
+// fraction of Numerical Recipes (betacf).
+func RegularizedIncompleteBeta(x, a, b float64) float64 {
+	switch {
+	case x <= 0:
+		return 0
+	case x >= 1:
+		return 1
+	}
+	front := math.Exp(a*math.Log(x) + b*math.Log1p(-x) - logBeta(a, b))
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(x, a, b) / a
+	}
+	return 1 - front*betaContinuedFraction(1-x, b, a)/b
+}
+
+func betaContinuedFraction(x, a, b float64) float64 {
+	const (
+		maxIterations = 100000
+		epsilon       = 1e-15
+		tiny          = 1e-300
+	)
+	clamp := func(v float64) float64 {
+		if math.Abs(v) < tiny {
+			return tiny
+		}
+		return v
+	}
+	c := 1.0
+	d := 1 / clamp(1-(a+b)*x/(a+1))
+	h := d
+	for m := 1.0; m <= maxIterations; m++ {
+		aa := m * (b - m) * x / ((a + 2*m - 1) * (a + 2*m))
+		d = 1 / clamp(1+aa*d)
+		c = clamp(1 + aa/c)
+		h *= d * c
+
+		aa = -(a + m) * (a + b + m) * x / ((a + 2*m) * (a + 2*m + 1))
+		d = 1 / clamp(1+aa*d)
+		c = clamp(1 + aa/c)
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return h
+}
+
+// ChiSquaredSurvival is the upper tail probability P(X > x) of the
+// chi-squared distribution with df degrees of freedom.
+func ChiSquaredSurvival(x, df float64) float64 {
+	return RegularizedUpperIncompleteGamma(df/2, x/2)
+}
+
+// RegularizedUpperIncompleteGamma is Q(a, x) = Γ(a, x)/Γ(a), evaluated with
+// the series and continued fraction of Numerical Recipes (gser, gcf). The
+// continued fraction keeps small tail probabilities accurate.
+func RegularizedUpperIncompleteGamma(a, x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	lga, _ := math.Lgamma(a)
+	front := math.Exp(a*math.Log(x) - x - lga)
+	if x < a+1 {
+		return math.Max(0, 1-front*gammaSeries(a, x))
+	}
+	return front * gammaContinuedFraction(a, x)
+}
+
+// gammaSeries is the series of the lower incomplete gamma function, without
+// its front factor.
+func gammaSeries(a, x float64) float64 {
+	const (
+		maxIterations = 100000
+		epsilon       = 1e-15
+	)
+	term := 1 / a
+	sum := term
+	for n := 1.0; n <= maxIterations; n++ {
+		term *= x / (a + n)
+		sum += term
+		if math.Abs(term) < math.Abs(sum)*epsilon {
+			break
+		}
+	}
+	return sum
+}
+
+// gammaContinuedFraction is the continued fraction of the upper incomplete
+// gamma function, without its front factor, by the modified Lentz method.
+func gammaContinuedFraction(a, x float64) float64 {
+	const (
+		maxIterations = 100000
+		epsilon       = 1e-15
+		tiny          = 1e-300
+	)
+	clamp := func(v float64) float64 {
+		if math.Abs(v) < tiny {
+			return tiny
+		}
+		return v
+	}
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / clamp(b)
+	h := d
+	for i := 1.0; i <= maxIterations; i++ {
+		an := -i * (i - a)
+		b += 2
+		d = 1 / clamp(an*d+b)
+		c = clamp(b + an/c)
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return h
+}
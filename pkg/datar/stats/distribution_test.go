@@ -0,0 +1,78 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Vectors are from published tables (t critical values, the Beta(2, 5) CDF)
+// or numerical integration of the densities.
+
+func TestNormal(t *testing.T) {
+	t.Parallel()
+	assert.InDelta(t, 0.9750021048517795, NormalCDF(1.96), 1e-12)
+	assert.InDelta(t, 0.15865525393145707, NormalCDF(-1), 1e-12)
+	assert.InDelta(t, 0.5, NormalCDF(0), 1e-15)
+	assert.InDelta(t, 1.959963984540054, NormalQuantile(0.975), 1e-9)
+	assert.InDelta(t, -1.6448536269514722, NormalQuantile(0.05), 1e-9)
+}
+
+func TestStudentT(t *testing.T) {
+	t.Parallel()
+	for _, v := range []struct{ x, df, p float64 }{
+		{2.228138851986, 10, 0.975},
+		{1.5, 3, 0.8847080673775887},
+		{-0.7, 7.5, 0.25252781093692145},
+		{3.2, 25.3, 0.99815956631975},
+		{0, 4, 0.5},
+		{1, 1, 0.75},
+		{2, 2, 0.9082482904638631},
+	} {
+		assert.InDelta(t, v.p, StudentTCDF(v.x, v.df), 1e-10, "t=%v df=%v", v.x, v.df)
+	}
+	for _, v := range []struct{ p, df, x float64 }{
+		{0.975, 10, 2.2281388519861585},
+		{0.995, 3, 5.840909309732362},
+		{0.95, 24.988, 1.708172307538614},
+		{0.025, 30, -2.0422724563012373},
+	} {
+		assert.InDelta(t, v.x, StudentTQuantile(v.p, v.df), 1e-8, "p=%v df=%v", v.p, v.df)
+	}
+}
+
+func TestBeta(t *testing.T) {
+	t.Parallel()
+	for _, v := range []struct{ x, a, b, p float64 }{
+		{0.3, 2, 5, 0.579825},
+		{0.5, 3.5, 1.5, 0.16046945473728955},
+		{0.9, 10, 2, 0.6973568802000013},
+		{0.5, 7, 7, 0.5},
+		{0.25, 1, 1, 0.25},
+		{0, 2, 3, 0},
+		{1, 2, 3, 1},
+	} {
+		assert.InDelta(t, v.p, BetaCDF(v.x, v.a, v.b), 1e-10, "x=%v a=%v b=%v", v.x, v.a, v.b)
+	}
+	assert.InDelta(t, 0.3, BetaQuantile(0.579825, 2, 5), 1e-9)
+
+	// Large parameters, as for a million trials, still converge.
+	assert.InDelta(t, 0.5, BetaCDF(0.2, 200000, 800000), 1e-3)
+}
+
+func TestChiSquaredSurvival(t *testing.T) {
+	t.Parallel()
+	// Closed forms for small degrees of freedom.
+	for _, x := range []float64{0.01, 0.5, 1, 2.5, 3.841458820694124, 7, 15, 40, 90} {
+		h := x / 2
+		assert.InEpsilon(t, math.Erfc(math.Sqrt(h)), ChiSquaredSurvival(x, 1), 1e-9, "x=%v df=1", x)
+		assert.InEpsilon(t, math.Exp(-h), ChiSquaredSurvival(x, 2), 1e-9, "x=%v df=2", x)
+		assert.InEpsilon(t, math.Erfc(math.Sqrt(h))+math.Sqrt(2*x/math.Pi)*math.Exp(-h), ChiSquaredSurvival(x, 3), 1e-9, "x=%v df=3", x)
+		assert.InEpsilon(t, math.Exp(-h)*(1+h), ChiSquaredSurvival(x, 4), 1e-9, "x=%v df=4", x)
+	}
+	assert.InDelta(t, 0.05, ChiSquaredSurvival(3.841458820694124, 1), 1e-12)
+	assert.InDelta(t, 0.001, ChiSquaredSurvival(10.827566170662733, 1), 1e-12)
+	assert.InDelta(t, 0.05, ChiSquaredSurvival(18.307038053275146, 10), 1e-12)
+	assert.Equal(t, 1.0, ChiSquaredSurvival(0, 3))
+}
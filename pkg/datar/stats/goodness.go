@@ -0,0 +1,45 @@
+package stats
+
+import "math"
+
+// GoodnessOfFit is the result of Pearson's chi-squared goodness-of-fit test.
+type GoodnessOfFit struct {
+	Statistic float64
+	DF        int
+	PValue    float64
+}
+
+// ChiSquaredGoodnessOfFit tests observed counts against expected shares of
+// their total with Pearson's chi-squared test. Shares need not sum to 1; they
+// are normalized. Observations in a category with a share of 0 are an
+// infinite statistic with a p-value of 0.
+func ChiSquaredGoodnessOfFit(observed []int64, shares []float64) (GoodnessOfFit, error) {
+	var total int64
+	var shareSum float64
+	categories := 0
+	for i, o := range observed {
+		total += o
+		shareSum += shares[i]
+		if shares[i] > 0 {
+			categories++
+		}
+	}
+	if total == 0 || categories < 2 {
+		return GoodnessOfFit{}, ErrInsufficientData
+	}
+
+	g := GoodnessOfFit{DF: categories - 1}
+	for i, o := range observed {
+		if shares[i] <= 0 {
+			if o > 0 {
+				return GoodnessOfFit{Statistic: math.Inf(1), DF: g.DF}, nil
+			}
+			continue
+		}
+		expected := float64(total) * shares[i] / shareSum
+		d := float64(o) - expected
+		g.Statistic += d * d / expected
+	}
+	g.PValue = ChiSquaredSurvival(g.Statistic, float64(g.DF))
+	return g, nil
+}
@@ -0,0 +1,50 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChiSquaredGoodnessOfFit(t *testing.T) {
+	t.Parallel()
+
+	g, err := ChiSquaredGoodnessOfFit([]int64{5000, 5200}, []float64{0.5, 0.5})
+	require.NoError(t, err)
+	assert.InDelta(t, 2*100.0*100/5100, g.Statistic, 1e-12)
+	assert.Equal(t, 1, g.DF)
+	assert.InDelta(t, math.Erfc(math.Sqrt(100.0*100/5100)), g.PValue, 1e-12)
+
+	// Shares are normalized.
+	g, err = ChiSquaredGoodnessOfFit([]int64{30, 50, 20}, []float64{1, 2, 1})
+	require.NoError(t, err)
+	assert.InDelta(t, 2, g.Statistic, 1e-12)
+	assert.Equal(t, 2, g.DF)
+	assert.InDelta(t, math.Exp(-1), g.PValue, 1e-12)
+
+	g, err = ChiSquaredGoodnessOfFit([]int64{100, 200, 300}, []float64{0.1, 0.2, 0.3})
+	require.NoError(t, err)
+	assert.InDelta(t, 0, g.Statistic, 1e-12)
+	assert.InDelta(t, 1, g.PValue, 1e-12)
+
+	t.Run("zero share", func(t *testing.T) {
+		g, err := ChiSquaredGoodnessOfFit([]int64{50, 50, 0}, []float64{0.5, 0.5, 0})
+		require.NoError(t, err)
+		assert.Equal(t, 1, g.DF)
+		assert.Zero(t, g.Statistic)
+
+		g, err = ChiSquaredGoodnessOfFit([]int64{50, 50, 1}, []float64{0.5, 0.5, 0})
+		require.NoError(t, err)
+		assert.True(t, math.IsInf(g.Statistic, 1))
+		assert.Zero(t, g.PValue)
+	})
+
+	t.Run("insufficient data", func(t *testing.T) {
+		_, err := ChiSquaredGoodnessOfFit([]int64{0, 0}, []float64{0.5, 0.5})
+		assert.ErrorIs(t, err, ErrInsufficientData)
+		_, err = ChiSquaredGoodnessOfFit([]int64{10, 0}, []float64{1, 0})
+		assert.ErrorIs(t, err, ErrInsufficientData)
+	})
+}
@@ -0,0 +1,30 @@
+package stats
+
+import "math"
+
+// SequentialEffectSize is the standardized effect size, in standard
+// deviations of a single trial, that the mixture of the sequential test is
+// tuned to detect.
+const SequentialEffectSize = 0.1
+
+// Sequential replaces the p-value and intervals of c with always-valid ones
+// from the mixture sequential probability ratio test (mSPRT) of Johari et al.
+// with a normal mixture. Unlike fixed-horizon results, they can be checked
+// after every new observation: the chance that the p-value ever drops below
+// alpha without an effect is at most alpha.
+func (c *Comparison) Sequential(alpha float64) {
+	if c.se == 0 {
+		return
+	}
+	// The ratio of the mixture variance to the variance of Difference.
+	r := SequentialEffectSize * SequentialEffectSize * c.effectiveN
+	z := c.Difference / c.se
+	logLikelihoodRatio := z*z/2*r/(1+r) - 0.5*math.Log1p(r)
+	c.PValue = math.Min(1, math.Exp(-logLikelihoodRatio))
+
+	width := math.Sqrt((1 + r) / r * (math.Log1p(r) - 2*math.Log(alpha)))
+	c.DifferenceCI = Interval{c.Difference - width*c.se, c.Difference + width*c.se}
+	if !math.IsNaN(c.Lift) {
+		c.LiftCI = Interval{c.Lift - width*c.liftSE, c.Lift + width*c.liftSE}
+	}
+}
@@ -0,0 +1,73 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequential(t *testing.T) {
+	t.Parallel()
+	c, err := TwoProportionZTest(Proportion{200, 1000}, Proportion{250, 1000}, 0.05)
+	require.NoError(t, err)
+	fixed := c
+	c.Sequential(0.05)
+	assert.InDelta(t, 0.1222457172633802, c.PValue, 1e-9)
+	assert.InDelta(t, -0.0069702063785285, c.DifferenceCI.Lower, 1e-9)
+	assert.InDelta(t, 0.10697020637852847, c.DifferenceCI.Upper, 1e-9)
+	assert.Greater(t, c.PValue, fixed.PValue, "always-valid p-values are conservative")
+	assert.Less(t, c.LiftCI.Lower, fixed.LiftCI.Lower)
+	assert.Equal(t, fixed.Difference, c.Difference)
+}
+
+// TestSequential_Peeking simulates A/A tests checked after every batch of
+// trials: the fixed-horizon p-value drops below alpha in far more than alpha
+// of the runs, the sequential one does not.
+func TestSequential_Peeking(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(42))
+	const runs, peeks, batch, rate, alpha = 400, 50, 200, 0.1, 0.05
+
+	fixedRejections, sequentialRejections := 0, 0
+	for range runs {
+		var control, treatment Proportion
+		fixedRejected, sequentialRejected := false, false
+		for range peeks {
+			for range batch {
+				control.Trials++
+				treatment.Trials++
+				if rng.Float64() < rate {
+					control.Successes++
+				}
+				if rng.Float64() < rate {
+					treatment.Successes++
+				}
+			}
+			c, err := TwoProportionZTest(control, treatment, alpha)
+			require.NoError(t, err)
+			fixedRejected = fixedRejected || c.PValue < alpha
+			c.Sequential(alpha)
+			sequentialRejected = sequentialRejected || c.PValue < alpha
+		}
+		if fixedRejected {
+			fixedRejections++
+		}
+		if sequentialRejected {
+			sequentialRejections++
+		}
+	}
+	assert.Greater(t, float64(fixedRejections)/runs, 2*alpha)
+	assert.LessOrEqual(t, float64(sequentialRejections)/runs, alpha)
+}
+
+func TestSequential_NoLift(t *testing.T) {
+	t.Parallel()
+	c, err := TwoProportionZTest(Proportion{0, 100}, Proportion{5, 100}, 0.05)
+	require.NoError(t, err)
+	c.Sequential(0.05)
+	assert.True(t, math.IsNaN(c.LiftCI.Lower))
+	assert.Less(t, c.DifferenceCI.Lower, c.Difference)
+}
@@ -0,0 +1,171 @@
+package datar
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Granularities of time series.
+const (
+	GranularityHour = "hour"
+	GranularityDay  = "day"
+)
+
+// maxTimeSeriesBuckets bounds the buckets of a time series, 100 days of
+// hours.
+const maxTimeSeriesBuckets = 100 * 24
+
+// ErrInvalidTimeSeries is wrapped by the errors of QueryTimeSeries caused by
+// the query rather than the DB.
+var ErrInvalidTimeSeries = errors.New("invalid time series")
+
+// TimeSeriesQuery selects the buckets and the breakdown of a time series.
+type TimeSeriesQuery struct {
+	Granularity string         // GranularityHour or GranularityDay, default day
+	ByVariant   bool           // a series per variant
+	BySegment   bool           // a series per segment
+	Location    *time.Location // of day boundaries and bucket starts, default UTC
+}
+
+// Series is the counts of a variant and/or segment, by bucket. The IDs of
+// dimensions the series are not broken down by are 0.
+type Series struct {
+	VariantID int64
+	SegmentID int64
+	Total     int64
+	Counts    []int64 // by bucket, zero-filled
+}
+
+// TimeSeries is the evaluations of a flag by time bucket.
+type TimeSeries struct {
+	FlagID      int64
+	Granularity string
+	Location    *time.Location
+	ByVariant   bool
+	BySegment   bool
+	Buckets     []time.Time // start of each bucket, in Location
+	Series      []Series    // by variant, then segment
+}
+
+// QueryTimeSeries returns the evaluations of a flag by hour or day, broken
+// down by variant and/or segment. Buckets without evaluations count 0.
+//
+// Day buckets start at midnight in q.Location and follow its daylight saving
+// changes. from is rounded down to the start of its bucket, so that the first
+// bucket is complete. Hours are stored in UTC, so in time zones with a
+// fractional offset hour buckets start at the half hour. Days rolled up by the
+// retention count in the hour bucket of their UTC midnight; day series over
+// them fail with ErrInvalidTimeSeries unless their days start at UTC midnight,
+// since their evaluations can't be split across the local days.
+func (e *Engine) QueryTimeSeries(flagID int64, from, to time.Time, q TimeSeriesQuery) (*TimeSeries, error) {
+	if e == nil {
+		return nil, errNilEngine
+	}
+	if q.Granularity == "" {
+		q.Granularity = GranularityDay
+	}
+	if q.Granularity != GranularityHour && q.Granularity != GranularityDay {
+		return nil, fmt.Errorf("%w: unknown granularity %q", ErrInvalidTimeSeries, q.Granularity)
+	}
+	if q.Location == nil {
+		q.Location = time.UTC
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidTimeSeries)
+	}
+	buckets := timeBuckets(from, to, q.Granularity, q.Location)
+	if len(buckets) > maxTimeSeriesBuckets {
+		return nil, fmt.Errorf("%w: more than %d %s buckets", ErrInvalidTimeSeries, maxTimeSeriesBuckets, q.Granularity)
+	}
+
+	columns := []string{"bucket_hour"}
+	if q.ByVariant {
+		columns = append(columns, "variant_id")
+	}
+	if q.BySegment {
+		columns = append(columns, "segment_id")
+	}
+	group := strings.Join(columns, ", ")
+	var rows []struct {
+		BucketHour  time.Time
+		Granularity string
+		VariantID   int64
+		SegmentID   int64
+		Count       int64
+	}
+	if err := e.events(buckets[0], to).
+		Select(group+", granularity, SUM(eval_count) AS count").
+		Where("flag_id = ?", flagID).
+		Group(group + ", granularity").
+		Scan(&rows).Error; err != nil {
+		logrus.WithError(err).Error("Datar: QueryTimeSeries failed")
+		return nil, err
+	}
+
+	type seriesKey struct{ VariantID, SegmentID int64 }
+	series := make(map[seriesKey]*Series)
+	for _, r := range rows {
+		i := sort.Search(len(buckets), func(i int) bool { return buckets[i].After(r.BucketHour) }) - 1
+		if i < 0 {
+			continue
+		}
+		if r.Granularity == GranularityDay && q.Granularity == GranularityDay && !buckets[i].Equal(r.BucketHour) {
+			return nil, fmt.Errorf("%w: the evaluations of %s are rolled up by UTC day, query them in UTC",
+				ErrInvalidTimeSeries, r.BucketHour.UTC().Format(time.DateOnly))
+		}
+		k := seriesKey{r.VariantID, r.SegmentID}
+		s, ok := series[k]
+		if !ok {
+			s = &Series{VariantID: r.VariantID, SegmentID: r.SegmentID, Counts: make([]int64, len(buckets))}
+			series[k] = s
+		}
+		s.Counts[i] += r.Count
+		s.Total += r.Count
+	}
+
+	ts := &TimeSeries{
+		FlagID:      flagID,
+		Granularity: q.Granularity,
+		Location:    q.Location,
+		ByVariant:   q.ByVariant,
+		BySegment:   q.BySegment,
+		Buckets:     buckets,
+		Series:      make([]Series, 0, len(series)),
+	}
+	for _, s := range series {
+		ts.Series = append(ts.Series, *s)
+	}
+	sort.Slice(ts.Series, func(i, j int) bool {
+		a, b := ts.Series[i], ts.Series[j]
+		if a.VariantID != b.VariantID {
+			return a.VariantID < b.VariantID
+		}
+		return a.SegmentID < b.SegmentID
+	})
+	return ts, nil
+}
+
+// timeBuckets returns the starts of the buckets from the one containing from
+// up to to, and at most one bucket more than maxTimeSeriesBuckets.
+func timeBuckets(from, to time.Time, granularity string, loc *time.Location) []time.Time {
+	var start time.Time
+	var next func(time.Time) time.Time
+	if granularity == GranularityHour {
+		start = from.Truncate(time.Hour).In(loc)
+		next = func(t time.Time) time.Time { return t.Add(time.Hour) }
+	} else {
+		f := from.In(loc)
+		start = time.Date(f.Year(), f.Month(), f.Day(), 0, 0, 0, 0, loc)
+		next = func(t time.Time) time.Time { return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc) }
+	}
+	var buckets []time.Time
+	for t := start; t.Before(to) && len(buckets) <= maxTimeSeriesBuckets; t = next(t) {
+		buckets = append(buckets, t)
+	}
+	return buckets
+}
@@ -0,0 +1,115 @@
+package datar
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryTimeSeries(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	e := New(db, true, time.Hour)
+	require.NotNil(t, e)
+	defer e.Shutdown()
+
+	d := time.Now().UTC().Truncate(day).AddDate(0, 0, -2)
+	require.NoError(t, db.Create(&[]entity.HourlyEvent{
+		{FlagID: 1, VariantID: 1, SegmentID: 10, BucketHour: d.Add(time.Hour), EvalCount: 3},
+		{FlagID: 1, VariantID: 2, SegmentID: 10, BucketHour: d.Add(time.Hour), EvalCount: 2},
+		{FlagID: 1, VariantID: 1, SegmentID: 0, BucketHour: d.Add(23 * time.Hour), EvalCount: 4},
+		{FlagID: 1, VariantID: 1, SegmentID: 10, BucketHour: d.Add(26 * time.Hour), EvalCount: 5},
+		{FlagID: 2, VariantID: 1, SegmentID: 10, BucketHour: d.Add(time.Hour), EvalCount: 100},
+	}).Error)
+
+	t.Run("days by variant", func(t *testing.T) {
+		ts, err := e.QueryTimeSeries(1, d.Add(5*time.Hour), d.Add(48*time.Hour), TimeSeriesQuery{ByVariant: true})
+		require.NoError(t, err)
+		assert.Equal(t, GranularityDay, ts.Granularity)
+		assert.Equal(t, []time.Time{d, d.Add(day)}, ts.Buckets, "from is rounded down to its day")
+		assert.Equal(t, []Series{
+			{VariantID: 1, Total: 12, Counts: []int64{7, 5}},
+			{VariantID: 2, Total: 2, Counts: []int64{2, 0}},
+		}, ts.Series)
+	})
+
+	t.Run("days in a time zone", func(t *testing.T) {
+		loc := time.FixedZone("UTC-5", -5*3600)
+		ts, err := e.QueryTimeSeries(1, d.Add(5*time.Hour), d.Add(48*time.Hour), TimeSeriesQuery{Location: loc})
+		require.NoError(t, err)
+		require.Len(t, ts.Buckets, 2)
+		assert.True(t, ts.Buckets[0].Equal(d.Add(5*time.Hour)))
+		assert.Equal(t, loc, ts.Buckets[0].Location())
+		// 01:00 UTC is the day before, 23:00 and 02:00 UTC the same day.
+		assert.Equal(t, []Series{{Total: 9, Counts: []int64{9, 0}}}, ts.Series)
+	})
+
+	t.Run("hours by variant and segment", func(t *testing.T) {
+		ts, err := e.QueryTimeSeries(1, d, d.Add(3*time.Hour), TimeSeriesQuery{
+			Granularity: GranularityHour, ByVariant: true, BySegment: true,
+		})
+		require.NoError(t, err)
+		assert.Len(t, ts.Buckets, 3)
+		assert.Equal(t, []Series{
+			{VariantID: 1, SegmentID: 10, Total: 3, Counts: []int64{0, 3, 0}},
+			{VariantID: 2, SegmentID: 10, Total: 2, Counts: []int64{0, 2, 0}},
+		}, ts.Series)
+	})
+
+	t.Run("no evaluations", func(t *testing.T) {
+		ts, err := e.QueryTimeSeries(3, d, d.Add(48*time.Hour), TimeSeriesQuery{})
+		require.NoError(t, err)
+		assert.Len(t, ts.Buckets, 2)
+		assert.Empty(t, ts.Series)
+	})
+
+	t.Run("rolled-up days", func(t *testing.T) {
+		r := d.AddDate(0, 0, -5)
+		require.NoError(t, db.Create(&entity.DailyEvent{FlagID: 1, VariantID: 1, SegmentID: 10, BucketDay: r, EvalCount: 6}).Error)
+
+		ts, err := e.QueryTimeSeries(1, r, r.Add(2*day), TimeSeriesQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, []Series{{Total: 6, Counts: []int64{6, 0}}}, ts.Series)
+
+		ts, err = e.QueryTimeSeries(1, r, r.Add(2*day), TimeSeriesQuery{Granularity: GranularityHour})
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), ts.Series[0].Counts[0], "in the hour of their UTC midnight")
+
+		// Local days would get the evaluations of the UTC day before or after.
+		_, err = e.QueryTimeSeries(1, r, r.Add(2*day), TimeSeriesQuery{Location: time.FixedZone("UTC-5", -5*3600)})
+		assert.ErrorIs(t, err, ErrInvalidTimeSeries)
+		_, err = e.QueryTimeSeries(1, r.Add(day), r.Add(3*day), TimeSeriesQuery{Location: time.FixedZone("UTC-5", -5*3600)})
+		assert.NoError(t, err, "the range does not cover rolled-up days")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, q := range map[string]struct {
+			from, to time.Time
+			q        TimeSeriesQuery
+		}{
+			"granularity": {d, d.Add(day), TimeSeriesQuery{Granularity: "week"}},
+			"range":       {d, d, TimeSeriesQuery{}},
+			"buckets":     {d.AddDate(0, 0, -101), d, TimeSeriesQuery{Granularity: GranularityHour}},
+		} {
+			_, err := e.QueryTimeSeries(1, q.from, q.to, q.q)
+			assert.ErrorIs(t, err, ErrInvalidTimeSeries, name)
+		}
+	})
+}
+
+func TestTimeBuckets_DaylightSaving(t *testing.T) {
+	t.Parallel()
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	from := time.Date(2026, 3, 7, 12, 0, 0, 0, loc)
+	buckets := timeBuckets(from, from.AddDate(0, 0, 3), GranularityDay, loc)
+	require.Len(t, buckets, 4)
+	assert.Equal(t, time.Date(2026, 3, 7, 0, 0, 0, 0, loc), buckets[0])
+	assert.Equal(t, 23*time.Hour, buckets[2].Sub(buckets[1]), "the day clocks spring forward")
+	assert.Equal(t, 24*time.Hour, buckets[3].Sub(buckets[2]))
+}
@@ -1,7 +1,9 @@
 package entity
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/openflagr/flagr/swagger_gen/models"
@@ -36,10 +38,35 @@ var OperatorToExprMap = map[string]string{
 	models.ConstraintOperatorNOTIN:       "NOT IN",
 	models.ConstraintOperatorCONTAINS:    "CONTAINS",
 	models.ConstraintOperatorNOTCONTAINS: "NOT CONTAINS",
+	models.ConstraintOperatorANYIN:       "CONTAINS",
+	models.ConstraintOperatorALLIN:       "CONTAINS",
+	models.ConstraintOperatorNONEIN:      "NOT CONTAINS",
+
+	// Native operators are evaluated in Go, see nativeOperators. Their
+	// tokens only appear in rendered constraints, e.g. in debug logs.
+	models.ConstraintOperatorSTARTSWITH: "STARTS WITH",
+	models.ConstraintOperatorENDSWITH:   "ENDS WITH",
+	models.ConstraintOperatorEQCI:       "EQ IGNORE CASE",
+	models.ConstraintOperatorINCI:       "IN IGNORE CASE",
+	models.ConstraintOperatorCONTAINSCI: "CONTAINS IGNORE CASE",
+}
+
+// arrayOperatorJoins joins the per-element expressions of the operators on
+// array-valued properties. Their value is a JSON array, and the property
+// matches when it contains any (ANY_IN), all (ALL_IN) or none (NONE_IN) of
+// its elements.
+var arrayOperatorJoins = map[string]string{
+	models.ConstraintOperatorANYIN:  " OR ",
+	models.ConstraintOperatorALLIN:  " AND ",
+	models.ConstraintOperatorNONEIN: " AND ",
 }
 
-// ToExpr transfer the constraint to conditions.Expr for evaluation
+// ToExpr transfer the constraint to conditions.Expr for evaluation. Native
+// operators have no conditions.Expr; use Matcher for any constraint.
 func (c *Constraint) ToExpr() (conditions.Expr, error) {
+	if IsNativeOperator(c.Operator) {
+		return nil, fmt.Errorf("%s is evaluated natively and has no conditions expression", c.Operator)
+	}
 	s, err := c.toExprStr()
 	if err != nil {
 		return nil, err
@@ -66,9 +93,26 @@ func (c *Constraint) toExprStr() (string, error) {
 		return "", fmt.Errorf("not supported operator: %s", c.Operator)
 	}
 
+	prop, err := propertyExpr(c.Property)
+	if err != nil {
+		return "", err
+	}
+
 	// Trim the value to be resilient against untrimmed values from API callers.
 	val := strings.TrimSpace(c.Value)
 
+	if join, ok := arrayOperatorJoins[c.Operator]; ok {
+		elems, err := arrayOperatorElems(c.Operator, val)
+		if err != nil {
+			return "", err
+		}
+		exprs := make([]string, len(elems))
+		for i, e := range elems {
+			exprs[i] = fmt.Sprintf("(%s %s %s)", prop, o, e)
+		}
+		return "(" + strings.Join(exprs, join) + ")", nil
+	}
+
 	// For EREG/NEREG with quoted string values, use regex literal form /pattern/
 	// to avoid Go text/scanner escape issues with sequences like \d, \., \s, etc.
 	// The scanner interprets escape sequences inside quoted strings and rejects
@@ -80,11 +124,47 @@ func (c *Constraint) toExprStr() (string, error) {
 		// Only use regex literal form when the pattern doesn't contain "/",
 		// because the conditions parser doesn't support escaping "/" inside //.
 		if !strings.Contains(pattern, "/") {
-			return fmt.Sprintf("({%s} %s /%s/)", c.Property, o, pattern), nil
+			return fmt.Sprintf("(%s %s /%s/)", prop, o, pattern), nil
 		}
 	}
 
-	return fmt.Sprintf("({%s} %s %s)", c.Property, o, val), nil
+	return fmt.Sprintf("(%s %s %s)", prop, o, val), nil
+}
+
+// arrayOperatorElems returns the elements of the JSON array value of an
+// ANY_IN, ALL_IN or NONE_IN constraint as conditions literals.
+func arrayOperatorElems(operator, val string) ([]string, error) {
+	var elems []any
+	d := json.NewDecoder(strings.NewReader(val))
+	d.UseNumber()
+	if err := d.Decode(&elems); err != nil || d.More() {
+		return nil, fmt.Errorf("%s requires a JSON array value, e.g. [\"a\", \"b\"]", operator)
+	}
+	if len(elems) == 0 {
+		return nil, fmt.Errorf("%s requires a non-empty JSON array value", operator)
+	}
+	lits := make([]string, len(elems))
+	for i, e := range elems {
+		switch e := e.(type) {
+		case string:
+			lits[i] = strconv.Quote(e)
+		case json.Number:
+			lits[i] = e.String()
+		default:
+			return nil, fmt.Errorf("%s values must be strings or numbers, got %v", operator, e)
+		}
+	}
+	return lits, nil
+}
+
+// ExprString renders the constraint like a conditions expression, e.g.
+// ({country} == "US"), or as it is if it is invalid.
+func (c *Constraint) ExprString() string {
+	s, err := c.toExprStr()
+	if err != nil {
+		return fmt.Sprintf("({%s} %s %s)", c.Property, c.Operator, c.Value)
+	}
+	return s
 }
 
 // isQuotedString reports whether s is a double-quoted string like "foo".
@@ -94,20 +174,27 @@ func isQuotedString(s string) bool {
 
 // Validate validates Constraint
 func (c *Constraint) Validate() error {
-	_, err := c.ToExpr()
+	_, err := c.Matcher()
 	return err
 }
 
-// ToExpr maps ConstraintArray to expr by joining 'AND'
+// ToExpr maps ConstraintArray to expr by joining 'AND'. Constraints with a
+// native operator are left out, and the expr is nil if there are only those.
 func (cs ConstraintArray) ToExpr() (conditions.Expr, error) {
 	strs := make([]string, 0, len(cs))
 	for _, c := range cs {
+		if IsNativeOperator(c.Operator) {
+			continue
+		}
 		s, err := c.toExprStr()
 		if err != nil {
 			return nil, err
 		}
 		strs = append(strs, s)
 	}
+	if len(strs) == 0 {
+		return nil, nil
+	}
 	exprStr := strings.Join(strs, " AND ")
 	p := conditions.NewParser(strings.NewReader(exprStr))
 	expr, err := p.Parse()
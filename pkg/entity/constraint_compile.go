@@ -0,0 +1,485 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"regexp"
+
+	"github.com/zhouzhuojie/conditions"
+)
+
+// Constraints are parsed with the conditions library, which also validates
+// them, and the parsed expression is then compiled into Go closures: numbers
+// are parsed, regexes compiled and IN lists turned into hash sets once, when
+// a segment is prepared for evaluation, rather than for every evaluation.
+//
+// The compiled closures have the semantics of conditions.Evaluate, including
+// which evaluations are errors, e.g. comparing a string property with a
+// number. An error is reported by evaluating the expression again with the
+// conditions library, so that its message stays the same; errors are rare
+// except for missing properties, which the library fails fast on. Expressions
+// that are not the shape of a constraint, e.g. a value referencing another
+// property, are evaluated with the conditions library.
+
+// errNotEvaluable is returned by compiled expressions when conditions.Evaluate
+// would return an error.
+var errNotEvaluable = errors.New("constraint cannot be evaluated")
+
+// conditionsEpsilon is the tolerance of the conditions library for comparing
+// numbers.
+const conditionsEpsilon = 1e-6
+
+// compileExpr compiles a conditions expression into a Matcher.
+func compileExpr(expr conditions.Expr) Matcher {
+	interpret := func(m map[string]any) (bool, error) {
+		return conditions.Evaluate(expr, m)
+	}
+	match, ok := compileBool(expr)
+	if !ok {
+		return interpret
+	}
+	return func(m map[string]any) (bool, error) {
+		ok, err := match(m)
+		if err != nil {
+			return interpret(m)
+		}
+		return ok, nil
+	}
+}
+
+// compileBool compiles an expression that evaluates to a boolean. It returns
+// false if the expression is not made of comparisons of a property with a
+// literal, joined with AND and OR.
+func compileBool(expr conditions.Expr) (Matcher, bool) {
+	switch e := expr.(type) {
+	case *conditions.ParenExpr:
+		return compileBool(e.Expr)
+	case *conditions.BooleanLiteral:
+		v := e.Val
+		return func(map[string]any) (bool, error) { return v, nil }, true
+	case *conditions.BinaryExpr:
+		switch e.Op {
+		case conditions.AND, conditions.OR:
+			l, ok := compileBool(e.LHS)
+			if !ok {
+				return nil, false
+			}
+			r, ok := compileBool(e.RHS)
+			if !ok {
+				return nil, false
+			}
+			return joinMatchers(e.Op == conditions.OR, l, r), true
+		}
+		resolve, ok := compileOperand(e.LHS)
+		if !ok {
+			return nil, false
+		}
+		test, ok := compileTest(e.Op, e.RHS)
+		if !ok {
+			return nil, false
+		}
+		return func(m map[string]any) (bool, error) {
+			v, ok := resolve(m)
+			if !ok {
+				return false, errNotEvaluable
+			}
+			return test(v)
+		}, true
+	}
+	return nil, false
+}
+
+// joinMatchers joins two matchers with OR or AND, short-circuiting like
+// the conditions library.
+func joinMatchers(or bool, l, r Matcher) Matcher {
+	return func(m map[string]any) (bool, error) {
+		ok, err := l(m)
+		if err != nil {
+			return false, err
+		}
+		if ok == or {
+			return ok, nil
+		}
+		return r(m)
+	}
+}
+
+// operandKind is the literal type the conditions library converts a
+// property value to.
+type operandKind uint8
+
+const (
+	operandString operandKind = iota + 1
+	operandNumber
+	operandBool
+	operandStrings
+	operandNumbers
+)
+
+// operand is a property value converted like the conditions library does.
+// Arrays from JSON keep their elements in list rather than being copied.
+type operand struct {
+	kind operandKind
+	s    string
+	f    float64
+	b    bool
+	strs []string
+	nums []float64
+	list []any
+}
+
+// compileOperand compiles a property reference into a function resolving it
+// to an operand. It returns false from the function when the property is
+// missing, null or of a type the conditions library does not support.
+func compileOperand(expr conditions.Expr) (func(m map[string]any) (operand, bool), bool) {
+	var root string
+	var steps []propertyStep
+	switch e := expr.(type) {
+	case *conditions.VarRef:
+		root = e.Val
+	case *conditions.PathRef:
+		root = e.Root
+		steps = make([]propertyStep, len(e.Steps))
+		for i, s := range e.Steps {
+			steps[i] = propertyStep{Key: s.Key, Index: s.Index, IsIndex: s.IsIndex}
+		}
+	default:
+		return nil, false
+	}
+	return func(m map[string]any) (operand, bool) {
+		v, ok := lookupProperty(m, root, steps)
+		if !ok || v == nil {
+			return operand{}, false
+		}
+		return toOperand(v)
+	}, true
+}
+
+func toOperand(v any) (operand, bool) {
+	switch v := v.(type) {
+	case string:
+		return operand{kind: operandString, s: v}, true
+	case float64:
+		return operand{kind: operandNumber, f: v}, true
+	case bool:
+		return operand{kind: operandBool, b: v}, true
+	case int:
+		return operand{kind: operandNumber, f: float64(v)}, true
+	case int8:
+		return operand{kind: operandNumber, f: float64(v)}, true
+	case int16:
+		return operand{kind: operandNumber, f: float64(v)}, true
+	case int32:
+		return operand{kind: operandNumber, f: float64(v)}, true
+	case int64:
+		return operand{kind: operandNumber, f: float64(v)}, true
+	case uint:
+		return operand{kind: operandNumber, f: float64(v)}, true
+	case uint8:
+		return operand{kind: operandNumber, f: float64(v)}, true
+	case uint16:
+		return operand{kind: operandNumber, f: float64(v)}, true
+	case uint32:
+		return operand{kind: operandNumber, f: float64(v)}, true
+	case uint64:
+		return operand{kind: operandNumber, f: float64(v)}, true
+	case float32:
+		return operand{kind: operandNumber, f: float64(v)}, true
+	case json.Number:
+		f, err := v.Float64()
+		return operand{kind: operandNumber, f: f}, err == nil
+	case []string:
+		return operand{kind: operandStrings, strs: v}, true
+	case []float64:
+		return operand{kind: operandNumbers, nums: v}, true
+	case []int:
+		return operand{kind: operandNumbers, nums: toFloats(v)}, true
+	case []int32:
+		return operand{kind: operandNumbers, nums: toFloats(v)}, true
+	case []int64:
+		return operand{kind: operandNumbers, nums: toFloats(v)}, true
+	case []float32:
+		return operand{kind: operandNumbers, nums: toFloats(v)}, true
+	case []json.Number:
+		return jsonNumbersOperand(v)
+	case []any:
+		return listOperand(v)
+	}
+	return operand{}, false
+}
+
+// listOperand converts an array from JSON. Its elements must all be strings
+// or all be numbers.
+func listOperand(list []any) (operand, bool) {
+	if len(list) == 0 {
+		return operand{}, false
+	}
+	switch list[0].(type) {
+	case string:
+		for _, e := range list {
+			if _, ok := e.(string); !ok {
+				return operand{}, false
+			}
+		}
+		return operand{kind: operandStrings, list: list}, true
+	case float64:
+		for _, e := range list {
+			if _, ok := e.(float64); !ok {
+				return operand{}, false
+			}
+		}
+		return operand{kind: operandNumbers, list: list}, true
+	case json.Number:
+		nums := make([]json.Number, len(list))
+		for i, e := range list {
+			n, ok := e.(json.Number)
+			if !ok {
+				return operand{}, false
+			}
+			nums[i] = n
+		}
+		return jsonNumbersOperand(nums)
+	}
+	return operand{}, false
+}
+
+func jsonNumbersOperand(nums []json.Number) (operand, bool) {
+	fs := make([]float64, len(nums))
+	for i, n := range nums {
+		f, err := n.Float64()
+		if err != nil {
+			return operand{}, false
+		}
+		fs[i] = f
+	}
+	return operand{kind: operandNumbers, nums: fs}, true
+}
+
+func toFloats[S ~[]E, E int | int32 | int64 | float32](s S) []float64 {
+	fs := make([]float64, len(s))
+	for i, v := range s {
+		fs[i] = float64(v)
+	}
+	return fs
+}
+
+// containsString reports whether the string array operand has s.
+func (o operand) containsString(s string) bool {
+	for _, e := range o.strs {
+		if e == s {
+			return true
+		}
+	}
+	for _, e := range o.list {
+		if e.(string) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// containsNumber reports whether the number array operand has f.
+func (o operand) containsNumber(f float64) bool {
+	for _, e := range o.nums {
+		if floatEqual(e, f) {
+			return true
+		}
+	}
+	for _, e := range o.list {
+		if floatEqual(e.(float64), f) {
+			return true
+		}
+	}
+	return false
+}
+
+// operandTest tests a resolved property value against a literal.
+type operandTest func(v operand) (bool, error)
+
+// compileTest compiles the comparison of a property value with the literal
+// lit. The literal is converted once; comparisons the conditions library
+// would always fail, e.g. < with a string, compile to an error.
+func compileTest(op conditions.Token, lit conditions.Expr) (operandTest, bool) {
+	switch op {
+	case conditions.EQ:
+		return equalTest(lit)
+	case conditions.NEQ:
+		return negateTest(equalTest(lit))
+	case conditions.LT:
+		return compareTest(lit, func(a, b float64) bool { return a < b })
+	case conditions.LTE:
+		return compareTest(lit, func(a, b float64) bool { return a < b || floatEqual(a, b) })
+	case conditions.GT:
+		return compareTest(lit, func(a, b float64) bool { return a > b })
+	case conditions.GTE:
+		return compareTest(lit, func(a, b float64) bool { return a > b || floatEqual(a, b) })
+	case conditions.EREG:
+		return regexTest(lit)
+	case conditions.NEREG:
+		return negateTest(regexTest(lit))
+	case conditions.IN:
+		return inTest(lit)
+	case conditions.NOTIN:
+		return negateTest(inTest(lit))
+	case conditions.CONTAINS:
+		return containsTest(lit)
+	case conditions.NOTCONTAINS:
+		return negateTest(containsTest(lit))
+	}
+	return nil, false
+}
+
+func notEvaluable(operand) (bool, error) {
+	return false, errNotEvaluable
+}
+
+func negateTest(test operandTest, ok bool) (operandTest, bool) {
+	if !ok {
+		return nil, false
+	}
+	return func(v operand) (bool, error) {
+		match, err := test(v)
+		return !match && err == nil, err
+	}, true
+}
+
+func equalTest(lit conditions.Expr) (operandTest, bool) {
+	switch l := lit.(type) {
+	case *conditions.StringLiteral:
+		s := l.Val
+		return func(v operand) (bool, error) {
+			if v.kind != operandString {
+				return false, errNotEvaluable
+			}
+			return v.s == s, nil
+		}, true
+	case *conditions.NumberLiteral:
+		f := l.Val
+		return func(v operand) (bool, error) {
+			if v.kind != operandNumber {
+				return false, errNotEvaluable
+			}
+			return floatEqual(v.f, f), nil
+		}, true
+	case *conditions.BooleanLiteral:
+		b := l.Val
+		return func(v operand) (bool, error) {
+			if v.kind != operandBool {
+				return false, errNotEvaluable
+			}
+			return v.b == b, nil
+		}, true
+	case *conditions.SliceStringLiteral, *conditions.SliceNumberLiteral:
+		return notEvaluable, true
+	}
+	return nil, false
+}
+
+func compareTest(lit conditions.Expr, cmp func(a, b float64) bool) (operandTest, bool) {
+	switch l := lit.(type) {
+	case *conditions.NumberLiteral:
+		f := l.Val
+		return func(v operand) (bool, error) {
+			if v.kind != operandNumber {
+				return false, errNotEvaluable
+			}
+			return cmp(v.f, f), nil
+		}, true
+	case *conditions.StringLiteral, *conditions.BooleanLiteral,
+		*conditions.SliceStringLiteral, *conditions.SliceNumberLiteral:
+		return notEvaluable, true
+	}
+	return nil, false
+}
+
+func regexTest(lit conditions.Expr) (operandTest, bool) {
+	switch l := lit.(type) {
+	case *conditions.StringLiteral:
+		re, err := regexp.Compile(l.Val)
+		if err != nil {
+			return notEvaluable, true
+		}
+		return func(v operand) (bool, error) {
+			if v.kind != operandString {
+				return false, errNotEvaluable
+			}
+			return re.MatchString(v.s), nil
+		}, true
+	case *conditions.NumberLiteral, *conditions.BooleanLiteral,
+		*conditions.SliceStringLiteral, *conditions.SliceNumberLiteral:
+		return notEvaluable, true
+	}
+	return nil, false
+}
+
+// inTest tests that a string or number property is in the array literal.
+func inTest(lit conditions.Expr) (operandTest, bool) {
+	switch l := lit.(type) {
+	case *conditions.SliceStringLiteral:
+		set := make(map[string]struct{}, len(l.Val))
+		for _, s := range l.Val {
+			set[s] = struct{}{}
+		}
+		return func(v operand) (bool, error) {
+			if v.kind != operandString {
+				return false, errNotEvaluable
+			}
+			_, ok := set[v.s]
+			return ok, nil
+		}, true
+	case *conditions.SliceNumberLiteral:
+		nums := l.Val
+		return func(v operand) (bool, error) {
+			if v.kind != operandNumber {
+				return false, errNotEvaluable
+			}
+			return operand{nums: nums}.containsNumber(v.f), nil
+		}, true
+	case *conditions.StringLiteral, *conditions.NumberLiteral, *conditions.BooleanLiteral:
+		return notEvaluable, true
+	}
+	return nil, false
+}
+
+// containsTest tests that an array property has the string or number
+// literal.
+func containsTest(lit conditions.Expr) (operandTest, bool) {
+	switch l := lit.(type) {
+	case *conditions.StringLiteral:
+		s := l.Val
+		return func(v operand) (bool, error) {
+			if v.kind != operandStrings {
+				return false, errNotEvaluable
+			}
+			return v.containsString(s), nil
+		}, true
+	case *conditions.NumberLiteral:
+		f := l.Val
+		return func(v operand) (bool, error) {
+			if v.kind != operandNumbers {
+				return false, errNotEvaluable
+			}
+			return v.containsNumber(f), nil
+		}, true
+	case *conditions.BooleanLiteral, *conditions.SliceStringLiteral, *conditions.SliceNumberLiteral:
+		return notEvaluable, true
+	}
+	return nil, false
+}
+
+// floatEqual compares numbers like the conditions library, with a relative
+// tolerance of conditionsEpsilon.
+func floatEqual(a, b float64) bool {
+	if a == b {
+		return true
+	}
+	diff := math.Abs(a - b)
+	if diff > conditionsEpsilon {
+		return false
+	}
+	if a == 0 || b == 0 {
+		return diff < conditionsEpsilon*math.SmallestNonzeroFloat32
+	}
+	return diff/math.Max(math.Abs(a), math.Abs(b)) < conditionsEpsilon
+}
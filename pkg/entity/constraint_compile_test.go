@@ -0,0 +1,178 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhouzhuojie/conditions"
+)
+
+// differentialValues are constraint values for every literal type, by
+// operator. Values that do not parse are skipped.
+var differentialValues = []string{
+	`"CA"`, `"ca"`, `""`, `"a\"b"`, `"@test\.com$"`, `"^\d+$"`, `"a/b"`, `"(["`,
+	`1`, `1.0000001`, `-2.5`, `0`, `30`, `true`, `false`,
+	`["CA", "NY"]`, `["ca"]`, `[1, 2, 30]`, `[-2.5, 0]`, `["CA", 1]`, `[true]`,
+}
+
+// differentialContexts are entity contexts with values of every type the
+// conditions library converts, and some it does not.
+var differentialContexts = []map[string]any{
+	{},
+	{"p": nil},
+	{"p": "CA"},
+	{"p": "ca"},
+	{"p": ""},
+	{"p": `a"b`},
+	{"p": "x@test.com"},
+	{"p": "123"},
+	{"p": "a/b"},
+	{"p": float64(1)},
+	{"p": 1.0000001},
+	{"p": float64(30)},
+	{"p": -2.5},
+	{"p": 0},
+	{"p": int64(30)},
+	{"p": uint8(1)},
+	{"p": float32(-2.5)},
+	{"p": json.Number("30")},
+	{"p": json.Number("1e400")},
+	{"p": true},
+	{"p": false},
+	{"p": []any{}},
+	{"p": []any{"CA", "NY"}},
+	{"p": []any{"ca"}},
+	{"p": []any{float64(1), float64(30)}},
+	{"p": []any{json.Number("1"), json.Number("2")}},
+	{"p": []any{json.Number("1"), float64(2)}},
+	{"p": []any{"CA", float64(1)}},
+	{"p": []any{true}},
+	{"p": []any{nil}},
+	{"p": []string{"CA"}},
+	{"p": []float64{-2.5}},
+	{"p": []int{1, 30}},
+	{"p": []json.Number{"30"}},
+	{"p": []uint{1}},
+	{"p": map[string]any{"q": "CA"}},
+	{"p": map[string]any{"q": nil}},
+	{"p": map[string]any{"q": []any{"CA", float64(1)}}},
+	{"p": map[string]any{"q": []any{map[string]any{"r": float64(30)}}}},
+	{"p": []any{"CA", map[string]any{"q": "CA"}}},
+}
+
+var differentialProperties = []string{"p", "p.q", "p[0]", "p[-1]", "p.q[0].r", "/p/q", "missing"}
+
+// TestConstraintMatcher_Differential proves that compiled constraints match
+// like the conditions library, for every operator and combination of the
+// values and contexts above.
+func TestConstraintMatcher_Differential(t *testing.T) {
+	t.Parallel()
+	cases := 0
+	for operator := range OperatorToExprMap {
+		if IsNativeOperator(operator) {
+			continue
+		}
+		for _, property := range differentialProperties {
+			for _, value := range differentialValues {
+				c := Constraint{Property: property, Operator: operator, Value: value}
+				expr, err := c.ToExpr()
+				if err != nil {
+					continue
+				}
+				compiled, ok := compileBool(expr)
+				require.True(t, ok, "%s is not compiled", c.ExprString())
+				match, err := c.Matcher()
+				require.NoError(t, err)
+
+				for _, m := range differentialContexts {
+					name := fmt.Sprintf("%s with %#v", c.ExprString(), m)
+					want, wantErr := conditions.Evaluate(expr, m)
+
+					got, gotErr := compiled(m)
+					assert.Equal(t, wantErr != nil, gotErr != nil, name)
+					if wantErr == nil {
+						assert.Equal(t, want, got, name)
+					}
+
+					got, gotErr = match(m)
+					assert.Equal(t, want, got, name)
+					assert.Equal(t, wantErr, gotErr, name)
+					cases++
+				}
+			}
+		}
+	}
+	assert.Greater(t, cases, 10000)
+}
+
+func TestConstraintMatcher_Uncompiled(t *testing.T) {
+	t.Parallel()
+	// A value that references another property is left to the conditions
+	// library.
+	c := Constraint{Property: "a", Operator: models.ConstraintOperatorEQ, Value: `{b}`}
+	expr, err := c.ToExpr()
+	require.NoError(t, err)
+	_, ok := compileBool(expr)
+	assert.False(t, ok)
+
+	match, err := c.Matcher()
+	require.NoError(t, err)
+	ok, err = match(map[string]any{"a": "x", "b": "x"})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = match(map[string]any{"a": "x", "b": "y"})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestConstraintMatcher_ErrorMessages(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		c   Constraint
+		m   map[string]any
+		err string
+	}{
+		{Constraint{Property: "p", Operator: models.ConstraintOperatorEQ, Value: `"CA"`}, map[string]any{}, "argument: p not found"},
+		{Constraint{Property: "p.q", Operator: models.ConstraintOperatorEQ, Value: `"CA"`}, map[string]any{"p": map[string]any{}}, `key "q" not found traversing p`},
+		{Constraint{Property: "p", Operator: models.ConstraintOperatorLT, Value: `1`}, map[string]any{"p": "CA"}, `literal is not a number: "CA"`},
+		{Constraint{Property: "p", Operator: models.ConstraintOperatorEREG, Value: `"(["`}, map[string]any{"p": "CA"}, "invalid regex pattern"},
+	} {
+		match, err := tc.c.Matcher()
+		require.NoError(t, err)
+		_, err = match(tc.m)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), tc.err)
+		}
+	}
+}
+
+func BenchmarkConstraintMatcher(b *testing.B) {
+	m := map[string]any{"country": "NY", "age": float64(30), "roles": []any{"dev", "admin"}}
+	for name, c := range map[string]Constraint{
+		"EQ":       {Property: "country", Operator: models.ConstraintOperatorEQ, Value: `"NY"`},
+		"GTE":      {Property: "age", Operator: models.ConstraintOperatorGTE, Value: `21`},
+		"EREG":     {Property: "country", Operator: models.ConstraintOperatorEREG, Value: `"^N[A-Z]$"`},
+		"IN":       {Property: "country", Operator: models.ConstraintOperatorIN, Value: `["CA", "WA", "OR", "NV", "AZ", "NY", "NJ", "MA"]`},
+		"CONTAINS": {Property: "roles", Operator: models.ConstraintOperatorCONTAINS, Value: `"admin"`},
+	} {
+		expr, err := c.ToExpr()
+		require.NoError(b, err)
+		match, err := c.Matcher()
+		require.NoError(b, err)
+
+		b.Run(name+"/conditions", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = conditions.Evaluate(expr, m)
+			}
+		})
+		b.Run(name+"/compiled", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = match(m)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/spf13/cast"
+)
+
+// MaxConstraintGroupDepth is how deeply constraint groups can be nested.
+const MaxConstraintGroupDepth = 8
+
+// ConstraintGroup is a boolean combination of constraints and nested groups.
+// AND and OR join its members; NOT matches when its members joined with AND
+// do not. A segment matches when all its Constraints and all its
+// ConstraintGroups match.
+type ConstraintGroup struct {
+	Operator    string
+	Constraints []GroupConstraint
+	Groups      []ConstraintGroup
+}
+
+// GroupConstraint is a constraint in a ConstraintGroup. It has the same
+// Property, Operator and Value as a Constraint, but is stored with its group.
+type GroupConstraint struct {
+	Property string
+	Operator string
+	Value    string
+}
+
+// ConstraintGroupArray is the list of constraint groups of a segment, stored
+// as JSON in the segment row.
+type ConstraintGroupArray []ConstraintGroup
+
+// Scan implements scanner interface
+func (a *ConstraintGroupArray) Scan(value any) error {
+	if value == nil {
+		return nil
+	}
+	s := cast.ToString(value)
+	if s == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(s), a); err != nil {
+		return fmt.Errorf("cannot scan %v into ConstraintGroupArray type. err: %v", value, err)
+	}
+	return nil
+}
+
+// Value implements valuer interface
+func (a ConstraintGroupArray) Value() (driver.Value, error) {
+	if len(a) == 0 {
+		return "", nil
+	}
+	bytes, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(bytes), nil
+}
+
+// Validate validates every group of the array.
+func (a ConstraintGroupArray) Validate() error {
+	for i, g := range a {
+		if err := g.Validate(); err != nil {
+			return fmt.Errorf("constraint group[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Validate validates the operator, members and nesting depth of the group
+// and its constraints.
+func (g ConstraintGroup) Validate() error {
+	_, err := g.toExpr(1)
+	return err
+}
+
+// ToExpr compiles the group for evaluation.
+func (g ConstraintGroup) ToExpr() (ConstraintGroupExpr, error) {
+	return g.toExpr(1)
+}
+
+func (g ConstraintGroup) toExpr(depth int) (ConstraintGroupExpr, error) {
+	ge := ConstraintGroupExpr{Operator: g.Operator}
+	switch g.Operator {
+	case models.ConstraintGroupOperatorAND, models.ConstraintGroupOperatorOR, models.ConstraintGroupOperatorNOT:
+	default:
+		return ge, fmt.Errorf("not supported group operator: %q", g.Operator)
+	}
+	if depth > MaxConstraintGroupDepth {
+		return ge, fmt.Errorf("constraint groups nested deeper than %d", MaxConstraintGroupDepth)
+	}
+	if len(g.Constraints) == 0 && len(g.Groups) == 0 {
+		return ge, fmt.Errorf("empty %s group", g.Operator)
+	}
+
+	ge.Matchers = make([]Matcher, len(g.Constraints))
+	for i, gc := range g.Constraints {
+		c := gc.constraint()
+		match, err := c.Matcher()
+		if err != nil {
+			return ge, fmt.Errorf("constraints[%d]: %w", i, err)
+		}
+		ge.Matchers[i] = match
+	}
+	ge.Groups = make([]ConstraintGroupExpr, len(g.Groups))
+	for i, sub := range g.Groups {
+		subExpr, err := sub.toExpr(depth + 1)
+		if err != nil {
+			return ge, fmt.Errorf("groups[%d]: %w", i, err)
+		}
+		ge.Groups[i] = subExpr
+	}
+	return ge, nil
+}
+
+// String renders the group like a conditions expression, e.g.
+// (({country} == "US") OR ({tier} == "enterprise")).
+func (g ConstraintGroup) String() string {
+	parts := make([]string, 0, len(g.Constraints)+len(g.Groups))
+	for _, gc := range g.Constraints {
+		c := gc.constraint()
+		parts = append(parts, c.ExprString())
+	}
+	for _, sub := range g.Groups {
+		parts = append(parts, sub.String())
+	}
+	if g.Operator == models.ConstraintGroupOperatorNOT {
+		return "NOT (" + strings.Join(parts, " AND ") + ")"
+	}
+	return "(" + strings.Join(parts, " "+g.Operator+" ") + ")"
+}
+
+func (gc GroupConstraint) constraint() Constraint {
+	return Constraint{Property: gc.Property, Operator: gc.Operator, Value: gc.Value}
+}
+
+// ConstraintGroupExpr is a ConstraintGroup compiled for evaluation, with a
+// Matcher for each of its constraints.
+type ConstraintGroupExpr struct {
+	Operator string
+	Matchers []Matcher
+	Groups   []ConstraintGroupExpr
+}
+
+// Evaluate evaluates the group against an entity context, with the
+// semantics of a conditions expression joining its members (see
+// joinMatchers): members are evaluated in order and short-circuit, and a
+// constraint that cannot be evaluated, e.g. because its property is not in
+// m, is an error that fails the whole group, NOT groups included, like it
+// fails a segment's Constraints.
+func (ge ConstraintGroupExpr) Evaluate(m map[string]any) (bool, error) {
+	ok, err := ge.join(ge.Operator == models.ConstraintGroupOperatorOR, m)
+	if err != nil {
+		return false, err
+	}
+	if ge.Operator == models.ConstraintGroupOperatorNOT {
+		return !ok, nil
+	}
+	return ok, nil
+}
+
+// join joins the members of the group with OR, or else with AND.
+func (ge ConstraintGroupExpr) join(or bool, m map[string]any) (bool, error) {
+	for _, match := range ge.Matchers {
+		ok, err := match(m)
+		if err != nil {
+			return false, err
+		}
+		if ok == or {
+			return ok, nil
+		}
+	}
+	for _, sub := range ge.Groups {
+		ok, err := sub.Evaluate(m)
+		if err != nil {
+			return false, err
+		}
+		if ok == or {
+			return ok, nil
+		}
+	}
+	return !or, nil
+}
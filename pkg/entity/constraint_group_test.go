@@ -0,0 +1,135 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// enterpriseOrUS matches US users and enterprise users outside the test
+// accounts.
+var enterpriseOrUS = ConstraintGroup{
+	Operator: models.ConstraintGroupOperatorOR,
+	Constraints: []GroupConstraint{
+		{Property: "country", Operator: models.ConstraintOperatorEQ, Value: `"US"`},
+	},
+	Groups: []ConstraintGroup{{
+		Operator: models.ConstraintGroupOperatorAND,
+		Constraints: []GroupConstraint{
+			{Property: "tier", Operator: models.ConstraintOperatorEQ, Value: `"enterprise"`},
+		},
+		Groups: []ConstraintGroup{{
+			Operator: models.ConstraintGroupOperatorNOT,
+			Constraints: []GroupConstraint{
+				{Property: "email", Operator: models.ConstraintOperatorEREG, Value: `"@test\.com$"`},
+			},
+		}},
+	}},
+}
+
+func TestConstraintGroupEvaluate(t *testing.T) {
+	t.Parallel()
+	ge, err := enterpriseOrUS.ToExpr()
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		m     map[string]any
+		match bool
+	}{
+		"US":                      {map[string]any{"country": "US"}, true},
+		"enterprise":              {map[string]any{"country": "DE", "tier": "enterprise", "email": "a@b.com"}, true},
+		"enterprise test account": {map[string]any{"country": "DE", "tier": "enterprise", "email": "a@test.com"}, false},
+		"free":                    {map[string]any{"country": "DE", "tier": "free", "email": "a@b.com"}, false},
+	} {
+		match, err := ge.Evaluate(tc.m)
+		assert.NoError(t, err, name)
+		assert.Equal(t, tc.match, match, name)
+	}
+
+	// A missing property fails the group like it fails a segment's
+	// Constraints, unless a member before it decided the group.
+	for name, m := range map[string]map[string]any{
+		"missing country":         {"tier": "enterprise", "email": "a@b.com"},
+		"missing email under NOT": {"country": "DE", "tier": "enterprise"},
+		"empty context":           {},
+	} {
+		match, err := ge.Evaluate(m)
+		assert.Error(t, err, name)
+		assert.False(t, match, name)
+	}
+
+	not := ConstraintGroup{
+		Operator:    models.ConstraintGroupOperatorNOT,
+		Constraints: []GroupConstraint{{Property: "email", Operator: models.ConstraintOperatorEREG, Value: `"@test\.com$"`}},
+	}
+	notExpr, err := not.ToExpr()
+	require.NoError(t, err)
+	match, err := notExpr.Evaluate(map[string]any{})
+	assert.Error(t, err, "a NOT group does not match a missing property")
+	assert.False(t, match)
+}
+
+func TestConstraintGroupValidate(t *testing.T) {
+	t.Parallel()
+	assert.NoError(t, enterpriseOrUS.Validate())
+
+	nested := ConstraintGroup{Operator: models.ConstraintGroupOperatorAND, Constraints: enterpriseOrUS.Groups[0].Constraints}
+	for i := 1; i < MaxConstraintGroupDepth; i++ {
+		nested = ConstraintGroup{Operator: models.ConstraintGroupOperatorAND, Groups: []ConstraintGroup{nested}}
+	}
+	assert.NoError(t, nested.Validate())
+
+	for name, tc := range map[string]struct {
+		g   ConstraintGroup
+		err string
+	}{
+		"unknown operator": {ConstraintGroup{Operator: "XOR", Constraints: nested.Constraints}, `not supported group operator: "XOR"`},
+		"empty group":      {ConstraintGroup{Operator: models.ConstraintGroupOperatorOR}, "empty OR group"},
+		"invalid constraint": {ConstraintGroup{
+			Operator:    models.ConstraintGroupOperatorAND,
+			Constraints: []GroupConstraint{{Property: "country", Operator: models.ConstraintOperatorEQ, Value: "US"}},
+		}, "constraints[0]: "},
+		"invalid nested group": {ConstraintGroup{
+			Operator: models.ConstraintGroupOperatorAND,
+			Groups:   []ConstraintGroup{{Operator: models.ConstraintGroupOperatorNOT}},
+		}, "groups[0]: empty NOT group"},
+		"too deep": {ConstraintGroup{Operator: models.ConstraintGroupOperatorAND, Groups: []ConstraintGroup{nested}}, "nested deeper than 8"},
+	} {
+		err := tc.g.Validate()
+		if assert.Error(t, err, name) {
+			assert.Contains(t, err.Error(), tc.err, name)
+		}
+	}
+
+	err := ConstraintGroupArray{enterpriseOrUS, {Operator: "AND"}}.Validate()
+	assert.EqualError(t, err, "constraint group[1]: empty AND group")
+}
+
+func TestConstraintGroupString(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t,
+		`(({country} == "US") OR (({tier} == "enterprise") AND NOT (({email} =~ /@test\.com$/))))`,
+		enterpriseOrUS.String())
+}
+
+func TestConstraintGroupArrayScanValue(t *testing.T) {
+	t.Parallel()
+	v, err := ConstraintGroupArray{enterpriseOrUS}.Value()
+	require.NoError(t, err)
+
+	var a ConstraintGroupArray
+	require.NoError(t, a.Scan(v))
+	assert.Equal(t, ConstraintGroupArray{enterpriseOrUS}, a)
+
+	v, err = ConstraintGroupArray(nil).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "", v)
+
+	a = nil
+	assert.NoError(t, a.Scan(nil))
+	assert.NoError(t, a.Scan(""))
+	assert.Nil(t, a)
+	assert.Error(t, a.Scan("{"))
+}
@@ -0,0 +1,128 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/openflagr/flagr/swagger_gen/models"
+)
+
+// Matcher evaluates a compiled constraint against an entity context.
+type Matcher func(m map[string]any) (bool, error)
+
+// nativeOperators compile the value of a constraint with a string operator
+// that the conditions library does not have into a test of the property
+// value. They use the strings package rather than regexes.
+var nativeOperators = map[string]func(value string) (func(actual any) (bool, error), error){
+	models.ConstraintOperatorSTARTSWITH: func(value string) (func(any) (bool, error), error) {
+		prefix, err := nativeStringValue(value)
+		return stringTest(func(s string) bool { return strings.HasPrefix(s, prefix) }), err
+	},
+	models.ConstraintOperatorENDSWITH: func(value string) (func(any) (bool, error), error) {
+		suffix, err := nativeStringValue(value)
+		return stringTest(func(s string) bool { return strings.HasSuffix(s, suffix) }), err
+	},
+	models.ConstraintOperatorEQCI: func(value string) (func(any) (bool, error), error) {
+		expected, err := nativeStringValue(value)
+		return stringTest(func(s string) bool { return strings.EqualFold(s, expected) }), err
+	},
+	models.ConstraintOperatorINCI: func(value string) (func(any) (bool, error), error) {
+		var list []string
+		if err := json.Unmarshal([]byte(value), &list); err != nil || len(list) == 0 {
+			return nil, fmt.Errorf("requires a non-empty JSON array of strings, e.g. [\"a\", \"b\"]")
+		}
+		set := make(map[string]struct{}, len(list))
+		for _, v := range list {
+			set[strings.ToLower(v)] = struct{}{}
+		}
+		return stringTest(func(s string) bool {
+			_, ok := set[strings.ToLower(s)]
+			return ok
+		}), nil
+	},
+	models.ConstraintOperatorCONTAINSCI: func(value string) (func(any) (bool, error), error) {
+		expected, err := nativeStringValue(value)
+		return func(actual any) (bool, error) {
+			switch a := actual.(type) {
+			case []any:
+				for _, e := range a {
+					if s, ok := e.(string); ok && strings.EqualFold(s, expected) {
+						return true, nil
+					}
+				}
+				return false, nil
+			case []string:
+				for _, s := range a {
+					if strings.EqualFold(s, expected) {
+						return true, nil
+					}
+				}
+				return false, nil
+			}
+			return false, fmt.Errorf("requires an array property, got %T", actual)
+		}, err
+	},
+}
+
+// IsNativeOperator reports whether constraints with the operator are
+// evaluated in Go instead of by the conditions library.
+func IsNativeOperator(operator string) bool {
+	_, ok := nativeOperators[operator]
+	return ok
+}
+
+// Matcher compiles the constraint for evaluation, see compileExpr.
+func (c *Constraint) Matcher() (Matcher, error) {
+	if !IsNativeOperator(c.Operator) {
+		expr, err := c.ToExpr()
+		if err != nil {
+			return nil, err
+		}
+		return compileExpr(expr), nil
+	}
+
+	if _, err := c.toExprStr(); err != nil {
+		return nil, err
+	}
+	root, steps, err := parseProperty(c.Property)
+	if err != nil {
+		return nil, err
+	}
+	test, err := nativeOperators[c.Operator](strings.TrimSpace(c.Value))
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", c.Operator, err)
+	}
+
+	property, operator := c.Property, c.Operator
+	return func(m map[string]any) (bool, error) {
+		actual, ok := lookupProperty(m, root, steps)
+		if !ok || actual == nil {
+			return false, fmt.Errorf("argument: %v not found", property)
+		}
+		match, err := test(actual)
+		if err != nil {
+			return false, fmt.Errorf("%s on %s: %w", operator, property, err)
+		}
+		return match, nil
+	}, nil
+}
+
+// nativeStringValue decodes the JSON string value of a native operator.
+func nativeStringValue(value string) (string, error) {
+	var s string
+	if err := json.Unmarshal([]byte(value), &s); err != nil {
+		return "", fmt.Errorf("requires a JSON string value, e.g. \"abc\"")
+	}
+	return s, nil
+}
+
+func stringTest(test func(s string) bool) func(actual any) (bool, error) {
+	return func(actual any) (bool, error) {
+		s, ok := actual.(string)
+		if !ok {
+			return false, fmt.Errorf("requires a string property, got %T", actual)
+		}
+		return test(s), nil
+	}
+}
@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstraintMatcher_NativeOperators(t *testing.T) {
+	t.Parallel()
+	m := map[string]any{
+		"email":   "Jane@Example.com",
+		"country": "us",
+		"user":    map[string]any{"roles": []any{"Admin", "dev"}},
+		"age":     float64(30),
+	}
+
+	for name, tc := range map[string]struct {
+		c     Constraint
+		match bool
+	}{
+		"STARTS_WITH":             {Constraint{Property: "email", Operator: models.ConstraintOperatorSTARTSWITH, Value: `"Jane@"`}, true},
+		"STARTS_WITH is cased":    {Constraint{Property: "email", Operator: models.ConstraintOperatorSTARTSWITH, Value: `"jane@"`}, false},
+		"ENDS_WITH":               {Constraint{Property: "email", Operator: models.ConstraintOperatorENDSWITH, Value: `"@Example.com"`}, true},
+		"ENDS_WITH other domain":  {Constraint{Property: "email", Operator: models.ConstraintOperatorENDSWITH, Value: `"@example.org"`}, false},
+		"EQ_CI":                   {Constraint{Property: "country", Operator: models.ConstraintOperatorEQCI, Value: `"US"`}, true},
+		"EQ_CI different":         {Constraint{Property: "country", Operator: models.ConstraintOperatorEQCI, Value: `"CA"`}, false},
+		"IN_CI":                   {Constraint{Property: "country", Operator: models.ConstraintOperatorINCI, Value: `["CA", "US"]`}, true},
+		"IN_CI not in list":       {Constraint{Property: "country", Operator: models.ConstraintOperatorINCI, Value: `["CA", "MX"]`}, false},
+		"CONTAINS_CI":             {Constraint{Property: "user.roles", Operator: models.ConstraintOperatorCONTAINSCI, Value: `"admin"`}, true},
+		"CONTAINS_CI not in list": {Constraint{Property: "/user/roles", Operator: models.ConstraintOperatorCONTAINSCI, Value: `"owner"`}, false},
+		"untrimmed value":         {Constraint{Property: "email", Operator: models.ConstraintOperatorENDSWITH, Value: ` ".com" `}, true},
+	} {
+		match, err := tc.c.Matcher()
+		require.NoError(t, err, name)
+		ok, err := match(m)
+		assert.NoError(t, err, name)
+		assert.Equal(t, tc.match, ok, name)
+	}
+
+	for name, c := range map[string]Constraint{
+		"missing property":   {Property: "missing", Operator: models.ConstraintOperatorEQCI, Value: `"US"`},
+		"number property":    {Property: "age", Operator: models.ConstraintOperatorSTARTSWITH, Value: `"3"`},
+		"CONTAINS_CI string": {Property: "email", Operator: models.ConstraintOperatorCONTAINSCI, Value: `"jane"`},
+	} {
+		match, err := c.Matcher()
+		require.NoError(t, err, name)
+		_, err = match(m)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestConstraintValidate_NativeOperators(t *testing.T) {
+	t.Parallel()
+	for name, c := range map[string]Constraint{
+		"unquoted value":   {Property: "email", Operator: models.ConstraintOperatorENDSWITH, Value: `@example.com`},
+		"number value":     {Property: "email", Operator: models.ConstraintOperatorEQCI, Value: `1`},
+		"IN_CI string":     {Property: "country", Operator: models.ConstraintOperatorINCI, Value: `"US"`},
+		"IN_CI empty":      {Property: "country", Operator: models.ConstraintOperatorINCI, Value: `[]`},
+		"IN_CI numbers":    {Property: "country", Operator: models.ConstraintOperatorINCI, Value: `[1]`},
+		"empty value":      {Property: "email", Operator: models.ConstraintOperatorSTARTSWITH, Value: ``},
+		"bad JSON pointer": {Property: "/a-b", Operator: models.ConstraintOperatorSTARTSWITH, Value: `"x"`},
+	} {
+		assert.Error(t, c.Validate(), name)
+	}
+
+	c := Constraint{Property: "email", Operator: models.ConstraintOperatorENDSWITH, Value: `"@example.com"`}
+	assert.NoError(t, c.Validate())
+	_, err := c.ToExpr()
+	assert.Error(t, err, "native operators have no conditions expression")
+}
+
+func TestSegmentPrepareEvaluation_NativeConstraints(t *testing.T) {
+	t.Parallel()
+	s := GenFixtureSegment()
+	s.Constraints = ConstraintArray{
+		{Property: "email", Operator: models.ConstraintOperatorENDSWITH, Value: `"@example.com"`},
+		{Property: "country", Operator: models.ConstraintOperatorEQ, Value: `"US"`},
+	}
+	require.NoError(t, s.PrepareEvaluation())
+	require.Len(t, s.SegmentEvaluation.Matchers, 2)
+	ok, err := s.SegmentEvaluation.Matchers[0](map[string]any{"email": "a@example.com"})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	s.Constraints[0].Value = "@example.com"
+	assert.Error(t, s.PrepareEvaluation())
+}
@@ -0,0 +1,212 @@
+package entity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A constraint property addresses a value in the entity context. Besides a
+// top-level key it can be a path into nested objects and arrays, written
+// either with dots and brackets, e.g. user.plan or roles[0], or as a JSON
+// pointer (RFC 6901), e.g. /user/plan or /roles/0.
+//
+// In a JSON pointer, segments made of digits are array indexes, and other
+// segments must be keys made of letters, digits and underscores, since the
+// path is compiled to a conditions path expression.
+//
+// The injected HTTP headers are top-level @http_<name> keys, which a path can
+// address as @http.<name> or /@http/<name> too.
+
+// httpHeaderRoot is the root of the paths resolved onto the @http_<name> keys.
+const httpHeaderRoot = "@http"
+
+// propertyStep is one step of a property path below its root key.
+type propertyStep struct {
+	Key     string
+	Index   int
+	IsIndex bool
+}
+
+// propertyExpr returns the conditions variable reference of a property, e.g.
+// {user.plan} for /user/plan.
+func propertyExpr(property string) (string, error) {
+	if !strings.HasPrefix(property, "/") {
+		if rest, ok := strings.CutPrefix(property, httpHeaderRoot+"."); ok {
+			return "{" + httpHeaderRoot + "_" + rest + "}", nil
+		}
+		return "{" + property + "}", nil
+	}
+	root, steps, err := parseJSONPointer(property)
+	if err != nil {
+		return "", err
+	}
+	root, steps = resolveHTTPHeaderPath(root, steps)
+
+	var b strings.Builder
+	b.WriteString("{")
+	b.WriteString(root)
+	for _, s := range steps {
+		if s.IsIndex {
+			b.WriteString("[" + strconv.Itoa(s.Index) + "]")
+			continue
+		}
+		b.WriteString("." + s.Key)
+	}
+	b.WriteString("}")
+	return b.String(), nil
+}
+
+// PropertyValue returns the value a constraint property addresses in an
+// entity context, and whether it was found.
+func PropertyValue(m map[string]any, property string) (any, bool) {
+	root, steps, err := parseProperty(property)
+	if err != nil {
+		return nil, false
+	}
+	return lookupProperty(m, root, steps)
+}
+
+func lookupProperty(m map[string]any, root string, steps []propertyStep) (any, bool) {
+	current, ok := m[root]
+	for _, s := range steps {
+		if !ok {
+			return nil, false
+		}
+		if s.IsIndex {
+			arr, isArr := current.([]any)
+			idx := s.Index
+			if idx < 0 {
+				idx += len(arr)
+			}
+			if !isArr || idx < 0 || idx >= len(arr) {
+				return nil, false
+			}
+			current = arr[idx]
+			continue
+		}
+		obj, isObj := current.(map[string]any)
+		if !isObj {
+			return nil, false
+		}
+		current, ok = obj[s.Key]
+	}
+	return current, ok
+}
+
+func parseProperty(property string) (string, []propertyStep, error) {
+	parse := parseDottedPath
+	if strings.HasPrefix(property, "/") {
+		parse = parseJSONPointer
+	}
+	root, steps, err := parse(property)
+	if err != nil {
+		return "", nil, err
+	}
+	root, steps = resolveHTTPHeaderPath(root, steps)
+	return root, steps, nil
+}
+
+// resolveHTTPHeaderPath moves the first key of a path under @http into its
+// root, e.g. @http.x_country to the key @http_x_country.
+func resolveHTTPHeaderPath(root string, steps []propertyStep) (string, []propertyStep) {
+	if root != httpHeaderRoot || len(steps) == 0 || steps[0].IsIndex {
+		return root, steps
+	}
+	return httpHeaderRoot + "_" + steps[0].Key, steps[1:]
+}
+
+// parseDottedPath parses user.plan, roles[0] or data[0].name. A property
+// without dots or brackets is a top-level key.
+func parseDottedPath(property string) (string, []propertyStep, error) {
+	end := strings.IndexAny(property, ".[")
+	if end < 0 {
+		return property, nil, nil
+	}
+	root, rest := property[:end], property[end:]
+	if root == "" {
+		return "", nil, fmt.Errorf("property path %q has no root key", property)
+	}
+
+	var steps []propertyStep
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			n := strings.IndexAny(rest, ".[")
+			if n < 0 {
+				n = len(rest)
+			}
+			if n == 0 {
+				return "", nil, fmt.Errorf("property path %q has an empty key", property)
+			}
+			steps = append(steps, propertyStep{Key: rest[:n]})
+			rest = rest[n:]
+		case '[':
+			n := strings.IndexByte(rest, ']')
+			if n < 0 {
+				return "", nil, fmt.Errorf("property path %q has an unclosed [", property)
+			}
+			idx, err := strconv.Atoi(rest[1:n])
+			if err != nil {
+				return "", nil, fmt.Errorf("property path %q has an invalid index %q", property, rest[1:n])
+			}
+			steps = append(steps, propertyStep{Index: idx, IsIndex: true})
+			rest = rest[n+1:]
+		default:
+			return "", nil, fmt.Errorf("property path %q has an unexpected %q", property, rest[0])
+		}
+	}
+	return root, steps, nil
+}
+
+// parseJSONPointer parses a JSON pointer like /user/plan or /roles/0.
+func parseJSONPointer(property string) (string, []propertyStep, error) {
+	segments := strings.Split(property[1:], "/")
+	for i, s := range segments {
+		s = strings.ReplaceAll(strings.ReplaceAll(s, "~1", "/"), "~0", "~")
+		if !isPathKey(s, i == 0) && (i == 0 || !isIndex(s)) {
+			return "", nil, fmt.Errorf("JSON pointer %q: segment %q is not supported, use letters, digits and underscores", property, s)
+		}
+		segments[i] = s
+	}
+
+	root := segments[0]
+	steps := make([]propertyStep, 0, len(segments)-1)
+	for _, s := range segments[1:] {
+		if isIndex(s) {
+			idx, _ := strconv.Atoi(s)
+			steps = append(steps, propertyStep{Index: idx, IsIndex: true})
+			continue
+		}
+		steps = append(steps, propertyStep{Key: s})
+	}
+	return root, steps, nil
+}
+
+// isPathKey reports whether s can be a key in a conditions path expression:
+// letters, digits and underscores, not starting with a digit. The root key
+// may start with @, like the built-in context keys.
+func isPathKey(s string, root bool) bool {
+	if root {
+		s = strings.TrimPrefix(s, "@")
+	}
+	if s == "" || ('0' <= s[0] && s[0] <= '9') {
+		return false
+	}
+	for _, r := range s {
+		if r != '_' && !('a' <= r && r <= 'z') && !('A' <= r && r <= 'Z') && !('0' <= r && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func isIndex(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPropertyValue(t *testing.T) {
+	t.Parallel()
+	m := map[string]any{
+		"country": "US",
+		"user":    map[string]any{"plan": "pro"},
+		"roles":   []any{"admin", map[string]any{"name": "dev"}},
+
+		"@http_x_country": "US",
+	}
+
+	for property, want := range map[string]any{
+		"country":          "US",
+		"user.plan":        "pro",
+		"/user/plan":       "pro",
+		"roles[0]":         "admin",
+		"roles[-1].name":   "dev",
+		"/roles/1/name":    "dev",
+		"@http.x_country":  "US",
+		"/@http/x_country": "US",
+	} {
+		v, ok := PropertyValue(m, property)
+		assert.True(t, ok, property)
+		assert.Equal(t, want, v, property)
+	}
+
+	for _, property := range []string{"missing", "user.missing", "roles[2]", "country.name", "user[0]", "/roles/x", "roles[x]", ".plan"} {
+		_, ok := PropertyValue(m, property)
+		assert.False(t, ok, property)
+	}
+}
+
+func TestPropertyExpr(t *testing.T) {
+	t.Parallel()
+	for property, want := range map[string]string{
+		"country":          "{country}",
+		"user.plan":        "{user.plan}",
+		"/user/plan":       "{user.plan}",
+		"/roles/0/name":    "{roles[0].name}",
+		"/@http/x_country": "{@http_x_country}",
+		"@http.x_country":  "{@http_x_country}",
+	} {
+		got, err := propertyExpr(property)
+		assert.NoError(t, err, property)
+		assert.Equal(t, want, got, property)
+	}
+}
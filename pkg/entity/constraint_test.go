@@ -391,6 +391,71 @@ func TestConstraintToExpr_NestedField(t *testing.T) {
 	})
 }
 
+func TestConstraintToExpr_JSONPointer(t *testing.T) {
+	t.Parallel()
+	m := map[string]any{
+		"user":            map[string]any{"plan": "pro"},
+		"roles":           []any{"admin", "dev"},
+		"@http_x_country": "US",
+	}
+
+	for property, value := range map[string]string{
+		"/user/plan":       `"pro"`,
+		"/roles/1":         `"dev"`,
+		"/@http/x_country": `"US"`,
+		"@http.x_country":  `"US"`,
+	} {
+		c := Constraint{Property: property, Operator: models.ConstraintOperatorEQ, Value: value}
+		expr, err := c.ToExpr()
+		if assert.NoError(t, err, property) {
+			match, err := conditions.Evaluate(expr, m)
+			assert.NoError(t, err, property)
+			assert.True(t, match, property)
+		}
+	}
+
+	for _, property := range []string{"/", "/user/", "/0/plan", "/user-id", "/user/a~1b"} {
+		c := Constraint{Property: property, Operator: models.ConstraintOperatorEQ, Value: `"pro"`}
+		assert.Error(t, c.Validate(), property)
+	}
+}
+
+func TestConstraintToExpr_ArrayOperators(t *testing.T) {
+	t.Parallel()
+	roles := func(r ...any) map[string]any { return map[string]any{"user": map[string]any{"roles": r}} }
+
+	for name, tc := range map[string]struct {
+		operator string
+		value    string
+		m        map[string]any
+		match    bool
+	}{
+		"ANY_IN one":        {models.ConstraintOperatorANYIN, `["admin", "owner"]`, roles("dev", "admin"), true},
+		"ANY_IN none":       {models.ConstraintOperatorANYIN, `["admin", "owner"]`, roles("dev"), false},
+		"ALL_IN all":        {models.ConstraintOperatorALLIN, `["admin", "dev"]`, roles("dev", "admin", "qa"), true},
+		"ALL_IN some":       {models.ConstraintOperatorALLIN, `["admin", "dev"]`, roles("dev"), false},
+		"NONE_IN none":      {models.ConstraintOperatorNONEIN, `["banned"]`, roles("dev"), true},
+		"NONE_IN one":       {models.ConstraintOperatorNONEIN, `["banned", "spam"]`, roles("dev", "spam"), false},
+		"ANY_IN numbers":    {models.ConstraintOperatorANYIN, `[1, 2.5]`, roles(float64(3), 2.5), true},
+		"CONTAINS in array": {models.ConstraintOperatorCONTAINS, `"admin"`, roles("dev", "admin"), true},
+		"quotes in value":   {models.ConstraintOperatorANYIN, `["say \"hi\""]`, roles(`say "hi"`), true},
+	} {
+		c := Constraint{Property: "/user/roles", Operator: tc.operator, Value: tc.value}
+		expr, err := c.ToExpr()
+		if !assert.NoError(t, err, name) {
+			continue
+		}
+		match, err := conditions.Evaluate(expr, tc.m)
+		assert.NoError(t, err, name)
+		assert.Equal(t, tc.match, match, name)
+	}
+
+	for _, value := range []string{`"admin"`, `[]`, `[true]`, `["a"] ["b"]`, `[["a"]]`} {
+		c := Constraint{Property: "roles", Operator: models.ConstraintOperatorANYIN, Value: value}
+		assert.Error(t, c.Validate(), value)
+	}
+}
+
 func TestConstraintToExpr_RegexEscaping(t *testing.T) {
 	t.Parallel()
 	// EREG with backslash sequences (the main fix)
@@ -12,10 +12,118 @@ type HourlyEvent struct {
 	VariantID  int64     `gorm:"not null;default:0;uniqueIndex:idx_datar_hourly,priority:3"`
 	SegmentID  int64     `gorm:"not null;default:0;uniqueIndex:idx_datar_hourly,priority:4"`
 	EvalCount  int32     `gorm:"not null;default:0"`
-	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+	// Sketch is the serialized HyperLogLog sketch of the evaluated entities,
+	// see pkg/datar/hll. Nil when no evaluation carried an entity ID.
+	Sketch    []byte
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
 }
 
 // TableName specifies the table name for GORM.
 func (HourlyEvent) TableName() string {
 	return "datar_hourly_events"
 }
+
+// DimensionHourlyEvent is one aggregate row of the records of a flag per hour
+// by the optional Datar dimensions. Dimensions that are not tracked are
+// empty. The natural key is (flag_id, bucket_hour, variant_id, segment_id,
+// record_source, entity_type).
+type DimensionHourlyEvent struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement"`
+	FlagID       int64     `gorm:"not null;uniqueIndex:idx_datar_dimension_hourly,priority:1"`
+	BucketHour   time.Time `gorm:"not null;uniqueIndex:idx_datar_dimension_hourly,priority:2"`
+	VariantID    int64     `gorm:"not null;default:0;uniqueIndex:idx_datar_dimension_hourly,priority:3"`
+	SegmentID    int64     `gorm:"not null;default:0;uniqueIndex:idx_datar_dimension_hourly,priority:4"`
+	RecordSource string    `gorm:"type:varchar(16);not null;default:'';uniqueIndex:idx_datar_dimension_hourly,priority:5"`
+	EntityType   string    `gorm:"type:varchar(64);not null;default:'';uniqueIndex:idx_datar_dimension_hourly,priority:6"`
+	RecordCount  int64     `gorm:"not null;default:0"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM.
+func (DimensionHourlyEvent) TableName() string {
+	return "datar_dimension_hourly_events"
+}
+
+// DailyEvent is the rollup of the HourlyEvents of a UTC day, once they are
+// older than the hourly retention. The natural key is (flag_id, variant_id,
+// segment_id, bucket_day).
+type DailyEvent struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	FlagID    int64     `gorm:"not null;uniqueIndex:idx_datar_daily,priority:1"`
+	BucketDay time.Time `gorm:"not null;uniqueIndex:idx_datar_daily,priority:2"`
+	VariantID int64     `gorm:"not null;default:0;uniqueIndex:idx_datar_daily,priority:3"`
+	SegmentID int64     `gorm:"not null;default:0;uniqueIndex:idx_datar_daily,priority:4"`
+	EvalCount int64     `gorm:"not null;default:0"`
+	// Sketch is the merged sketch of the hourly rows.
+	Sketch []byte
+	// LastEvaluatedAt is the latest UpdatedAt of the hourly rows.
+	LastEvaluatedAt time.Time
+}
+
+// TableName specifies the table name for GORM.
+func (DailyEvent) TableName() string {
+	return "datar_daily_events"
+}
+
+// DatarLock is a lease on a Datar background job, so that one Flagr instance
+// runs it at a time. The lease is free once ExpiresAt has passed.
+type DatarLock struct {
+	Name      string    `gorm:"type:varchar(64);primaryKey"`
+	Owner     string    `gorm:"type:varchar(255);not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM.
+func (DatarLock) TableName() string {
+	return "datar_locks"
+}
+
+// SRMAlert marks a flag whose sample ratio mismatch was alerted, so that
+// instances sharing the database alert once per mismatch. The row is deleted
+// once a check of the flag finds no mismatch.
+type SRMAlert struct {
+	FlagID    int64     `gorm:"primaryKey;autoIncrement:false"`
+	AlertedAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM.
+func (SRMAlert) TableName() string {
+	return "datar_srm_alerts"
+}
+
+// Assignment records the variant an entity was first assigned for a flag, so
+// that its metric events can be attributed to the variant. The natural key is
+// (flag_id, entity_id); BucketHour is the hour of the first assignment.
+type Assignment struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement"`
+	FlagID     int64     `gorm:"not null;uniqueIndex:idx_datar_assignment,priority:1;index:idx_datar_assignment_hour,priority:1"`
+	EntityID   string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_datar_assignment,priority:2;index:idx_datar_assignment_entity"`
+	VariantID  int64     `gorm:"not null;default:0"`
+	BucketHour time.Time `gorm:"not null;index:idx_datar_assignment_hour,priority:2"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM.
+func (Assignment) TableName() string {
+	return "datar_assignments"
+}
+
+// Conversion is the events of a metric of an entity in an hour, attributed to
+// the entity's variant of a flag. Entities with rows in a time range converted
+// in it, and the values of their rows add up to their totals in it. The
+// natural key is (flag_id, metric_key, entity_id, bucket_hour).
+type Conversion struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement"`
+	FlagID     int64     `gorm:"not null;uniqueIndex:idx_datar_conversion,priority:1;index:idx_datar_conversion_hour,priority:1"`
+	MetricKey  string    `gorm:"type:varchar(64);not null;uniqueIndex:idx_datar_conversion,priority:2"`
+	EntityID   string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_datar_conversion,priority:3"`
+	BucketHour time.Time `gorm:"not null;uniqueIndex:idx_datar_conversion,priority:4;index:idx_datar_conversion_hour,priority:2"`
+	VariantID  int64     `gorm:"not null;default:0"`
+	EventCount int64     `gorm:"not null;default:0"`
+	Value      float64   `gorm:"not null;default:0"`
+}
+
+// TableName specifies the table name for GORM.
+func (Conversion) TableName() string {
+	return "datar_conversions"
+}
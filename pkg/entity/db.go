@@ -2,23 +2,10 @@ package entity
 
 import (
 	"os"
-	"sync"
-	"time"
 
 	sqlite "github.com/glebarez/sqlite" // sqlite driver with pure go
-	mysql "gorm.io/driver/mysql"        // mysql driver
-	postgres "gorm.io/driver/postgres"  // postgres driver
-
-	retry "github.com/avast/retry-go"
-	"github.com/openflagr/flagr/pkg/config"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
-	gorm_logger "gorm.io/gorm/logger"
-)
-
-var (
-	singletonDB   *gorm.DB
-	singletonOnce sync.Once
 )
 
 // AutoMigrateTables stores the entity tables that we can auto migrate in gorm
@@ -33,57 +20,13 @@ var AutoMigrateTables = []any{
 	Tag{},
 	FlagEntityType{},
 	HourlyEvent{},
-}
-
-func connectDB() (db *gorm.DB, err error) {
-	logger := &Logger{
-		LogLevel:                  gorm_logger.Info,
-		SlowThreshold:             time.Millisecond,
-		IgnoreRecordNotFoundError: false,
-	}
-
-	err = retry.Do(
-		func() error {
-			switch config.Config.DBDriver {
-			case "postgres":
-				db, err = gorm.Open(postgres.Open(config.Config.DBConnectionStr), &gorm.Config{
-					Logger: logger,
-				})
-			case "sqlite3":
-				db, err = gorm.Open(sqlite.Open(config.Config.DBConnectionStr), &gorm.Config{
-					Logger: logger,
-				})
-			case "mysql":
-				db, err = gorm.Open(mysql.Open(config.Config.DBConnectionStr), &gorm.Config{
-					Logger: logger,
-				})
-			}
-			return err
-		},
-		retry.Attempts(config.Config.DBConnectionRetryAttempts),
-		retry.Delay(config.Config.DBConnectionRetryDelay),
-	)
-	return db, err
-}
-
-// GetDB gets the db singleton
-func GetDB() *gorm.DB {
-	singletonOnce.Do(func() {
-		db, err := connectDB()
-		if err != nil {
-			if config.Config.DBConnectionDebug {
-				logrus.WithField("err", err).Fatal("failed to connect to db")
-			} else {
-				logrus.Fatal("failed to connect to db")
-			}
-		}
-		if err := db.AutoMigrate(AutoMigrateTables...); err != nil {
-			logrus.WithField("err", err).Fatal("failed to auto-migrate database")
-		}
-		singletonDB = db
-	})
-
-	return singletonDB
+	DailyEvent{},
+	DimensionHourlyEvent{},
+	DatarLock{},
+	SRMAlert{},
+	Metric{},
+	Assignment{},
+	Conversion{},
 }
 
 // NewSQLiteDB creates a new sqlite db
@@ -45,42 +45,72 @@ type DistributionDebugLog struct {
 // Rollout rolls out the entity based on rolloutPercent. When withDebug is false,
 // the returned message is empty and debug formatting is skipped (hot eval path).
 func (d DistributionArray) Rollout(entityID string, salt string, rolloutPercent uint, withDebug bool) (variantID *uint, msg string) {
+	variantID, msg, _ = d.rolloutWithLog(entityID, salt, rolloutPercent, withDebug)
+	return variantID, msg
+}
+
+// RolloutWithDebugLog is Rollout with debug, also returning the bucketing
+// decision. The DistributionDebugLog is nil if the entity was not bucketed,
+// e.g. because of an empty entityID; msg then says why.
+func (d DistributionArray) RolloutWithDebugLog(entityID string, salt string, rolloutPercent uint) (variantID *uint, msg string, log *DistributionDebugLog) {
+	return d.rolloutWithLog(entityID, salt, rolloutPercent, true)
+}
+
+func (d DistributionArray) rolloutWithLog(entityID string, salt string, rolloutPercent uint, withDebug bool) (variantID *uint, msg string, log *DistributionDebugLog) {
 	if entityID == "" {
-		return nil, "rollout no. empty entityID"
+		return nil, "rollout no. empty entityID", nil
 	}
 
 	if rolloutPercent == uint(0) {
-		return nil, "rollout no. 0% rolloutPercent"
+		return nil, "rollout no. 0% rolloutPercent", nil
 	}
 
 	if len(d.VariantIDs) == 0 || len(d.PercentsAccumulated) == 0 {
-		return nil, "rollout no. there's no distribution set"
+		return nil, "rollout no. there's no distribution set", nil
 	}
 
 	num := crc32Num(entityID, salt)
 	vID, index := d.bucketByNum(num)
-	if !d.rollout(num, rolloutPercent, index) {
-		if withDebug {
-			log := fmt.Sprintf("%+v", DistributionDebugLog{
-				BucketNum:         num,
-				DistributionArray: d,
-				VariantID:         vID,
-				RolloutPercent:    rolloutPercent,
-			})
-			return nil, "rollout no. " + log
+	rolledOut := d.rollout(num, rolloutPercent, index)
+	if rolledOut {
+		variantID = &vID
+	}
+	if !withDebug {
+		return variantID, "", nil
+	}
+
+	log = &DistributionDebugLog{
+		BucketNum:         num,
+		DistributionArray: d,
+		VariantID:         vID,
+		RolloutPercent:    rolloutPercent,
+	}
+	if rolledOut {
+		return variantID, "rollout yes. " + fmt.Sprintf("%+v", *log), log
+	}
+	return nil, "rollout no. " + fmt.Sprintf("%+v", *log), log
+}
+
+// RolloutShares is the share of all buckets rolled out to each variant of d at
+// rolloutPercent, in the order of VariantIDs. It follows Rollout bucket by
+// bucket, so the shares sum to about rolloutPercent/100.
+func (d DistributionArray) RolloutShares(rolloutPercent uint) []float64 {
+	shares := make([]float64, len(d.VariantIDs))
+	if len(d.VariantIDs) == 0 || len(d.PercentsAccumulated) == 0 || rolloutPercent == 0 {
+		return shares
+	}
+	buckets := make([]int, len(d.VariantIDs))
+	last := d.PercentsAccumulated[len(d.PercentsAccumulated)-1]
+	for num := uint(0); num < TotalBucketNum && int(num) < last; num++ {
+		_, index := d.bucketByNum(num)
+		if d.rollout(num, rolloutPercent, index) {
+			buckets[index]++
 		}
-		return nil, ""
 	}
-	if withDebug {
-		log := fmt.Sprintf("%+v", DistributionDebugLog{
-			BucketNum:         num,
-			DistributionArray: d,
-			VariantID:         vID,
-			RolloutPercent:    rolloutPercent,
-		})
-		return &vID, "rollout yes. " + log
+	for i, b := range buckets {
+		shares[i] = float64(b) / float64(TotalBucketNum)
 	}
-	return &vID, ""
+	return shares
 }
 
 func (d DistributionArray) bucketByNum(bucketNum uint) (variantID uint, index int) {
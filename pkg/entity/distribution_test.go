@@ -138,6 +138,27 @@ func TestRollout(t *testing.T) {
 	assert.Equal(t, d.rollout(uint(500*0.34), uint(34), 0), false)
 }
 
+func TestRolloutShares(t *testing.T) {
+	d := DistributionArray{
+		VariantIDs:          []uint{1111, 2222},
+		PercentsAccumulated: []int{500, 1000},
+	}
+	assert.Equal(t, []float64{0.5, 0.5}, d.RolloutShares(100))
+	assert.Equal(t, []float64{0.25, 0.25}, d.RolloutShares(50))
+	assert.Equal(t, []float64{0, 0}, d.RolloutShares(0))
+
+	// Rollout rounds up per variant, which favors small variants: 4 of the
+	// 10 buckets of 1111 and 337 of the 990 buckets of 3333 at 34%.
+	d = DistributionArray{
+		VariantIDs:          []uint{1111, 2222, 3333},
+		PercentsAccumulated: []int{10, 10, 1000},
+	}
+	assert.Equal(t, []float64{0.01, 0, 0.99}, d.RolloutShares(100))
+	assert.Equal(t, []float64{0.004, 0, 0.337}, d.RolloutShares(34))
+
+	assert.Empty(t, DistributionArray{}.RolloutShares(100))
+}
+
 func TestRolloutWithEntity(t *testing.T) {
 	t.Run("normal distributions cases", func(t *testing.T) {
 		d := DistributionArray{
@@ -205,3 +226,31 @@ func TestRolloutWithEntity(t *testing.T) {
 		assert.Contains(t, msg, "0% rolloutPercent")
 	})
 }
+
+func TestRolloutWithDebugLog(t *testing.T) {
+	d := DistributionArray{
+		VariantIDs:          []uint{1111, 2222},
+		PercentsAccumulated: []int{500, 1000},
+	}
+
+	vID, msg, log := d.RolloutWithDebugLog("entity123", "salt", uint(100))
+	vRollout, msgRollout := d.Rollout("entity123", "salt", uint(100), true)
+	assert.Equal(t, vRollout, vID)
+	assert.Equal(t, msgRollout, msg)
+	if assert.NotNil(t, log) && assert.NotNil(t, vID) {
+		assert.Equal(t, crc32Num("entity123", "salt"), log.BucketNum)
+		assert.Equal(t, *vID, log.VariantID)
+		assert.Equal(t, uint(100), log.RolloutPercent)
+	}
+
+	vID, _, log = d.RolloutWithDebugLog("entity123", "salt", uint(1))
+	assert.Nil(t, vID)
+	if assert.NotNil(t, log) {
+		assert.NotZero(t, log.VariantID, "the bucketed variant is logged even if not rolled out")
+	}
+
+	vID, msg, log = d.RolloutWithDebugLog("", "salt", uint(100))
+	assert.Nil(t, vID)
+	assert.Nil(t, log)
+	assert.Contains(t, msg, "empty entityID")
+}
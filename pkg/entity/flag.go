@@ -32,6 +32,10 @@ type Flag struct {
 type FlagEvaluation struct {
 	VariantsMap map[uint]*Variant
 	TagValues   []string // denormalized tag values for eval results
+
+	// SourceRevision is the revision of the eval-only flag source (e.g. the
+	// git commit SHA) the flag was loaded from. Set by the EvalCache.
+	SourceRevision string
 }
 
 // Preloads just the tags
@@ -55,9 +55,10 @@ func SourceFlagTemplate(source *Flag) Flag {
 
 	for _, ss := range segments {
 		seg := Segment{
-			Description:    ss.Description,
-			Rank:           ss.Rank,
-			RolloutPercent: ss.RolloutPercent,
+			Description:      ss.Description,
+			Rank:             ss.Rank,
+			RolloutPercent:   ss.RolloutPercent,
+			ConstraintGroups: ss.ConstraintGroups,
 		}
 		for _, sc := range ss.Constraints {
 			seg.Constraints = append(seg.Constraints, Constraint{
@@ -103,10 +104,14 @@ func ApplyFlagTemplate(tx *gorm.DB, flagID uint, template Flag) error {
 
 	for _, ss := range template.Segments {
 		ns := &Segment{
-			FlagID:         flagID,
-			Description:    ss.Description,
-			Rank:           ss.Rank,
-			RolloutPercent: ss.RolloutPercent,
+			FlagID:           flagID,
+			Description:      ss.Description,
+			Rank:             ss.Rank,
+			RolloutPercent:   ss.RolloutPercent,
+			ConstraintGroups: ss.ConstraintGroups,
+		}
+		if err := ns.ConstraintGroups.Validate(); err != nil {
+			return err
 		}
 		if err := tx.Create(ns).Error; err != nil {
 			return err
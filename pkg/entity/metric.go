@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/openflagr/flagr/pkg/util"
+	"gorm.io/gorm"
+)
+
+// Metric kinds. A conversion metric counts the entities with at least one
+// event; a value metric also sums the numeric values of its events, e.g.
+// revenue.
+const (
+	MetricKindConversion = "conversion"
+	MetricKindValue      = "value"
+)
+
+// Metric defines an outcome that can be sent to POST /metrics/events, e.g.
+// "purchase" or "revenue". Events are only accepted for defined metrics.
+type Metric struct {
+	gorm.Model
+	Key         string `gorm:"type:varchar(64);uniqueIndex:idx_metric_key"`
+	Description string `gorm:"type:text"`
+	Kind        string `gorm:"type:varchar(16)"`
+}
+
+// Validate validates the Metric
+func (m *Metric) Validate() error {
+	if ok, msg := util.IsSafeKey(m.Key); !ok {
+		return fmt.Errorf("%s", msg)
+	}
+	switch m.Kind {
+	case MetricKindConversion, MetricKindValue:
+		return nil
+	}
+	return fmt.Errorf("metric kind must be %s or %s, got %q", MetricKindConversion, MetricKindValue, m.Kind)
+}
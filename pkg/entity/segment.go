@@ -1,9 +1,9 @@
 package entity
 
 import (
+	"fmt"
 	"strconv"
 
-	"github.com/zhouzhuojie/conditions"
 	"gorm.io/gorm"
 )
 
@@ -18,7 +18,9 @@ type Segment struct {
 	Rank           uint
 	RolloutPercent uint
 	Constraints    ConstraintArray
-	Distributions  []Distribution
+	// ConstraintGroups are ANDed with Constraints; see ConstraintGroup.
+	ConstraintGroups ConstraintGroupArray `gorm:"type:text"`
+	Distributions    []Distribution
 
 	// Purely for evaluation
 	SegmentEvaluation SegmentEvaluation `gorm:"-" json:"-"`
@@ -43,7 +45,9 @@ func (s *Segment) Preload(db *gorm.DB) error {
 
 // SegmentEvaluation is a struct that holds the necessary info for evaluation
 type SegmentEvaluation struct {
-	ConditionsExpr    conditions.Expr
+	// Matchers are the compiled Constraints, in the same order.
+	Matchers          []Matcher
+	ConstraintGroups  []ConstraintGroupExpr
 	DistributionArray DistributionArray
 	FlagIDStr         string // pre-formatted flagID string used as salt in rollout
 }
@@ -61,11 +65,24 @@ func (s *Segment) PrepareEvaluation() error {
 	}
 
 	if len(s.Constraints) != 0 {
-		expr, err := s.Constraints.ToExpr()
-		if err != nil {
-			return err
+		se.Matchers = make([]Matcher, len(s.Constraints))
+		for i := range s.Constraints {
+			match, err := s.Constraints[i].Matcher()
+			if err != nil {
+				return err
+			}
+			se.Matchers[i] = match
+		}
+	}
+	if len(s.ConstraintGroups) != 0 {
+		se.ConstraintGroups = make([]ConstraintGroupExpr, len(s.ConstraintGroups))
+		for i, g := range s.ConstraintGroups {
+			ge, err := g.ToExpr()
+			if err != nil {
+				return fmt.Errorf("constraint group[%d]: %w", i, err)
+			}
+			se.ConstraintGroups[i] = ge
 		}
-		se.ConditionsExpr = expr
 	}
 
 	for i, d := range s.Distributions {
@@ -11,7 +11,7 @@ func TestSegmentPrepareEvaluation(t *testing.T) {
 	t.Run("happy code path", func(t *testing.T) {
 		s := GenFixtureSegment()
 		assert.NoError(t, s.PrepareEvaluation())
-		assert.NotNil(t, s.SegmentEvaluation.ConditionsExpr)
+		assert.Len(t, s.SegmentEvaluation.Matchers, len(s.Constraints))
 		assert.NotNil(t, s.SegmentEvaluation.DistributionArray)
 	})
 
@@ -20,7 +20,7 @@ func TestSegmentPrepareEvaluation(t *testing.T) {
 		s.SegmentEvaluation = SegmentEvaluation{}
 		s.Constraints[0].Value = `"CA"]` // invalid value
 		assert.Error(t, s.PrepareEvaluation())
-		assert.Empty(t, s.SegmentEvaluation.ConditionsExpr)
+		assert.Empty(t, s.SegmentEvaluation.Matchers)
 		assert.Empty(t, s.SegmentEvaluation.DistributionArray.VariantIDs)
 		assert.Empty(t, s.SegmentEvaluation.DistributionArray.PercentsAccumulated)
 	})
@@ -0,0 +1,276 @@
+package evaluator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/sirupsen/logrus"
+)
+
+// Config configures a Client. Only Source is required.
+type Config struct {
+	// Source provides the EvalCacheJSON document.
+	Source Source
+	// RefreshInterval is how often Source is fetched again. Zero disables
+	// background refreshing; Refresh can still be called explicitly.
+	RefreshInterval time.Duration
+	// RefreshTimeout bounds a single fetch. Defaults to 10s.
+	RefreshTimeout time.Duration
+
+	// Debug enables segment debug logs for eval contexts that set
+	// EnableDebug.
+	Debug bool
+
+	// ExposuresURL is the Flagr server's exposures endpoint, e.g.
+	// http://flagr:18000/api/v1/exposures. Empty disables reporting.
+	ExposuresURL string
+	// RecordEvaluations also reports every evaluation of a flag with
+	// DataRecordsEnabled as an exposure, the way the server records its own
+	// evaluations.
+	RecordEvaluations bool
+	// ExposureBatchSize is the maximum number of exposures per request.
+	// Defaults to 100, the server's default FLAGR_EXPOSURE_BATCH_SIZE.
+	ExposureBatchSize int
+	// ExposureFlushInterval is how often a partial batch is sent. Defaults
+	// to 5s.
+	ExposureFlushInterval time.Duration
+	// ExposureQueueSize caps the exposures waiting to be sent; further
+	// exposures are dropped. Defaults to 10000.
+	ExposureQueueSize int
+
+	// HTTPClient sends exposures to the server. Defaults to a client with a
+	// 10s timeout.
+	HTTPClient *http.Client
+	// Header is added to every exposures request, e.g. for authentication.
+	Header http.Header
+}
+
+// Client evaluates flags in-process from an EvalCacheJSON document that it
+// keeps refreshed, and optionally reports exposures back to the server.
+type Client struct {
+	cfg       Config
+	evaluator Evaluator
+	reporter  *reporter
+
+	flags    atomic.Pointer[FlagSet]
+	mu       sync.Mutex // serializes Refresh
+	lastBody []byte
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// New creates a Client and loads the flags once. It fails if the first load
+// fails; later refresh errors are logged and the last good flags are kept.
+func New(cfg Config) (*Client, error) {
+	if cfg.Source == nil {
+		return nil, errors.New("evaluator: Config.Source is required")
+	}
+	if cfg.RefreshTimeout <= 0 {
+		cfg.RefreshTimeout = 10 * time.Second
+	}
+	if cfg.ExposureBatchSize <= 0 {
+		cfg.ExposureBatchSize = 100
+	}
+	if cfg.ExposureFlushInterval <= 0 {
+		cfg.ExposureFlushInterval = 5 * time.Second
+	}
+	if cfg.ExposureQueueSize <= 0 {
+		cfg.ExposureQueueSize = 10000
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	c := &Client{cfg: cfg, stop: make(chan struct{})}
+	c.evaluator = Evaluator{Debug: cfg.Debug}
+	if err := c.Refresh(); err != nil {
+		return nil, err
+	}
+
+	if cfg.ExposuresURL != "" {
+		c.reporter = newReporter(cfg)
+		if cfg.RecordEvaluations {
+			c.evaluator.Record = func(r *models.EvalResult, flag *entity.Flag) {
+				if flag.DataRecordsEnabled {
+					c.reporter.enqueue(exposureFromResult(r))
+				}
+			}
+		}
+	}
+
+	if cfg.RefreshInterval > 0 {
+		c.wg.Add(1)
+		go c.refreshLoop()
+	}
+	return c, nil
+}
+
+func (c *Client) refreshLoop() {
+	defer c.wg.Done()
+	ticker := time.NewTicker(c.cfg.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.Refresh(); err != nil {
+				logrus.WithField("err", err).Error("reload flagr evaluator flags error")
+			}
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// Refresh fetches the Source now and swaps in the new flags. An unchanged
+// document is not parsed again.
+func (c *Client) Refresh() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.RefreshTimeout)
+	defer cancel()
+	b, err := c.cfg.Source.Fetch(ctx)
+	if err != nil {
+		return err
+	}
+	if c.flags.Load() != nil && bytes.Equal(b, c.lastBody) {
+		return nil
+	}
+
+	flags, err := ParseEvalCacheJSON(b)
+	if err != nil {
+		return err
+	}
+	fs, err := NewFlagSet(flags)
+	if err != nil {
+		return err
+	}
+	c.flags.Store(fs)
+	c.lastBody = b
+	return nil
+}
+
+// Flags returns the currently loaded flags.
+func (c *Client) Flags() *FlagSet {
+	return c.flags.Load()
+}
+
+// Evaluate evaluates the flag identified by evalContext.FlagID or
+// evalContext.FlagKey, like POST /evaluation.
+func (c *Client) Evaluate(evalContext models.EvalContext) *models.EvalResult {
+	return c.evaluator.Evaluate(c.Flags().Lookup(evalContext), evalContext)
+}
+
+// EvaluateBatch evaluates every entity against the requested flags, like
+// POST /evaluation/batch. Results are ordered by entity, then tag matches,
+// flag IDs and flag keys.
+func (c *Client) EvaluateBatch(batchReq models.EvaluationBatchRequest) *models.EvaluationBatchResponse {
+	fs := c.Flags()
+	flagIDs := dedupe(batchReq.FlagIDs)
+	flagKeys := dedupe(batchReq.FlagKeys)
+
+	results := &models.EvaluationBatchResponse{}
+	for _, e := range batchReq.Entities {
+		if e == nil {
+			continue
+		}
+		evalContext := models.EvalContext{
+			EnableDebug:   batchReq.EnableDebug,
+			EntityContext: e.EntityContext,
+			EntityID:      e.EntityID,
+			EntityType:    e.EntityType,
+		}
+		if len(batchReq.FlagTags) > 0 {
+			ctx := evalContext
+			ctx.FlagTags = batchReq.FlagTags
+			ctx.FlagTagsOperator = batchReq.FlagTagsOperator
+			for _, f := range fs.GetByTags(batchReq.FlagTags, batchReq.FlagTagsOperator) {
+				results.EvaluationResults = append(results.EvaluationResults, c.evaluator.Evaluate(f, ctx))
+			}
+		}
+		for _, flagID := range flagIDs {
+			ctx := evalContext
+			ctx.FlagID = flagID
+			results.EvaluationResults = append(results.EvaluationResults, c.evaluator.Evaluate(fs.Lookup(ctx), ctx))
+		}
+		for _, flagKey := range flagKeys {
+			ctx := evalContext
+			ctx.FlagKey = flagKey
+			results.EvaluationResults = append(results.EvaluationResults, c.evaluator.Evaluate(fs.Lookup(ctx), ctx))
+		}
+	}
+	return results
+}
+
+// Expose reports that an entity was shown a flag variant. It never blocks;
+// it is a no-op when ExposuresURL is not configured.
+func (c *Client) Expose(e models.Exposure) {
+	if c.reporter == nil {
+		return
+	}
+	if time.Time(e.Timestamp).IsZero() {
+		e.Timestamp = strfmt.DateTime(time.Now().UTC())
+	}
+	c.reporter.enqueue(&e)
+}
+
+// ExposeResult reports the variant of an evaluation result as an exposure.
+func (c *Client) ExposeResult(r *models.EvalResult) {
+	if c.reporter == nil || r == nil {
+		return
+	}
+	c.reporter.enqueue(exposureFromResult(r))
+}
+
+// Flush sends all queued exposures and waits until they were posted.
+func (c *Client) Flush() {
+	if c.reporter != nil {
+		c.reporter.flush()
+	}
+}
+
+// ExposureStats returns how many exposures were posted to the server and how
+// many were dropped because the queue was full or the request failed.
+func (c *Client) ExposureStats() (sent int64, dropped int64) {
+	if c.reporter == nil {
+		return 0, 0
+	}
+	return c.reporter.sent.Load(), c.reporter.dropped.Load()
+}
+
+// Close stops refreshing and sends all queued exposures. Closing a closed
+// Client is a no-op.
+func (c *Client) Close() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+		c.wg.Wait()
+		if c.reporter != nil {
+			c.reporter.close()
+		}
+	})
+}
+
+func dedupe[T comparable](in []T) []T {
+	if len(in) < 2 {
+		return in
+	}
+	seen := make(map[T]struct{}, len(in))
+	out := make([]T, 0, len(in))
+	for _, v := range in {
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,251 @@
+package evaluator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exposureServer is a stand-in for POST /api/v1/exposures that keeps every
+// exposure it receives.
+type exposureServer struct {
+	mu        sync.Mutex
+	requests  int
+	exposures []*models.Exposure
+	header    http.Header
+}
+
+func (s *exposureServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req models.ExposuresRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	s.requests++
+	s.exposures = append(s.exposures, req.Exposures...)
+	s.header = r.Header
+	s.mu.Unlock()
+	json.NewEncoder(w).Encode(&models.ExposuresResponse{LoggedCount: int64(len(req.Exposures))})
+}
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("file source", func(t *testing.T) {
+		t.Parallel()
+		c, err := New(Config{Source: FileSource("./testdata/flags.json")})
+		require.NoError(t, err)
+		defer c.Close()
+
+		r := c.Evaluate(models.EvalContext{FlagKey: "banner", EntityID: "e1"})
+		assert.Equal(t, "on", r.VariantKey)
+		assert.Equal(t, int64(2), r.FlagID)
+	})
+
+	t.Run("initial load errors", func(t *testing.T) {
+		t.Parallel()
+		_, err := New(Config{})
+		assert.Error(t, err)
+		_, err = New(Config{Source: FileSource("./testdata/missing.json")})
+		assert.Error(t, err)
+		_, err = New(Config{Source: ReaderSource(strings.NewReader("{"))})
+		assert.Error(t, err)
+		_, err = New(Config{Source: ReaderSource(strings.NewReader(`{"Flags": [{"Key": "a"}, {"Key": "a"}]}`))})
+		assert.ErrorContains(t, err, `duplicate flag key "a"`)
+	})
+
+	t.Run("close twice", func(t *testing.T) {
+		t.Parallel()
+		c, err := New(Config{Source: FileSource("./testdata/flags.json"), RefreshInterval: time.Minute})
+		require.NoError(t, err)
+		c.Close()
+		c.Close()
+	})
+
+	t.Run("url source", func(t *testing.T) {
+		t.Parallel()
+		b, err := os.ReadFile("./testdata/flags.json")
+		require.NoError(t, err)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/v1/export/eval_cache/json" {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write(b)
+		}))
+		defer server.Close()
+
+		c, err := New(Config{Source: URLSource(server.URL+"/api/v1/export/eval_cache/json", nil)})
+		require.NoError(t, err)
+		defer c.Close()
+		assert.Equal(t, 3, c.Flags().Len())
+
+		_, err = URLSource(server.URL+"/nope", nil).Fetch(context.Background())
+		assert.ErrorContains(t, err, "unexpected status 404")
+	})
+
+	t.Run("refreshes and keeps the last good flags", func(t *testing.T) {
+		t.Parallel()
+		var doc atomic.Value
+		doc.Store(`{"Flags": [{"Key": "a", "Enabled": true}]}`)
+		var fetches atomic.Int32
+		src := SourceFunc(func(ctx context.Context) ([]byte, error) {
+			fetches.Add(1)
+			return []byte(doc.Load().(string)), nil
+		})
+
+		c, err := New(Config{Source: src, RefreshInterval: 10 * time.Millisecond})
+		require.NoError(t, err)
+		defer c.Close()
+		first := c.Flags()
+		assert.NotNil(t, first.Get("a"))
+
+		require.Eventually(t, func() bool { return fetches.Load() > 2 }, time.Second, 5*time.Millisecond)
+		assert.Same(t, first, c.Flags(), "unchanged document should not be rebuilt")
+
+		doc.Store(`{"Flags": [{"Key": "b", "Enabled": true}]}`)
+		require.Eventually(t, func() bool { return c.Flags().Get("b") != nil }, time.Second, 5*time.Millisecond)
+		assert.Nil(t, c.Flags().Get("a"))
+
+		doc.Store(`not json`)
+		assert.Error(t, c.Refresh())
+		assert.NotNil(t, c.Flags().Get("b"))
+	})
+
+	t.Run("evaluate batch", func(t *testing.T) {
+		t.Parallel()
+		c, err := New(Config{Source: FileSource("./testdata/flags.json")})
+		require.NoError(t, err)
+		defer c.Close()
+
+		res := c.EvaluateBatch(models.EvaluationBatchRequest{
+			Entities: []*models.EvaluationEntity{
+				{EntityID: "e1", EntityContext: map[string]any{"country": "US"}},
+				{EntityID: "e2"},
+			},
+			FlagKeys: []string{"banner", "missing", "banner"},
+			FlagIDs:  []int64{1},
+			FlagTags: []string{"payments"},
+		})
+		require.Len(t, res.EvaluationResults, 8)
+		var got []string
+		for _, r := range res.EvaluationResults {
+			got = append(got, r.EvalContext.EntityID+":"+r.FlagKey)
+		}
+		assert.Equal(t, []string{
+			"e1:checkout", "e1:checkout", "e1:banner", "e1:missing",
+			"e2:checkout", "e2:checkout", "e2:banner", "e2:missing",
+		}, got)
+		assert.Equal(t, []string{"payments"}, res.EvaluationResults[0].EvalContext.FlagTags)
+	})
+}
+
+func TestClientExposures(t *testing.T) {
+	t.Parallel()
+
+	t.Run("batches exposures and evaluation records", func(t *testing.T) {
+		t.Parallel()
+		es := &exposureServer{}
+		server := httptest.NewServer(es)
+		defer server.Close()
+
+		c, err := New(Config{
+			Source:                FileSource("./testdata/flags.json"),
+			ExposuresURL:          server.URL,
+			RecordEvaluations:     true,
+			ExposureBatchSize:     2,
+			ExposureFlushInterval: time.Hour,
+			Header:                http.Header{"Authorization": []string{"Bearer token"}},
+		})
+		require.NoError(t, err)
+
+		// checkout has DataRecordsEnabled, banner does not
+		r := c.Evaluate(models.EvalContext{FlagKey: "checkout", EntityID: "e1", EntityContext: map[string]any{"country": "US"}})
+		c.Evaluate(models.EvalContext{FlagKey: "banner", EntityID: "e1"})
+		c.Evaluate(models.EvalContext{FlagKey: "missing", EntityID: "e1"})
+		entityID := "e2"
+		c.Expose(models.Exposure{EntityID: &entityID, FlagKey: "banner", VariantKey: "on"})
+		c.ExposeResult(r)
+		c.Close()
+
+		es.mu.Lock()
+		defer es.mu.Unlock()
+		require.Len(t, es.exposures, 3)
+		assert.Equal(t, 2, es.requests)
+		assert.Equal(t, "Bearer token", es.header.Get("Authorization"))
+
+		assert.Equal(t, "checkout", es.exposures[0].FlagKey)
+		assert.Equal(t, "e1", *es.exposures[0].EntityID)
+		assert.Equal(t, r.VariantID, es.exposures[0].VariantID)
+		assert.Equal(t, r.VariantKey, es.exposures[0].VariantKey)
+		assert.False(t, es.exposures[0].Timestamp.IsZero())
+		assert.Equal(t, "banner", es.exposures[1].FlagKey)
+		assert.False(t, es.exposures[1].Timestamp.IsZero())
+		assert.Equal(t, es.exposures[0], es.exposures[2])
+
+		sent, dropped := c.ExposureStats()
+		assert.Equal(t, int64(3), sent)
+		assert.Zero(t, dropped)
+	})
+
+	t.Run("flush and failed requests", func(t *testing.T) {
+		t.Parallel()
+		fail := atomic.Bool{}
+		es := &exposureServer{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if fail.Load() {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"message": "bad"}`))
+				return
+			}
+			es.ServeHTTP(w, r)
+		}))
+		defer server.Close()
+
+		c, err := New(Config{
+			Source:                FileSource("./testdata/flags.json"),
+			ExposuresURL:          server.URL,
+			ExposureFlushInterval: time.Hour,
+		})
+		require.NoError(t, err)
+		defer c.Close()
+
+		entityID := "e1"
+		c.Expose(models.Exposure{EntityID: &entityID, FlagKey: "banner"})
+		c.Flush()
+		sent, dropped := c.ExposureStats()
+		assert.Equal(t, int64(1), sent)
+		assert.Zero(t, dropped)
+
+		fail.Store(true)
+		c.Expose(models.Exposure{EntityID: &entityID, FlagKey: "banner"})
+		c.Flush()
+		sent, dropped = c.ExposureStats()
+		assert.Equal(t, int64(1), sent)
+		assert.Equal(t, int64(1), dropped)
+	})
+
+	t.Run("disabled without url", func(t *testing.T) {
+		t.Parallel()
+		c, err := New(Config{Source: FileSource("./testdata/flags.json"), RecordEvaluations: true})
+		require.NoError(t, err)
+		c.ExposeResult(c.Evaluate(models.EvalContext{FlagKey: "checkout", EntityID: "e1"}))
+		c.Flush()
+		c.Close()
+		sent, dropped := c.ExposureStats()
+		assert.Zero(t, sent)
+		assert.Zero(t, dropped)
+	})
+}
@@ -0,0 +1,247 @@
+// Package evaluator evaluates Flagr flags in-process.
+//
+// The evaluation logic is the same code the Flagr server runs for
+// POST /evaluation, so a flag evaluated with this package returns the same
+// variant as the server for the same flag config and evalContext. Use
+// Evaluator directly with flags you manage yourself, or Client to load an
+// EvalCacheJSON (e.g. GET /api/v1/export/eval_cache/json) from a file, URL or
+// stream, keep it refreshed, and report exposures back to the server.
+package evaluator
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/pkg/util"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"gorm.io/gorm"
+)
+
+// Evaluator evaluates flags prepared with entity.Flag.PrepareEvaluation. It
+// holds no flag state, so the zero value is ready to use and one Evaluator
+// can be shared by any number of goroutines.
+type Evaluator struct {
+	// Debug enables segment debug logs for eval contexts that set
+	// EnableDebug, like FLAGR_EVAL_DEBUG_ENABLED does on the server.
+	Debug bool
+
+	// Record, if set, is called with every result of a flag that was actually
+	// evaluated, i.e. not for missing, disabled or segment-less flags. The
+	// server uses it for logging, metrics and data recorders.
+	Record func(r *models.EvalResult, flag *entity.Flag)
+}
+
+// generatedEntityIDPrefix prefixes the entity IDs generated for eval
+// contexts without one.
+const generatedEntityIDPrefix = "randomly_generated_"
+
+func generateEntityID() string {
+	return fmt.Sprintf("%s%d", generatedEntityIDPrefix, rand.Int31())
+}
+
+// IsGeneratedEntityID reports whether id was generated by Evaluate for an
+// eval context without an entity ID. Such an ID buckets a single evaluation,
+// and does not identify an entity.
+func IsGeneratedEntityID(id string) bool {
+	return strings.HasPrefix(id, generatedEntityIDPrefix)
+}
+
+// Evaluate evaluates flag for evalContext. flag may be nil, in which case the
+// result is a blank result for evalContext.FlagID/FlagKey.
+func (e Evaluator) Evaluate(flag *entity.Flag, evalContext models.EvalContext) *models.EvalResult {
+	flagID := util.SafeUint(evalContext.FlagID)
+	flagKey := util.SafeString(evalContext.FlagKey)
+
+	if flag == nil {
+		emptyFlag := &entity.Flag{Model: gorm.Model{ID: flagID}, Key: flagKey}
+		return BlankResult(emptyFlag, evalContext, fmt.Sprintf("flagID %v not found or deleted", flagID))
+	}
+
+	if !flag.Enabled {
+		return BlankResult(flag, evalContext, fmt.Sprintf("flagID %v is not enabled", flag.ID))
+	}
+
+	if len(flag.Segments) == 0 {
+		return BlankResult(flag, evalContext, fmt.Sprintf("flagID %v has no segments", flag.ID))
+	}
+
+	if evalContext.EntityID == "" {
+		evalContext.EntityID = generateEntityID()
+	}
+
+	if flag.EntityType != "" {
+		evalContext.EntityType = flag.EntityType
+	}
+
+	debug := e.Debug && evalContext.EnableDebug
+
+	var vID int64
+	var sID int64
+	var logs []*models.SegmentDebugLog
+	if debug {
+		logs = make([]*models.SegmentDebugLog, 0, len(flag.Segments))
+	}
+	for _, segment := range flag.Segments {
+		variantID, log, evalNextSegment := EvalSegment(evalContext, segment, debug)
+		if variantID != nil {
+			vID = int64(*variantID)
+			sID = int64(segment.ID)
+		}
+		if debug {
+			logs = append(logs, log)
+		}
+		if !evalNextSegment {
+			break
+		}
+	}
+	evalResult := BlankResult(flag, evalContext, "")
+	evalResult.EvalDebugLog.SegmentDebugLogs = logs
+	evalResult.SegmentID = sID
+	evalResult.VariantID = vID
+	v := flag.FlagEvaluation.VariantsMap[util.SafeUint(vID)]
+	if v != nil {
+		evalResult.VariantAttachment = v.Attachment
+		evalResult.VariantKey = v.Key
+	}
+
+	if e.Record != nil {
+		e.Record(evalResult, flag)
+	}
+	return evalResult
+}
+
+// BlankResult creates a blank result
+func BlankResult(f *entity.Flag, evalContext models.EvalContext, msg string) *models.EvalResult {
+	flagID := uint(0)
+	flagKey := ""
+	flagSnapshotID := uint(0)
+	flagSourceRevision := ""
+	var flagTags []string
+	var dataRecordsEnabled bool
+	if f != nil {
+		flagID = f.ID
+		flagSnapshotID = f.SnapshotID
+		flagSourceRevision = f.FlagEvaluation.SourceRevision
+		flagKey = f.Key
+		flagTags = f.FlagEvaluation.TagValues
+		dataRecordsEnabled = f.DataRecordsEnabled
+	}
+	ec := evalContext
+	return &models.EvalResult{
+		EvalContext: &ec,
+		EvalDebugLog: &models.EvalDebugLog{
+			Msg:              msg,
+			SegmentDebugLogs: nil,
+		},
+		FlagID:             int64(flagID),
+		FlagKey:            flagKey,
+		FlagSnapshotID:     int64(flagSnapshotID),
+		FlagSourceRevision: flagSourceRevision,
+		FlagTags:           flagTags,
+		Timestamp:          util.TimeNow(),
+		RecordSource:       models.EvalResultRecordSourceEvaluation,
+		DataRecordsEnabled: dataRecordsEnabled,
+	}
+}
+
+// EvalSegment evaluates a single segment: its constraints against
+// evalContext.EntityContext, then its rollout and distribution. It returns
+// the variant ID (nil when the entity is not rolled out), a debug log when
+// debug is true, and whether the next segment should be evaluated.
+func EvalSegment(
+	evalContext models.EvalContext,
+	segment entity.Segment,
+	debug bool,
+) (
+	vID *uint, // returns VariantID
+	log *models.SegmentDebugLog,
+	evalNextSegment bool,
+) {
+	var constraintLogs []*models.ConstraintDebugLog
+	var groupLogs []*models.ConstraintGroupDebugLog
+	if len(segment.Constraints) != 0 || len(segment.ConstraintGroups) != 0 {
+		m, ok := evalContext.EntityContext.(map[string]any)
+		if !ok {
+			if debug {
+				log = &models.SegmentDebugLog{
+					Msg:       fmt.Sprintf("constraints are present in the segment_id %v, but got invalid entity_context: %s.", segment.ID, spew.Sdump(evalContext.EntityContext)),
+					SegmentID: int64(segment.ID),
+				}
+			}
+			return nil, log, true
+		}
+		if debug {
+			constraintLogs = constraintDebugLogs(segment, m)
+			groupLogs = constraintGroupDebugLogs(segment, m)
+		}
+
+		for i, match := range segment.SegmentEvaluation.Matchers {
+			ok, err := match(m)
+			if err != nil || !ok {
+				if debug {
+					msg := debugConstraintMsg(segment.Constraints[i], m)
+					if err != nil {
+						msg = err.Error()
+					}
+					log = &models.SegmentDebugLog{
+						Msg:         msg,
+						SegmentID:   int64(segment.ID),
+						Constraints: constraintLogs,
+						Groups:      groupLogs,
+					}
+				}
+				return nil, log, true
+			}
+		}
+
+		for i, ge := range segment.SegmentEvaluation.ConstraintGroups {
+			ok, err := ge.Evaluate(m)
+			if err != nil || !ok {
+				if debug {
+					msg := fmt.Sprintf("constraint group not match. group: %s, entity_context: %+v.", segment.ConstraintGroups[i], m)
+					if err != nil {
+						msg = err.Error()
+					}
+					log = &models.SegmentDebugLog{
+						Msg:         msg,
+						SegmentID:   int64(segment.ID),
+						Constraints: constraintLogs,
+						Groups:      groupLogs,
+					}
+				}
+				return nil, log, true
+			}
+		}
+	}
+
+	da := segment.SegmentEvaluation.DistributionArray
+	if !debug {
+		vID, _ = da.Rollout(evalContext.EntityID, segment.SegmentEvaluation.FlagIDStr, segment.RolloutPercent, false)
+		return vID, nil, false
+	}
+
+	vID, debugMsg, distributionLog := da.RolloutWithDebugLog(
+		evalContext.EntityID,
+		segment.SegmentEvaluation.FlagIDStr,
+		segment.RolloutPercent,
+	)
+	if constraintLogs == nil {
+		constraintLogs = []*models.ConstraintDebugLog{}
+	}
+	log = &models.SegmentDebugLog{
+		Msg:         "matched all constraints. " + debugMsg,
+		SegmentID:   int64(segment.ID),
+		Matched:     true,
+		Constraints: constraintLogs,
+		Groups:      groupLogs,
+		Rollout:     rolloutDebugLog(segment, vID, debugMsg, distributionLog),
+	}
+	return vID, log, false
+}
+
+func debugConstraintMsg(c entity.Constraint, m map[string]any) string {
+	return fmt.Sprintf("constraint not match. constraint: %s, entity_context: %+v.", c.ExprString(), m)
+}
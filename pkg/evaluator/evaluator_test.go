@@ -0,0 +1,312 @@
+package evaluator
+
+import (
+	"os"
+	"testing"
+
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadTestFlagSet(t *testing.T) *FlagSet {
+	t.Helper()
+	b, err := os.ReadFile("./testdata/flags.json")
+	require.NoError(t, err)
+	flags, err := ParseEvalCacheJSON(b)
+	require.NoError(t, err)
+	fs, err := NewFlagSet(flags)
+	require.NoError(t, err)
+	return fs
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+	fs := loadTestFlagSet(t)
+
+	t.Run("flag not found", func(t *testing.T) {
+		r := Evaluator{}.Evaluate(nil, models.EvalContext{FlagID: 100, EntityID: "e1"})
+		assert.Equal(t, int64(100), r.FlagID)
+		assert.Zero(t, r.VariantID)
+		assert.Equal(t, "flagID 100 not found or deleted", r.EvalDebugLog.Msg)
+	})
+
+	t.Run("disabled flag", func(t *testing.T) {
+		r := Evaluator{}.Evaluate(fs.Get("disabled"), models.EvalContext{EntityID: "e1"})
+		assert.Equal(t, "disabled", r.FlagKey)
+		assert.Zero(t, r.VariantID)
+		assert.Contains(t, r.EvalDebugLog.Msg, "is not enabled")
+	})
+
+	t.Run("constraint match", func(t *testing.T) {
+		var recorded []*models.EvalResult
+		e := Evaluator{
+			Debug: true,
+			Record: func(r *models.EvalResult, flag *entity.Flag) {
+				recorded = append(recorded, r)
+			},
+		}
+		f := fs.Get("checkout")
+		r := e.Evaluate(f, models.EvalContext{
+			EnableDebug:   true,
+			EntityID:      "e1",
+			EntityContext: map[string]any{"country": "US"},
+		})
+		assert.Equal(t, int64(f.Segments[0].ID), r.SegmentID)
+		assert.Contains(t, []string{"control", "treatment"}, r.VariantKey)
+		assert.True(t, r.DataRecordsEnabled)
+		require.Len(t, r.EvalDebugLog.SegmentDebugLogs, 1)
+		assert.Contains(t, r.EvalDebugLog.SegmentDebugLogs[0].Msg, "matched all constraints")
+		assert.Equal(t, []*models.EvalResult{r}, recorded)
+
+		// deterministic for the same entity
+		r2 := e.Evaluate(f, models.EvalContext{EntityID: "e1", EntityContext: map[string]any{"country": "US"}})
+		assert.Equal(t, r.VariantID, r2.VariantID)
+		assert.Nil(t, r2.EvalDebugLog.SegmentDebugLogs)
+	})
+
+	t.Run("falls through to the next segment", func(t *testing.T) {
+		e := Evaluator{Debug: true}
+		r := e.Evaluate(fs.Get("checkout"), models.EvalContext{
+			EnableDebug:   true,
+			EntityID:      "e1",
+			EntityContext: map[string]any{"country": "CA"},
+		})
+		require.Len(t, r.EvalDebugLog.SegmentDebugLogs, 2)
+		assert.Contains(t, r.EvalDebugLog.SegmentDebugLogs[0].Msg, "constraint not match")
+	})
+
+	t.Run("structured debug log", func(t *testing.T) {
+		f := fs.Get("checkout")
+		r := Evaluator{Debug: true}.Evaluate(f, models.EvalContext{
+			EnableDebug:   true,
+			EntityID:      "e1",
+			EntityContext: map[string]any{"country": "CA"},
+		})
+		require.Len(t, r.EvalDebugLog.SegmentDebugLogs, 2)
+
+		us := r.EvalDebugLog.SegmentDebugLogs[0]
+		assert.False(t, us.Matched)
+		assert.Nil(t, us.Rollout)
+		assert.Equal(t, []*models.ConstraintDebugLog{{
+			ConstraintID: int64(f.Segments[0].Constraints[0].ID),
+			Property:     "country",
+			Operator:     "EQ",
+			Expected:     "US",
+			Actual:       "CA",
+			Matched:      false,
+		}}, us.Constraints)
+
+		everyone := r.EvalDebugLog.SegmentDebugLogs[1]
+		assert.True(t, everyone.Matched)
+		assert.Empty(t, everyone.Constraints)
+		require.NotNil(t, everyone.Rollout)
+		rollout := everyone.Rollout
+		assert.Equal(t, int64(20), rollout.RolloutPercent)
+		assert.Equal(t, r.VariantKey == "control", rollout.RolledOut)
+		assert.Equal(t, "control", rollout.VariantKey)
+		assert.GreaterOrEqual(t, rollout.BucketNum, int64(0))
+		assert.Less(t, rollout.BucketNum, int64(1000))
+		assert.Equal(t, []*models.DistributionDebugLog{{
+			VariantID:   rollout.VariantID,
+			VariantKey:  "control",
+			Percent:     100,
+			BucketStart: 0,
+			BucketEnd:   1000,
+		}}, rollout.Distributions)
+
+		r = Evaluator{Debug: true}.Evaluate(f, models.EvalContext{
+			EnableDebug:   true,
+			EntityID:      "e1",
+			EntityContext: map[string]any{"tier": "gold"},
+		})
+		us = r.EvalDebugLog.SegmentDebugLogs[0]
+		assert.Nil(t, us.Constraints[0].Actual, "missing properties have no actual value")
+		assert.False(t, us.Constraints[0].Matched)
+	})
+
+	t.Run("structured debug log with property paths", func(t *testing.T) {
+		f := loadTestFlagSet(t).Get("checkout")
+		f.Segments[0].Constraints[0].Property = "/user/country"
+		require.NoError(t, f.PrepareEvaluation())
+
+		r := Evaluator{Debug: true}.Evaluate(f, models.EvalContext{
+			EnableDebug:   true,
+			EntityID:      "e1",
+			EntityContext: map[string]any{"user": map[string]any{"country": "US"}},
+		})
+		assert.Equal(t, int64(f.Segments[0].ID), r.SegmentID)
+		c := r.EvalDebugLog.SegmentDebugLogs[0].Constraints[0]
+		assert.True(t, c.Matched)
+		assert.Equal(t, "US", c.Actual)
+	})
+
+	t.Run("structured rollout without an entity bucket", func(t *testing.T) {
+		log := rolloutDebugLog(fs.Get("banner").Segments[0], nil, "rollout no. empty entityID", nil)
+		assert.False(t, log.RolledOut)
+		assert.Equal(t, "empty entityID", log.Reason)
+		assert.Len(t, log.Distributions, 1)
+	})
+
+	t.Run("debug requires both the evaluator and the context", func(t *testing.T) {
+		r := Evaluator{}.Evaluate(fs.Get("banner"), models.EvalContext{EnableDebug: true, EntityID: "e1"})
+		assert.Equal(t, "on", r.VariantKey)
+		assert.Nil(t, r.EvalDebugLog.SegmentDebugLogs)
+	})
+}
+
+// constraintGroupsFlag targets US users, or enterprise users outside the
+// test accounts, and only on the web platform.
+const constraintGroupsFlag = `{"Flags": [{
+  "Key": "groups",
+  "Enabled": true,
+  "Variants": [{"Key": "on"}],
+  "Segments": [{
+    "RolloutPercent": 100,
+    "Constraints": [{"Property": "platform", "Operator": "EQ", "Value": "\"web\""}],
+    "ConstraintGroups": [{
+      "Operator": "OR",
+      "Constraints": [{"Property": "country", "Operator": "EQ", "Value": "\"US\""}],
+      "Groups": [{
+        "Operator": "AND",
+        "Constraints": [{"Property": "tier", "Operator": "EQ", "Value": "\"enterprise\""}],
+        "Groups": [{
+          "Operator": "NOT",
+          "Constraints": [{"Property": "email", "Operator": "EREG", "Value": "\"@test\\.com$\""}]
+        }]
+      }]
+    }],
+    "Distributions": [{"VariantKey": "on", "Percent": 100}]
+  }]
+}]}`
+
+func TestEvaluateConstraintGroups(t *testing.T) {
+	t.Parallel()
+	flags, err := ParseEvalCacheJSON([]byte(constraintGroupsFlag))
+	require.NoError(t, err)
+	fs, err := NewFlagSet(flags)
+	require.NoError(t, err)
+	f := fs.Get("groups")
+	require.NotNil(t, f)
+	require.Len(t, f.Segments[0].SegmentEvaluation.ConstraintGroups, 1)
+
+	e := Evaluator{Debug: true}
+	eval := func(m map[string]any) *models.EvalResult {
+		return e.Evaluate(f, models.EvalContext{EnableDebug: true, EntityID: "e1", EntityContext: m})
+	}
+
+	for name, tc := range map[string]struct {
+		m     map[string]any
+		match bool
+	}{
+		"US":                      {map[string]any{"platform": "web", "country": "US"}, true},
+		"enterprise":              {map[string]any{"platform": "web", "country": "DE", "tier": "enterprise", "email": "a@b.com"}, true},
+		"enterprise test account": {map[string]any{"platform": "web", "country": "DE", "tier": "enterprise", "email": "a@test.com"}, false},
+		"US on mobile":            {map[string]any{"platform": "ios", "country": "US"}, false},
+		"no platform":             {map[string]any{"country": "US"}, false},
+		"no country":              {map[string]any{"platform": "web", "tier": "enterprise", "email": "a@b.com"}, false},
+		"no email under NOT":      {map[string]any{"platform": "web", "country": "DE", "tier": "enterprise"}, false},
+	} {
+		assert.Equal(t, tc.match, eval(tc.m).VariantKey == "on", name)
+	}
+
+	t.Run("structured debug log", func(t *testing.T) {
+		r := eval(map[string]any{"platform": "web", "country": "DE", "tier": "enterprise", "email": "a@test.com"})
+		require.Len(t, r.EvalDebugLog.SegmentDebugLogs, 1)
+		log := r.EvalDebugLog.SegmentDebugLogs[0]
+		assert.False(t, log.Matched)
+		assert.Contains(t, log.Msg, "constraint group not match")
+		assert.Contains(t, log.Msg, `NOT (({email} =~ /@test\.com$/))`)
+		require.Len(t, log.Constraints, 1)
+		assert.True(t, log.Constraints[0].Matched)
+
+		require.Len(t, log.Groups, 1)
+		or := log.Groups[0]
+		assert.Equal(t, "OR", or.Operator)
+		assert.False(t, or.Matched)
+		require.Len(t, or.Constraints, 1)
+		assert.False(t, or.Constraints[0].Matched)
+		assert.Empty(t, or.Constraints[0].Error)
+		and := or.Groups[0]
+		assert.False(t, and.Matched)
+		assert.True(t, and.Constraints[0].Matched)
+		assert.Equal(t, "enterprise", and.Constraints[0].Expected)
+		not := and.Groups[0]
+		assert.Equal(t, "NOT", not.Operator)
+		assert.False(t, not.Matched)
+		assert.True(t, not.Constraints[0].Matched)
+		assert.Equal(t, "a@test.com", not.Constraints[0].Actual)
+
+		r = eval(map[string]any{"platform": "web", "country": "US"})
+		log = r.EvalDebugLog.SegmentDebugLogs[0]
+		assert.True(t, log.Matched)
+		require.Len(t, log.Groups, 1)
+		assert.True(t, log.Groups[0].Matched)
+
+		r = eval(map[string]any{"platform": "web", "tier": "enterprise", "email": "a@b.com"})
+		log = r.EvalDebugLog.SegmentDebugLogs[0]
+		assert.False(t, log.Matched)
+		assert.Contains(t, log.Msg, "country", "a missing property fails the group")
+		assert.NotEmpty(t, log.Groups[0].Constraints[0].Error)
+	})
+}
+
+func TestEvaluateNativeConstraints(t *testing.T) {
+	t.Parallel()
+	f := loadTestFlagSet(t).Get("checkout")
+	f.Segments[0].Constraints = append(f.Segments[0].Constraints, entity.Constraint{
+		Property: "email", Operator: models.ConstraintOperatorENDSWITH, Value: `"@example.com"`,
+	})
+	f.Segments[0].ConstraintGroups = entity.ConstraintGroupArray{{
+		Operator:    models.ConstraintGroupOperatorOR,
+		Constraints: []entity.GroupConstraint{{Property: "tier", Operator: models.ConstraintOperatorINCI, Value: `["pro", "enterprise"]`}},
+	}}
+	require.NoError(t, f.PrepareEvaluation())
+
+	eval := func(m map[string]any) *models.EvalResult {
+		return Evaluator{Debug: true}.Evaluate(f, models.EvalContext{EnableDebug: true, EntityID: "e1", EntityContext: m})
+	}
+	us := int64(f.Segments[0].ID)
+
+	r := eval(map[string]any{"country": "US", "email": "jane@example.com", "tier": "Pro"})
+	assert.Equal(t, us, r.SegmentID)
+	log := r.EvalDebugLog.SegmentDebugLogs[0]
+	require.Len(t, log.Constraints, 2)
+	assert.True(t, log.Constraints[1].Matched)
+	assert.True(t, log.Groups[0].Constraints[0].Matched)
+
+	r = eval(map[string]any{"country": "US", "email": "jane@example.org", "tier": "pro"})
+	assert.NotEqual(t, us, r.SegmentID)
+	log = r.EvalDebugLog.SegmentDebugLogs[0]
+	assert.Contains(t, log.Msg, `({email} ENDS WITH "@example.com")`)
+	assert.False(t, log.Constraints[1].Matched)
+
+	r = eval(map[string]any{"country": "US", "tier": "pro"})
+	assert.NotEqual(t, us, r.SegmentID)
+	assert.Equal(t, "argument: email not found", r.EvalDebugLog.SegmentDebugLogs[0].Msg)
+
+	r = eval(map[string]any{"country": "US", "email": "jane@example.com", "tier": "free"})
+	assert.NotEqual(t, us, r.SegmentID)
+	assert.Contains(t, r.EvalDebugLog.SegmentDebugLogs[0].Msg, "constraint group not match")
+}
+
+func TestFlagSet(t *testing.T) {
+	t.Parallel()
+	fs := loadTestFlagSet(t)
+	assert.Equal(t, 3, fs.Len())
+
+	checkout := fs.Get("checkout")
+	require.NotNil(t, checkout)
+	assert.Equal(t, uint(1), checkout.ID, "IDs are assigned in file order")
+	assert.Equal(t, checkout, fs.Get(uint(1)))
+	assert.Equal(t, checkout, fs.Lookup(models.EvalContext{FlagKey: "checkout"}))
+	assert.Equal(t, checkout, fs.Lookup(models.EvalContext{FlagID: 1, FlagKey: "banner"}))
+	assert.Nil(t, fs.Lookup(models.EvalContext{FlagKey: "missing"}))
+
+	assert.Len(t, fs.GetByTags([]string{"web"}, nil), 2)
+	assert.Len(t, fs.GetByTags([]string{"payments", "missing"}, nil), 1)
+	all := models.EvaluationBatchRequestFlagTagsOperatorALL
+	assert.Len(t, fs.GetByTags([]string{"web", "payments"}, &all), 1)
+	assert.Empty(t, fs.GetByTags([]string{"web", "missing"}, &all))
+}
@@ -0,0 +1,129 @@
+package evaluator
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/swagger_gen/models"
+)
+
+// constraintDebugLogs evaluates every constraint of segment on its own
+// against m with its compiled matcher, so that a debug log can tell which of
+// them did not match. It is nil if the segment was not prepared.
+func constraintDebugLogs(segment entity.Segment, m map[string]any) []*models.ConstraintDebugLog {
+	matchers := segment.SegmentEvaluation.Matchers
+	if len(matchers) != len(segment.Constraints) {
+		return nil
+	}
+	logs := make([]*models.ConstraintDebugLog, 0, len(segment.Constraints))
+	for i, c := range segment.Constraints {
+		l := &models.ConstraintDebugLog{
+			ConstraintID: int64(c.ID),
+			Property:     c.Property,
+			Operator:     c.Operator,
+			Expected:     constraintExpected(c.Value),
+		}
+		l.Actual, _ = entity.PropertyValue(m, c.Property)
+		match, err := matchers[i](m)
+		l.Matched = err == nil && match
+		if err != nil {
+			l.Error = err.Error()
+		}
+		logs = append(logs, l)
+	}
+	return logs
+}
+
+// constraintGroupDebugLogs explains every constraint group of segment
+// against m, down to each constraint. It is nil if the segment has no groups.
+func constraintGroupDebugLogs(segment entity.Segment, m map[string]any) []*models.ConstraintGroupDebugLog {
+	exprs := segment.SegmentEvaluation.ConstraintGroups
+	if len(segment.ConstraintGroups) == 0 || len(exprs) != len(segment.ConstraintGroups) {
+		return nil
+	}
+	logs := make([]*models.ConstraintGroupDebugLog, len(exprs))
+	for i, g := range segment.ConstraintGroups {
+		logs[i] = constraintGroupDebugLog(g, exprs[i], m)
+	}
+	return logs
+}
+
+func constraintGroupDebugLog(g entity.ConstraintGroup, ge entity.ConstraintGroupExpr, m map[string]any) *models.ConstraintGroupDebugLog {
+	matched, err := ge.Evaluate(m)
+	l := &models.ConstraintGroupDebugLog{
+		Operator:    g.Operator,
+		Matched:     err == nil && matched,
+		Constraints: make([]*models.ConstraintDebugLog, len(g.Constraints)),
+		Groups:      make([]*models.ConstraintGroupDebugLog, len(g.Groups)),
+	}
+	for i, gc := range g.Constraints {
+		cl := &models.ConstraintDebugLog{
+			Property: gc.Property,
+			Operator: gc.Operator,
+			Expected: constraintExpected(gc.Value),
+		}
+		cl.Actual, _ = entity.PropertyValue(m, gc.Property)
+		match, err := ge.Matchers[i](m)
+		cl.Matched = err == nil && match
+		if err != nil {
+			cl.Error = err.Error()
+		}
+		l.Constraints[i] = cl
+	}
+	for i, sub := range g.Groups {
+		l.Groups[i] = constraintGroupDebugLog(sub, ge.Groups[i], m)
+	}
+	return l
+}
+
+// constraintExpected decodes a constraint value like "CA" or [1, 2] from
+// JSON, and returns other values, e.g. regex literals, as they are.
+func constraintExpected(value string) any {
+	var v any
+	if err := json.Unmarshal([]byte(strings.TrimSpace(value)), &v); err != nil {
+		return value
+	}
+	return v
+}
+
+// rolloutDebugLog describes the rollout decision of a segment whose
+// constraints matched. log is nil if the entity was not bucketed, in which
+// case msg says why.
+func rolloutDebugLog(segment entity.Segment, variantID *uint, msg string, log *entity.DistributionDebugLog) *models.RolloutDebugLog {
+	r := &models.RolloutDebugLog{
+		RolledOut:      variantID != nil,
+		RolloutPercent: int64(segment.RolloutPercent),
+		Distributions:  make([]*models.DistributionDebugLog, 0, len(segment.Distributions)),
+	}
+
+	accumulated := segment.SegmentEvaluation.DistributionArray.PercentsAccumulated
+	for i, d := range segment.Distributions {
+		dl := &models.DistributionDebugLog{
+			VariantID:  int64(d.VariantID),
+			VariantKey: d.VariantKey,
+			Percent:    int64(d.Percent),
+		}
+		if i < len(accumulated) {
+			dl.BucketEnd = int64(accumulated[i])
+			if i > 0 {
+				dl.BucketStart = int64(accumulated[i-1])
+			}
+		}
+		r.Distributions = append(r.Distributions, dl)
+	}
+
+	if log == nil {
+		r.Reason = strings.TrimPrefix(msg, "rollout no. ")
+		return r
+	}
+	r.BucketNum = int64(log.BucketNum)
+	r.VariantID = int64(log.VariantID)
+	for _, d := range segment.Distributions {
+		if d.VariantID == log.VariantID {
+			r.VariantKey = d.VariantKey
+			break
+		}
+	}
+	return r
+}
@@ -0,0 +1,229 @@
+package evaluator
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/pkg/util"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/sirupsen/logrus"
+)
+
+// FlagSet is an immutable, indexed set of flags ready for evaluation. Flags
+// are looked up the same way the server's EvalCache looks them up.
+type FlagSet struct {
+	idCache  map[string]*entity.Flag
+	keyCache map[string]*entity.Flag
+	tagCache map[string]map[uint]*entity.Flag
+}
+
+// ParseEvalCacheJSON parses an EvalCacheJSON document ({"Flags": [...]}),
+// validates it with ValidateFlags and assigns IDs to entities that have none,
+// exactly like the server's eval-only drivers do. Flags with validation
+// errors are rejected; warnings are logged.
+func ParseEvalCacheJSON(b []byte) ([]entity.Flag, error) {
+	ecj := struct{ Flags []entity.Flag }{}
+	if err := json.Unmarshal(b, &ecj); err != nil {
+		return nil, err
+	}
+	result := ValidateFlags(ecj.Flags)
+	if !result.OK() {
+		return nil, fmt.Errorf("flag validation failed with %d error(s): %s",
+			len(result.Errors), strings.Join(result.Errors, "; "))
+	}
+	for _, w := range result.Warnings {
+		logrus.Warnf("flag validation warning: %s", w)
+	}
+	NormalizeIDs(ecj.Flags)
+	return ecj.Flags, nil
+}
+
+// NewFlagSet prepares flags for evaluation and indexes them by ID, key and
+// tag. The FlagSet takes ownership of the slice.
+func NewFlagSet(flags []entity.Flag) (*FlagSet, error) {
+	fs := &FlagSet{
+		idCache:  make(map[string]*entity.Flag),
+		keyCache: make(map[string]*entity.Flag),
+		tagCache: make(map[string]map[uint]*entity.Flag),
+	}
+	for i := range flags {
+		f := &flags[i]
+		if err := f.PrepareEvaluation(); err != nil {
+			return nil, err
+		}
+		if f.ID != 0 {
+			fs.idCache[util.SafeString(f.ID)] = f
+		}
+		if f.Key != "" {
+			fs.keyCache[f.Key] = f
+		}
+		for _, t := range f.Tags {
+			if fs.tagCache[t.Value] == nil {
+				fs.tagCache[t.Value] = make(map[uint]*entity.Flag)
+			}
+			fs.tagCache[t.Value][f.ID] = f
+		}
+	}
+	return fs, nil
+}
+
+// Len returns the number of flags with an ID.
+func (fs *FlagSet) Len() int {
+	return len(fs.idCache)
+}
+
+// Get gets the flag by key or ID.
+func (fs *FlagSet) Get(keyOrID any) *entity.Flag {
+	s := util.SafeString(keyOrID)
+	f, ok := fs.idCache[s]
+	if !ok {
+		f = fs.keyCache[s]
+	}
+	return f
+}
+
+// Lookup finds the flag an evalContext refers to, by FlagID first and then
+// by FlagKey.
+func (fs *FlagSet) Lookup(evalContext models.EvalContext) *entity.Flag {
+	f := fs.Get(util.SafeUint(evalContext.FlagID))
+	if f == nil {
+		f = fs.Get(util.SafeString(evalContext.FlagKey))
+	}
+	return f
+}
+
+// GetByTags returns the flags having any (operator nil or ANY) or all
+// (operator ALL) of the tags, in no particular order.
+func (fs *FlagSet) GetByTags(tags []string, operator *string) []*entity.Flag {
+	results := map[uint]*entity.Flag{}
+	switch {
+	case operator == nil || *operator == models.EvaluationBatchRequestFlagTagsOperatorANY:
+		for _, t := range tags {
+			maps.Copy(results, fs.tagCache[t])
+		}
+	case *operator == models.EvaluationBatchRequestFlagTagsOperatorALL:
+		for i, t := range tags {
+			fSet, ok := fs.tagCache[t]
+			if !ok {
+				return []*entity.Flag{}
+			}
+			if i == 0 {
+				maps.Copy(results, fSet)
+				continue
+			}
+			for fID := range results {
+				if _, ok := fSet[fID]; !ok {
+					delete(results, fID)
+				}
+			}
+		}
+	}
+
+	values := make([]*entity.Flag, 0, len(results))
+	for _, f := range results {
+		values = append(values, f)
+	}
+	return values
+}
+
+// setIfZeroAndBumpNext evaluates *target: if zero, sets it to next and
+// returns next+1 (to advance the counter); otherwise returns next unchanged.
+// Used by NormalizeIDs to auto-assign sequential IDs to zero-valued entities.
+func setIfZeroAndBumpNext(target *uint, next uint) uint {
+	if *target == 0 {
+		*target = next
+		return next + 1
+	}
+	return next
+}
+
+// NormalizeIDs assigns sequential IDs to any entities with zero IDs.
+// This allows hand-edited JSON files to omit IDs entirely — the system
+// auto-generates unique ones. Entities with explicit non-zero IDs are
+// left untouched.
+//
+// All entity types use global counters (not per-flag) to match the
+// behavior of a real database where every table has its own auto-increment.
+// This also means IDs are stable if a flag is later migrated to a DB backend.
+//
+// Invariants:
+//   - Every entity type has globally unique IDs
+//   - Distribution.VariantID matches a Variant.ID in the same flag
+//   - Segment.FlagID, Constraint.SegmentID, Distribution.SegmentID are set
+func NormalizeIDs(flags []entity.Flag) {
+	// Pass 1: find the max existing ID per type so we never collide
+	var nextFlagID, nextVariantID, nextSegmentID, nextConstraintID, nextDistributionID, nextTagID uint = 1, 1, 1, 1, 1, 1
+	for i := range flags {
+		if flags[i].ID >= nextFlagID {
+			nextFlagID = flags[i].ID + 1
+		}
+		for _, v := range flags[i].Variants {
+			if v.ID >= nextVariantID {
+				nextVariantID = v.ID + 1
+			}
+		}
+		for _, s := range flags[i].Segments {
+			if s.ID >= nextSegmentID {
+				nextSegmentID = s.ID + 1
+			}
+			for _, c := range s.Constraints {
+				if c.ID >= nextConstraintID {
+					nextConstraintID = c.ID + 1
+				}
+			}
+			for _, d := range s.Distributions {
+				if d.ID >= nextDistributionID {
+					nextDistributionID = d.ID + 1
+				}
+			}
+		}
+		for _, t := range flags[i].Tags {
+			if t.ID >= nextTagID {
+				nextTagID = t.ID + 1
+			}
+		}
+	}
+
+	// Pass 2: assign IDs where missing and fix parent references
+	for i := range flags {
+		nextFlagID = setIfZeroAndBumpNext(&flags[i].ID, nextFlagID)
+		for j := range flags[i].Variants {
+			nextVariantID = setIfZeroAndBumpNext(&flags[i].Variants[j].ID, nextVariantID)
+		}
+		for j := range flags[i].Segments {
+			nextSegmentID = setIfZeroAndBumpNext(&flags[i].Segments[j].ID, nextSegmentID)
+			flags[i].Segments[j].FlagID = flags[i].ID
+			for k := range flags[i].Segments[j].Constraints {
+				nextConstraintID = setIfZeroAndBumpNext(&flags[i].Segments[j].Constraints[k].ID, nextConstraintID)
+				flags[i].Segments[j].Constraints[k].SegmentID = flags[i].Segments[j].ID
+			}
+			for k := range flags[i].Segments[j].Distributions {
+				d := &flags[i].Segments[j].Distributions[k]
+				nextDistributionID = setIfZeroAndBumpNext(&d.ID, nextDistributionID)
+				d.SegmentID = flags[i].Segments[j].ID
+				// Resolve VariantID from VariantKey when VariantID is missing.
+				// This lets hand-edited files omit numeric variant IDs entirely —
+				// just set "VariantKey": "control" and the link is resolved.
+				if d.VariantID == 0 && d.VariantKey != "" {
+					found := false
+					for _, v := range flags[i].Variants {
+						if v.Key == d.VariantKey {
+							d.VariantID = v.ID
+							found = true
+							break
+						}
+					}
+					if !found {
+						logrus.Warnf("flag %q: distribution references unknown variant key %q", flags[i].Key, d.VariantKey)
+					}
+				}
+			}
+		}
+		for j := range flags[i].Tags {
+			nextTagID = setIfZeroAndBumpNext(&flags[i].Tags[j].ID, nextTagID)
+		}
+	}
+}
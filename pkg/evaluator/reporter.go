@@ -0,0 +1,184 @@
+package evaluator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/sirupsen/logrus"
+)
+
+// reporter batches exposures in memory and POSTs them to a Flagr server's
+// /exposures endpoint. Exposures are dropped rather than blocking the caller
+// when the queue is full or the server cannot be reached.
+type reporter struct {
+	url           string
+	client        *http.Client
+	header        http.Header
+	batchSize     int
+	flushInterval time.Duration
+
+	queue   chan *models.Exposure
+	flushCh chan chan struct{}
+	done    chan struct{}
+	wg      sync.WaitGroup
+
+	sent    atomic.Int64
+	dropped atomic.Int64
+}
+
+func newReporter(cfg Config) *reporter {
+	r := &reporter{
+		url:           cfg.ExposuresURL,
+		client:        cfg.HTTPClient,
+		header:        cfg.Header,
+		batchSize:     cfg.ExposureBatchSize,
+		flushInterval: cfg.ExposureFlushInterval,
+		queue:         make(chan *models.Exposure, cfg.ExposureQueueSize),
+		flushCh:       make(chan chan struct{}),
+		done:          make(chan struct{}),
+	}
+	r.wg.Add(1)
+	go r.run()
+	return r
+}
+
+// enqueue adds an exposure to the next batch without blocking.
+func (r *reporter) enqueue(e *models.Exposure) {
+	select {
+	case r.queue <- e:
+	default:
+		r.dropped.Add(1)
+	}
+}
+
+// flush sends everything queued so far and waits for it to be posted.
+func (r *reporter) flush() {
+	ack := make(chan struct{})
+	select {
+	case r.flushCh <- ack:
+		<-ack
+	case <-r.done:
+	}
+}
+
+// close stops the reporter after sending everything still queued.
+func (r *reporter) close() {
+	close(r.done)
+	r.wg.Wait()
+}
+
+func (r *reporter) run() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*models.Exposure, 0, r.batchSize)
+	send := func() {
+		if len(batch) > 0 {
+			r.post(batch)
+			batch = make([]*models.Exposure, 0, r.batchSize)
+		}
+	}
+	drain := func() {
+		for {
+			select {
+			case e := <-r.queue:
+				batch = append(batch, e)
+				if len(batch) >= r.batchSize {
+					send()
+				}
+			default:
+				send()
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case e := <-r.queue:
+			batch = append(batch, e)
+			if len(batch) >= r.batchSize {
+				send()
+			}
+		case <-ticker.C:
+			send()
+		case ack := <-r.flushCh:
+			drain()
+			close(ack)
+		case <-r.done:
+			drain()
+			return
+		}
+	}
+}
+
+func (r *reporter) post(batch []*models.Exposure) {
+	if err := r.doPost(batch); err != nil {
+		r.dropped.Add(int64(len(batch)))
+		logrus.WithField("err", err).WithField("count", len(batch)).Error("failed to report exposures to flagr")
+		return
+	}
+	r.sent.Add(int64(len(batch)))
+}
+
+func (r *reporter) doPost(batch []*models.Exposure) error {
+	b, err := json.Marshal(&models.ExposuresRequest{Exposures: batch})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, r.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	for k, vs := range r.header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("POST %s: unexpected status %s: %s", r.url, res.Status, bytes.TrimSpace(msg))
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	return nil
+}
+
+// exposureFromResult converts an evaluation result into the exposure row the
+// server records for it.
+func exposureFromResult(r *models.EvalResult) *models.Exposure {
+	e := &models.Exposure{
+		FlagID:         r.FlagID,
+		FlagKey:        r.FlagKey,
+		FlagSnapshotID: r.FlagSnapshotID,
+		VariantID:      r.VariantID,
+		VariantKey:     r.VariantKey,
+	}
+	if r.EvalContext != nil {
+		entityID := r.EvalContext.EntityID
+		e.EntityID = &entityID
+		e.EntityType = r.EvalContext.EntityType
+		e.EntityContext = r.EvalContext.EntityContext
+	}
+	if ts, err := time.Parse(time.RFC3339, r.Timestamp); err == nil {
+		e.Timestamp = strfmt.DateTime(ts)
+	}
+	return e
+}
@@ -0,0 +1,162 @@
+package evaluator
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/swagger_gen/models"
+)
+
+// Simulate evaluates flag for every eval context and counts the contexts that
+// matched each segment and were assigned each variant. If current is not nil,
+// it is evaluated too and the counts are compared: variants get deltas
+// against current, and up to switchedLimit entities assigned a different
+// variant are listed. To keep entities in their buckets, flag must have the
+// same ID as current.
+//
+// Nothing is recorded. Contexts without an EntityID get a random one, like
+// on evaluation, that is shared by both flags.
+func Simulate(flag, current *entity.Flag, contexts []models.EvalContext, switchedLimit int) *models.EvaluationSimulationResponse {
+	total := int64(len(contexts))
+	resp := &models.EvaluationSimulationResponse{
+		Total:    &total,
+		Switched: []*models.SimulationSwitch{},
+	}
+
+	segments := make(map[uint]int64, len(flag.Segments))
+	currentSegments := make(map[uint]int64)
+	variants := make(map[string]int64, len(flag.Variants))
+	currentVariants := make(map[string]int64)
+	for _, evalContext := range contexts {
+		if evalContext.EntityID == "" {
+			evalContext.EntityID = generateEntityID()
+		}
+		a := assign(flag, evalContext)
+		segments[a.segmentID]++
+		variants[a.variantKey]++
+		if current == nil {
+			continue
+		}
+
+		c := assign(current, evalContext)
+		currentSegments[c.segmentID]++
+		currentVariants[c.variantKey]++
+		if c.variantKey != a.variantKey {
+			resp.SwitchedTotal++
+			if len(resp.Switched) < switchedLimit {
+				resp.Switched = append(resp.Switched, &models.SimulationSwitch{
+					EntityID:          evalContext.EntityID,
+					CurrentVariantKey: c.variantKey,
+					VariantKey:        a.variantKey,
+					CurrentSegmentID:  int64(c.segmentID),
+					SegmentID:         int64(a.segmentID),
+				})
+			}
+		}
+	}
+
+	resp.Segments = simulationSegments(flag, segments, total)
+	if current != nil {
+		resp.CurrentSegments = simulationSegments(current, currentSegments, total)
+	}
+
+	keys := make([]string, 0, len(flag.Variants))
+	seen := make(map[string]bool, len(flag.Variants))
+	for _, fl := range []*entity.Flag{flag, current} {
+		if fl == nil {
+			continue
+		}
+		for _, v := range fl.Variants {
+			if v.Key != "" && !seen[v.Key] {
+				seen[v.Key] = true
+				keys = append(keys, v.Key)
+			}
+		}
+	}
+	resp.Variants = make([]*models.SimulationVariant, 0, len(keys))
+	for _, k := range keys {
+		resp.Variants = append(resp.Variants, simulationVariant(k, variants[k], currentVariants[k], total, current != nil))
+	}
+	resp.Unassigned = simulationVariant("", variants[""], currentVariants[""], total, current != nil)
+	return resp
+}
+
+// SyntheticContexts returns n eval contexts with random entity IDs, all with
+// entityType and entityContext. The same seed returns the same entity IDs.
+func SyntheticContexts(n int, seed int64, entityType string, entityContext any) []models.EvalContext {
+	r := rand.New(rand.NewSource(seed))
+	contexts := make([]models.EvalContext, n)
+	for i := range contexts {
+		contexts[i] = models.EvalContext{
+			EntityID:      fmt.Sprintf("synthetic_%016x", r.Uint64()),
+			EntityType:    entityType,
+			EntityContext: entityContext,
+		}
+	}
+	return contexts
+}
+
+type assignment struct {
+	segmentID  uint
+	variantKey string
+}
+
+// assign walks the segments of flag like Evaluator.Evaluate, returning the
+// segment whose constraints matched (0 if none) and the assigned variant key
+// ("" if none).
+func assign(flag *entity.Flag, evalContext models.EvalContext) assignment {
+	if !flag.Enabled {
+		return assignment{}
+	}
+	for _, segment := range flag.Segments {
+		variantID, _, evalNextSegment := EvalSegment(evalContext, segment, false)
+		if evalNextSegment {
+			continue
+		}
+		a := assignment{segmentID: segment.ID}
+		if variantID != nil {
+			if v := flag.FlagEvaluation.VariantsMap[*variantID]; v != nil {
+				a.variantKey = v.Key
+			}
+		}
+		return a
+	}
+	return assignment{}
+}
+
+func simulationSegments(flag *entity.Flag, matched map[uint]int64, total int64) []*models.SimulationSegment {
+	ss := make([]*models.SimulationSegment, 0, len(flag.Segments))
+	for _, s := range flag.Segments {
+		ss = append(ss, &models.SimulationSegment{
+			SegmentID:      int64(s.ID),
+			Description:    s.Description,
+			RolloutPercent: int64(s.RolloutPercent),
+			Matched:        matched[s.ID],
+			Percent:        percentOf(matched[s.ID], total),
+		})
+	}
+	return ss
+}
+
+func simulationVariant(key string, count, currentCount, total int64, compare bool) *models.SimulationVariant {
+	v := &models.SimulationVariant{
+		VariantKey: key,
+		Count:      count,
+		Percent:    percentOf(count, total),
+	}
+	if compare {
+		v.CurrentCount = currentCount
+		v.CurrentPercent = percentOf(currentCount, total)
+		v.Delta = count - currentCount
+		v.PercentDelta = v.Percent - v.CurrentPercent
+	}
+	return v
+}
+
+func percentOf(n, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) * 100 / float64(total)
+}
@@ -0,0 +1,87 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	t.Parallel()
+	current := loadTestFlagSet(t).Get("checkout")
+	proposed := loadTestFlagSet(t).Get("checkout")
+	proposed.Segments[1].RolloutPercent = 50
+	require.NoError(t, proposed.PrepareEvaluation())
+
+	contexts := append(
+		SyntheticContexts(1000, 1, "", map[string]any{"country": "US"}),
+		SyntheticContexts(1000, 2, "", map[string]any{"country": "DE"})...,
+	)
+
+	t.Run("compared with the current config", func(t *testing.T) {
+		resp := Simulate(proposed, current, contexts, 10)
+		assert.Equal(t, int64(2000), *resp.Total)
+
+		require.Len(t, resp.Segments, 2)
+		assert.Equal(t, int64(1000), resp.Segments[0].Matched)
+		assert.Equal(t, int64(1000), resp.Segments[1].Matched)
+		assert.Equal(t, int64(50), resp.Segments[1].RolloutPercent)
+		require.Len(t, resp.CurrentSegments, 2)
+		assert.Equal(t, int64(20), resp.CurrentSegments[1].RolloutPercent)
+
+		// the counts are those of Evaluate
+		counts := map[string]int64{}
+		for _, c := range contexts {
+			counts[Evaluator{}.Evaluate(proposed, c).VariantKey]++
+		}
+		require.Len(t, resp.Variants, 2)
+		assert.Equal(t, "control", resp.Variants[0].VariantKey)
+		assert.Equal(t, counts["control"], resp.Variants[0].Count)
+		assert.Equal(t, counts["treatment"], resp.Variants[1].Count)
+		assert.Equal(t, counts[""], resp.Unassigned.Count)
+		assert.InDelta(t, float64(counts[""])/20, resp.Unassigned.Percent, 1e-9)
+
+		// raising the rollout only moves unassigned entities to control
+		assert.Positive(t, resp.Variants[0].Delta)
+		assert.Zero(t, resp.Variants[1].Delta)
+		assert.Equal(t, -resp.Variants[0].Delta, resp.Unassigned.Delta)
+		assert.InDelta(t, resp.Variants[0].Percent-resp.Variants[0].CurrentPercent, resp.Variants[0].PercentDelta, 1e-9)
+		assert.Equal(t, resp.Variants[0].Delta, resp.SwitchedTotal)
+		require.Len(t, resp.Switched, 10)
+		for _, s := range resp.Switched {
+			assert.Empty(t, s.CurrentVariantKey)
+			assert.Equal(t, "control", s.VariantKey)
+			assert.Equal(t, resp.Segments[1].SegmentID, s.SegmentID)
+		}
+	})
+
+	t.Run("without a current config", func(t *testing.T) {
+		resp := Simulate(current, nil, contexts, 10)
+		assert.Nil(t, resp.CurrentSegments)
+		assert.Empty(t, resp.Switched)
+		assert.Zero(t, resp.SwitchedTotal)
+		assert.Zero(t, resp.Variants[0].CurrentCount)
+		assert.Zero(t, resp.Variants[0].Delta)
+	})
+
+	t.Run("disabled flag", func(t *testing.T) {
+		resp := Simulate(loadTestFlagSet(t).Get("disabled"), nil, contexts[:5], 10)
+		assert.Zero(t, resp.Segments[0].Matched)
+		assert.Equal(t, int64(5), resp.Unassigned.Count)
+	})
+}
+
+func TestSyntheticContexts(t *testing.T) {
+	t.Parallel()
+	a := SyntheticContexts(3, 7, "user", map[string]any{"k": "v"})
+	require.Len(t, a, 3)
+	assert.Equal(t, "user", a[0].EntityType)
+	assert.Equal(t, map[string]any{"k": "v"}, a[0].EntityContext)
+	assert.NotEqual(t, a[0].EntityID, a[1].EntityID)
+
+	assert.Equal(t, a, SyntheticContexts(3, 7, "user", map[string]any{"k": "v"}))
+	assert.NotEqual(t, a[0].EntityID, SyntheticContexts(1, 8, "", nil)[0].EntityID)
+	assert.Equal(t, []models.EvalContext{}, SyntheticContexts(0, 7, "", nil))
+}
@@ -0,0 +1,70 @@
+package evaluator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// Source provides the EvalCacheJSON document a Client evaluates.
+type Source interface {
+	Fetch(ctx context.Context) ([]byte, error)
+}
+
+// SourceFunc adapts a function to a Source.
+type SourceFunc func(ctx context.Context) ([]byte, error)
+
+// Fetch calls f(ctx).
+func (f SourceFunc) Fetch(ctx context.Context) ([]byte, error) {
+	return f(ctx)
+}
+
+// FileSource reads the EvalCacheJSON from a local file, e.g. one written by
+// a sidecar or mounted from a ConfigMap.
+func FileSource(path string) Source {
+	return SourceFunc(func(ctx context.Context) ([]byte, error) {
+		return os.ReadFile(path)
+	})
+}
+
+// URLSource reads the EvalCacheJSON with a GET request, typically against a
+// Flagr server's /api/v1/export/eval_cache/json. client may be nil to use
+// http.DefaultClient.
+func URLSource(url string, client *http.Client) Source {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return SourceFunc(func(ctx context.Context) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("GET %s: unexpected status %s", url, res.Status)
+		}
+		return io.ReadAll(res.Body)
+	})
+}
+
+// ReaderSource reads the EvalCacheJSON from r once, on the first Fetch.
+// Later fetches return the same document, so a Client using it never sees
+// updates; use it for embedded or one-shot configs.
+func ReaderSource(r io.Reader) Source {
+	var once sync.Once
+	var b []byte
+	var err error
+	return SourceFunc(func(ctx context.Context) ([]byte, error) {
+		once.Do(func() {
+			b, err = io.ReadAll(r)
+		})
+		return b, err
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/openflagr/flagr/pkg/entity"
 	"github.com/openflagr/flagr/swagger_gen/models"
 	"github.com/prashantv/gostub"
+	"github.com/zhouzhuojie/conditions"
 )
 
 // BenchmarkEvalSegmentBaseline benchmarks evalSegment without injected context (current behavior).
@@ -105,6 +106,82 @@ func BenchmarkEvalSegmentWithHTTPHeaders(b *testing.B) {
 	}
 }
 
+// BenchmarkEvalSegmentConstraints compares the compiled constraints of a
+// segment with evaluating them with the conditions library, for constraints
+// on the entity context and the injected context.
+func BenchmarkEvalSegmentConstraints(b *testing.B) {
+	config.Config.InjectedContextEnabled = true
+	config.Config.InjectedContextHTTPHeaders = []string{"X-Environment"}
+	config.Config.InjectedContextHTTPHeaderPrefixes = []string{"CF-"}
+	defer func() {
+		config.Config.InjectedContextEnabled = false
+		config.Config.InjectedContextHTTPHeaders = nil
+		config.Config.InjectedContextHTTPHeaderPrefixes = nil
+	}()
+	defer gostub.StubFunc(&logEvalResult).Reset()
+
+	s := entity.GenFixtureSegment()
+	s.RolloutPercent = 100
+	s.Constraints = entity.ConstraintArray{
+		{Property: "dl_state", Operator: models.ConstraintOperatorIN, Value: `["CA", "WA", "OR", "NV", "AZ", "NY", "NJ", "MA"]`},
+		{Property: "email", Operator: models.ConstraintOperatorEREG, Value: `"@example\.com$"`},
+		{Property: "age", Operator: models.ConstraintOperatorGTE, Value: `21`},
+		{Property: "roles", Operator: models.ConstraintOperatorCONTAINS, Value: `"admin"`},
+		{Property: "@http.x_environment", Operator: models.ConstraintOperatorEQ, Value: `"production"`},
+		{Property: BuiltInKeyTsHour, Operator: models.ConstraintOperatorLT, Value: `24`},
+	}
+	if err := s.PrepareEvaluation(); err != nil {
+		b.Fatal(err)
+	}
+
+	interpreted := s
+	interpreted.SegmentEvaluation.Matchers = make([]entity.Matcher, len(s.Constraints))
+	for i := range s.Constraints {
+		expr, err := s.Constraints[i].ToExpr()
+		if err != nil {
+			b.Fatal(err)
+		}
+		interpreted.SegmentEvaluation.Matchers[i] = func(m map[string]any) (bool, error) {
+			return conditions.Evaluate(expr, m)
+		}
+	}
+
+	r := &http.Request{
+		Header: http.Header{
+			"X-Environment": []string{"production"},
+			"CF-IPCountry":  []string{"US"},
+		},
+		Host: "flagr.prod.example.com",
+	}
+	injectedCtx := InjectBuiltInContext(map[string]any{
+		"dl_state": "NY",
+		"email":    "jane@example.com",
+		"age":      float64(30),
+		"roles":    []any{"dev", "admin"},
+	}, r)
+	ec := models.EvalContext{
+		EnableDebug:   false,
+		EntityContext: injectedCtx,
+		EntityID:      "bench-entity",
+		EntityType:    "bench-type",
+		FlagID:        100,
+	}
+	if vID, _, _ := evalSegment(ec, s); vID == nil {
+		b.Fatal("segment does not match")
+	}
+
+	b.Run("compiled", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			evalSegment(ec, s)
+		}
+	})
+	b.Run("conditions", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			evalSegment(ec, interpreted)
+		}
+	})
+}
+
 // BenchmarkInjectBuiltInContext benchmarks the injection function itself.
 func BenchmarkInjectBuiltInContext(b *testing.B) {
 	config.Config.InjectedContextEnabled = true
@@ -168,6 +168,46 @@ func TestEvalWithBuiltinContext(t *testing.T) {
 		assert.False(t, evalNextSegment)
 	})
 
+	t.Run("http header path constraints match", func(t *testing.T) {
+		config.Config.InjectedContextEnabled = true
+		config.Config.InjectedContextHTTPHeaders = []string{"X-Environment"}
+		ResetHeaderMatchCache()
+		defer func() {
+			config.Config.InjectedContextEnabled = false
+			config.Config.InjectedContextHTTPHeaders = nil
+			ResetHeaderMatchCache()
+		}()
+
+		r := &http.Request{
+			Header: http.Header{
+				"X-Environment": []string{"production"},
+			},
+			Host: "flagr.example.com",
+		}
+		ctx := InjectBuiltInContext(map[string]any{}, r)
+
+		for _, property := range []string{"@http.x_environment", "/@http/x_environment"} {
+			s := entity.GenFixtureSegment()
+			s.Constraints = []entity.Constraint{
+				{
+					Property: property,
+					Operator: models.ConstraintOperatorEQ,
+					Value:    `"production"`,
+				},
+			}
+			s.PrepareEvaluation()
+
+			vID, _, evalNextSegment := evalSegment(models.EvalContext{
+				EntityContext: ctx,
+				EntityID:      "entity1",
+				FlagID:        100,
+			}, s)
+
+			assert.NotNil(t, vID, property)
+			assert.False(t, evalNextSegment, property)
+		}
+	})
+
 	t.Run("http_x_environment constraint does not match", func(t *testing.T) {
 		config.Config.InjectedContextEnabled = true
 		config.Config.InjectedContextHTTPHeaders = []string{"X-Environment"}
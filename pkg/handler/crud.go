@@ -516,6 +516,10 @@ func (c *crud) CreateSegment(params segment.CreateSegmentParams) middleware.Resp
 	s.RolloutPercent = uint(*params.Body.RolloutPercent)
 	s.Description = util.SafeString(params.Body.Description)
 	s.Rank = entity.SegmentDefaultRank
+	s.ConstraintGroups = r2e.MapConstraintGroups(params.Body.ConstraintGroups)
+	if err := s.ConstraintGroups.Validate(); err != nil {
+		return segment.NewCreateSegmentDefault(400).WithPayload(ErrorMessage("%s", err))
+	}
 
 	err := commitFlagMutation(flagID, subject, notification.OperationCreate, notification.ComponentSegment, func(tx *gorm.DB) (uint, mutationNotify, error) {
 		if err := tx.Create(s).Error; err != nil {
@@ -556,6 +560,10 @@ func (c *crud) PutSegment(params segment.PutSegmentParams) middleware.Responder
 	segmentID := util.SafeUint(params.SegmentID)
 	subject := getSubjectFromRequest(params.HTTPRequest)
 	s := &entity.Segment{}
+	groups := r2e.MapConstraintGroups(params.Body.ConstraintGroups)
+	if err := groups.Validate(); err != nil {
+		return segment.NewPutSegmentDefault(400).WithPayload(ErrorMessage("%s", err))
+	}
 
 	err := commitFlagMutation(flagID, subject, notification.OperationUpdate, notification.ComponentSegment, func(tx *gorm.DB) (uint, mutationNotify, error) {
 		if err := validateSegmentOwnership(tx, flagID, uint(params.SegmentID)); err != nil {
@@ -566,6 +574,9 @@ func (c *crud) PutSegment(params segment.PutSegmentParams) middleware.Responder
 		}
 		s.RolloutPercent = util.SafeUint(params.Body.RolloutPercent)
 		s.Description = util.SafeString(params.Body.Description)
+		if params.Body.ConstraintGroups != nil {
+			s.ConstraintGroups = groups
+		}
 		if err := tx.Save(s).Error; err != nil {
 			return 0, mutationNotify{}, err
 		}
@@ -924,9 +935,9 @@ func commitFlagMutation(
 	if err := tx.Commit().Error; err != nil {
 		return err
 	}
-	snap.NotifyAfterCommit(flagIDForSnapshot, subject, operation, componentType, notify.ComponentID, notify.ComponentKey)
+	snap.notifyAfterCommit(flagIDForSnapshot, subject, operation, componentType, notify.ComponentID, notify.ComponentKey)
 	return nil
 }
 
 // writeFlagSnapshotTx is the indirection used by commitFlagMutation (stubbable in tests).
-var writeFlagSnapshotTx = entity.WriteFlagSnapshotTx
+var writeFlagSnapshotTx = writeFlagSnapshot
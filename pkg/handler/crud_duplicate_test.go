@@ -328,8 +328,8 @@ func TestCommitFlagMutation_RollbackOnWriteFlagSnapshotFailure(t *testing.T) {
 	var before int64
 	require.NoError(t, db.Model(&entity.FlagSnapshot{}).Where("flag_id = ?", flagID).Count(&before).Error)
 
-	stub := gostub.Stub(&writeFlagSnapshotTx, func(tx *gorm.DB, flagID uint, updatedBy string) (entity.SnapshotNotification, error) {
-		return entity.SnapshotNotification{}, fmt.Errorf("snapshot write failed")
+	stub := gostub.Stub(&writeFlagSnapshotTx, func(tx *gorm.DB, flagID uint, updatedBy string) (snapshotNotification, error) {
+		return snapshotNotification{}, fmt.Errorf("snapshot write failed")
 	})
 	defer stub.Reset()
 
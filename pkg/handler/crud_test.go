@@ -697,6 +697,101 @@ func TestCrudSegmentsWithFailures(t *testing.T) {
 	})
 }
 
+func TestCrudSegmentConstraintGroups(t *testing.T) {
+	var res middleware.Responder
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	tmpDB, dbErr := db.DB()
+	if dbErr != nil {
+		t.Errorf("Failed to get database")
+	}
+
+	defer tmpDB.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	c.CreateFlag(flag.CreateFlagParams{
+		Body: &models.CreateFlagRequest{
+			Description: new("funny flag"),
+		},
+	})
+
+	usOrCA := []*models.ConstraintGroup{{
+		Operator: new(models.ConstraintGroupOperatorOR),
+		Constraints: []*models.Constraint{
+			{Property: new("country"), Operator: new(models.ConstraintOperatorEQ), Value: new(`"US"`)},
+			{Property: new("country"), Operator: new(models.ConstraintOperatorEQ), Value: new(`"CA"`)},
+		},
+	}}
+
+	// step 1. it should be able to create a segment with constraint groups
+	res = c.CreateSegment(segment.CreateSegmentParams{
+		FlagID: int64(1),
+		Body: &models.CreateSegmentRequest{
+			Description:      new("north america"),
+			RolloutPercent:   new(int64(100)),
+			ConstraintGroups: usOrCA,
+		},
+	})
+	assert.Equal(t, usOrCA, res.(*segment.CreateSegmentOK).Payload.ConstraintGroups)
+
+	// step 2. it should be able to find them
+	res = c.FindSegments(segment.FindSegmentsParams{FlagID: int64(1)})
+	assert.Equal(t, usOrCA, res.(*segment.FindSegmentsOK).Payload[0].ConstraintGroups)
+
+	// step 3. putting the segment without constraint groups should keep them
+	res = c.PutSegment(segment.PutSegmentParams{
+		FlagID:    int64(1),
+		SegmentID: int64(1),
+		Body: &models.PutSegmentRequest{
+			Description:    new("north america"),
+			RolloutPercent: new(int64(50)),
+		},
+	})
+	assert.Equal(t, usOrCA, res.(*segment.PutSegmentOK).Payload.ConstraintGroups)
+
+	// step 4. an empty array should remove them
+	res = c.PutSegment(segment.PutSegmentParams{
+		FlagID:    int64(1),
+		SegmentID: int64(1),
+		Body: &models.PutSegmentRequest{
+			Description:      new("north america"),
+			RolloutPercent:   new(int64(50)),
+			ConstraintGroups: []*models.ConstraintGroup{},
+		},
+	})
+	assert.Empty(t, res.(*segment.PutSegmentOK).Payload.ConstraintGroups)
+	res = c.FindSegments(segment.FindSegmentsParams{FlagID: int64(1)})
+	assert.Empty(t, res.(*segment.FindSegmentsOK).Payload[0].ConstraintGroups)
+
+	// step 5. invalid constraint groups should be rejected
+	invalid := []*models.ConstraintGroup{{
+		Operator: new(models.ConstraintGroupOperatorNOT),
+		Constraints: []*models.Constraint{
+			{Property: new("country"), Operator: new(models.ConstraintOperatorEQ), Value: new("US")},
+		},
+	}}
+	res = c.CreateSegment(segment.CreateSegmentParams{
+		FlagID: int64(1),
+		Body: &models.CreateSegmentRequest{
+			Description:      new("invalid"),
+			RolloutPercent:   new(int64(100)),
+			ConstraintGroups: invalid,
+		},
+	})
+	assert.Contains(t, *res.(*segment.CreateSegmentDefault).Payload.Message, "constraint group[0]: constraints[0]")
+	res = c.PutSegment(segment.PutSegmentParams{
+		FlagID:    int64(1),
+		SegmentID: int64(1),
+		Body: &models.PutSegmentRequest{
+			Description:      new("invalid"),
+			RolloutPercent:   new(int64(100)),
+			ConstraintGroups: invalid,
+		},
+	})
+	assert.Contains(t, *res.(*segment.PutSegmentDefault).Payload.Message, "constraint group[0]: constraints[0]")
+}
+
 func TestCrudConstraints(t *testing.T) {
 	var res middleware.Responder
 	db := entity.NewTestDB()
@@ -1480,23 +1575,16 @@ func TestAllMutationHandlersCallSaveFlagSnapshot(t *testing.T) {
 					case "commitFlagMutation":
 						callsSnapshot = true
 						return false
-					case "SaveFlagSnapshot", "WriteFlagSnapshotTx":
+					case "saveFlagSnapshot", "writeFlagSnapshot", "writeFlagSnapshotTx":
 						directSnapshot = true
 						return false
 					}
-				case *ast.SelectorExpr:
-					if id, ok := fun.X.(*ast.Ident); ok && id.Name == "entity" && fun.Sel != nil {
-						if fun.Sel.Name == "SaveFlagSnapshot" || fun.Sel.Name == "WriteFlagSnapshotTx" {
-							directSnapshot = true
-							return false
-						}
-					}
 				}
 				return true
 			})
 
 			if directSnapshot {
-				t.Errorf("%s: mutation handler %q must not call SaveFlagSnapshot/WriteFlagSnapshotTx directly", filename, name)
+				t.Errorf("%s: mutation handler %q must not call saveFlagSnapshot/writeFlagSnapshotTx directly", filename, name)
 			}
 			if !callsSnapshot {
 				t.Errorf("%s: mutation handler %q must call commitFlagMutation", filename, name)
@@ -23,6 +23,17 @@ func (se *simpleboxEncryptor) Encrypt(b []byte) (string, error) {
 	return s, nil
 }
 
+// Seal encrypts a record at rest, e.g. in a spool, without the encoding of
+// Encrypt.
+func (se *simpleboxEncryptor) Seal(b []byte) []byte {
+	return simplebox.NewFromSecretKey(&se.key).Encrypt(b)
+}
+
+// Open decrypts a record sealed with Seal.
+func (se *simpleboxEncryptor) Open(b []byte) ([]byte, error) {
+	return simplebox.NewFromSecretKey(&se.key).Decrypt(b)
+}
+
 func newSimpleboxEncryptor(k string) dataRecordEncryptor {
 	key := [simplebox.KeySize]byte{}
 	copy(key[:], k)
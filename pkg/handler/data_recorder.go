@@ -2,6 +2,7 @@ package handler
 
 import (
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/openflagr/flagr/pkg/config"
@@ -44,6 +45,43 @@ func recordCountsTowardDatar(r models.EvalResult) bool {
 	return r.RecordSource != models.EvalResultRecordSourceExposure
 }
 
+// recordAssignsDatarVariant reports whether the row assigns its entity to the
+// variant for Datar metric attribution, see FLAGR_RECORDER_DATAR_ASSIGNMENT_SOURCE.
+func recordAssignsDatarVariant(r models.EvalResult) bool {
+	if config.Config.RecorderDatarAssignmentSource == models.EvalResultRecordSourceExposure {
+		return r.RecordSource == models.EvalResultRecordSourceExposure
+	}
+	return true
+}
+
+// Optional Datar dimensions, see FLAGR_RECORDER_DATAR_DIMENSIONS.
+const (
+	datarDimensionRecordSource = "record_source"
+	datarDimensionEntityType   = "entity_type"
+)
+
+// datarDimensions returns the optional Datar dimensions of the row, and
+// whether the row is counted by them. Exposures are counted only with the
+// record_source dimension; dimensions that are not tracked are empty.
+func datarDimensions(r models.EvalResult) (recordSource, entityType string, ok bool) {
+	dims := config.Config.RecorderDatarDimensions
+	if len(dims) == 0 {
+		return "", "", false
+	}
+	if slices.Contains(dims, datarDimensionRecordSource) {
+		recordSource = r.RecordSource
+		if recordSource == "" {
+			recordSource = models.EvalResultRecordSourceEvaluation
+		}
+	} else if !recordCountsTowardDatar(r) {
+		return "", "", false
+	}
+	if slices.Contains(dims, datarDimensionEntityType) && r.EvalContext != nil {
+		entityType = r.EvalContext.EntityType
+	}
+	return recordSource, entityType, true
+}
+
 func recordCountsTowardEvalKafkaStatsd(r models.EvalResult) bool {
 	return r.RecordSource != models.EvalResultRecordSourceExposure
 }
@@ -58,18 +96,25 @@ func GetDataRecorder() DataRecorder {
 
 		var recs []DataRecorder
 		for _, rt := range config.Config.RecorderType {
+			var rec DataRecorder
 			switch rt {
 			case "kafka":
-				recs = append(recs, NewKafkaRecorder())
+				rec = NewKafkaRecorder()
 			case "kinesis":
-				recs = append(recs, NewKinesisRecorder())
+				rec = NewKinesisRecorder()
 			case "pubsub":
-				recs = append(recs, NewPubsubRecorder())
+				rec = NewPubsubRecorder()
+			case "nats":
+				rec = NewNATSRecorder()
 			case "datar":
-				recs = append(recs, NewDatarRecorder())
+				rec = NewDatarRecorder()
 			default:
 				panic(fmt.Sprintf("recorderType %q not supported", rt))
 			}
+			if slices.Contains(config.Config.RecorderSpoolTypes, rt) {
+				rec = newSpoolRecorder(rt, rec)
+			}
+			recs = append(recs, rec)
 		}
 		singletonDataRecorder = fanOutRecorder(recs)
 	})
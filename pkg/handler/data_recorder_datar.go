@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"time"
+
 	"github.com/openflagr/flagr/pkg/datar"
+	"github.com/openflagr/flagr/pkg/evaluator"
 	"github.com/openflagr/flagr/swagger_gen/models"
 )
 
@@ -11,7 +14,9 @@ func NewDatarRecorder() DataRecorder {
 }
 
 // datarRecorder wraps a datar.Engine as a DataRecorder.
-// It feeds evaluation results into the in-memory aggregate buffer.
+// It feeds evaluation results and their entities into the in-memory aggregate
+// buffer, the variants assigned to entities into the metric attribution
+// buffer, and records into the buffer of the optional dimensions.
 // Unlike Kafka/Kinesis/Pubsub recorders, it does not produce serialized
 // frames — NewDataRecordFrame returns an empty frame.
 type datarRecorder struct {
@@ -19,10 +24,30 @@ type datarRecorder struct {
 }
 
 func (d *datarRecorder) AsyncRecord(r models.EvalResult) {
+	if recordAssignsDatarVariant(r) && r.EvalContext != nil {
+		at, err := time.Parse(time.RFC3339, r.Timestamp)
+		if err != nil {
+			at = time.Now()
+		}
+		d.engine.RecordAssignment(r.FlagID, r.VariantID, datarEntityID(r), at)
+	}
+	if recordSource, entityType, ok := datarDimensions(r); ok {
+		d.engine.RecordDimensions(r.FlagID, r.VariantID, r.SegmentID, recordSource, entityType)
+	}
 	if !recordCountsTowardDatar(r) {
 		return
 	}
-	d.engine.Record(r.FlagID, r.VariantID, r.SegmentID)
+	d.engine.RecordEntity(r.FlagID, r.VariantID, r.SegmentID, datarEntityID(r))
+}
+
+// datarEntityID is the entity ID of r, or "" when the evaluator generated it
+// for an eval context without one, so that anonymous evaluations are neither
+// counted as distinct entities nor kept as assignments.
+func datarEntityID(r models.EvalResult) string {
+	if r.EvalContext == nil || evaluator.IsGeneratedEntityID(r.EvalContext.EntityID) {
+		return ""
+	}
+	return r.EvalContext.EntityID
 }
 
 func (d *datarRecorder) NewDataRecordFrame(_ models.EvalResult) DataRecordFrame {
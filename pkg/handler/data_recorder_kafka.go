@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -58,6 +60,8 @@ var NewKafkaRecorder = func() DataRecorder {
 	cfg.Producer.Retry.Max = config.Config.RecorderKafkaRetryMax
 	cfg.Producer.Flush.Frequency = config.Config.RecorderKafkaFlushFrequency
 	cfg.Version = mustParseKafkaVersion(config.Config.RecorderKafkaVersion)
+	// A spool waits for the brokers to acknowledge its records.
+	cfg.Producer.Return.Successes = slices.Contains(config.Config.RecorderSpoolTypes, "kafka")
 
 	brokerList := strings.Split(config.Config.RecorderKafkaBrokers, ",")
 	producer, err := saramaNewAsyncProducer(brokerList, cfg)
@@ -69,9 +73,21 @@ var NewKafkaRecorder = func() DataRecorder {
 	if producer != nil {
 		go func() {
 			for err := range producer.Errors() {
+				if done, ok := err.Msg.Metadata.(chan error); ok {
+					done <- err.Err
+				}
 				logrus.WithField("kafka_error", err).Error("failed to write access log entry")
 			}
 		}()
+		if cfg.Producer.Return.Successes {
+			go func() {
+				for msg := range producer.Successes() {
+					if done, ok := msg.Metadata.(chan error); ok {
+						done <- nil
+					}
+				}
+			}()
+		}
 	}
 
 	var encryptor dataRecordEncryptor
@@ -83,6 +99,7 @@ var NewKafkaRecorder = func() DataRecorder {
 		topic:               config.Config.RecorderKafkaTopic,
 		partitionKeyEnabled: config.Config.RecorderKafkaPartitionKeyEnabled,
 		producer:            producer,
+		acks:                cfg.Producer.Return.Successes,
 		options: DataRecordFrameOptions{
 			Encrypted:       config.Config.RecorderKafkaEncrypted,
 			Encryptor:       encryptor,
@@ -129,6 +146,7 @@ type kafkaRecorder struct {
 	topic               string
 	options             DataRecordFrameOptions
 	partitionKeyEnabled bool
+	acks                bool // the producer returns successes, see DeliverRecords
 }
 
 func (k *kafkaRecorder) NewDataRecordFrame(r models.EvalResult) DataRecordFrame {
@@ -139,24 +157,78 @@ func (k *kafkaRecorder) NewDataRecordFrame(r models.EvalResult) DataRecordFrame
 }
 
 func (k *kafkaRecorder) AsyncRecord(r models.EvalResult) {
-	frame := k.NewDataRecordFrame(r)
-	output, err := frame.Output()
+	msg, err := k.newMessage(r)
 	if err != nil {
 		logrus.WithField("err", err).Error("failed to generate data record frame for kafka recorder")
 		return
 	}
+	k.producer.Input() <- msg
+
+	logKafkaAsyncRecordToDatadog(r)
+}
+
+// DeliverRecords produces the records and waits for the brokers to
+// acknowledge them. Without acknowledgements it is AsyncRecord.
+func (k *kafkaRecorder) DeliverRecords(ctx context.Context, rs []models.EvalResult) error {
+	if !k.acks {
+		for _, r := range rs {
+			k.AsyncRecord(r)
+		}
+		return nil
+	}
+	done := make(chan error, len(rs))
+	sent := 0
+	for _, r := range rs {
+		msg, err := k.newMessage(r)
+		if err != nil {
+			logrus.WithField("err", err).Error("failed to generate data record frame for kafka recorder")
+			continue
+		}
+		msg.Metadata = done
+		select {
+		case k.producer.Input() <- msg:
+			sent++
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	var errs []error
+	for range sent {
+		select {
+		case err := <-done:
+			if err != nil {
+				errs = append(errs, err)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d records not acknowledged: %w", len(errs), sent, errs[0])
+	}
+	for _, r := range rs {
+		logKafkaAsyncRecordToDatadog(r)
+	}
+	return nil
+}
+
+func (k *kafkaRecorder) newMessage(r models.EvalResult) (*sarama.ProducerMessage, error) {
+	frame := k.NewDataRecordFrame(r)
+	output, err := frame.Output()
+	if err != nil {
+		return nil, err
+	}
 	var partitionKey sarama.Encoder
 	if k.partitionKeyEnabled {
 		partitionKey = sarama.StringEncoder(frame.GetPartitionKey())
 	}
-	k.producer.Input() <- &sarama.ProducerMessage{
+	return &sarama.ProducerMessage{
 		Topic:     k.topic,
 		Key:       partitionKey,
 		Value:     sarama.ByteEncoder(output),
 		Timestamp: time.Now().UTC(),
-	}
-
-	logKafkaAsyncRecordToDatadog(r)
+	}, nil
 }
 
 var logKafkaAsyncRecordToDatadog = func(r models.EvalResult) {
@@ -2,11 +2,14 @@ package handler
 
 import (
 	"context"
+	"fmt"
 
 	producer "github.com/a8m/kinesis-producer"
 	"github.com/a8m/kinesis-producer/loggers/kplogrus"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
 	flagrConfig "github.com/openflagr/flagr/pkg/config"
 	"github.com/openflagr/flagr/swagger_gen/models"
 	"github.com/sirupsen/logrus"
@@ -16,9 +19,19 @@ var (
 	newKinesisProducer = producer.New
 )
 
+// kinesisPutRecordsMaxCount is the most records a PutRecords request takes.
+const kinesisPutRecordsMaxCount = 500
+
+// kinesisPutRecordsAPI is the part of the Kinesis client DeliverRecords uses.
+type kinesisPutRecordsAPI interface {
+	PutRecords(ctx context.Context, params *kinesis.PutRecordsInput, optFns ...func(*kinesis.Options)) (*kinesis.PutRecordsOutput, error)
+}
+
 type kinesisRecorder struct {
-	producer *producer.Producer
-	options  DataRecordFrameOptions
+	producer   *producer.Producer
+	client     kinesisPutRecordsAPI
+	streamName string
+	options    DataRecordFrameOptions
 }
 
 // NewKinesisRecorder creates a new Kinesis recorder
@@ -53,7 +66,9 @@ var NewKinesisRecorder = func() DataRecorder {
 	}()
 
 	return &kinesisRecorder{
-		producer: p,
+		producer:   p,
+		client:     client,
+		streamName: flagrConfig.Config.RecorderKinesisStreamName,
 		options: DataRecordFrameOptions{
 			Encrypted:       false, // not implemented yet
 			FrameOutputMode: flagrConfig.Config.RecorderFrameOutputMode,
@@ -80,3 +95,42 @@ func (k *kinesisRecorder) AsyncRecord(r models.EvalResult) {
 		logrus.WithField("kinesis_error", err).Error("error pushing to kinesis")
 	}
 }
+
+// DeliverRecords puts the records with PutRecords, bypassing the producer,
+// whose puts are not acknowledged. Records are not aggregated.
+func (k *kinesisRecorder) DeliverRecords(ctx context.Context, rs []models.EvalResult) error {
+	entries := make([]types.PutRecordsRequestEntry, 0, len(rs))
+	for _, r := range rs {
+		frame := k.NewDataRecordFrame(r)
+		output, err := frame.Output()
+		if err != nil {
+			logrus.WithField("err", err).Error("failed to generate data record frame for kinesis recorder")
+			continue
+		}
+		// Like Put of the producer, Kinesis takes no empty partition keys.
+		key := frame.GetPartitionKey()
+		if key == "" {
+			logrus.Error("error pushing to kinesis: record without a partition key")
+			continue
+		}
+		entries = append(entries, types.PutRecordsRequestEntry{
+			Data:         output,
+			PartitionKey: aws.String(key),
+		})
+	}
+
+	for start := 0; start < len(entries); start += kinesisPutRecordsMaxCount {
+		chunk := entries[start:min(start+kinesisPutRecordsMaxCount, len(entries))]
+		out, err := k.client.PutRecords(ctx, &kinesis.PutRecordsInput{
+			StreamName: aws.String(k.streamName),
+			Records:    chunk,
+		})
+		if err != nil {
+			return err
+		}
+		if failed := aws.ToInt32(out.FailedRecordCount); failed > 0 {
+			return fmt.Errorf("%d of %d records not acknowledged", failed, len(chunk))
+		}
+	}
+	return nil
+}
@@ -1,11 +1,16 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	producer "github.com/a8m/kinesis-producer"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
 	"github.com/openflagr/flagr/swagger_gen/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewKinesisRecorder(t *testing.T) {
@@ -51,3 +56,50 @@ func TestKinesisAsyncRecord(t *testing.T) {
 		})
 	})
 }
+
+// fakeKinesisClient records the sizes of PutRecords requests, and fails
+// the records beyond failAfter of a request.
+type fakeKinesisClient struct {
+	requests  []int
+	failAfter int
+	err       error
+}
+
+func (f *fakeKinesisClient) PutRecords(_ context.Context, in *kinesis.PutRecordsInput, _ ...func(*kinesis.Options)) (*kinesis.PutRecordsOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.requests = append(f.requests, len(in.Records))
+	failed := 0
+	if f.failAfter > 0 {
+		failed = max(len(in.Records)-f.failAfter, 0)
+	}
+	return &kinesis.PutRecordsOutput{FailedRecordCount: aws.Int32(int32(failed))}, nil
+}
+
+func TestKinesisDeliverRecords(t *testing.T) {
+	rs := make([]models.EvalResult, 0, 1201)
+	for range 1200 {
+		rs = append(rs, models.EvalResult{EvalContext: &models.EvalContext{EntityID: "d08042018"}, FlagID: 1})
+	}
+	rs = append(rs, models.EvalResult{FlagID: 1}) // no partition key
+
+	t.Run("in requests of up to 500 records", func(t *testing.T) {
+		client := &fakeKinesisClient{}
+		kr := &kinesisRecorder{client: client, streamName: "flagr-records"}
+		require.NoError(t, kr.DeliverRecords(context.Background(), rs))
+		assert.Equal(t, []int{500, 500, 200}, client.requests)
+	})
+
+	t.Run("failed records", func(t *testing.T) {
+		client := &fakeKinesisClient{failAfter: 490}
+		kr := &kinesisRecorder{client: client, streamName: "flagr-records"}
+		assert.EqualError(t, kr.DeliverRecords(context.Background(), rs), "10 of 500 records not acknowledged")
+		assert.Equal(t, []int{500}, client.requests)
+	})
+
+	t.Run("failed request", func(t *testing.T) {
+		kr := &kinesisRecorder{client: &fakeKinesisClient{err: errors.New("throttled")}}
+		assert.EqualError(t, kr.DeliverRecords(context.Background(), rs), "throttled")
+	})
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/openflagr/flagr/pkg/config"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	natsConnect = nats.Connect
+)
+
+// natsRecordStallWait is how long AsyncRecord waits for a slot when
+// FLAGR_RECORDER_NATS_MAX_PENDING records wait for an ack, before it drops
+// the record rather than hold up the evaluation.
+const natsRecordStallWait = time.Millisecond
+
+type natsRecorder struct {
+	conn            *nats.Conn
+	js              jetstream.JetStream
+	subject         string
+	publishOpts     []jetstream.PublishOpt
+	shutdownTimeout time.Duration
+	options         DataRecordFrameOptions
+}
+
+// NewNATSRecorder creates a new NATS JetStream recorder
+var NewNATSRecorder = func() DataRecorder {
+	opts := []nats.Option{
+		nats.Name("flagr"),
+		// Keep reconnecting, and start while the servers are down.
+		nats.MaxReconnects(-1),
+		nats.RetryOnFailedConnect(true),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			if err != nil {
+				logrus.WithField("nats_error", err).Warn("disconnected from nats")
+			}
+		}),
+		nats.ReconnectHandler(func(nc *nats.Conn) {
+			logrus.WithField("url", nc.ConnectedUrlRedacted()).Info("reconnected to nats")
+		}),
+	}
+	if config.Config.RecorderNATSCredsFile != "" {
+		opts = append(opts, nats.UserCredentials(config.Config.RecorderNATSCredsFile))
+	}
+	tlscfg := createTLSConfiguration(
+		config.Config.RecorderNATSCertFile,
+		config.Config.RecorderNATSKeyFile,
+		config.Config.RecorderNATSCAFile,
+		config.Config.RecorderNATSVerifySSL,
+		config.Config.RecorderNATSSimpleSSL,
+	)
+	if tlscfg != nil {
+		opts = append(opts, nats.Secure(tlscfg))
+	}
+
+	nc, err := natsConnect(config.Config.RecorderNATSURL, opts...)
+	if err != nil {
+		logrus.WithField("nats_error", err).Fatal("error connecting to nats")
+	}
+	js, err := jetstream.New(nc,
+		jetstream.WithPublishAsyncMaxPending(max(config.Config.RecorderNATSMaxPending, 1)),
+		jetstream.WithPublishAsyncTimeout(config.Config.RecorderNATSAckTimeout),
+		// We will just log if the servers don't acknowledge a record.
+		jetstream.WithPublishAsyncErrHandler(func(_ jetstream.JetStream, msg *nats.Msg, err error) {
+			logrus.WithFields(logrus.Fields{"nats_error": err, "subject": msg.Subject}).Error("error publishing to nats")
+		}),
+	)
+	if err != nil {
+		logrus.WithField("nats_error", err).Fatal("error creating nats jetstream context")
+	}
+
+	var publishOpts []jetstream.PublishOpt
+	if config.Config.RecorderNATSStream != "" {
+		publishOpts = append(publishOpts, jetstream.WithExpectStream(config.Config.RecorderNATSStream))
+	}
+
+	var encryptor dataRecordEncryptor
+	if config.Config.RecorderNATSEncrypted && config.Config.RecorderNATSEncryptionKey != "" {
+		encryptor = newSimpleboxEncryptor(config.Config.RecorderNATSEncryptionKey)
+	}
+
+	return &natsRecorder{
+		conn:            nc,
+		js:              js,
+		subject:         config.Config.RecorderNATSSubject,
+		publishOpts:     publishOpts,
+		shutdownTimeout: config.Config.RecorderNATSShutdownTimeout,
+		options: DataRecordFrameOptions{
+			Encrypted:       config.Config.RecorderNATSEncrypted,
+			Encryptor:       encryptor,
+			FrameOutputMode: config.Config.RecorderFrameOutputMode,
+		},
+	}
+}
+
+func (n *natsRecorder) NewDataRecordFrame(r models.EvalResult) DataRecordFrame {
+	return DataRecordFrame{
+		evalResult: r,
+		options:    n.options,
+	}
+}
+
+func (n *natsRecorder) AsyncRecord(r models.EvalResult) {
+	_, err := n.publish(r, jetstream.WithStallWait(natsRecordStallWait))
+	if errors.Is(err, jetstream.ErrTooManyStalledMsgs) {
+		logrus.WithField("nats_error", err).Debug("dropped data record, too many pending nats publishes")
+		if config.Global.Prometheus.RecorderDropped != nil {
+			config.Global.Prometheus.RecorderDropped.WithLabelValues("nats").Inc()
+		}
+		return
+	}
+	if err != nil {
+		logrus.WithField("nats_error", err).Error("failed to publish data record to nats")
+	}
+}
+
+// DeliverRecords publishes the records and waits for the servers to
+// acknowledge them.
+func (n *natsRecorder) DeliverRecords(ctx context.Context, rs []models.EvalResult) error {
+	futures := make([]jetstream.PubAckFuture, 0, len(rs))
+	for _, r := range rs {
+		f, err := n.publish(r)
+		if err != nil {
+			return err
+		}
+		futures = append(futures, f)
+	}
+
+	var errs []error
+	for _, f := range futures {
+		select {
+		case <-f.Ok():
+		case err := <-f.Err():
+			errs = append(errs, err)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d records not acknowledged: %w", len(errs), len(futures), errs[0])
+	}
+	return nil
+}
+
+// Close waits up to FLAGR_RECORDER_NATS_SHUTDOWN_TIMEOUT for the pending
+// publishes to be acknowledged, and closes the connection.
+func (n *natsRecorder) Close() error {
+	defer n.conn.Close()
+	select {
+	case <-n.js.PublishAsyncComplete():
+		return nil
+	case <-time.After(n.shutdownTimeout):
+		return fmt.Errorf("%d records published to nats not acknowledged", n.js.PublishAsyncPending())
+	}
+}
+
+func (n *natsRecorder) publish(r models.EvalResult, opts ...jetstream.PublishOpt) (jetstream.PubAckFuture, error) {
+	frame := n.NewDataRecordFrame(r)
+	output, err := frame.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate data record frame for nats recorder: %w", err)
+	}
+	msg := &nats.Msg{Subject: natsSubject(n.subject, r), Data: output}
+	return n.js.PublishMsgAsync(msg, append(opts, n.publishOpts...)...)
+}
+
+// natsSubjectToken escapes the characters that NATS reserves in subjects.
+var natsSubjectToken = strings.NewReplacer(".", "_", "*", "_", ">", "_", " ", "_", "\t", "_")
+
+// natsSubject fills in the placeholders of the subject template. A flag key
+// is a single token of the subject, e.g. flagr.records.{flagKey} publishes
+// the records of "checkout.v2" to flagr.records.checkout_v2.
+func natsSubject(tmpl string, r models.EvalResult) string {
+	token := func(s string) string {
+		if s == "" {
+			return "_"
+		}
+		return natsSubjectToken.Replace(s)
+	}
+	return strings.NewReplacer(
+		"{flagKey}", token(r.FlagKey),
+		"{flagID}", strconv.FormatInt(r.FlagID, 10),
+		"{recordSource}", token(r.RecordSource),
+	).Replace(tmpl)
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	natstest "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/openflagr/flagr/pkg/config"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/prashantv/gostub"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runNATSServer starts an embedded NATS server with a FLAGR stream of the
+// flagr.records.> subjects.
+func runNATSServer(t *testing.T) (*server.Server, jetstream.Stream) {
+	t.Helper()
+	opts := natstest.DefaultTestOptions
+	opts.Port = -1
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	s := natstest.RunServer(&opts)
+	t.Cleanup(s.Shutdown)
+
+	nc, err := nats.Connect(s.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(nc.Close)
+	js, err := jetstream.New(nc)
+	require.NoError(t, err)
+	stream, err := js.CreateStream(context.Background(), jetstream.StreamConfig{
+		Name:     "FLAGR",
+		Subjects: []string{"flagr.records.>"},
+	})
+	require.NoError(t, err)
+	return s, stream
+}
+
+func stubNATSConfig(t *testing.T, s *server.Server) {
+	t.Helper()
+	stubs := gostub.Stub(&config.Config.RecorderNATSURL, s.ClientURL())
+	stubs.Stub(&config.Config.RecorderNATSStream, "FLAGR")
+	stubs.Stub(&config.Config.RecorderNATSShutdownTimeout, time.Second)
+	t.Cleanup(stubs.Reset)
+}
+
+func TestNewNATSRecorder(t *testing.T) {
+	s, stream := runNATSServer(t)
+	stubNATSConfig(t, s)
+	ctx := context.Background()
+	r := models.EvalResult{
+		EvalContext: &models.EvalContext{EntityID: "d08042018"},
+		FlagID:      1,
+		FlagKey:     "checkout.v2",
+		VariantID:   1,
+		VariantKey:  "control",
+	}
+
+	t.Run("publishes frames by flag key", func(t *testing.T) {
+		rec := NewNATSRecorder()
+		rec.AsyncRecord(r)
+		require.NoError(t, rec.(*natsRecorder).Close())
+
+		msg, err := stream.GetLastMsgForSubject(ctx, "flagr.records.checkout_v2")
+		require.NoError(t, err)
+		frame := rec.NewDataRecordFrame(r)
+		output, err := frame.Output()
+		require.NoError(t, err)
+		assert.Equal(t, output, msg.Data)
+	})
+
+	t.Run("encrypted", func(t *testing.T) {
+		defer gostub.Stub(&config.Config.RecorderNATSEncrypted, true).
+			Stub(&config.Config.RecorderNATSEncryptionKey, "fake_key").Reset()
+		rec := NewNATSRecorder()
+		rec.AsyncRecord(r)
+		require.NoError(t, rec.(*natsRecorder).Close())
+
+		msg, err := stream.GetLastMsgForSubject(ctx, "flagr.records.checkout_v2")
+		require.NoError(t, err)
+		var frame struct {
+			Payload   string `json:"payload"`
+			Encrypted bool   `json:"encrypted"`
+		}
+		require.NoError(t, json.Unmarshal(msg.Data, &frame))
+		assert.True(t, frame.Encrypted)
+		assert.NotContains(t, frame.Payload, "d08042018")
+	})
+}
+
+func TestNATSAsyncRecordDropsWhenStalled(t *testing.T) {
+	s, _ := runNATSServer(t)
+	stubNATSConfig(t, s)
+	defer gostub.Stub(&config.Config.RecorderNATSMaxPending, 1).Reset()
+	dropped := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "dropped"}, []string{"recorder"})
+	defer gostub.Stub(&config.Global.Prometheus.RecorderDropped, dropped).Reset()
+
+	rec := NewNATSRecorder().(*natsRecorder)
+	defer rec.Close()
+	// Without a server no publish is acked, so the first one stays pending.
+	s.Shutdown()
+	start := time.Now()
+	rec.AsyncRecord(models.EvalResult{FlagID: 1, FlagKey: "a"})
+	rec.AsyncRecord(models.EvalResult{FlagID: 1, FlagKey: "a"})
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+	assert.Equal(t, float64(1), testutil.ToFloat64(dropped.WithLabelValues("nats")))
+}
+
+func TestNATSDeliverRecords(t *testing.T) {
+	s, stream := runNATSServer(t)
+	stubNATSConfig(t, s)
+	ctx := context.Background()
+	rs := []models.EvalResult{{FlagID: 1, FlagKey: "a"}, {FlagID: 2, FlagKey: "b"}}
+
+	rec := NewNATSRecorder().(*natsRecorder)
+	defer rec.Close()
+	require.NoError(t, rec.DeliverRecords(ctx, rs))
+	info, err := stream.Info(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), info.State.Msgs)
+
+	t.Run("not acknowledged by the expected stream", func(t *testing.T) {
+		defer gostub.Stub(&config.Config.RecorderNATSStream, "OTHER").Reset()
+		rec := NewNATSRecorder().(*natsRecorder)
+		defer rec.Close()
+		err := rec.DeliverRecords(ctx, rs)
+		assert.ErrorContains(t, err, "2 of 2 records not acknowledged")
+	})
+
+	t.Run("no stream for the subject", func(t *testing.T) {
+		defer gostub.Stub(&config.Config.RecorderNATSSubject, "elsewhere.{flagKey}").Reset()
+		rec := NewNATSRecorder().(*natsRecorder)
+		defer rec.Close()
+		ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		assert.Error(t, rec.DeliverRecords(ctx, rs))
+	})
+}
+
+func TestNATSSubject(t *testing.T) {
+	r := models.EvalResult{FlagID: 42, FlagKey: "new.checkout *>", RecordSource: models.EvalResultRecordSourceExposure}
+	assert.Equal(t, "flagr.records.new_checkout___", natsSubject("flagr.records.{flagKey}", r))
+	assert.Equal(t, "flagr.exposure.42", natsSubject("flagr.{recordSource}.{flagID}", r))
+	assert.Equal(t, "flagr._.0", natsSubject("flagr.{flagKey}.{flagID}", models.EvalResult{}))
+	assert.Equal(t, "flagr-records", natsSubject("flagr-records", r))
+}
+
+func TestGetDataRecorderWhenNATSIsSet(t *testing.T) {
+	singletonDataRecorderOnce = sync.Once{}
+	defer gostub.StubFunc(&NewNATSRecorder, nil).Reset()
+	config.Config.RecorderType = []string{"nats"}
+
+	assert.NotPanics(t, func() {
+		GetDataRecorder()
+	})
+
+	config.Config.RecorderType = []string{"kafka"}
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"fmt"
 
 	"cloud.google.com/go/pubsub/v2"
 	"github.com/openflagr/flagr/pkg/config"
@@ -70,3 +71,28 @@ func (p *pubsubRecorder) AsyncRecord(r models.EvalResult) {
 		}()
 	}
 }
+
+// DeliverRecords publishes the records and waits for the server to
+// acknowledge them.
+func (p *pubsubRecorder) DeliverRecords(ctx context.Context, rs []models.EvalResult) error {
+	results := make([]*pubsub.PublishResult, 0, len(rs))
+	for _, r := range rs {
+		frame := p.NewDataRecordFrame(r)
+		output, err := frame.Output()
+		if err != nil {
+			logrus.WithField("err", err).Error("failed to generate data record frame for pubsub recorder")
+			continue
+		}
+		results = append(results, p.publisher.Publish(ctx, &pubsub.Message{Data: output}))
+	}
+	var errs []error
+	for _, res := range results {
+		if _, err := res.Get(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d records not acknowledged: %w", len(errs), len(results), errs[0])
+	}
+	return nil
+}
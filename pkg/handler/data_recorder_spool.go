@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/openflagr/flagr/pkg/config"
+	"github.com/openflagr/flagr/pkg/spool"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/sirupsen/logrus"
+)
+
+// Backoff of deliveries from a spool while the sink is unavailable.
+const (
+	spoolMinBackoff = 100 * time.Millisecond
+	spoolMaxBackoff = 30 * time.Second
+)
+
+// dataRecordDeliverer is a DataRecorder that can tell when its sink took
+// records. A spool keeps records until they are delivered, so only these
+// recorders are spooled.
+type dataRecordDeliverer interface {
+	DeliverRecords(ctx context.Context, rs []models.EvalResult) error
+}
+
+// dataRecordSealer encrypts the spooled records of an encrypted recorder with
+// its key, see simpleboxEncryptor.
+type dataRecordSealer interface {
+	Seal([]byte) []byte
+	Open([]byte) ([]byte, error)
+}
+
+// spoolRecorder writes the records of a DataRecorder ahead to a spool on disk,
+// and delivers them from there in order, so that records outlive an
+// unavailable sink and restarts. Records are delivered at least once.
+type spoolRecorder struct {
+	name   string // recorder type, the label of the spool metrics
+	rec    DataRecorder
+	spool  *spool.Spool
+	sealer dataRecordSealer // nil unless rec is encrypted
+
+	batchSize       int
+	deliveryTimeout time.Duration
+	shutdownTimeout time.Duration
+
+	closeOnce sync.Once
+	closeCh   chan struct{}
+	done      chan struct{}
+}
+
+// newSpoolRecorder wraps rec in a spool in a subdirectory of
+// FLAGR_RECORDER_SPOOL_DIR. Records of an encrypted recorder are encrypted in
+// the spool too. rec is returned as is when it can't acknowledge records, when
+// its encryption can't encrypt the spool, or when the spool can't be opened.
+var newSpoolRecorder = func(name string, rec DataRecorder) DataRecorder {
+	if _, ok := rec.(dataRecordDeliverer); !ok {
+		logrus.WithField("recorder", name).Warn("recorder does not acknowledge records, recording without a spool")
+		return rec
+	}
+	var sealer dataRecordSealer
+	if opts := rec.NewDataRecordFrame(models.EvalResult{}).options; opts.Encrypted && opts.Encryptor != nil {
+		var ok bool
+		if sealer, ok = opts.Encryptor.(dataRecordSealer); !ok {
+			logrus.WithField("recorder", name).Warn("recorder encryption can't encrypt the spool, recording without a spool")
+			return rec
+		}
+	}
+	sp, err := spool.Open(filepath.Join(config.Config.RecorderSpoolDir, name), spool.Options{
+		SegmentBytes: config.Config.RecorderSpoolSegmentBytes,
+		MaxBytes:     config.Config.RecorderSpoolMaxBytes,
+		MaxAge:       config.Config.RecorderSpoolMaxAge,
+		SyncInterval: config.Config.RecorderSpoolSyncInterval,
+		OnDrop: func(reason string, records int) {
+			countSpoolDropped(name, reason, records)
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).WithField("recorder", name).Error("failed to open the spool, recording without it")
+		return rec
+	}
+	s := &spoolRecorder{
+		name:            name,
+		rec:             rec,
+		spool:           sp,
+		sealer:          sealer,
+		batchSize:       max(config.Config.RecorderSpoolBatchSize, 1),
+		deliveryTimeout: config.Config.RecorderSpoolDeliveryTimeout,
+		shutdownTimeout: config.Config.RecorderSpoolShutdownTimeout,
+		closeCh:         make(chan struct{}),
+		done:            make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *spoolRecorder) NewDataRecordFrame(r models.EvalResult) DataRecordFrame {
+	return s.rec.NewDataRecordFrame(r)
+}
+
+func (s *spoolRecorder) AsyncRecord(r models.EvalResult) {
+	b, err := r.MarshalBinary()
+	if err == nil {
+		if s.sealer != nil {
+			b = s.sealer.Seal(b)
+		}
+		err = s.spool.Append(b)
+	}
+	if err != nil {
+		logrus.WithError(err).WithField("recorder", s.name).Error("failed to spool data record")
+		countSpoolDropped(s.name, "error", 1)
+	}
+}
+
+// Close stops accepting records, delivers the spooled records for up to
+// FLAGR_RECORDER_SPOOL_SHUTDOWN_TIMEOUT and syncs the rest to disk, to be
+// delivered after a restart. The wrapped recorder is closed last.
+func (s *spoolRecorder) Close() error {
+	s.closeOnce.Do(func() { close(s.closeCh) })
+	<-s.done
+	err := s.spool.Close()
+	if c, ok := s.rec.(interface{ Close() error }); ok {
+		err = errors.Join(err, c.Close())
+	}
+	return err
+}
+
+// run delivers the spooled records in batches, until Close.
+func (s *spoolRecorder) run() {
+	defer close(s.done)
+	var (
+		backoff  time.Duration
+		failing  bool
+		shutdown context.Context
+	)
+	for {
+		s.updateStats()
+		closing := shutdown != nil
+		if !closing {
+			select {
+			case <-s.closeCh:
+				var cancel context.CancelFunc
+				shutdown, cancel = context.WithTimeout(context.Background(), s.shutdownTimeout)
+				defer cancel()
+				closing = true
+			default:
+			}
+		}
+
+		records, err := s.spool.Peek(s.batchSize)
+		if err != nil {
+			logrus.WithError(err).WithField("recorder", s.name).Error("failed to read the spool")
+			if closing {
+				return
+			}
+			s.wait(time.Second)
+			continue
+		}
+		if len(records) == 0 {
+			if closing {
+				return
+			}
+			select {
+			case <-s.spool.Notify():
+			case <-s.closeCh:
+			}
+			continue
+		}
+
+		rs := make([]models.EvalResult, 0, len(records))
+		for _, b := range records {
+			var r models.EvalResult
+			if err := s.unmarshal(b, &r); err != nil {
+				logrus.WithError(err).WithField("recorder", s.name).Error("dropping an unreadable spooled data record")
+				countSpoolDropped(s.name, "unreadable", 1)
+				continue
+			}
+			rs = append(rs, r)
+		}
+
+		ctx := shutdown
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		if err := s.deliver(ctx, rs); err != nil {
+			if config.Global.Prometheus.SpoolDeliveryFailures != nil {
+				config.Global.Prometheus.SpoolDeliveryFailures.WithLabelValues(s.name).Inc()
+			}
+			if !failing {
+				logrus.WithError(err).WithField("recorder", s.name).Warn("failed to deliver spooled data records, retrying")
+				failing = true
+			}
+			if closing {
+				logrus.WithField("recorder", s.name).WithField("records", s.spool.Stats().Records).
+					Warn("keeping undelivered data records in the spool until the restart")
+				return
+			}
+			backoff = min(max(2*backoff, spoolMinBackoff), spoolMaxBackoff)
+			s.wait(backoff)
+			continue
+		}
+		s.spool.Ack(len(records))
+		if failing {
+			logrus.WithField("recorder", s.name).Info("delivering spooled data records again")
+			failing = false
+		}
+		backoff = 0
+	}
+}
+
+// unmarshal reads a spooled record, decrypting it if the recorder is
+// encrypted. Records spooled with another key are unreadable.
+func (s *spoolRecorder) unmarshal(b []byte, r *models.EvalResult) error {
+	if s.sealer != nil {
+		var err error
+		if b, err = s.sealer.Open(b); err != nil {
+			return err
+		}
+	}
+	return r.UnmarshalBinary(b)
+}
+
+// deliver delivers a batch to the wrapped recorder within the delivery timeout.
+func (s *spoolRecorder) deliver(ctx context.Context, rs []models.EvalResult) error {
+	if s.deliveryTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.deliveryTimeout)
+		defer cancel()
+	}
+	return s.rec.(dataRecordDeliverer).DeliverRecords(ctx, rs)
+}
+
+// wait sleeps for d, or until Close.
+func (s *spoolRecorder) wait(d time.Duration) {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-s.closeCh:
+	}
+}
+
+func (s *spoolRecorder) updateStats() {
+	if config.Global.Prometheus.SpoolRecords == nil {
+		return
+	}
+	st := s.spool.Stats()
+	config.Global.Prometheus.SpoolRecords.WithLabelValues(s.name).Set(float64(st.Records))
+	config.Global.Prometheus.SpoolBytes.WithLabelValues(s.name).Set(float64(st.Bytes))
+}
+
+func countSpoolDropped(name, reason string, records int) {
+	if config.Global.Prometheus.SpoolDropped != nil {
+		config.Global.Prometheus.SpoolDropped.WithLabelValues(name, reason).Add(float64(records))
+	}
+}
+
+// Close closes the recorders that hold resources, such as spools.
+func (f fanOutRecorder) Close() error {
+	var errs []error
+	for _, rec := range f {
+		if c, ok := rec.(interface{ Close() error }); ok {
+			errs = append(errs, c.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/openflagr/flagr/pkg/config"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDeliverer records the flag IDs of the records delivered to it, and
+// fails while down.
+type fakeDeliverer struct {
+	mu        sync.Mutex
+	down      bool
+	delivered []int64
+}
+
+func (f *fakeDeliverer) AsyncRecord(r models.EvalResult) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.delivered = append(f.delivered, r.FlagID)
+}
+
+func (f *fakeDeliverer) NewDataRecordFrame(r models.EvalResult) DataRecordFrame {
+	return DataRecordFrame{evalResult: r}
+}
+
+func (f *fakeDeliverer) DeliverRecords(_ context.Context, rs []models.EvalResult) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.down {
+		return errors.New("sink is down")
+	}
+	for _, r := range rs {
+		f.delivered = append(f.delivered, r.FlagID)
+	}
+	return nil
+}
+
+func (f *fakeDeliverer) setDown(down bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.down = down
+}
+
+func (f *fakeDeliverer) flagIDs() []int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]int64(nil), f.delivered...)
+}
+
+func stubSpoolConfig(t *testing.T) {
+	t.Helper()
+	stubs := gostub.Stub(&config.Config.RecorderSpoolDir, t.TempDir())
+	stubs.Stub(&config.Config.RecorderSpoolSegmentBytes, int64(256))
+	stubs.Stub(&config.Config.RecorderSpoolShutdownTimeout, time.Second)
+	t.Cleanup(stubs.Reset)
+}
+
+func TestSpoolRecorder(t *testing.T) {
+	stubSpoolConfig(t)
+
+	t.Run("delivers in order once the sink is back", func(t *testing.T) {
+		sink := &fakeDeliverer{down: true}
+		rec := newSpoolRecorder("fake", sink)
+		s, ok := rec.(*spoolRecorder)
+		require.True(t, ok)
+
+		for id := range int64(20) {
+			rec.AsyncRecord(models.EvalResult{FlagID: id})
+		}
+		assert.Eventually(t, func() bool { return s.spool.Stats().Records == 20 }, time.Second, 10*time.Millisecond)
+		assert.Empty(t, sink.flagIDs())
+
+		sink.setDown(false)
+		assert.Eventually(t, func() bool { return len(sink.flagIDs()) == 20 }, 5*time.Second, 10*time.Millisecond)
+		for i, id := range sink.flagIDs() {
+			assert.Equal(t, int64(i), id)
+		}
+		require.NoError(t, s.Close())
+		assert.Equal(t, int64(0), s.spool.Stats().Records)
+	})
+
+	t.Run("keeps records across restarts", func(t *testing.T) {
+		sink := &fakeDeliverer{down: true}
+		rec := newSpoolRecorder("restart", sink)
+		for id := range int64(3) {
+			rec.AsyncRecord(models.EvalResult{FlagID: id})
+		}
+		require.NoError(t, fanOutRecorder{rec}.Close(), "gives up delivering on shutdown")
+		rec.AsyncRecord(models.EvalResult{FlagID: 99})
+
+		sink = &fakeDeliverer{}
+		rec = newSpoolRecorder("restart", sink)
+		require.NoError(t, rec.(*spoolRecorder).Close(), "delivers on shutdown")
+		assert.Equal(t, []int64{0, 1, 2}, sink.flagIDs())
+	})
+
+	t.Run("recorders without acknowledgements are not spooled", func(t *testing.T) {
+		sink := &fakeAsyncRecorder{}
+		rec := newSpoolRecorder("async", sink)
+		assert.Same(t, sink, rec)
+	})
+}
+
+// fakeEncryptedDeliverer is a fakeDeliverer with encrypted frames.
+type fakeEncryptedDeliverer struct {
+	fakeDeliverer
+	encryptor dataRecordEncryptor
+}
+
+func (f *fakeEncryptedDeliverer) NewDataRecordFrame(r models.EvalResult) DataRecordFrame {
+	return DataRecordFrame{evalResult: r, options: DataRecordFrameOptions{Encrypted: true, Encryptor: f.encryptor}}
+}
+
+type encodeOnlyEncryptor struct{}
+
+func (encodeOnlyEncryptor) Encrypt(b []byte) (string, error) { return string(b), nil }
+
+func TestSpoolRecorderEncrypted(t *testing.T) {
+	stubSpoolConfig(t)
+	r := models.EvalResult{FlagID: 1, EvalContext: &models.EvalContext{EntityID: "d08042018"}}
+
+	t.Run("encrypts the spooled records", func(t *testing.T) {
+		sink := &fakeEncryptedDeliverer{encryptor: newSimpleboxEncryptor("fake_key")}
+		sink.setDown(true)
+		rec := newSpoolRecorder("encrypted", sink)
+		rec.AsyncRecord(r)
+		require.NoError(t, rec.(*spoolRecorder).Close())
+
+		segments, err := filepath.Glob(filepath.Join(config.Config.RecorderSpoolDir, "encrypted", "*"))
+		require.NoError(t, err)
+		require.NotEmpty(t, segments)
+		for _, name := range segments {
+			b, err := os.ReadFile(name)
+			require.NoError(t, err)
+			assert.NotContains(t, string(b), "d08042018")
+		}
+
+		sink.setDown(false)
+		rec = newSpoolRecorder("encrypted", sink)
+		require.NoError(t, rec.(*spoolRecorder).Close())
+		assert.Equal(t, []int64{1}, sink.flagIDs())
+	})
+
+	t.Run("records of another key are dropped", func(t *testing.T) {
+		sink := &fakeEncryptedDeliverer{encryptor: newSimpleboxEncryptor("old_key")}
+		sink.setDown(true)
+		rec := newSpoolRecorder("rotated", sink)
+		rec.AsyncRecord(r)
+		require.NoError(t, rec.(*spoolRecorder).Close())
+
+		sink = &fakeEncryptedDeliverer{encryptor: newSimpleboxEncryptor("new_key")}
+		rec = newSpoolRecorder("rotated", sink)
+		require.NoError(t, rec.(*spoolRecorder).Close())
+		assert.Empty(t, sink.flagIDs())
+	})
+
+	t.Run("encryption that can't encrypt the spool", func(t *testing.T) {
+		sink := &fakeEncryptedDeliverer{encryptor: encodeOnlyEncryptor{}}
+		assert.Same(t, sink, newSpoolRecorder("encode_only", sink))
+	})
+}
+
+type fakeAsyncRecorder struct {
+	flagIDs []int64
+}
+
+func (f *fakeAsyncRecorder) AsyncRecord(r models.EvalResult) {
+	f.flagIDs = append(f.flagIDs, r.FlagID)
+}
+
+func (f *fakeAsyncRecorder) NewDataRecordFrame(r models.EvalResult) DataRecordFrame {
+	return DataRecordFrame{evalResult: r}
+}
+
+func TestKafkaDeliverRecords(t *testing.T) {
+	p := &mockAsyncProducer{inputCh: make(chan *sarama.ProducerMessage)}
+	kr := &kafkaRecorder{producer: p, topic: "test-topic", acks: true}
+
+	// The brokers acknowledge the first record and fail the second.
+	go func() {
+		(<-p.inputCh).Metadata.(chan error) <- nil
+		(<-p.inputCh).Metadata.(chan error) <- sarama.ErrNotEnoughReplicas
+	}()
+	err := kr.DeliverRecords(context.Background(), []models.EvalResult{{FlagID: 1}, {FlagID: 2}})
+	assert.ErrorIs(t, err, sarama.ErrNotEnoughReplicas)
+
+	go func() {
+		for range 2 {
+			(<-p.inputCh).Metadata.(chan error) <- nil
+		}
+	}()
+	assert.NoError(t, kr.DeliverRecords(context.Background(), []models.EvalResult{{FlagID: 1}, {FlagID: 2}}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, kr.DeliverRecords(ctx, []models.EvalResult{{FlagID: 1}}), context.DeadlineExceeded)
+}
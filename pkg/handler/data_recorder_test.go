@@ -7,6 +7,7 @@ import (
 
 	"github.com/openflagr/flagr/pkg/config"
 	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/pkg/evaluator"
 	"github.com/openflagr/flagr/swagger_gen/models"
 
 	"github.com/prashantv/gostub"
@@ -46,6 +47,24 @@ func TestGetDataRecorderWhenPubsubIsSet(t *testing.T) {
 	config.Config.RecorderType = []string{"kafka"}
 }
 
+func TestGetDataRecorderWhenSpoolIsSet(t *testing.T) {
+	singletonDataRecorderOnce = sync.Once{}
+	defer gostub.StubFunc(&NewPubsubRecorder, &fakeDeliverer{}).Reset()
+	defer gostub.Stub(&config.Config.RecorderSpoolTypes, []string{"pubsub"}).Reset()
+	defer gostub.Stub(&config.Config.RecorderSpoolDir, t.TempDir()).Reset()
+	defer gostub.StubFunc(&NewKinesisRecorder, &mockRecorder{}).Reset()
+	defer gostub.Stub(&config.Config.RecorderEnabled, true).Reset()
+	config.Config.RecorderType = []string{"pubsub", "kinesis"}
+	defer func() { config.Config.RecorderType = []string{"kafka"} }()
+
+	f, ok := GetDataRecorder().(fanOutRecorder)
+	assert.True(t, ok)
+	assert.Len(t, f, 2)
+	assert.IsType(t, &spoolRecorder{}, f[0])
+	assert.IsType(t, &mockRecorder{}, f[1])
+	assert.NoError(t, f.Close())
+}
+
 func TestGetDataRecorderPanicsWhenRecorderIsInvalid(t *testing.T) {
 	singletonDataRecorderOnce = sync.Once{}
 	config.Config.RecorderType = []string{"invalid"}
@@ -103,6 +122,42 @@ func TestRecordCountsTowardDatar(t *testing.T) {
 	}))
 }
 
+func TestRecordAssignsDatarVariant(t *testing.T) {
+	evaluation := models.EvalResult{RecordSource: models.EvalResultRecordSourceEvaluation}
+	exposure := models.EvalResult{RecordSource: models.EvalResultRecordSourceExposure}
+	assert.True(t, recordAssignsDatarVariant(evaluation))
+	assert.True(t, recordAssignsDatarVariant(exposure))
+
+	defer gostub.Stub(&config.Config.RecorderDatarAssignmentSource, "exposure").Reset()
+	assert.False(t, recordAssignsDatarVariant(evaluation))
+	assert.True(t, recordAssignsDatarVariant(exposure))
+}
+
+func TestDatarDimensions(t *testing.T) {
+	evaluation := models.EvalResult{EvalContext: &models.EvalContext{EntityType: "user"}}
+	exposure := models.EvalResult{RecordSource: models.EvalResultRecordSourceExposure}
+
+	_, _, ok := datarDimensions(evaluation)
+	assert.False(t, ok, "no dimensions are tracked by default")
+
+	defer gostub.Stub(&config.Config.RecorderDatarDimensions, []string{"entity_type"}).Reset()
+	source, entityType, ok := datarDimensions(evaluation)
+	assert.True(t, ok)
+	assert.Equal(t, "", source)
+	assert.Equal(t, "user", entityType)
+	_, _, ok = datarDimensions(exposure)
+	assert.False(t, ok, "exposures need the record_source dimension")
+
+	config.Config.RecorderDatarDimensions = []string{"record_source"}
+	source, entityType, ok = datarDimensions(evaluation)
+	assert.True(t, ok)
+	assert.Equal(t, models.EvalResultRecordSourceEvaluation, source)
+	assert.Equal(t, "", entityType)
+	source, _, ok = datarDimensions(exposure)
+	assert.True(t, ok)
+	assert.Equal(t, models.EvalResultRecordSourceExposure, source)
+}
+
 func TestRecordCountsTowardEvalKafkaStatsd(t *testing.T) {
 	assert.True(t, recordCountsTowardEvalKafkaStatsd(models.EvalResult{
 		RecordSource: models.EvalResultRecordSourceEvaluation,
@@ -194,6 +249,68 @@ func TestDatarRecorder_AsyncRecord(t *testing.T) {
 	assert.Equal(t, 2, d.Len(), "2 distinct keys")
 }
 
+func TestDatarRecorder_CountsEntities(t *testing.T) {
+	defer ResetDatar()
+
+	defer gostub.Stub(&config.Config.RecorderType, []string{"datar"}).Reset()
+	defer gostub.Stub(&config.Config.RecorderEnabled, true).Reset()
+
+	db := entity.NewTestDB()
+	defer gostub.StubFunc(&getDB, db).Reset()
+	assert.NoError(t, db.AutoMigrate(entity.AutoMigrateTables...))
+
+	r := NewDatarRecorder()
+	for _, id := range []string{"a", "a", "b", ""} {
+		r.AsyncRecord(models.EvalResult{FlagID: 1, VariantID: 10, SegmentID: 20, EvalContext: &models.EvalContext{EntityID: id}})
+	}
+	r.AsyncRecord(models.EvalResult{FlagID: 1, VariantID: 10, SegmentID: 20})
+
+	d := GetDatar()
+	assert.NoError(t, d.Shutdown())
+	summary, err := d.QueryFlagSummaryBreakdown(1, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), summary.UniqueEntities)
+	if assert.Len(t, summary.Variants, 1) {
+		assert.Equal(t, int64(5), summary.Variants[0].Count)
+		assert.Equal(t, int64(2), summary.Variants[0].UniqueEntities)
+	}
+}
+
+func TestDatarRecorder_SkipsGeneratedEntityIDs(t *testing.T) {
+	defer ResetDatar()
+
+	defer gostub.Stub(&config.Config.RecorderType, []string{"datar"}).Reset()
+	defer gostub.Stub(&config.Config.RecorderEnabled, true).Reset()
+
+	db := entity.NewTestDB()
+	defer gostub.StubFunc(&getDB, db).Reset()
+	assert.NoError(t, db.AutoMigrate(entity.AutoMigrateTables...))
+
+	f := entity.GenFixtureFlag()
+	assert.NoError(t, f.PrepareEvaluation())
+	r := NewDatarRecorder()
+	e := evaluator.Evaluator{Record: func(res *models.EvalResult, _ *entity.Flag) { r.AsyncRecord(*res) }}
+	for range 10 {
+		res := e.Evaluate(&f, models.EvalContext{EntityContext: map[string]any{"dl_state": "CA"}})
+		assert.True(t, evaluator.IsGeneratedEntityID(res.EvalContext.EntityID))
+	}
+
+	d := GetDatar()
+	assert.NoError(t, d.Shutdown())
+	summary, err := d.QueryFlagSummaryBreakdown(int64(f.ID), time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), summary.UniqueEntities)
+	var count int64
+	for _, v := range summary.Variants {
+		count += v.Count
+	}
+	assert.Equal(t, int64(10), count)
+
+	var assignments int64
+	assert.NoError(t, db.Model(&entity.Assignment{}).Count(&assignments).Error)
+	assert.Zero(t, assignments)
+}
+
 func TestDatarRecorder_SkipsExposure(t *testing.T) {
 	defer ResetDatar()
 	defer gostub.Stub(&config.Config.RecorderType, []string{"datar"}).Reset()
@@ -217,6 +334,37 @@ func TestDatarRecorder_SkipsExposure(t *testing.T) {
 	assert.Equal(t, 1, GetDatar().Len())
 }
 
+func TestDatarRecorder_Dimensions(t *testing.T) {
+	defer ResetDatar()
+	defer gostub.Stub(&config.Config.RecorderType, []string{"datar"}).Reset()
+	defer gostub.Stub(&config.Config.RecorderEnabled, true).Reset()
+	defer gostub.Stub(&config.Config.RecorderDatarDimensions, []string{"record_source", "entity_type"}).Reset()
+
+	db := entity.NewTestDB()
+	defer gostub.StubFunc(&getDB, db).Reset()
+	assert.NoError(t, db.AutoMigrate(entity.AutoMigrateTables...))
+
+	r := NewDatarRecorder()
+	r.AsyncRecord(models.EvalResult{
+		FlagID:       1,
+		VariantID:    10,
+		RecordSource: models.EvalResultRecordSourceExposure,
+		EvalContext:  &models.EvalContext{EntityType: "user"},
+	})
+	r.AsyncRecord(models.EvalResult{FlagID: 1, VariantID: 10, SegmentID: 20})
+	assert.Equal(t, 1, GetDatar().Len(), "exposures still skip the evaluation counters")
+
+	assert.NoError(t, GetDatar().Shutdown())
+	var rows []entity.DimensionHourlyEvent
+	assert.NoError(t, db.Order("record_source").Find(&rows).Error)
+	if assert.Len(t, rows, 2) {
+		assert.Equal(t, "evaluation", rows[0].RecordSource)
+		assert.Equal(t, "", rows[0].EntityType)
+		assert.Equal(t, "exposure", rows[1].RecordSource)
+		assert.Equal(t, "user", rows[1].EntityType)
+	}
+}
+
 func TestDatarRecorder_RecordsEvaluationSource(t *testing.T) {
 	defer ResetDatar()
 
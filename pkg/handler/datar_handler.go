@@ -1,18 +1,29 @@
 package handler
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"net/http"
 	"slices"
 	"sync"
 	"time"
+	_ "time/tzdata" // time zones of Datar time series, also in images without tzdata
 
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
 	"github.com/openflagr/flagr/pkg/config"
 	"github.com/openflagr/flagr/pkg/datar"
+	"github.com/openflagr/flagr/pkg/datar/stats"
+	"github.com/openflagr/flagr/pkg/notification"
+	"github.com/openflagr/flagr/pkg/util"
 	"github.com/openflagr/flagr/swagger_gen/models"
 	datarapi "github.com/openflagr/flagr/swagger_gen/restapi/operations/datar"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 // ---------------------------------------------------------------------------
@@ -36,11 +47,29 @@ func GetDatar() *datar.Engine {
 	if !config.Config.RecorderEnabled || !slices.Contains(config.Config.RecorderType, "datar") {
 		return nil
 	}
+	for _, dim := range config.Config.RecorderDatarDimensions {
+		if dim != datarDimensionRecordSource && dim != datarDimensionEntityType {
+			logrus.WithField("dimension", dim).Warn("Datar: ignoring unknown dimension of FLAGR_RECORDER_DATAR_DIMENSIONS")
+		}
+	}
 	singletonEngine = datar.New(
 		getDB(),
 		true,
 		config.Config.RecorderDatarFlushInterval,
 	)
+	singletonEngine.StartSRMMonitor(
+		config.Config.RecorderDatarSRMCheckInterval,
+		config.Config.RecorderDatarSRMLookback,
+		srmOptions(),
+		notifySampleRatioMismatch,
+	)
+	singletonEngine.StartCompaction(
+		config.Config.RecorderDatarCompactionInterval,
+		datar.RetentionOptions{
+			HourlyDays: config.Config.RecorderDatarHourlyRetentionDays,
+			DailyDays:  config.Config.RecorderDatarDailyRetentionDays,
+		},
+	)
 	return singletonEngine
 }
 
@@ -54,6 +83,18 @@ func ResetDatar() {
 	}
 }
 
+// registerDatarCollector exposes the rolling evaluations of the flags of d to
+// Prometheus.
+func registerDatarCollector(d *datar.Engine) {
+	c := datar.NewCollector(d,
+		config.Config.RecorderDatarPrometheusWindow,
+		config.Config.RecorderDatarPrometheusMaxFlags,
+	)
+	if err := prometheus.Register(c); err != nil {
+		logrus.WithError(err).Error("Datar: failed to register the Prometheus collector")
+	}
+}
+
 const defaultLookbackDays = 7
 
 // ---------------------------------------------------------------------------
@@ -86,6 +127,7 @@ func toSwaggerSummaryFlag(r datar.SummaryRow) *models.DatarSummaryFlag {
 		Enabled:        r.Enabled,
 		Description:    r.Description,
 		TotalEvalCount: r.TotalEvalCount,
+		UniqueEntities: r.UniqueEntities,
 	}
 	if r.LastEvaluated != "" {
 		if t, err := time.Parse(time.RFC3339, r.LastEvaluated); err == nil {
@@ -103,7 +145,7 @@ func toSwaggerDay(d datar.DayEntry) *models.DatarDayEntry {
 		logrus.WithError(err).WithField("date", d.Day).Warn("Datar: invalid date in time bucket")
 		return nil
 	}
-	return &models.DatarDayEntry{Date: strfmt.Date(t), Count: d.Count}
+	return &models.DatarDayEntry{Date: strfmt.Date(t), Count: d.Count, UniqueEntities: d.UniqueEntities}
 }
 
 // ---------------------------------------------------------------------------
@@ -151,6 +193,64 @@ func respondDatarSummary(d *datar.Engine, params datarapi.GetDatarSummaryParams)
 	)
 }
 
+// HandleGetDatarExport is the handler for GET /datar/export.
+func HandleGetDatarExport(params datarapi.GetDatarExportParams) middleware.Responder {
+	d := GetDatar()
+	if d == nil {
+		return jsonResponder(datarapi.NewGetDatarExportDefault(503).WithPayload(
+			datarError("Datar is not enabled"),
+		))
+	}
+	return respondDatarExport(d, params)
+}
+
+// datarExportContentTypes is the content type of each export format.
+var datarExportContentTypes = map[string]string{
+	datar.ExportCSV:    "text/csv",
+	datar.ExportNDJSON: "application/x-ndjson",
+}
+
+func respondDatarExport(d *datar.Engine, params datarapi.GetDatarExportParams) middleware.Responder {
+	from, to := parseTimeRange(params.From, params.To)
+	if !from.Before(to) {
+		return jsonResponder(datarapi.NewGetDatarExportDefault(400).WithPayload(
+			datarError("from must be before to"),
+		))
+	}
+	format := datar.ExportCSV
+	if params.Format != nil {
+		format = *params.Format
+	}
+	contentType, ok := datarExportContentTypes[format]
+	if !ok {
+		return jsonResponder(datarapi.NewGetDatarExportDefault(400).WithPayload(
+			datarError("unknown format %q", format),
+		))
+	}
+
+	// The format rather than the Accept header decides the content type, and
+	// rows are written as they are read, so the status is sent before any
+	// error of the query.
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, _ runtime.Producer) {
+		rw.Header().Set(runtime.HeaderContentType, contentType)
+		rw.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="datar_%s_%s.%s"`,
+			from.Format("20060102T150405Z"), to.Format("20060102T150405Z"), format))
+		rw.WriteHeader(http.StatusOK)
+		if err := d.Export(rw, format, from, to); err != nil {
+			logrus.WithError(err).Error("Datar: Export failed, the response is incomplete")
+		}
+	})
+}
+
+// jsonResponder writes r as JSON, whatever content type was negotiated, for
+// the errors of operations that produce other types.
+func jsonResponder(r middleware.Responder) middleware.Responder {
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, _ runtime.Producer) {
+		rw.Header().Set(runtime.HeaderContentType, runtime.JSONMime)
+		r.WriteResponse(rw, runtime.JSONProducer())
+	})
+}
+
 // HandleGetDatarFlagSummary is the handler for GET /datar/flags/{flagID}/summary.
 func HandleGetDatarFlagSummary(params datarapi.GetDatarFlagSummaryParams) middleware.Responder {
 	d := GetDatar()
@@ -175,12 +275,12 @@ func respondDatarFlagSummary(d *datar.Engine, params datarapi.GetDatarFlagSummar
 
 	variants := make([]*models.DatarVariantEntry, len(summary.Variants))
 	for i, v := range summary.Variants {
-		variants[i] = &models.DatarVariantEntry{VariantID: v.VariantID, Count: v.Count}
+		variants[i] = &models.DatarVariantEntry{VariantID: v.VariantID, Count: v.Count, UniqueEntities: v.UniqueEntities}
 	}
 
 	segs := make([]*models.DatarSegmentEntry, len(summary.Segments))
 	for i, s := range summary.Segments {
-		segs[i] = &models.DatarSegmentEntry{SegmentID: s.SegmentID, Count: s.Count}
+		segs[i] = &models.DatarSegmentEntry{SegmentID: s.SegmentID, Count: s.Count, UniqueEntities: s.UniqueEntities}
 	}
 
 	days := make([]*models.DatarDayEntry, 0, len(summary.Days))
@@ -190,10 +290,373 @@ func respondDatarFlagSummary(d *datar.Engine, params datarapi.GetDatarFlagSummar
 		}
 	}
 
+	// The sample ratio check needs the flag's distributions; deleted flags
+	// have none to check against.
+	var sampleRatio *models.DatarSampleRatio
+	srm, err := d.QuerySampleRatioMismatch(params.FlagID, from, to, srmOptions())
+	switch {
+	case err == nil:
+		sampleRatio = toSwaggerSampleRatio(srm)
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		logrus.WithError(err).Error("Datar: QuerySampleRatioMismatch failed")
+		return datarapi.NewGetDatarFlagSummaryDefault(500).WithPayload(
+			datarError("query failed: %s", err),
+		)
+	}
+
 	return datarapi.NewGetDatarFlagSummaryOK().WithPayload(&models.DatarFlagSummaryResponse{
 		FlagID:           summary.FlagID,
+		UniqueEntities:   summary.UniqueEntities,
 		TrafficByVariant: variants,
 		TrafficBySegment: segs,
 		TrafficByDay:     days,
+		SampleRatio:      sampleRatio,
+	})
+}
+
+func srmOptions() datar.SRMOptions {
+	return datar.SRMOptions{
+		Alpha:    config.Config.RecorderDatarSRMAlpha,
+		MinCount: config.Config.RecorderDatarSRMMinCount,
+	}
+}
+
+// toSwaggerSampleRatio converts an engine SampleRatioMismatch to a swagger model.
+func toSwaggerSampleRatio(srm *datar.SampleRatioMismatch) *models.DatarSampleRatio {
+	m := &models.DatarSampleRatio{
+		Alpha:            srm.Alpha,
+		Statistic:        finite(srm.Statistic),
+		DegreesOfFreedom: int64(srm.DF),
+		PValue:           finite(srm.PValue),
+		Mismatch:         srm.Mismatch,
+		Segments:         make([]*models.DatarSampleRatioSegment, len(srm.Segments)),
+	}
+	for i, s := range srm.Segments {
+		seg := &models.DatarSampleRatioSegment{
+			SegmentID:        s.SegmentID,
+			RolloutPercent:   int64(s.RolloutPercent),
+			Total:            s.Total,
+			Variants:         make([]*models.DatarSampleRatioVariant, len(s.Variants)),
+			Statistic:        finite(s.Statistic),
+			DegreesOfFreedom: int64(s.DF),
+			PValue:           finite(s.PValue),
+			Mismatch:         s.Mismatch,
+			Error:            s.Error,
+		}
+		for j, v := range s.Variants {
+			seg.Variants[j] = &models.DatarSampleRatioVariant{
+				VariantID:     v.VariantID,
+				Observed:      v.Observed,
+				Expected:      v.Expected,
+				ExpectedShare: v.ExpectedShare,
+			}
+		}
+		m.Segments[i] = seg
+	}
+	return m
+}
+
+// notifySampleRatioMismatch sends an alert notification for a flag whose
+// evaluations stopped following its distributions, see StartSRMMonitor.
+func notifySampleRatioMismatch(srm *datar.SampleRatioMismatch) {
+	body, err := json.Marshal(toSwaggerSampleRatio(srm))
+	if err != nil {
+		logrus.WithError(err).Error("Datar: failed to marshal sample ratio mismatch")
+		return
+	}
+	notification.SendNotification(notification.Notification{
+		Operation:     notification.OperationAlert,
+		FlagID:        util.SafeUint(srm.FlagID),
+		FlagKey:       srm.FlagKey,
+		ComponentType: notification.ComponentSampleRatio,
+		PostValue:     string(body),
+	})
+}
+
+// HandleGetDatarTagSummary is the handler for GET /datar/tags/{tag}/summary.
+func HandleGetDatarTagSummary(params datarapi.GetDatarTagSummaryParams) middleware.Responder {
+	d := GetDatar()
+	if d == nil {
+		return datarapi.NewGetDatarTagSummaryDefault(503).WithPayload(
+			datarError("Datar is not enabled"),
+		)
+	}
+	return respondDatarTagSummary(d, params)
+}
+
+func respondDatarTagSummary(d *datar.Engine, params datarapi.GetDatarTagSummaryParams) middleware.Responder {
+	from, to := parseTimeRange(params.From, params.To)
+
+	var filter datar.TagSummaryFilter
+	if params.RecordSource != nil {
+		filter.RecordSource = *params.RecordSource
+	}
+	if params.EntityType != nil {
+		filter.EntityType = *params.EntityType
+	}
+	summary, err := d.QueryTagSummary(params.Tag, from, to, filter)
+	if err != nil {
+		logrus.WithError(err).Error("Datar: QueryTagSummary failed")
+		return datarapi.NewGetDatarTagSummaryDefault(500).WithPayload(
+			datarError("query failed: %s", err),
+		)
+	}
+
+	flags := make([]*models.DatarTagFlagEntry, len(summary.Flags))
+	for i, f := range summary.Flags {
+		flags[i] = &models.DatarTagFlagEntry{FlagID: f.FlagID, FlagKey: f.FlagKey, Count: f.Count}
+	}
+	return datarapi.NewGetDatarTagSummaryOK().WithPayload(&models.DatarTagSummaryResponse{
+		Tag:            summary.Tag,
+		Total:          summary.Total,
+		Flags:          flags,
+		ByRecordSource: toSwaggerDimensionEntries(summary.RecordSources),
+		ByEntityType:   toSwaggerDimensionEntries(summary.EntityTypes),
+	})
+}
+
+func toSwaggerDimensionEntries(counts []datar.DimensionCount) []*models.DatarDimensionEntry {
+	entries := make([]*models.DatarDimensionEntry, len(counts))
+	for i, c := range counts {
+		entries[i] = &models.DatarDimensionEntry{Value: c.Value, Count: c.Count}
+	}
+	return entries
+}
+
+// HandleGetDatarFlagTimeSeries is the handler for GET /datar/flags/{flagID}/timeseries.
+func HandleGetDatarFlagTimeSeries(params datarapi.GetDatarFlagTimeSeriesParams) middleware.Responder {
+	d := GetDatar()
+	if d == nil {
+		return datarapi.NewGetDatarFlagTimeSeriesDefault(503).WithPayload(
+			datarError("Datar is not enabled"),
+		)
+	}
+	return respondDatarFlagTimeSeries(d, params)
+}
+
+func respondDatarFlagTimeSeries(d *datar.Engine, params datarapi.GetDatarFlagTimeSeriesParams) middleware.Responder {
+	from, to := parseTimeRange(params.From, params.To)
+
+	q := datar.TimeSeriesQuery{Location: time.UTC}
+	if params.Granularity != nil {
+		q.Granularity = *params.Granularity
+	}
+	if params.Timezone != nil && *params.Timezone != "" {
+		loc, err := time.LoadLocation(*params.Timezone)
+		if err != nil {
+			return datarapi.NewGetDatarFlagTimeSeriesDefault(400).WithPayload(
+				datarError("unknown timezone %q", *params.Timezone),
+			)
+		}
+		q.Location = loc
+	}
+	for _, dim := range params.Breakdown {
+		switch dim {
+		case "variant":
+			q.ByVariant = true
+		case "segment":
+			q.BySegment = true
+		default:
+			return datarapi.NewGetDatarFlagTimeSeriesDefault(400).WithPayload(
+				datarError("unknown breakdown %q", dim),
+			)
+		}
+	}
+
+	ts, err := d.QueryTimeSeries(params.FlagID, from, to, q)
+	if errors.Is(err, datar.ErrInvalidTimeSeries) {
+		return datarapi.NewGetDatarFlagTimeSeriesDefault(400).WithPayload(datarError("%s", err))
+	}
+	if err != nil {
+		logrus.WithError(err).Error("Datar: QueryTimeSeries failed")
+		return datarapi.NewGetDatarFlagTimeSeriesDefault(500).WithPayload(
+			datarError("query failed: %s", err),
+		)
+	}
+
+	buckets := make([]strfmt.DateTime, len(ts.Buckets))
+	for i, b := range ts.Buckets {
+		buckets[i] = strfmt.DateTime(b)
+	}
+	series := make([]*models.DatarTimeSeries, len(ts.Series))
+	for i, s := range ts.Series {
+		series[i] = &models.DatarTimeSeries{Total: s.Total, Counts: s.Counts}
+		if ts.ByVariant {
+			series[i].VariantID = &s.VariantID
+		}
+		if ts.BySegment {
+			series[i].SegmentID = &s.SegmentID
+		}
+	}
+	return datarapi.NewGetDatarFlagTimeSeriesOK().WithPayload(&models.DatarTimeSeriesResponse{
+		FlagID:      ts.FlagID,
+		Granularity: ts.Granularity,
+		Timezone:    ts.Location.String(),
+		Buckets:     buckets,
+		Series:      series,
+	})
+}
+
+// HandleGetDatarFlagMetrics is the handler for GET /datar/flags/{flagID}/metrics.
+func HandleGetDatarFlagMetrics(params datarapi.GetDatarFlagMetricsParams) middleware.Responder {
+	d := GetDatar()
+	if d == nil {
+		return datarapi.NewGetDatarFlagMetricsDefault(503).WithPayload(
+			datarError("Datar is not enabled"),
+		)
+	}
+	return respondDatarFlagMetrics(d, params)
+}
+
+func respondDatarFlagMetrics(d *datar.Engine, params datarapi.GetDatarFlagMetricsParams) middleware.Responder {
+	from, to := parseTimeRange(params.From, params.To)
+
+	fm, err := d.QueryFlagMetrics(params.FlagID, from, to)
+	if err != nil {
+		logrus.WithError(err).Error("Datar: QueryFlagMetrics failed")
+		return datarapi.NewGetDatarFlagMetricsDefault(500).WithPayload(
+			datarError("query failed: %s", err),
+		)
+	}
+
+	variants := make([]*models.DatarVariantMetrics, len(fm.Variants))
+	for i, v := range fm.Variants {
+		metrics := make([]*models.DatarMetricEntry, len(v.Metrics))
+		for j, m := range v.Metrics {
+			metrics[j] = &models.DatarMetricEntry{
+				MetricKey:       m.MetricKey,
+				Events:          m.Events,
+				Conversions:     m.Conversions,
+				ValueSum:        m.ValueSum,
+				ValueSumSquares: m.ValueSumSquares,
+			}
+		}
+		variants[i] = &models.DatarVariantMetrics{VariantID: v.VariantID, Exposures: v.Exposures, Metrics: metrics}
+	}
+
+	return datarapi.NewGetDatarFlagMetricsOK().WithPayload(&models.DatarFlagMetricsResponse{
+		FlagID:   fm.FlagID,
+		Variants: variants,
 	})
 }
+
+// HandlePostDatarFlagExperiment is the handler for POST /datar/flags/{flagID}/experiment.
+func HandlePostDatarFlagExperiment(params datarapi.PostDatarFlagExperimentParams) middleware.Responder {
+	d := GetDatar()
+	if d == nil {
+		return datarapi.NewPostDatarFlagExperimentDefault(503).WithPayload(
+			datarError("Datar is not enabled"),
+		)
+	}
+	return respondDatarFlagExperiment(d, params)
+}
+
+func respondDatarFlagExperiment(d *datar.Engine, params datarapi.PostDatarFlagExperimentParams) middleware.Responder {
+	body := params.Body
+	if body == nil || body.ControlVariantID == nil {
+		return datarapi.NewPostDatarFlagExperimentDefault(400).WithPayload(
+			datarError("controlVariantID is required"),
+		)
+	}
+	var from, to *strfmt.DateTime
+	if !time.Time(body.From).IsZero() {
+		from = &body.From
+	}
+	if !time.Time(body.To).IsZero() {
+		to = &body.To
+	}
+	start, end := parseTimeRange(from, to)
+
+	q := datar.ExperimentQuery{
+		ControlVariantID: *body.ControlVariantID,
+		MetricKey:        body.MetricKey,
+		Kind:             string(body.Kind),
+		Denominator:      body.Denominator,
+		Alpha:            body.Alpha,
+		Bayesian:         body.Bayesian,
+		Sequential:       body.Sequential,
+	}
+	for _, o := range body.Variants {
+		if o == nil || o.VariantID == nil {
+			return datarapi.NewPostDatarFlagExperimentDefault(400).WithPayload(
+				datarError("variantID is required for every variant"),
+			)
+		}
+		q.Outcomes = append(q.Outcomes, datar.VariantOutcome{
+			VariantID:       *o.VariantID,
+			Successes:       o.Successes,
+			ValueSum:        o.ValueSum,
+			ValueSumSquares: o.ValueSumSquares,
+		})
+	}
+
+	exp, err := d.QueryExperiment(params.FlagID, start, end, q)
+	if errors.Is(err, datar.ErrInvalidExperiment) {
+		return datarapi.NewPostDatarFlagExperimentDefault(400).WithPayload(datarError("%s", err))
+	}
+	if err != nil {
+		logrus.WithError(err).Error("Datar: QueryExperiment failed")
+		return datarapi.NewPostDatarFlagExperimentDefault(500).WithPayload(
+			datarError("query failed: %s", err),
+		)
+	}
+
+	variants := make([]*models.DatarExperimentVariant, len(exp.Variants))
+	for i, v := range exp.Variants {
+		variants[i] = toSwaggerExperimentVariant(v, exp.Alpha)
+	}
+	return datarapi.NewPostDatarFlagExperimentOK().WithPayload(&models.DatarExperimentResponse{
+		FlagID:           exp.FlagID,
+		ControlVariantID: exp.ControlVariantID,
+		MetricKey:        exp.MetricKey,
+		Kind:             models.MetricKind(exp.Kind),
+		Denominator:      exp.Denominator,
+		Test:             exp.Test,
+		Alpha:            exp.Alpha,
+		Bayesian:         exp.Bayesian,
+		Sequential:       exp.Sequential,
+		Variants:         variants,
+	})
+}
+
+// toSwaggerExperimentVariant converts an engine VariantReadout to a swagger
+// model. Values that are not finite, e.g. the lift over a control without
+// successes, are left out.
+func toSwaggerExperimentVariant(v datar.VariantReadout, alpha float64) *models.DatarExperimentVariant {
+	r := &models.DatarExperimentVariant{
+		VariantID:                v.VariantID,
+		Control:                  v.Control,
+		Trials:                   v.Trials,
+		Successes:                v.Successes,
+		Mean:                     v.Mean,
+		MeanCI:                   toSwaggerInterval(&v.MeanCI),
+		ProbabilityToBeatControl: finite(v.ProbabilityToBeatControl),
+		CredibleInterval:         toSwaggerInterval(v.CredibleInterval),
+		Error:                    v.Error,
+	}
+	if c := v.Comparison; c != nil {
+		r.Difference = finite(c.Difference)
+		r.DifferenceCI = toSwaggerInterval(&c.DifferenceCI)
+		r.Lift = finite(c.Lift)
+		r.LiftCI = toSwaggerInterval(&c.LiftCI)
+		r.Statistic = finite(c.Statistic)
+		r.DegreesOfFreedom = finite(c.DF)
+		r.PValue = finite(c.PValue)
+		r.Significant = c.PValue < alpha
+	}
+	return r
+}
+
+func toSwaggerInterval(i *stats.Interval) *models.DatarInterval {
+	if i == nil || finite(i.Lower) == nil || finite(i.Upper) == nil {
+		return nil
+	}
+	return &models.DatarInterval{Lower: i.Lower, Upper: i.Upper}
+}
+
+func finite(f float64) *float64 {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil
+	}
+	return &f
+}
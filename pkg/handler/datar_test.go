@@ -1,15 +1,21 @@
 package handler
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
+	"net/http/httptest"
 	"slices"
 	"testing"
 	"time"
 
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/strfmt"
 	"github.com/openflagr/flagr/pkg/config"
 	"github.com/openflagr/flagr/pkg/datar"
 	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/pkg/notification"
+	"github.com/openflagr/flagr/swagger_gen/models"
 	datarapi "github.com/openflagr/flagr/swagger_gen/restapi/operations/datar"
 	"github.com/prashantv/gostub"
 	"github.com/stretchr/testify/assert"
@@ -56,6 +62,17 @@ func TestDatarEndpoints_QueryError(t *testing.T) {
 	flagResp := respondDatarFlagSummary(engine, datarapi.GetDatarFlagSummaryParams{FlagID: 1})
 	_, ok = flagResp.(*datarapi.GetDatarFlagSummaryDefault)
 	assert.True(t, ok, "expected 500 when query fails, got %T", flagResp)
+
+	tsResp := respondDatarFlagTimeSeries(engine, datarapi.GetDatarFlagTimeSeriesParams{FlagID: 1})
+	_, ok = tsResp.(*datarapi.GetDatarFlagTimeSeriesDefault)
+	assert.True(t, ok, "expected 500 when query fails, got %T", tsResp)
+
+	if err := db.Exec("DROP TABLE datar_assignments").Error; err != nil {
+		t.Fatal(err)
+	}
+	metricsResp := respondDatarFlagMetrics(engine, datarapi.GetDatarFlagMetricsParams{FlagID: 1})
+	_, ok = metricsResp.(*datarapi.GetDatarFlagMetricsDefault)
+	assert.True(t, ok, "expected 500 when query fails, got %T", metricsResp)
 }
 
 func TestDatarEndpoints_Summary(t *testing.T) {
@@ -118,6 +135,9 @@ func TestDatarEndpoints_FlagSummary(t *testing.T) {
 	assert.NoError(t, db.Exec(`INSERT INTO variants (id, flag_id, key) VALUES (1, 1, 'control')`).Error)
 	assert.NoError(t, db.Exec(`INSERT INTO variants (id, flag_id, key) VALUES (2, 1, 'treatment')`).Error)
 	assert.NoError(t, db.Exec(`INSERT INTO segments (id, flag_id, description, rank, rollout_percent) VALUES (10, 1, 'US users', 1, 100)`).Error)
+	assert.NoError(t, db.Exec(`INSERT INTO distributions (segment_id, variant_id, variant_key, percent) VALUES (10, 1, 'control', 50)`).Error)
+	assert.NoError(t, db.Exec(`INSERT INTO distributions (segment_id, variant_id, variant_key, percent) VALUES (10, 2, 'treatment', 50)`).Error)
+	defer gostub.Stub(&config.Config.RecorderDatarSRMMinCount, int64(100)).Reset()
 
 	// Seed hourly data across multiple days.
 	now := time.Now().UTC().Truncate(time.Hour)
@@ -147,6 +167,54 @@ func TestDatarEndpoints_FlagSummary(t *testing.T) {
 	assert.Equal(t, int64(10), okResp.Payload.TrafficBySegment[0].SegmentID)
 
 	assert.Len(t, okResp.Payload.TrafficByDay, 2, "should have 2 daily buckets")
+
+	// 125 and 50 evaluations of a 50/50 split.
+	srm := okResp.Payload.SampleRatio
+	if assert.NotNil(t, srm) && assert.Len(t, srm.Segments, 1) {
+		assert.True(t, srm.Mismatch)
+		assert.Equal(t, int64(1), srm.DegreesOfFreedom)
+		assert.InDelta(t, 2*37.5*37.5/87.5, *srm.Statistic, 1e-9)
+		assert.Less(t, *srm.PValue, 0.001)
+		seg := srm.Segments[0]
+		assert.Equal(t, int64(175), seg.Total)
+		if assert.Len(t, seg.Variants, 2) {
+			assert.Equal(t, int64(125), seg.Variants[0].Observed)
+			assert.InDelta(t, 87.5, seg.Variants[0].Expected, 1e-9)
+		}
+	}
+
+	// Flags that no longer exist have no sample ratio check.
+	resp = HandleGetDatarFlagSummary(datarapi.GetDatarFlagSummaryParams{FlagID: 2})
+	if okResp, ok := resp.(*datarapi.GetDatarFlagSummaryOK); assert.True(t, ok) {
+		assert.Nil(t, okResp.Payload.SampleRatio)
+	}
+}
+
+func TestNotifySampleRatioMismatch(t *testing.T) {
+	mockNotifier := notification.NewMockNotifier()
+	defer gostub.Stub(&notification.Notifiers, []notification.Notifier{mockNotifier}).Reset()
+
+	notifySampleRatioMismatch(&datar.SampleRatioMismatch{
+		FlagID:    7,
+		FlagKey:   "checkout",
+		Alpha:     0.001,
+		Statistic: 32,
+		DF:        1,
+		PValue:    1e-8,
+		Mismatch:  true,
+		Segments:  []datar.SRMSegment{{SegmentID: 3, Statistic: math.NaN(), PValue: math.NaN(), Error: "not enough data"}},
+	})
+
+	assert.Eventually(t, func() bool {
+		return len(mockNotifier.GetSentNotifications()) > 0
+	}, time.Second, 10*time.Millisecond)
+	sent := mockNotifier.GetSentNotifications()[0]
+	assert.Equal(t, notification.OperationAlert, sent.Operation)
+	assert.Equal(t, notification.ComponentSampleRatio, sent.ComponentType)
+	assert.Equal(t, uint(7), sent.FlagID)
+	assert.Equal(t, "checkout", sent.FlagKey)
+	assert.JSONEq(t, `{"alpha":0.001,"statistic":32,"degreesOfFreedom":1,"pValue":1e-8,"mismatch":true,`+
+		`"segments":[{"segmentID":3,"variants":[],"error":"not enough data"}]}`, sent.PostValue)
 }
 
 func TestDatarEndpoints_NotEnabled(t *testing.T) {
@@ -162,6 +230,232 @@ func TestDatarEndpoints_NotEnabled(t *testing.T) {
 	flagResp := HandleGetDatarFlagSummary(datarapi.GetDatarFlagSummaryParams{FlagID: 1})
 	_, ok = flagResp.(*datarapi.GetDatarFlagSummaryDefault)
 	assert.True(t, ok, "expected 503 response when datar disabled")
+
+	metricsResp := HandleGetDatarFlagMetrics(datarapi.GetDatarFlagMetricsParams{FlagID: 1})
+	_, ok = metricsResp.(*datarapi.GetDatarFlagMetricsDefault)
+	assert.True(t, ok, "expected 503 response when datar disabled")
+
+	expResp := HandlePostDatarFlagExperiment(datarapi.PostDatarFlagExperimentParams{FlagID: 1})
+	_, ok = expResp.(*datarapi.PostDatarFlagExperimentDefault)
+	assert.True(t, ok, "expected 503 response when datar disabled")
+
+	tsResp := HandleGetDatarFlagTimeSeries(datarapi.GetDatarFlagTimeSeriesParams{FlagID: 1})
+	_, ok = tsResp.(*datarapi.GetDatarFlagTimeSeriesDefault)
+	assert.True(t, ok, "expected 503 response when datar disabled")
+
+	tagResp := HandleGetDatarTagSummary(datarapi.GetDatarTagSummaryParams{Tag: "checkout"})
+	_, ok = tagResp.(*datarapi.GetDatarTagSummaryDefault)
+	assert.True(t, ok, "expected 503 response when datar disabled")
+
+	rec := httptest.NewRecorder()
+	HandleGetDatarExport(datarapi.GetDatarExportParams{}).WriteResponse(rec, runtime.CSVProducer())
+	assert.Equal(t, 503, rec.Code)
+	assert.Equal(t, runtime.JSONMime, rec.Header().Get(runtime.HeaderContentType), "errors are JSON")
+}
+
+func TestDatarEndpoints_TagSummary(t *testing.T) {
+	defer ResetDatar()
+
+	defer gostub.Stub(&config.Config.RecorderType, []string{"datar"}).Reset()
+	defer gostub.Stub(&config.Config.RecorderEnabled, true).Reset()
+	defer gostub.Stub(&config.Config.RecorderDatarFlushInterval, 24*time.Hour).Reset()
+
+	db := entity.NewTestDB()
+	defer gostub.StubFunc(&getDB, db).Reset()
+	db.AutoMigrate(entity.AutoMigrateTables...)
+
+	assert.NoError(t, db.Create(&entity.Flag{Key: "cart", Tags: []entity.Tag{{Value: "checkout"}}}).Error)
+	now := time.Now().UTC().Truncate(time.Hour)
+	assert.NoError(t, db.Create(&[]entity.DimensionHourlyEvent{
+		{FlagID: 1, VariantID: 1, BucketHour: now, RecordSource: "evaluation", EntityType: "user", RecordCount: 30},
+		{FlagID: 1, VariantID: 1, BucketHour: now, RecordSource: "exposure", EntityType: "user", RecordCount: 12},
+	}).Error)
+
+	resp := HandleGetDatarTagSummary(datarapi.GetDatarTagSummaryParams{Tag: "checkout"})
+	okResp, ok := resp.(*datarapi.GetDatarTagSummaryOK)
+	if !ok {
+		t.Fatalf("expected *datarapi.GetDatarTagSummaryOK, got %T", resp)
+	}
+	p := okResp.Payload
+	assert.Equal(t, "checkout", p.Tag)
+	assert.Equal(t, int64(42), p.Total)
+	if assert.Len(t, p.Flags, 1) {
+		assert.Equal(t, "cart", p.Flags[0].FlagKey)
+	}
+	if assert.Len(t, p.ByRecordSource, 2) {
+		assert.Equal(t, &models.DatarDimensionEntry{Value: "exposure", Count: 12}, p.ByRecordSource[1])
+	}
+	assert.Equal(t, []*models.DatarDimensionEntry{{Value: "user", Count: 42}}, p.ByEntityType)
+
+	resp = HandleGetDatarTagSummary(datarapi.GetDatarTagSummaryParams{Tag: "checkout", RecordSource: new("exposure")})
+	if okResp, ok := resp.(*datarapi.GetDatarTagSummaryOK); assert.True(t, ok) {
+		assert.Equal(t, int64(12), okResp.Payload.Total)
+	}
+
+	assert.NoError(t, db.Exec("DROP TABLE datar_dimension_hourly_events").Error)
+	resp = HandleGetDatarTagSummary(datarapi.GetDatarTagSummaryParams{Tag: "checkout"})
+	_, ok = resp.(*datarapi.GetDatarTagSummaryDefault)
+	assert.True(t, ok, "expected 500 when query fails, got %T", resp)
+}
+
+func TestDatarEndpoints_FlagTimeSeries(t *testing.T) {
+	defer ResetDatar()
+
+	defer gostub.Stub(&config.Config.RecorderType, []string{"datar"}).Reset()
+	defer gostub.Stub(&config.Config.RecorderEnabled, true).Reset()
+	defer gostub.Stub(&config.Config.RecorderDatarFlushInterval, 24*time.Hour).Reset()
+
+	db := entity.NewTestDB()
+	defer gostub.StubFunc(&getDB, db).Reset()
+	db.AutoMigrate(entity.AutoMigrateTables...)
+
+	day := time.Now().UTC().Truncate(24 * time.Hour).Add(-48 * time.Hour)
+	assert.NoError(t, db.Exec(`INSERT INTO datar_hourly_events (flag_id, variant_id, segment_id, bucket_hour, eval_count) VALUES (1, 1, 10, ?, 100)`, day.Add(2*time.Hour)).Error)
+	assert.NoError(t, db.Exec(`INSERT INTO datar_hourly_events (flag_id, variant_id, segment_id, bucket_hour, eval_count) VALUES (1, 2, 10, ?, 50)`, day.Add(26*time.Hour)).Error)
+
+	from, to := strfmt.DateTime(day), strfmt.DateTime(day.Add(48*time.Hour))
+	resp := HandleGetDatarFlagTimeSeries(datarapi.GetDatarFlagTimeSeriesParams{
+		FlagID:    1,
+		From:      &from,
+		To:        &to,
+		Breakdown: []string{"variant"},
+	})
+	okResp, ok := resp.(*datarapi.GetDatarFlagTimeSeriesOK)
+	if !ok {
+		t.Fatalf("expected *datarapi.GetDatarFlagTimeSeriesOK, got %T", resp)
+	}
+	p := okResp.Payload
+	assert.Equal(t, "day", p.Granularity)
+	assert.Equal(t, "UTC", p.Timezone)
+	assert.Len(t, p.Buckets, 2)
+	if assert.Len(t, p.Series, 2) {
+		assert.Equal(t, int64(1), *p.Series[0].VariantID)
+		assert.Nil(t, p.Series[0].SegmentID, "not broken down by segment")
+		assert.Equal(t, []int64{100, 0}, p.Series[0].Counts)
+		assert.Equal(t, []int64{0, 50}, p.Series[1].Counts)
+	}
+
+	// Day boundaries in a time zone: 02:00 UTC is the day before in New York.
+	resp = HandleGetDatarFlagTimeSeries(datarapi.GetDatarFlagTimeSeriesParams{
+		FlagID: 1, From: &from, To: &to, Timezone: new("America/New_York"),
+	})
+	if okResp, ok := resp.(*datarapi.GetDatarFlagTimeSeriesOK); assert.True(t, ok) {
+		assert.Equal(t, "America/New_York", okResp.Payload.Timezone)
+		assert.Len(t, okResp.Payload.Buckets, 3)
+		if assert.Len(t, okResp.Payload.Series, 1) {
+			assert.Nil(t, okResp.Payload.Series[0].VariantID)
+			assert.Equal(t, []int64{100, 50, 0}, okResp.Payload.Series[0].Counts)
+		}
+	}
+
+	for message, params := range map[string]datarapi.GetDatarFlagTimeSeriesParams{
+		`unknown timezone "Mars/Olympus_Mons"`:            {FlagID: 1, Timezone: new("Mars/Olympus_Mons")},
+		`unknown breakdown "tag"`:                         {FlagID: 1, Breakdown: []string{"tag"}},
+		`invalid time series: unknown granularity "week"`: {FlagID: 1, Granularity: new("week")},
+	} {
+		resp := HandleGetDatarFlagTimeSeries(params)
+		if def, ok := resp.(*datarapi.GetDatarFlagTimeSeriesDefault); assert.True(t, ok, message) {
+			assert.Equal(t, message, *def.Payload.Message)
+		}
+	}
+}
+
+func TestDatarEndpoints_Export(t *testing.T) {
+	defer ResetDatar()
+
+	defer gostub.Stub(&config.Config.RecorderType, []string{"datar"}).Reset()
+	defer gostub.Stub(&config.Config.RecorderEnabled, true).Reset()
+	defer gostub.Stub(&config.Config.RecorderDatarFlushInterval, 24*time.Hour).Reset()
+
+	db := entity.NewTestDB()
+	defer gostub.StubFunc(&getDB, db).Reset()
+	db.AutoMigrate(entity.AutoMigrateTables...)
+
+	f := entity.GenFixtureFlag()
+	db.Create(&f)
+	hour := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, db.Exec(`INSERT INTO datar_hourly_events (flag_id, variant_id, segment_id, bucket_hour, eval_count) VALUES (?, ?, 0, ?, 100)`,
+		f.ID, f.Variants[0].ID, hour).Error)
+
+	from, to := strfmt.DateTime(hour.Add(-time.Hour)), strfmt.DateTime(hour.Add(time.Hour))
+	rec := httptest.NewRecorder()
+	HandleGetDatarExport(datarapi.GetDatarExportParams{From: &from, To: &to}).WriteResponse(rec, runtime.JSONProducer())
+	assert.Equal(t, 200, rec.Code)
+	assert.Equal(t, "text/csv", rec.Header().Get(runtime.HeaderContentType), "csv by default")
+	assert.Equal(t, `attachment; filename="datar_20260501T110000Z_20260501T130000Z.csv"`, rec.Header().Get("Content-Disposition"))
+	assert.Equal(t, fmt.Sprintf("bucket,granularity,flag_id,flag_key,variant_id,variant_key,segment_id,eval_count\n"+
+		"2026-05-01T12:00:00Z,hour,%d,%s,%d,%s,0,100\n", f.ID, f.Key, f.Variants[0].ID, f.Variants[0].Key), rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	HandleGetDatarExport(datarapi.GetDatarExportParams{From: &from, To: &to, Format: new("ndjson")}).WriteResponse(rec, runtime.CSVProducer())
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get(runtime.HeaderContentType), "the format decides the content type")
+	var row datar.ExportRow
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &row))
+	assert.Equal(t, int64(100), row.EvalCount)
+	assert.Equal(t, f.Key, row.FlagKey)
+
+	rec = httptest.NewRecorder()
+	HandleGetDatarExport(datarapi.GetDatarExportParams{From: &to, To: &from}).WriteResponse(rec, runtime.CSVProducer())
+	assert.Equal(t, 400, rec.Code)
+	assert.JSONEq(t, `{"message": "from must be before to"}`, rec.Body.String())
+}
+
+func TestDatarEndpoints_FlagExperiment(t *testing.T) {
+	defer ResetDatar()
+
+	defer gostub.Stub(&config.Config.RecorderType, []string{"datar"}).Reset()
+	defer gostub.Stub(&config.Config.RecorderEnabled, true).Reset()
+	defer gostub.Stub(&config.Config.RecorderDatarFlushInterval, 24*time.Hour).Reset()
+
+	db := entity.NewTestDB()
+	defer gostub.StubFunc(&getDB, db).Reset()
+	db.AutoMigrate(entity.AutoMigrateTables...)
+
+	now := time.Now().UTC().Truncate(time.Hour)
+	assert.NoError(t, db.Exec(`INSERT INTO datar_hourly_events (flag_id, variant_id, segment_id, bucket_hour, eval_count) VALUES (1, 1, 10, ?, 1000)`, now).Error)
+	assert.NoError(t, db.Exec(`INSERT INTO datar_hourly_events (flag_id, variant_id, segment_id, bucket_hour, eval_count) VALUES (1, 2, 10, ?, 1000)`, now).Error)
+
+	body := &models.DatarExperimentRequest{
+		ControlVariantID: new(int64(1)),
+		Variants: []*models.DatarExperimentOutcome{
+			{VariantID: new(int64(1)), Successes: 0},
+			{VariantID: new(int64(2)), Successes: 25},
+		},
+		From:     strfmt.DateTime(now.Add(-time.Hour)),
+		Bayesian: true,
+	}
+	resp := HandlePostDatarFlagExperiment(datarapi.PostDatarFlagExperimentParams{FlagID: 1, Body: body})
+	okResp, ok := resp.(*datarapi.PostDatarFlagExperimentOK)
+	if !assert.True(t, ok, "got %T", resp) {
+		return
+	}
+	assert.Equal(t, "two_proportion_z", okResp.Payload.Test)
+	assert.Equal(t, "evaluations", okResp.Payload.Denominator)
+	if assert.Len(t, okResp.Payload.Variants, 2) {
+		control, treatment := okResp.Payload.Variants[0], okResp.Payload.Variants[1]
+		assert.True(t, control.Control)
+		assert.Nil(t, control.PValue)
+		assert.NotNil(t, control.MeanCI)
+
+		assert.Equal(t, int64(1000), treatment.Trials)
+		assert.InDelta(t, 0.025, *treatment.Difference, 1e-12)
+		assert.Nil(t, treatment.Lift, "lift over a control without successes is undefined")
+		assert.Nil(t, treatment.LiftCI)
+		assert.Nil(t, treatment.DegreesOfFreedom)
+		assert.True(t, treatment.Significant)
+		assert.Greater(t, *treatment.ProbabilityToBeatControl, 0.99)
+	}
+
+	// Invalid queries are client errors.
+	body.MetricKey = "purchase"
+	resp = HandlePostDatarFlagExperiment(datarapi.PostDatarFlagExperimentParams{FlagID: 1, Body: body})
+	if def, ok := resp.(*datarapi.PostDatarFlagExperimentDefault); assert.True(t, ok) {
+		assert.Contains(t, *def.Payload.Message, "exactly one of metricKey and variants")
+	}
+	resp = HandlePostDatarFlagExperiment(datarapi.PostDatarFlagExperimentParams{FlagID: 1, Body: &models.DatarExperimentRequest{}})
+	if def, ok := resp.(*datarapi.PostDatarFlagExperimentDefault); assert.True(t, ok) {
+		assert.Equal(t, "controlVariantID is required", *def.Payload.Message)
+	}
 }
 
 func TestDatarEndpoints_Pagination(t *testing.T) {
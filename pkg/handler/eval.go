@@ -4,24 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"math/rand"
 	"net/http"
 	"sync"
 	"time"
 
 	"github.com/openflagr/flagr/pkg/config"
 	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/pkg/evaluator"
 	"github.com/openflagr/flagr/pkg/util"
 	"github.com/openflagr/flagr/swagger_gen/models"
 	"github.com/openflagr/flagr/swagger_gen/restapi/operations/evaluation"
-	"gorm.io/gorm"
 
 	"github.com/bsm/ratelimit"
-	"github.com/davecgh/go-spew/spew"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/validate"
-	"github.com/zhouzhuojie/conditions"
 )
 
 // Eval is the Eval interface
@@ -30,6 +27,10 @@ type Eval interface {
 	GetEvaluationBatch(evaluation.GetEvaluationBatchParams) middleware.Responder
 	PostEvaluation(evaluation.PostEvaluationParams) middleware.Responder
 	PostEvaluationBatch(evaluation.PostEvaluationBatchParams) middleware.Responder
+	GetEvaluationBootstrap(evaluation.GetEvaluationBootstrapParams) middleware.Responder
+	PostEvaluationBootstrap(evaluation.PostEvaluationBootstrapParams) middleware.Responder
+	PostEvaluationPreview(evaluation.PostEvaluationPreviewParams) middleware.Responder
+	PostEvaluationSimulation(evaluation.PostEvaluationSimulationParams) middleware.Responder
 }
 
 // NewEval creates a new Eval instance
@@ -66,7 +67,7 @@ func (e *eval) GetEvaluationBatch(params evaluation.GetEvaluationBatchParams) mi
 	if errPayload != nil {
 		return evaluation.NewGetEvaluationBatchDefault(400).WithPayload(errPayload)
 	}
-	results, errPayload := EvaluateBatch(&batchReq, nil)
+	results, errPayload := EvaluateBatch(requestContext(params.HTTPRequest), &batchReq, nil)
 	if errPayload != nil {
 		return evaluation.NewGetEvaluationBatchDefault(400).WithPayload(errPayload)
 	}
@@ -96,7 +97,7 @@ func (e *eval) PostEvaluationBatch(params evaluation.PostEvaluationBatchParams)
 		return evaluation.NewPostEvaluationBatchDefault(400).WithPayload(
 			ErrorMessage("empty body"))
 	}
-	results, errPayload := EvaluateBatch(params.Body, params.HTTPRequest)
+	results, errPayload := EvaluateBatch(requestContext(params.HTTPRequest), params.Body, params.HTTPRequest)
 	if errPayload != nil {
 		return evaluation.NewPostEvaluationBatchDefault(400).WithPayload(errPayload)
 	}
@@ -110,7 +111,8 @@ func (e *eval) PostEvaluationBatch(params evaluation.PostEvaluationBatchParams)
 
 // EvaluateBatch runs the same logic as POST/GET /evaluation/batch for the given request body.
 // When r is non-nil, built-in context keys (@ts_*, @http_*) are injected per entity (POST path).
-func EvaluateBatch(batchReq *models.EvaluationBatchRequest, r *http.Request) (*models.EvaluationBatchResponse, *models.Error) {
+// Evaluation stops at the deadline of ctx or FLAGR_EVAL_BATCH_TIMEOUT, see evalBatchItems.
+func EvaluateBatch(ctx context.Context, batchReq *models.EvaluationBatchRequest, r *http.Request) (*models.EvaluationBatchResponse, *models.Error) {
 	if batchReq == nil {
 		return nil, ErrorMessage("empty batch request")
 	}
@@ -154,56 +156,59 @@ func EvaluateBatch(batchReq *models.EvaluationBatchRequest, r *http.Request) (*m
 		}
 	}
 
-	est := len(entities) * (len(flagIDs) + len(flagKeys))
-	if len(flagTags) > 0 {
-		est += len(entities)
-	}
-	results := &models.EvaluationBatchResponse{
-		EvaluationResults: make([]*models.EvalResult, 0, est),
-	}
-
 	enableDebug := batchReq.EnableDebug
+	items := make([]models.EvalContext, 0, len(entities)*(len(flagIDs)+len(flagKeys)+1))
 	for _, entity := range entities {
-		if entity != nil {
-			entity.EntityContext = InjectBuiltInContext(entity.EntityContext, r)
+		if entity == nil {
+			continue
 		}
+		entity.EntityContext = InjectBuiltInContext(entity.EntityContext, r)
 		if len(flagTags) > 0 {
-			evalContext := models.EvalContext{
+			items = append(items, models.EvalContext{
 				EnableDebug:      enableDebug,
 				EntityContext:    entity.EntityContext,
 				EntityID:         entity.EntityID,
 				EntityType:       entity.EntityType,
 				FlagTags:         flagTags,
 				FlagTagsOperator: flagTagsOperator,
-			}
-			evalResults := EvalFlagsByTags(evalContext)
-			results.EvaluationResults = append(results.EvaluationResults, evalResults...)
+			})
 		}
 		for _, flagID := range flagIDs {
-			evalContext := models.EvalContext{
+			items = append(items, models.EvalContext{
 				EnableDebug:   enableDebug,
 				EntityContext: entity.EntityContext,
 				EntityID:      entity.EntityID,
 				EntityType:    entity.EntityType,
 				FlagID:        flagID,
-			}
-			evalResult := EvalFlag(evalContext)
-			results.EvaluationResults = append(results.EvaluationResults, evalResult)
+			})
 		}
 		for _, flagKey := range flagKeys {
-			evalContext := models.EvalContext{
+			items = append(items, models.EvalContext{
 				EnableDebug:   enableDebug,
 				EntityContext: entity.EntityContext,
 				EntityID:      entity.EntityID,
 				EntityType:    entity.EntityType,
 				FlagKey:       flagKey,
-			}
-			evalResult := EvalFlag(evalContext)
-			results.EvaluationResults = append(results.EvaluationResults, evalResult)
+			})
 		}
 	}
 
-	return results, nil
+	if timeout := config.Config.EvalBatchTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return evalBatchItems(ctx, items, evalBatchWorkers(batchReq)), nil
+}
+
+// evalBatchWorkers is the number of workers of a batch request: its own, or
+// FLAGR_EVAL_BATCH_WORKERS, bounded by the global cap of extra workers.
+func evalBatchWorkers(batchReq *models.EvaluationBatchRequest) int {
+	workers := int64(config.Config.EvalBatchWorkers)
+	if batchReq.Workers > 0 {
+		workers = batchReq.Workers
+	}
+	return int(max(min(workers, int64(config.Config.EvalBatchMaxWorkers)+1), 1))
 }
 
 // GET evaluation: query decode and POST-parity validation
@@ -287,33 +292,7 @@ func validateEvaluationBatchRequestAfterJSON(r *http.Request, batchReq *models.E
 
 // BlankResult creates a blank result
 func BlankResult(f *entity.Flag, evalContext models.EvalContext, msg string) *models.EvalResult {
-	flagID := uint(0)
-	flagKey := ""
-	flagSnapshotID := uint(0)
-	var flagTags []string
-	var dataRecordsEnabled bool
-	if f != nil {
-		flagID = f.ID
-		flagSnapshotID = f.SnapshotID
-		flagKey = f.Key
-		flagTags = f.FlagEvaluation.TagValues
-		dataRecordsEnabled = f.DataRecordsEnabled
-	}
-	ec := evalContext
-	return &models.EvalResult{
-		EvalContext: &ec,
-		EvalDebugLog: &models.EvalDebugLog{
-			Msg:              msg,
-			SegmentDebugLogs: nil,
-		},
-		FlagID:             int64(flagID),
-		FlagKey:            flagKey,
-		FlagSnapshotID:     int64(flagSnapshotID),
-		FlagTags:           flagTags,
-		Timestamp:          util.TimeNow(),
-		RecordSource:       models.EvalResultRecordSourceEvaluation,
-		DataRecordsEnabled: dataRecordsEnabled,
-	}
+	return evaluator.BlankResult(f, evalContext, msg)
 }
 
 var LookupFlag = func(evalContext models.EvalContext) *entity.Flag {
@@ -343,61 +322,13 @@ var EvalFlag = func(evalContext models.EvalContext) *models.EvalResult {
 }
 
 var EvalFlagWithContext = func(flag *entity.Flag, evalContext models.EvalContext) *models.EvalResult {
-	flagID := util.SafeUint(evalContext.FlagID)
-	flagKey := util.SafeString(evalContext.FlagKey)
-
-	if flag == nil {
-		emptyFlag := &entity.Flag{Model: gorm.Model{ID: flagID}, Key: flagKey}
-		return BlankResult(emptyFlag, evalContext, fmt.Sprintf("flagID %v not found or deleted", flagID))
-	}
-
-	if !flag.Enabled {
-		return BlankResult(flag, evalContext, fmt.Sprintf("flagID %v is not enabled", flag.ID))
-	}
-
-	if len(flag.Segments) == 0 {
-		return BlankResult(flag, evalContext, fmt.Sprintf("flagID %v has no segments", flag.ID))
-	}
-
-	if evalContext.EntityID == "" {
-		evalContext.EntityID = fmt.Sprintf("randomly_generated_%d", rand.Int31())
-	}
-
-	if flag.EntityType != "" {
-		evalContext.EntityType = flag.EntityType
-	}
-
-	var vID int64
-	var sID int64
-	var logs []*models.SegmentDebugLog
-	if config.Config.EvalDebugEnabled && evalContext.EnableDebug {
-		logs = make([]*models.SegmentDebugLog, 0, len(flag.Segments))
-	}
-	for _, segment := range flag.Segments {
-		variantID, log, evalNextSegment := evalSegment(evalContext, segment)
-		if variantID != nil {
-			vID = int64(*variantID)
-			sID = int64(segment.ID)
-		}
-		if config.Config.EvalDebugEnabled && evalContext.EnableDebug {
-			logs = append(logs, log)
-		}
-		if !evalNextSegment {
-			break
-		}
-	}
-	evalResult := BlankResult(flag, evalContext, "")
-	evalResult.EvalDebugLog.SegmentDebugLogs = logs
-	evalResult.SegmentID = sID
-	evalResult.VariantID = vID
-	v := flag.FlagEvaluation.VariantsMap[util.SafeUint(vID)]
-	if v != nil {
-		evalResult.VariantAttachment = v.Attachment
-		evalResult.VariantKey = v.Key
+	e := evaluator.Evaluator{
+		Debug: config.Config.EvalDebugEnabled,
+		Record: func(r *models.EvalResult, flag *entity.Flag) {
+			logEvalResult(r, flag)
+		},
 	}
-
-	logEvalResult(evalResult, flag)
-	return evalResult
+	return e.Evaluate(flag, evalContext)
 }
 
 var logEvalResult = func(r *models.EvalResult, flag *entity.Flag) {
@@ -457,65 +388,7 @@ var evalSegment = func(
 	log *models.SegmentDebugLog,
 	evalNextSegment bool,
 ) {
-	debug := config.Config.EvalDebugEnabled && evalContext.EnableDebug
-
-	if len(segment.Constraints) != 0 {
-		m, ok := evalContext.EntityContext.(map[string]any)
-		if !ok {
-			if debug {
-				log = &models.SegmentDebugLog{
-					Msg:       fmt.Sprintf("constraints are present in the segment_id %v, but got invalid entity_context: %s.", segment.ID, spew.Sdump(evalContext.EntityContext)),
-					SegmentID: int64(segment.ID),
-				}
-			}
-			return nil, log, true
-		}
-
-		expr := segment.SegmentEvaluation.ConditionsExpr
-		match, err := conditions.Evaluate(expr, m)
-		if err != nil {
-			if debug {
-				log = &models.SegmentDebugLog{
-					Msg:       err.Error(),
-					SegmentID: int64(segment.ID),
-				}
-			}
-			return nil, log, true
-		}
-		if !match {
-			if debug {
-				log = &models.SegmentDebugLog{
-					Msg:       debugConstraintMsg(true, expr, m),
-					SegmentID: int64(segment.ID),
-				}
-			}
-			return nil, log, true
-		}
-	}
-
-	var debugMsg string
-	vID, debugMsg = segment.SegmentEvaluation.DistributionArray.Rollout(
-		evalContext.EntityID,
-		segment.SegmentEvaluation.FlagIDStr,
-		segment.RolloutPercent,
-		debug,
-	)
-
-	if debug {
-		log = &models.SegmentDebugLog{
-			Msg:       "matched all constraints. " + debugMsg,
-			SegmentID: int64(segment.ID),
-		}
-	}
-
-	return vID, log, false
-}
-
-func debugConstraintMsg(enableDebug bool, expr conditions.Expr, m map[string]any) string {
-	if !enableDebug {
-		return ""
-	}
-	return fmt.Sprintf("constraint not match. constraint: %s, entity_context: %+v.", expr, m)
+	return evaluator.EvalSegment(evalContext, segment, config.Config.EvalDebugEnabled && evalContext.EnableDebug)
 }
 
 var rateLimitMap = sync.Map{}
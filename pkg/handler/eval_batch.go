@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/openflagr/flagr/pkg/config"
+	"github.com/openflagr/flagr/swagger_gen/models"
+)
+
+// evalBatchExtraWorkers counts the worker goroutines of all batch requests,
+// besides their request goroutines, against FLAGR_EVAL_BATCH_MAX_WORKERS.
+var evalBatchExtraWorkers atomic.Int64
+
+// acquireEvalBatchWorkers reserves up to n extra workers under the global
+// cap and returns how many it got.
+func acquireEvalBatchWorkers(n int) int {
+	max := int64(config.Config.EvalBatchMaxWorkers)
+	for got := 0; got < n; got++ {
+		if evalBatchExtraWorkers.Add(1) > max {
+			evalBatchExtraWorkers.Add(-1)
+			return got
+		}
+	}
+	return n
+}
+
+func releaseEvalBatchWorkers(n int) {
+	evalBatchExtraWorkers.Add(-int64(n))
+}
+
+// evalBatchItems evaluates the eval contexts of a batch request, each with a
+// flag ID, key or tags, with up to workers workers: the calling goroutine and
+// as many extra ones as the global cap allows. Results are in the order of
+// items, as if they were evaluated one after the other.
+//
+// Items not started by the deadline of ctx are not evaluated. They get a
+// result with an error instead, and the response is marked partial.
+func evalBatchItems(ctx context.Context, items []models.EvalContext, workers int) *models.EvaluationBatchResponse {
+	results := make([][]*models.EvalResult, len(items))
+	var next atomic.Int64
+	work := func() {
+		for {
+			i := int(next.Add(1) - 1)
+			if i >= len(items) {
+				return
+			}
+			if err := ctx.Err(); err != nil {
+				results[i] = []*models.EvalResult{unevaluatedResult(items[i], err)}
+				continue
+			}
+			if len(items[i].FlagTags) > 0 {
+				results[i] = EvalFlagsByTags(items[i])
+			} else {
+				results[i] = []*models.EvalResult{EvalFlag(items[i])}
+			}
+		}
+	}
+
+	extra := 0
+	if workers > 1 && len(items) > 1 {
+		extra = acquireEvalBatchWorkers(min(workers, len(items)) - 1)
+		defer releaseEvalBatchWorkers(extra)
+	}
+	var wg sync.WaitGroup
+	for range extra {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			work()
+		}()
+	}
+	work()
+	wg.Wait()
+
+	resp := &models.EvaluationBatchResponse{EvaluationResults: make([]*models.EvalResult, 0, len(items))}
+	for _, rs := range results {
+		for _, r := range rs {
+			resp.Partial = resp.Partial || r.Error != ""
+		}
+		resp.EvaluationResults = append(resp.EvaluationResults, rs...)
+	}
+	return resp
+}
+
+// unevaluatedResult is the result of a batch item that was not evaluated. It
+// identifies the entity and the flag ID, key or tags of the item.
+func unevaluatedResult(evalContext models.EvalContext, err error) *models.EvalResult {
+	msg := fmt.Sprintf("not evaluated: %v", err)
+	r := BlankResult(nil, evalContext, msg)
+	r.FlagID = evalContext.FlagID
+	r.FlagKey = evalContext.FlagKey
+	r.FlagTags = evalContext.FlagTags
+	r.Error = msg
+	return r
+}
+
+// requestContext is the context of r, which is nil when handlers are called
+// directly.
+func requestContext(r *http.Request) context.Context {
+	if r == nil {
+		return context.Background()
+	}
+	return r.Context()
+}
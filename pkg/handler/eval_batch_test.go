@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openflagr/flagr/pkg/config"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func batchRequest(entities int, flagIDs []int64, flagKeys []string, flagTags []string) *models.EvaluationBatchRequest {
+	req := &models.EvaluationBatchRequest{FlagIDs: flagIDs, FlagKeys: flagKeys, FlagTags: flagTags}
+	for i := range entities {
+		req.Entities = append(req.Entities, &models.EvaluationEntity{EntityID: fmt.Sprintf("e%d", i)})
+	}
+	return req
+}
+
+// stubBatchEval stubs EvalFlag and EvalFlagsByTags with results identifying
+// the entity and flag, after sleeping for delay.
+func stubBatchEval(delay time.Duration) *gostub.Stubs {
+	stubs := gostub.Stub(&EvalFlag, func(ec models.EvalContext) *models.EvalResult {
+		time.Sleep(delay)
+		return &models.EvalResult{FlagID: ec.FlagID, FlagKey: ec.FlagKey, EvalContext: &ec}
+	})
+	return stubs.Stub(&EvalFlagsByTags, func(ec models.EvalContext) []*models.EvalResult {
+		time.Sleep(delay)
+		return []*models.EvalResult{
+			{FlagKey: "tagged1", EvalContext: &ec},
+			{FlagKey: "tagged2", EvalContext: &ec},
+		}
+	})
+}
+
+func batchResultKeys(resp *models.EvaluationBatchResponse) []string {
+	keys := make([]string, len(resp.EvaluationResults))
+	for i, r := range resp.EvaluationResults {
+		keys[i] = fmt.Sprintf("%s/%d/%s", r.EvalContext.EntityID, r.FlagID, r.FlagKey)
+	}
+	return keys
+}
+
+func TestEvaluateBatch_Parallel(t *testing.T) {
+	defer stubBatchEval(0).Reset()
+	req := func() *models.EvaluationBatchRequest {
+		return batchRequest(50, []int64{1, 2}, []string{"a", "b"}, []string{"t"})
+	}
+
+	sequential, errPayload := EvaluateBatch(context.Background(), req(), nil)
+	require.Nil(t, errPayload)
+	require.Len(t, sequential.EvaluationResults, 50*6)
+	assert.Equal(t, []string{"e0/0/tagged1", "e0/0/tagged2", "e0/1/", "e0/2/", "e0/0/a", "e0/0/b"}, batchResultKeys(sequential)[:6])
+	assert.False(t, sequential.Partial)
+
+	defer gostub.Stub(&config.Config.EvalBatchWorkers, 8).Reset()
+	parallel, errPayload := EvaluateBatch(context.Background(), req(), nil)
+	require.Nil(t, errPayload)
+	assert.Equal(t, batchResultKeys(sequential), batchResultKeys(parallel))
+	assert.False(t, parallel.Partial)
+	assert.Zero(t, evalBatchExtraWorkers.Load())
+
+	r := req()
+	r.Workers = 4
+	perRequest, errPayload := EvaluateBatch(context.Background(), r, nil)
+	require.Nil(t, errPayload)
+	assert.Equal(t, batchResultKeys(sequential), batchResultKeys(perRequest))
+}
+
+func TestEvalBatchWorkers(t *testing.T) {
+	defer gostub.Stub(&config.Config.EvalBatchWorkers, 2).Reset()
+	defer gostub.Stub(&config.Config.EvalBatchMaxWorkers, 7).Reset()
+
+	assert.Equal(t, 2, evalBatchWorkers(&models.EvaluationBatchRequest{}), "the server default")
+	assert.Equal(t, 1, evalBatchWorkers(&models.EvaluationBatchRequest{Workers: 1}), "a request can run sequentially")
+	assert.Equal(t, 6, evalBatchWorkers(&models.EvaluationBatchRequest{Workers: 6}))
+	assert.Equal(t, 8, evalBatchWorkers(&models.EvaluationBatchRequest{Workers: 1000}), "capped by the extra workers")
+}
+
+func TestEvaluateBatch_Deadline(t *testing.T) {
+	defer stubBatchEval(10 * time.Millisecond).Reset()
+	defer gostub.Stub(&config.Config.EvalBatchWorkers, 2).Reset()
+	defer gostub.Stub(&config.Config.EvalBatchTimeout, 25*time.Millisecond).Reset()
+
+	resp, errPayload := EvaluateBatch(context.Background(), batchRequest(20, []int64{1}, []string{"a"}, nil), nil)
+	require.Nil(t, errPayload)
+	require.Len(t, resp.EvaluationResults, 40)
+	assert.True(t, resp.Partial)
+
+	evaluated := 0
+	for i, r := range resp.EvaluationResults {
+		assert.Equal(t, fmt.Sprintf("e%d", i/2), r.EvalContext.EntityID)
+		if r.Error == "" {
+			evaluated++
+			continue
+		}
+		assert.Equal(t, "not evaluated: context deadline exceeded", r.Error)
+		if i%2 == 0 {
+			assert.Equal(t, int64(1), r.FlagID)
+		} else {
+			assert.Equal(t, "a", r.FlagKey)
+		}
+	}
+	assert.Greater(t, evaluated, 0)
+	assert.Less(t, evaluated, 40)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resp, errPayload = EvaluateBatch(ctx, batchRequest(1, nil, nil, []string{"t"}), nil)
+	require.Nil(t, errPayload)
+	require.Len(t, resp.EvaluationResults, 1)
+	assert.Equal(t, []string{"t"}, resp.EvaluationResults[0].FlagTags)
+	assert.Equal(t, "not evaluated: context canceled", resp.EvaluationResults[0].Error)
+}
+
+func TestEvaluateBatch_BatchSizeFirst(t *testing.T) {
+	var calls atomic.Int64
+	defer gostub.Stub(&EvalFlag, func(ec models.EvalContext) *models.EvalResult {
+		calls.Add(1)
+		return &models.EvalResult{}
+	}).Reset()
+	defer gostub.Stub(&config.Config.EvalBatchWorkers, 8).Reset()
+	defer gostub.Stub(&config.Config.EvalBatchSize, 10).Reset()
+
+	_, errPayload := EvaluateBatch(context.Background(), batchRequest(6, []int64{1, 2}, nil, nil), nil)
+	require.NotNil(t, errPayload)
+	assert.Contains(t, *errPayload.Message, "exceeds maximum allowed size of 10")
+	assert.Zero(t, calls.Load())
+}
+
+func TestAcquireEvalBatchWorkers(t *testing.T) {
+	defer gostub.Stub(&config.Config.EvalBatchMaxWorkers, 3).Reset()
+
+	assert.Equal(t, 2, acquireEvalBatchWorkers(2))
+	assert.Equal(t, 1, acquireEvalBatchWorkers(4))
+	assert.Equal(t, 0, acquireEvalBatchWorkers(1))
+	releaseEvalBatchWorkers(3)
+	assert.Zero(t, evalBatchExtraWorkers.Load())
+
+	// Without extra workers a batch is evaluated in the request goroutine.
+	defer stubBatchEval(0).Reset()
+	defer gostub.Stub(&config.Config.EvalBatchWorkers, 4).Reset()
+	config.Config.EvalBatchMaxWorkers = 0
+	resp, errPayload := EvaluateBatch(context.Background(), batchRequest(3, []int64{1}, nil, nil), nil)
+	require.Nil(t, errPayload)
+	assert.Len(t, resp.EvaluationResults, 3)
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/openflagr/flagr/pkg/config"
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/pkg/util"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/openflagr/flagr/swagger_gen/restapi/operations/evaluation"
+)
+
+func (e *eval) GetEvaluationBootstrap(params evaluation.GetEvaluationBootstrapParams) middleware.Responder {
+	var bootstrapReq models.EvaluationBootstrapRequest
+	if errPayload := decodeFromGetQuery(
+		params.HTTPRequest, len(params.HTTPRequest.URL.RawQuery), params.JSON, "evaluationBootstrapRequest", &bootstrapReq,
+		func() *models.Error {
+			return validateSwaggerModelAfterJSON(params.HTTPRequest, "evaluationBootstrapRequest", &bootstrapReq)
+		},
+	); errPayload != nil {
+		return evaluation.NewGetEvaluationBootstrapDefault(400).WithPayload(errPayload)
+	}
+
+	payload, etag, err := EvaluateBootstrap(&bootstrapReq, params.IfNoneMatch, params.HTTPRequest)
+	if err != nil {
+		return evaluation.NewGetEvaluationBootstrapDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	if payload == nil {
+		return evaluation.NewGetEvaluationBootstrapNotModified().WithETag(etag)
+	}
+	return evaluation.NewGetEvaluationBootstrapOK().WithETag(etag).WithPayload(payload)
+}
+
+func (e *eval) PostEvaluationBootstrap(params evaluation.PostEvaluationBootstrapParams) middleware.Responder {
+	if params.Body == nil {
+		return evaluation.NewPostEvaluationBootstrapDefault(400).WithPayload(
+			ErrorMessage("empty body"))
+	}
+
+	payload, etag, err := EvaluateBootstrap(params.Body, params.IfNoneMatch, params.HTTPRequest)
+	if err != nil {
+		return evaluation.NewPostEvaluationBootstrapDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	if payload == nil {
+		return evaluation.NewPostEvaluationBootstrapNotModified().WithETag(etag)
+	}
+	return evaluation.NewPostEvaluationBootstrapOK().WithETag(etag).WithPayload(payload)
+}
+
+// EvaluateBootstrap runs the same logic as POST/GET /evaluation/bootstrap. It
+// returns a nil payload when ifNoneMatch matches the ETag, without evaluating
+// when it can.
+//
+// The ETag is derived from the version of the flags in the EvalCache and the
+// request, see versionETag, so it can be checked before evaluating. Otherwise
+// it is computed from the evaluated flags.
+func EvaluateBootstrap(bootstrapReq *models.EvaluationBootstrapRequest, ifNoneMatch *string, r *http.Request) (*models.EvaluationBootstrapResponse, string, error) {
+	ec := GetEvalCache()
+	etag, err := versionETag(ec, bootstrapReq)
+	if err != nil {
+		return nil, "", err
+	}
+	if etag != "" {
+		if ifNoneMatch != nil && etagMatches(*ifNoneMatch, etag) {
+			return nil, etag, nil
+		}
+		return evalBootstrap(ec, bootstrapReq, r), etag, nil
+	}
+
+	// hash the request before evalBootstrap injects built-in context into it
+	requestETag, err := jsonETag(bootstrapReq)
+	if err != nil {
+		return nil, "", err
+	}
+	payload := evalBootstrap(ec, bootstrapReq, r)
+	etag, err = jsonETag(bootstrapETagInput{Request: requestETag, Flags: payload.Flags})
+	if err != nil {
+		return nil, "", err
+	}
+	if ifNoneMatch != nil && etagMatches(*ifNoneMatch, etag) {
+		return nil, etag, nil
+	}
+	return payload, etag, nil
+}
+
+// versionETag returns the ETag of a request evaluated against the version of
+// the flags in the EvalCache (the flag_snapshot MAX(id), or the source
+// revision in eval-only mode). It returns "" when the same request may
+// evaluate differently for the same version: for eval-only sources without a
+// revision, and with injected context, whose time and HTTP headers
+// constraints can depend on.
+func versionETag(ec *EvalCache, request any) (string, error) {
+	version := ec.version()
+	if version == "" || config.Config.InjectedContextEnabled {
+		return "", nil
+	}
+	return jsonETag(bootstrapETagInput{Version: version, Request: request})
+}
+
+type bootstrapETagInput struct {
+	Version string
+	Request any
+	Flags   map[string]models.EvaluationBootstrapFlag `json:",omitempty"`
+}
+
+// evalBootstrap evaluates every enabled flag selected by bootstrapReq for its
+// entity. Each evaluation is a normal evaluation for metrics and data
+// recorders.
+func evalBootstrap(ec *EvalCache, bootstrapReq *models.EvaluationBootstrapRequest, r *http.Request) *models.EvaluationBootstrapResponse {
+	var fs []*entity.Flag
+	if len(bootstrapReq.FlagTags) > 0 {
+		fs = ec.GetByTags(bootstrapReq.FlagTags, bootstrapReq.FlagTagsOperator)
+	} else {
+		fs = ec.getAllByKey()
+	}
+
+	evalContext := models.EvalContext{
+		EntityID:      util.SafeString(bootstrapReq.EntityID),
+		EntityType:    bootstrapReq.EntityType,
+		EntityContext: InjectBuiltInContext(bootstrapReq.EntityContext, r),
+	}
+	payload := &models.EvaluationBootstrapResponse{
+		Flags: make(map[string]models.EvaluationBootstrapFlag, len(fs)),
+	}
+	for _, f := range fs {
+		if !f.Enabled || f.Key == "" {
+			continue
+		}
+		if bootstrapReq.FlagEntityType != "" && f.EntityType != bootstrapReq.FlagEntityType {
+			continue
+		}
+		ctx := evalContext
+		ctx.FlagKey = f.Key
+		result := EvalFlagWithContext(f, ctx)
+		if result.VariantID == 0 {
+			continue
+		}
+		payload.Flags[f.Key] = models.EvaluationBootstrapFlag{
+			VariantKey:        result.VariantKey,
+			VariantAttachment: result.VariantAttachment,
+		}
+	}
+	return payload
+}
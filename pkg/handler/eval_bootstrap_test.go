@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/openflagr/flagr/pkg/config"
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/openflagr/flagr/swagger_gen/restapi/operations/evaluation"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestPostEvaluationBootstrap(t *testing.T) {
+	evaluated := 0
+	defer gostub.Stub(&logEvalResult, func(*models.EvalResult, *entity.Flag) { evaluated++ }).Reset()
+	ec := genFixtureOFREPEvalCache()
+	defer gostub.StubFunc(&GetEvalCache, ec).Reset()
+	e := NewEval()
+
+	post := func(req *models.EvaluationBootstrapRequest, ifNoneMatch *string) any {
+		return e.PostEvaluationBootstrap(evaluation.PostEvaluationBootstrapParams{
+			HTTPRequest: httptest.NewRequest("POST", "/api/v1/evaluation/bootstrap", nil),
+			IfNoneMatch: ifNoneMatch,
+			Body:        req,
+		})
+	}
+	entityID := "user1"
+	req := func(state string) *models.EvaluationBootstrapRequest {
+		return &models.EvaluationBootstrapRequest{
+			EntityID:      &entityID,
+			EntityContext: map[string]any{"dl_state": state},
+		}
+	}
+
+	t.Run("evaluates all enabled flags", func(t *testing.T) {
+		ok, isOK := post(req("CA"), nil).(*evaluation.PostEvaluationBootstrapOK)
+		require.True(t, isOK)
+		require.Len(t, ok.Payload.Flags, 2)
+		assert.Contains(t, []string{"control", "treatment"}, ok.Payload.Flags["flag_key_100"].VariantKey)
+		assert.Equal(t, "control", ok.Payload.Flags["single_variant"].VariantKey)
+		assert.NotContains(t, ok.Payload.Flags, "disabled")
+		assert.NotEmpty(t, ok.ETag)
+
+		ok = post(req("NY"), nil).(*evaluation.PostEvaluationBootstrapOK)
+		assert.Len(t, ok.Payload.Flags, 1, "flags without a variant are left out")
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		r := req("CA")
+		r.FlagTags = []string{"missing"}
+		ok := post(r, nil).(*evaluation.PostEvaluationBootstrapOK)
+		assert.Empty(t, ok.Payload.Flags)
+
+		r = req("CA")
+		r.FlagTags = []string{"tag1"}
+		ok = post(r, nil).(*evaluation.PostEvaluationBootstrapOK)
+		assert.Len(t, ok.Payload.Flags, 2)
+
+		r = req("CA")
+		r.FlagEntityType = "device"
+		ok = post(r, nil).(*evaluation.PostEvaluationBootstrapOK)
+		assert.Empty(t, ok.Payload.Flags)
+	})
+
+	t.Run("etag from the evaluated flags without a cache version", func(t *testing.T) {
+		etag := post(req("CA"), nil).(*evaluation.PostEvaluationBootstrapOK).ETag
+		nm, isNotModified := post(req("CA"), &etag).(*evaluation.PostEvaluationBootstrapNotModified)
+		require.True(t, isNotModified)
+		assert.Equal(t, etag, nm.ETag)
+
+		_, isOK := post(req("NY"), &etag).(*evaluation.PostEvaluationBootstrapOK)
+		assert.True(t, isOK)
+	})
+
+	t.Run("etag from the snapshot max ID skips evaluation", func(t *testing.T) {
+		ec.lastSnapshotMaxID = 7
+		defer func() { ec.lastSnapshotMaxID = 0 }()
+
+		etag := post(req("CA"), nil).(*evaluation.PostEvaluationBootstrapOK).ETag
+		evaluated = 0
+		_, isNotModified := post(req("CA"), &etag).(*evaluation.PostEvaluationBootstrapNotModified)
+		assert.True(t, isNotModified)
+		assert.Zero(t, evaluated)
+
+		ok, isOK := post(req("NY"), &etag).(*evaluation.PostEvaluationBootstrapOK)
+		require.True(t, isOK, "a different context must not match")
+		assert.NotEqual(t, etag, ok.ETag)
+
+		ec.lastSnapshotMaxID = 8
+		_, isOK = post(req("CA"), &etag).(*evaluation.PostEvaluationBootstrapOK)
+		assert.True(t, isOK, "a new snapshot must not match")
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		_, isDefault := post(nil, nil).(*evaluation.PostEvaluationBootstrapDefault)
+		assert.True(t, isDefault)
+	})
+}
+
+func TestPostEvaluationBootstrapInjectedContext(t *testing.T) {
+	defer gostub.StubFunc(&logEvalResult).Reset()
+	defer gostub.Stub(&config.Config.InjectedContextEnabled, true).
+		Stub(&config.Config.InjectedContextHTTPHeaders, []string{"X-Tier"}).Reset()
+	ResetHeaderMatchCache()
+	defer ResetHeaderMatchCache()
+
+	f := entity.GenFixtureFlag()
+	f.Segments[0].Constraints[0].Property = "@http_x_tier"
+	f.Segments[0].Constraints[0].Value = `"gold"`
+	f.Segments[0].Distributions = []entity.Distribution{
+		{Model: gorm.Model{ID: 400}, VariantID: 300, VariantKey: "control", Percent: 100},
+	}
+	f.PrepareEvaluation()
+	ec := GenFixtureEvalCacheWithFlags([]entity.Flag{f})
+	ec.lastSnapshotMaxID = 7
+	defer gostub.StubFunc(&GetEvalCache, ec).Reset()
+	e := NewEval()
+
+	entityID := "user1"
+	post := func(tier string, ifNoneMatch *string) any {
+		r := httptest.NewRequest("POST", "/api/v1/evaluation/bootstrap", nil)
+		r.Header.Set("X-Tier", tier)
+		return e.PostEvaluationBootstrap(evaluation.PostEvaluationBootstrapParams{
+			HTTPRequest: r,
+			IfNoneMatch: ifNoneMatch,
+			Body:        &models.EvaluationBootstrapRequest{EntityID: &entityID},
+		})
+	}
+
+	ok := post("gold", nil).(*evaluation.PostEvaluationBootstrapOK)
+	require.Contains(t, ok.Payload.Flags, f.Key)
+	etag := ok.ETag
+
+	ok, isOK := post("silver", &etag).(*evaluation.PostEvaluationBootstrapOK)
+	require.True(t, isOK, "a header that changes the result must not match")
+	assert.Empty(t, ok.Payload.Flags)
+	assert.NotEqual(t, etag, ok.ETag)
+
+	_, isNotModified := post("gold", &etag).(*evaluation.PostEvaluationBootstrapNotModified)
+	assert.True(t, isNotModified)
+}
+
+func TestGetEvaluationBootstrap(t *testing.T) {
+	defer gostub.StubFunc(&logEvalResult).Reset()
+	defer gostub.StubFunc(&GetEvalCache, genFixtureOFREPEvalCache()).Reset()
+	e := NewEval()
+
+	get := func(jsonParam string) any {
+		r := httptest.NewRequest("GET", "/api/v1/evaluation/bootstrap?json="+url.QueryEscape(jsonParam), nil)
+		return e.GetEvaluationBootstrap(evaluation.GetEvaluationBootstrapParams{HTTPRequest: r, JSON: jsonParam})
+	}
+
+	ok, isOK := get(`{"entityID": "user1", "entityContext": {"dl_state": "CA"}}`).(*evaluation.GetEvaluationBootstrapOK)
+	require.True(t, isOK)
+	assert.Len(t, ok.Payload.Flags, 2)
+
+	for _, bad := range []string{``, `{`, `{"entityContext": {}}`, `{"entityID": "u", "flagTagsOperator": "SOME"}`} {
+		_, isDefault := get(bad).(*evaluation.GetEvaluationBootstrapDefault)
+		assert.True(t, isDefault, bad)
+	}
+}
@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"fmt"
 	"maps"
+	"sort"
 	"sync"
 	"time"
 
@@ -52,8 +54,8 @@ type EvalCache struct {
 
 	// fetcher is the source of flag data. It is created once in Start()
 	// and reused across refresh cycles. For DB mode it wraps *gorm.DB;
-	// for eval-only mode (json_file, json_http) it reads from the
-	// configured file or HTTP endpoint.
+	// for eval-only mode (json_file, json_http, file_dir) it reads from the
+	// configured file, HTTP endpoint or directory.
 	fetcher evalCacheFetcher
 
 	// lastSnapshotMaxID tracks the highest flag_snapshot ID seen on the last
@@ -61,6 +63,15 @@ type EvalCache struct {
 	// because every API mutation that affects eval data creates a snapshot.
 	// lastSnapshotMaxID > 0 indicates at least one successful load has occurred.
 	lastSnapshotMaxID uint
+
+	// lastRevision is the source revision reported by fetchers implementing
+	// evalCacheRevisioner on the last successful reload. Empty when the
+	// fetcher has no revision or it could not be read.
+	lastRevision string
+
+	// updatedCh is closed and cleared when newly loaded flags are swapped in,
+	// see updated.
+	updatedCh chan struct{}
 }
 
 // GetEvalCache gets the EvalCache
@@ -95,6 +106,15 @@ func (ec *EvalCache) Start() {
 	}()
 }
 
+// close releases the resources of the fetcher, see evalCacheCloser.
+func (ec *EvalCache) close() {
+	if c, ok := ec.fetcher.(evalCacheCloser); ok {
+		if err := c.close(); err != nil {
+			logrus.WithError(err).Error("failed to close evaluation cache fetcher")
+		}
+	}
+}
+
 func (ec *EvalCache) GetByTags(tags []string, operator *string) []*entity.Flag {
 	var results map[uint]*entity.Flag
 
@@ -176,13 +196,38 @@ func (ec *EvalCache) GetByFlagKeyOrID(keyOrID any) *entity.Flag {
 	return f
 }
 
+// getAllByKey returns every cached flag that has a key, ordered by key.
+func (ec *EvalCache) getAllByKey() []*entity.Flag {
+	ec.cacheMutex.RLock()
+	fs := make([]*entity.Flag, 0, len(ec.cache.keyCache))
+	for _, f := range ec.cache.keyCache {
+		fs = append(fs, f)
+	}
+	ec.cacheMutex.RUnlock()
+
+	sort.Slice(fs, func(i, j int) bool { return fs[i].Key < fs[j].Key })
+	return fs
+}
+
+// updated returns a channel that is closed the next time reloadMapCache swaps
+// in newly loaded flags. Short-circuited reloads do not close it.
+func (ec *EvalCache) updated() <-chan struct{} {
+	ec.cacheMutex.Lock()
+	defer ec.cacheMutex.Unlock()
+	if ec.updatedCh == nil {
+		ec.updatedCh = make(chan struct{})
+	}
+	return ec.updatedCh
+}
+
 // getSnapshotMaxID queries the latest flag_snapshot id. Returns 0 on error.
 // This is the lightweight change indicator used by the EvalCache to decide
 // whether a full reload is needed.
 func (ec *EvalCache) getSnapshotMaxID() uint {
-	// In eval-only mode (json_file, json_http), there is no database.
-	// Return 0 so shortCircuitReload never short-circuits, forcing a
-	// fresh fetch from the JSON source on every poll interval.
+	// In eval-only mode (json_file, json_http, file_dir), there is no
+	// database. Return 0 so shortCircuitReload never short-circuits; fetchers
+	// that report a revision are short-circuited by shortCircuitRevision
+	// instead, everything else is fetched fresh on every poll interval.
 	if config.Config.EvalOnlyMode {
 		return 0
 	}
@@ -205,6 +250,53 @@ func (ec *EvalCache) shortCircuitReload(snapshotMaxID uint) bool {
 	return snapshotMaxID == ec.lastSnapshotMaxID && ec.lastSnapshotMaxID > 0
 }
 
+// revision returns the source revision of the flags currently in the cache,
+// e.g. the git commit SHA for json_git. Empty when the source has none.
+func (ec *EvalCache) revision() string {
+	ec.cacheMutex.RLock()
+	defer ec.cacheMutex.RUnlock()
+	return ec.lastRevision
+}
+
+// version identifies the flags currently in the cache across Flagr
+// instances: the flag_snapshot MAX(id) they were loaded at, or the revision
+// of an eval-only source. Empty when neither is known.
+func (ec *EvalCache) version() string {
+	ec.cacheMutex.RLock()
+	defer ec.cacheMutex.RUnlock()
+	switch {
+	case ec.lastSnapshotMaxID > 0:
+		return fmt.Sprintf("snapshot:%d", ec.lastSnapshotMaxID)
+	case ec.lastRevision != "":
+		return "revision:" + ec.lastRevision
+	}
+	return ""
+}
+
+// getRevision returns the current revision of the fetcher's source, or "" when
+// the fetcher does not report one or the lookup fails (forcing a full fetch).
+func (ec *EvalCache) getRevision() string {
+	r, ok := ec.getFetcher().(evalCacheRevisioner)
+	if !ok {
+		return ""
+	}
+	rev, err := r.revision()
+	if err != nil {
+		logrus.WithField("err", err).Warn(
+			"failed to read eval cache source revision, falling back to full reload")
+		return ""
+	}
+	return rev
+}
+
+// shortCircuitRevision reports whether the source revision is unchanged since
+// the last successful reload.
+func (ec *EvalCache) shortCircuitRevision(revision string) bool {
+	ec.cacheMutex.RLock()
+	defer ec.cacheMutex.RUnlock()
+	return revision != "" && revision == ec.lastRevision
+}
+
 // reloadMapCache reloads the evaluation cache from the database. It short-circuits
 // when no new flag_snapshots have been created, since every API mutation that
 // affects evaluation data (flags, segments, variants, constraints, distributions,
@@ -218,13 +310,14 @@ func (ec *EvalCache) reloadMapCache() error {
 	// for both the short-circuit decision and the post-reload store guarantees
 	// that lastSnapshotMaxID is never newer than the data in the cache.
 	preFetchMaxID := ec.getSnapshotMaxID()
+	preFetchRevision := ec.getRevision()
 
-	if ec.shortCircuitReload(preFetchMaxID) {
+	if ec.shortCircuitReload(preFetchMaxID) || ec.shortCircuitRevision(preFetchRevision) {
 		return nil
 	}
 
 	_, _, err := withtimeout.Do(ec.refreshTimeout, func() (any, error) {
-		idCache, keyCache, tagCache, err := ec.loadAndBuildCaches()
+		idCache, keyCache, tagCache, err := ec.loadAndBuildCaches(preFetchRevision)
 		if err != nil {
 			return nil, err
 		}
@@ -236,6 +329,11 @@ func (ec *EvalCache) reloadMapCache() error {
 			tagCache: tagCache,
 		}
 		ec.lastSnapshotMaxID = preFetchMaxID
+		ec.lastRevision = preFetchRevision
+		if ec.updatedCh != nil {
+			close(ec.updatedCh)
+			ec.updatedCh = nil
+		}
 		ec.cacheMutex.Unlock()
 
 		return nil, nil
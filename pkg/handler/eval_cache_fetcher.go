@@ -10,6 +10,7 @@ import (
 
 	"github.com/openflagr/flagr/pkg/config"
 	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/pkg/evaluator"
 	"github.com/openflagr/flagr/pkg/util"
 	"github.com/openflagr/flagr/swagger_gen/restapi/operations/export"
 	"github.com/sirupsen/logrus"
@@ -109,7 +110,9 @@ func (ec *EvalCache) export(query export.GetExportEvalCacheJSONParams) EvalCache
 
 // loadAndBuildCaches fetches all flags from the configured fetcher and builds
 // the three lookup caches (idCache, keyCache, tagCache) used by the EvalCache.
-func (ec *EvalCache) loadAndBuildCaches() (idCache map[string]*entity.Flag, keyCache map[string]*entity.Flag, tagCache map[string]map[uint]*entity.Flag, err error) {
+// revision is the source revision the fetch is expected to return; it is
+// stamped on every flag so eval results can report it.
+func (ec *EvalCache) loadAndBuildCaches(revision string) (idCache map[string]*entity.Flag, keyCache map[string]*entity.Flag, tagCache map[string]map[uint]*entity.Flag, err error) {
 	fs, err := ec.getFetcher().fetch()
 	if err != nil {
 		return nil, nil, nil, err
@@ -124,6 +127,7 @@ func (ec *EvalCache) loadAndBuildCaches() (idCache map[string]*entity.Flag, keyC
 		if err := f.PrepareEvaluation(); err != nil {
 			return nil, nil, nil, err
 		}
+		f.FlagEvaluation.SourceRevision = revision
 
 		if f.ID != 0 {
 			idCache[util.SafeString(f.ID)] = f
@@ -147,6 +151,20 @@ type evalCacheFetcher interface {
 	fetch() ([]entity.Flag, error)
 }
 
+// evalCacheRevisioner is implemented by eval-only fetchers that can cheaply
+// report the current revision of their source. The EvalCache skips the fetch
+// while the revision stays the same, the way it does with flag_snapshots in
+// DB mode.
+type evalCacheRevisioner interface {
+	revision() (string, error)
+}
+
+// evalCacheCloser is implemented by fetchers holding resources, e.g. a local
+// clone, to release when the server shuts down.
+type evalCacheCloser interface {
+	close() error
+}
+
 func newFetcher() (evalCacheFetcher, error) {
 	if !config.Config.EvalOnlyMode {
 		return &dbFetcher{db: getDB()}, nil
@@ -157,6 +175,22 @@ func newFetcher() (evalCacheFetcher, error) {
 		return &jsonFileFetcher{filePath: config.Config.DBConnectionStr}, nil
 	case "json_http":
 		return &jsonHTTPFetcher{url: config.Config.DBConnectionStr}, nil
+	case "file_dir":
+		return &dirFetcher{dir: config.Config.DBConnectionStr}, nil
+	case "json_git":
+		return &gitFetcher{
+			url:      gitRemoteURL(config.Config.DBConnectionStr),
+			ref:      config.Config.DBGitRef,
+			path:     config.Config.DBGitPath,
+			cloneDir: config.Config.DBGitCloneDir,
+			timeout:  config.Config.EvalCacheRefreshTimeout,
+		}, nil
+	case "json_s3":
+		sf, err := newS3Fetcher(config.Config.DBConnectionStr)
+		if err != nil {
+			return nil, err
+		}
+		return sf, nil
 	default:
 		return nil, fmt.Errorf(
 			"failed to create evaluation cache fetcher. DBDriver:%s is not supported",
@@ -219,7 +253,12 @@ func unmarshalFlags(b []byte) ([]entity.Flag, error) {
 
 	// Validate after parsing — operates on entity structs directly,
 	// giving actionable warnings for hand-edited files.
-	result := ValidateFlags(ecj.Flags)
+	return prepareFlags(ecj.Flags, ValidateFlags(ecj.Flags))
+}
+
+// prepareFlags logs the validation result of freshly parsed flags, rejects
+// them if there are errors, and otherwise normalizes their IDs.
+func prepareFlags(flags []entity.Flag, result ValidationResult) ([]entity.Flag, error) {
 	if !result.OK() {
 		for _, e := range result.Errors {
 			logrus.Errorf("flag validation error: %s", e)
@@ -230,108 +269,14 @@ func unmarshalFlags(b []byte) ([]entity.Flag, error) {
 		logrus.Warnf("flag validation warning: %s", w)
 	}
 
-	normalizeIDs(ecj.Flags)
-	return ecj.Flags, nil
-}
-
-// setIfZeroAndBumpNext evaluates *target: if zero, sets it to next and
-// returns next+1 (to advance the counter); otherwise returns next unchanged.
-// Used by normalizeIDs to auto-assign sequential IDs to zero-valued entities.
-func setIfZeroAndBumpNext(target *uint, next uint) uint {
-	if *target == 0 {
-		*target = next
-		return next + 1
-	}
-	return next
+	normalizeIDs(flags)
+	return flags, nil
 }
 
-// normalizeIDs assigns sequential IDs to any entities with zero IDs.
-// This allows hand-edited JSON files to omit IDs entirely — the system
-// auto-generates unique ones. Entities with explicit non-zero IDs are
-// left untouched.
-//
-// All entity types use global counters (not per-flag) to match the
-// behavior of a real database where every table has its own auto-increment.
-// This also means IDs are stable if a flag is later migrated to a DB backend.
-//
-// Invariants:
-//   - Every entity type has globally unique IDs
-//   - Distribution.VariantID matches a Variant.ID in the same flag
-//   - Segment.FlagID, Constraint.SegmentID, Distribution.SegmentID are set
-func normalizeIDs(flags []entity.Flag) {
-	// Pass 1: find the max existing ID per type so we never collide
-	var nextFlagID, nextVariantID, nextSegmentID, nextConstraintID, nextDistributionID, nextTagID uint = 1, 1, 1, 1, 1, 1
-	for i := range flags {
-		if flags[i].ID >= nextFlagID {
-			nextFlagID = flags[i].ID + 1
-		}
-		for _, v := range flags[i].Variants {
-			if v.ID >= nextVariantID {
-				nextVariantID = v.ID + 1
-			}
-		}
-		for _, s := range flags[i].Segments {
-			if s.ID >= nextSegmentID {
-				nextSegmentID = s.ID + 1
-			}
-			for _, c := range s.Constraints {
-				if c.ID >= nextConstraintID {
-					nextConstraintID = c.ID + 1
-				}
-			}
-			for _, d := range s.Distributions {
-				if d.ID >= nextDistributionID {
-					nextDistributionID = d.ID + 1
-				}
-			}
-		}
-		for _, t := range flags[i].Tags {
-			if t.ID >= nextTagID {
-				nextTagID = t.ID + 1
-			}
-		}
-	}
-
-	// Pass 2: assign IDs where missing and fix parent references
-	for i := range flags {
-		nextFlagID = setIfZeroAndBumpNext(&flags[i].ID, nextFlagID)
-		for j := range flags[i].Variants {
-			nextVariantID = setIfZeroAndBumpNext(&flags[i].Variants[j].ID, nextVariantID)
-		}
-		for j := range flags[i].Segments {
-			nextSegmentID = setIfZeroAndBumpNext(&flags[i].Segments[j].ID, nextSegmentID)
-			flags[i].Segments[j].FlagID = flags[i].ID
-			for k := range flags[i].Segments[j].Constraints {
-				nextConstraintID = setIfZeroAndBumpNext(&flags[i].Segments[j].Constraints[k].ID, nextConstraintID)
-				flags[i].Segments[j].Constraints[k].SegmentID = flags[i].Segments[j].ID
-			}
-			for k := range flags[i].Segments[j].Distributions {
-				d := &flags[i].Segments[j].Distributions[k]
-				nextDistributionID = setIfZeroAndBumpNext(&d.ID, nextDistributionID)
-				d.SegmentID = flags[i].Segments[j].ID
-				// Resolve VariantID from VariantKey when VariantID is missing.
-				// This lets hand-edited files omit numeric variant IDs entirely —
-				// just set "VariantKey": "control" and the link is resolved.
-				if d.VariantID == 0 && d.VariantKey != "" {
-					found := false
-					for _, v := range flags[i].Variants {
-						if v.Key == d.VariantKey {
-							d.VariantID = v.ID
-							found = true
-							break
-						}
-					}
-					if !found {
-						logrus.Warnf("flag %q: distribution references unknown variant key %q", flags[i].Key, d.VariantKey)
-					}
-				}
-			}
-		}
-		for j := range flags[i].Tags {
-			nextTagID = setIfZeroAndBumpNext(&flags[i].Tags[j].ID, nextTagID)
-		}
-	}
-}
+// normalizeIDs assigns sequential IDs to any entities with zero IDs, see
+// evaluator.NormalizeIDs. The embeddable evaluator shares it so that flags
+// loaded in-process get the same IDs, and thus the same buckets, as here.
+var normalizeIDs = evaluator.NormalizeIDs
 
 type dbFetcher struct {
 	db *gorm.DB
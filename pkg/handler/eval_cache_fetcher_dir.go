@@ -0,0 +1,299 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/openflagr/flagr/pkg/entity"
+	"gopkg.in/yaml.v3"
+)
+
+// dirFetcher loads flags from every JSON and YAML file under a directory
+// tree (the "file_dir" DBDriver).
+type dirFetcher struct {
+	dir string
+}
+
+func (df *dirFetcher) fetch() ([]entity.Flag, error) {
+	flags, sources, err := ReadFlagsDir(df.dir)
+	if err != nil {
+		return nil, err
+	}
+	return prepareFlags(flags, ValidateFlagsWithSources(flags, sources))
+}
+
+// revision fingerprints the tree by path, size and modification time, so the
+// EvalCache only re-parses the files when one of them is added, removed or
+// changed.
+func (df *dirFetcher) revision() (string, error) {
+	paths, err := flagFilePaths(df.dir)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00%d\n", p, info.Size(), info.ModTime().UnixNano())
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// isFlagFile reports whether the file extension is one the directory loader reads.
+func isFlagFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// flagFilePaths returns the flag files under dir in lexical order. Hidden
+// files and directories (e.g. .git) are skipped.
+func flagFilePaths(dir string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() && isFlagFile(p) {
+			paths = append(paths, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// ReadFlagsDir reads every *.json, *.yaml and *.yml file under dir and
+// returns the merged flags in file order, together with where each flag was
+// defined. The flags are neither validated nor normalized.
+func ReadFlagsDir(dir string) ([]entity.Flag, []FlagSource, error) {
+	paths, err := flagFilePaths(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var flags []entity.Flag
+	var sources []FlagSource
+	for _, p := range paths {
+		fs, ss, err := ReadFlagsFile(p)
+		if err != nil {
+			return nil, nil, err
+		}
+		flags = append(flags, fs...)
+		sources = append(sources, ss...)
+	}
+	return flags, sources, nil
+}
+
+// ReadFlagsFile reads a single JSON or YAML flag file. A file holds either
+// an EvalCacheJSON object ({"Flags": [...]}), a list of flags, or a single
+// flag; YAML files may contain several documents.
+func ReadFlagsFile(path string) ([]entity.Flag, []FlagSource, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return parseFlagsFile(path, b)
+}
+
+// parseFlagsFile parses the content of a flag file, picking JSON or YAML by
+// the extension of name. name is also used in error messages and sources.
+func parseFlagsFile(name string, b []byte) ([]entity.Flag, []FlagSource, error) {
+	if strings.EqualFold(filepath.Ext(name), ".json") {
+		return parseJSONFlagsFile(name, b)
+	}
+	return parseYAMLFlagsFile(name, b)
+}
+
+func parseJSONFlagsFile(name string, b []byte) ([]entity.Flag, []FlagSource, error) {
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil, nil, nil
+	}
+
+	var doc any
+	if err := json.Unmarshal(b, &doc); err != nil {
+		var se *json.SyntaxError
+		if errors.As(err, &se) {
+			return nil, nil, fmt.Errorf("%s:%d: %v", name, lineAt(b, se.Offset), err)
+		}
+		return nil, nil, fmt.Errorf("%s: %v", name, err)
+	}
+
+	// Find the raw bytes and starting offset of every flag object.
+	dec := json.NewDecoder(bytes.NewReader(b))
+	var raws []json.RawMessage
+	var offsets []int64
+	readArray := func() error {
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+		for dec.More() {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return err
+			}
+			raws = append(raws, raw)
+			offsets = append(offsets, dec.InputOffset()-int64(len(raw)))
+		}
+		_, err := dec.Token()
+		return err
+	}
+
+	var err error
+	switch v := doc.(type) {
+	case []any:
+		err = readArray()
+	case map[string]any:
+		if !hasFlagsKey(v) {
+			raws = append(raws, json.RawMessage(bytes.TrimSpace(b)))
+			offsets = append(offsets, int64(len(b)-len(bytes.TrimLeft(b, " \t\r\n"))))
+			break
+		}
+		if _, err = dec.Token(); err != nil {
+			break
+		}
+		for dec.More() {
+			var key json.Token
+			if key, err = dec.Token(); err != nil {
+				break
+			}
+			if k, _ := key.(string); strings.EqualFold(k, "flags") {
+				if err = readArray(); err != nil {
+					break
+				}
+				continue
+			}
+			var skip json.RawMessage
+			if err = dec.Decode(&skip); err != nil {
+				break
+			}
+		}
+	default:
+		return nil, nil, fmt.Errorf("%s:1: expected a flag object, a list of flags or {\"Flags\": [...]}", name)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %v", name, err)
+	}
+
+	flags := make([]entity.Flag, 0, len(raws))
+	sources := make([]FlagSource, 0, len(raws))
+	for i, raw := range raws {
+		src := FlagSource{File: name, Line: lineAt(b, offsets[i])}
+		var f entity.Flag
+		if err := json.Unmarshal(raw, &f); err != nil {
+			return nil, nil, fmt.Errorf("%s: %v", src, err)
+		}
+		flags = append(flags, f)
+		sources = append(sources, src)
+	}
+	return flags, sources, nil
+}
+
+func parseYAMLFlagsFile(name string, b []byte) ([]entity.Flag, []FlagSource, error) {
+	var flags []entity.Flag
+	var sources []FlagSource
+
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	for {
+		var doc yaml.Node
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, nil, fmt.Errorf("%s: %v", name, err)
+		}
+		if len(doc.Content) == 0 {
+			continue
+		}
+
+		var nodes []*yaml.Node
+		root := doc.Content[0]
+		switch root.Kind {
+		case yaml.SequenceNode:
+			nodes = root.Content
+		case yaml.MappingNode:
+			nodes = []*yaml.Node{root}
+			for i := 0; i+1 < len(root.Content); i += 2 {
+				if strings.EqualFold(root.Content[i].Value, "flags") {
+					if root.Content[i+1].Kind != yaml.SequenceNode {
+						return nil, nil, fmt.Errorf("%s:%d: Flags must be a list", name, root.Content[i+1].Line)
+					}
+					nodes = root.Content[i+1].Content
+					break
+				}
+			}
+		default:
+			return nil, nil, fmt.Errorf("%s:%d: expected a flag mapping, a list of flags or Flags: [...]", name, root.Line)
+		}
+
+		for _, n := range nodes {
+			src := FlagSource{File: name, Line: n.Line}
+			f, err := decodeYAMLFlag(n)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", src, err)
+			}
+			flags = append(flags, f)
+			sources = append(sources, src)
+		}
+	}
+	return flags, sources, nil
+}
+
+// decodeYAMLFlag converts a YAML flag node to entity.Flag by way of JSON, so
+// YAML files accept exactly the same field names and value shapes as JSON.
+func decodeYAMLFlag(n *yaml.Node) (entity.Flag, error) {
+	var f entity.Flag
+	if n.Kind != yaml.MappingNode {
+		return f, fmt.Errorf("expected a flag mapping")
+	}
+	var v any
+	if err := n.Decode(&v); err != nil {
+		return f, err
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return f, err
+	}
+	err = json.Unmarshal(b, &f)
+	return f, err
+}
+
+func hasFlagsKey(m map[string]any) bool {
+	for k := range m {
+		if strings.EqualFold(k, "flags") {
+			return true
+		}
+	}
+	return false
+}
+
+// lineAt returns the 1-based line number of the byte offset in b.
+func lineAt(b []byte, offset int64) int {
+	if offset > int64(len(b)) {
+		offset = int64(len(b))
+	}
+	return bytes.Count(b[:offset], []byte("\n")) + 1
+}
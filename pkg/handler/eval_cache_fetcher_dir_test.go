@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFlagFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	p := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	return p
+}
+
+func TestReadFlagsDir(t *testing.T) {
+	t.Parallel()
+
+	t.Run("happy code path", func(t *testing.T) {
+		t.Parallel()
+		flags, sources, err := ReadFlagsDir("./testdata/flags_dir")
+		require.NoError(t, err)
+		require.Len(t, flags, 4)
+		require.Len(t, sources, 4)
+
+		keys := make([]string, len(flags))
+		for i, f := range flags {
+			keys[i] = f.Key
+		}
+		assert.Equal(t, []string{"checkout-v2", "banner-color", "banner-size", "search-ranking"}, keys)
+
+		assert.Equal(t, filepath.Join("testdata", "flags_dir", "checkout.json")+":3", sources[0].String())
+		assert.Equal(t, 2, sources[1].Line)
+		assert.Equal(t, 17, sources[2].Line)
+		assert.Equal(t, 1, sources[3].Line)
+
+		assert.Equal(t, "#ff0000", flags[1].Variants[0].Attachment["hex"])
+		assert.Equal(t, uint(50), flags[1].Segments[0].Distributions[1].Percent)
+		assert.Equal(t, "search", flags[3].Tags[0].Value)
+	})
+
+	t.Run("non-exists dir", func(t *testing.T) {
+		t.Parallel()
+		_, _, err := ReadFlagsDir("./testdata/non-exists")
+		assert.Error(t, err)
+	})
+
+	t.Run("single flag and list json", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeFlagFile(t, dir, "a.json", "{\n  \"Key\": \"a\"\n}\n")
+		writeFlagFile(t, dir, "b.json", "[\n  {\"Key\": \"b1\"},\n  {\"Key\": \"b2\"}\n]\n")
+		writeFlagFile(t, dir, "empty.json", "")
+
+		flags, sources, err := ReadFlagsDir(dir)
+		require.NoError(t, err)
+		require.Len(t, flags, 3)
+		assert.Equal(t, "a", flags[0].Key)
+		assert.Equal(t, 1, sources[0].Line)
+		assert.Equal(t, "b2", flags[2].Key)
+		assert.Equal(t, 3, sources[2].Line)
+	})
+
+	t.Run("json syntax error names file and line", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		p := writeFlagFile(t, dir, "bad.json", "{\n  \"Flags\": [\n    {\"Key\": \"x\",}\n  ]\n}\n")
+
+		_, _, err := ReadFlagsDir(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), p+":3:")
+	})
+
+	t.Run("json type error names file and flag line", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		p := writeFlagFile(t, dir, "bad.json", "{\"Flags\": [\n  {\"Key\": \"x\"},\n  {\"Key\": \"y\", \"Enabled\": \"yes\"}\n]}\n")
+
+		_, _, err := ReadFlagsDir(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), p+":3:")
+	})
+
+	t.Run("yaml errors name file and line", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		p := writeFlagFile(t, dir, "bad.yaml", "Key: x\nVariants:\n  - Key: a\n   - Key: b\n")
+
+		_, _, err := ReadFlagsDir(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), p+": yaml: line 2:")
+
+		dir = t.TempDir()
+		p = writeFlagFile(t, dir, "bad.yaml", "- Key: x\n- Key: y\n  Segments: nope\n")
+		_, _, err = ReadFlagsDir(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), p+":2:")
+	})
+}
+
+func TestValidateFlagsWithSources(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	a := writeFlagFile(t, dir, "a.yaml", "Key: dup\n---\nKey: broken\nSegments:\n  - RolloutPercent: 100\n    Distributions:\n      - VariantKey: nope\n        Percent: 100\n")
+	b := writeFlagFile(t, dir, "b.json", `{"Flags": [{"Key": "dup"}]}`)
+
+	flags, sources, err := ReadFlagsDir(dir)
+	require.NoError(t, err)
+
+	result := ValidateFlagsWithSources(flags, sources)
+	assert.Contains(t, result.Errors, `duplicate flag key "dup" (`+a+`:1, `+b+`:1)`)
+	assert.Contains(t, result.Errors, a+`:3: flag "broken", segment[0]: distribution references unknown variant key "nope"`)
+	assert.Contains(t, result.Warnings, a+`:1: flag "dup": no variants defined`)
+
+	// nil sources keep the plain ValidateFlags messages
+	assert.Equal(t, ValidateFlags(flags), ValidateFlagsWithSources(flags, nil))
+}
+
+func TestDirFetcher(t *testing.T) {
+	t.Parallel()
+
+	t.Run("happy code path", func(t *testing.T) {
+		t.Parallel()
+		df := &dirFetcher{dir: "./testdata/flags_dir"}
+		fs, err := df.fetch()
+		require.NoError(t, err)
+		require.Len(t, fs, 4)
+
+		// IDs are normalized across files
+		ids := map[uint]bool{}
+		for _, f := range fs {
+			assert.NotZero(t, f.ID)
+			ids[f.ID] = true
+			for _, s := range f.Segments {
+				for _, d := range s.Distributions {
+					assert.NotZero(t, d.VariantID)
+				}
+			}
+		}
+		assert.Len(t, ids, 4)
+	})
+
+	t.Run("validation errors block loading", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeFlagFile(t, dir, "a.yaml", "Key: dup\n")
+		writeFlagFile(t, dir, "b.yaml", "Key: dup\n")
+
+		df := &dirFetcher{dir: dir}
+		fs, err := df.fetch()
+		assert.Error(t, err)
+		assert.Nil(t, fs)
+	})
+
+	t.Run("revision follows file changes", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		p := writeFlagFile(t, dir, "a.yaml", "Key: a\n")
+		writeFlagFile(t, dir, "notes.txt", "ignored")
+
+		df := &dirFetcher{dir: dir}
+		rev1, err := df.revision()
+		require.NoError(t, err)
+		rev2, err := df.revision()
+		require.NoError(t, err)
+		assert.Equal(t, rev1, rev2)
+
+		// non-flag files don't count
+		writeFlagFile(t, dir, "notes.txt", "still ignored")
+		rev2, err = df.revision()
+		require.NoError(t, err)
+		assert.Equal(t, rev1, rev2)
+
+		require.NoError(t, os.WriteFile(p, []byte("Key: a2\n"), 0o644))
+		require.NoError(t, os.Chtimes(p, time.Now(), time.Now().Add(time.Second)))
+		rev3, err := df.revision()
+		require.NoError(t, err)
+		assert.NotEqual(t, rev1, rev3)
+
+		writeFlagFile(t, dir, "sub/b.json", `{"Key": "b"}`)
+		rev4, err := df.revision()
+		require.NoError(t, err)
+		assert.NotEqual(t, rev3, rev4)
+	})
+}
+
+func TestEvalCacheReloadsDirOnChange(t *testing.T) {
+	defer setDBDriverConfig("file_dir", true)()
+
+	dir := t.TempDir()
+	p := writeFlagFile(t, dir, "flags.yaml", "Key: a\nEnabled: true\n")
+
+	ec := &EvalCache{cache: &cacheContainer{}, refreshTimeout: time.Second}
+	spy := &countingFetcher{wrapped: &dirFetcher{dir: dir}}
+	ec.fetcher = &revisionedCountingFetcher{countingFetcher: spy, dir: &dirFetcher{dir: dir}}
+
+	require.NoError(t, ec.reloadMapCache())
+	assert.Equal(t, 1, spy.count)
+	assert.NotNil(t, ec.GetByFlagKeyOrID("a"))
+
+	require.NoError(t, ec.reloadMapCache())
+	assert.Equal(t, 1, spy.count, "unchanged directory should short-circuit")
+
+	require.NoError(t, os.WriteFile(p, []byte("Key: b\nEnabled: true\n"), 0o644))
+	require.NoError(t, os.Chtimes(p, time.Now(), time.Now().Add(time.Second)))
+	require.NoError(t, ec.reloadMapCache())
+	assert.Equal(t, 2, spy.count, "changed file should reload")
+	assert.Nil(t, ec.GetByFlagKeyOrID("a"))
+	assert.NotNil(t, ec.GetByFlagKeyOrID("b"))
+}
+
+// revisionedCountingFetcher is a countingFetcher that also reports the
+// revision of a dirFetcher.
+type revisionedCountingFetcher struct {
+	*countingFetcher
+	dir *dirFetcher
+}
+
+func (r *revisionedCountingFetcher) revision() (string, error) {
+	return r.dir.revision()
+}
@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openflagr/flagr/pkg/entity"
+)
+
+// gitFetcher loads flags from a path inside a git repository at a branch,
+// tag or commit (the "json_git" DBDriver). It keeps a bare clone in cloneDir
+// and shells out to the git binary, so every transport and credential helper
+// git supports works, including plain local paths and file:// URLs.
+//
+// The path may name a single JSON/YAML flag file or a directory, which is
+// read like the file_dir driver reads a directory tree.
+type gitFetcher struct {
+	url      string
+	ref      string
+	path     string
+	cloneDir string
+	timeout  time.Duration
+
+	mu     sync.Mutex
+	commit string
+	// tempDir is set when cloneDir is a temporary directory created by sync,
+	// which close removes.
+	tempDir bool
+	closed  bool
+}
+
+var errGitFetcherClosed = errors.New("git fetcher is closed")
+
+// revision fetches the repository and returns the commit SHA the ref
+// currently resolves to. fetch loads that same commit.
+func (gf *gitFetcher) revision() (string, error) {
+	gf.mu.Lock()
+	defer gf.mu.Unlock()
+	return gf.sync()
+}
+
+func (gf *gitFetcher) fetch() ([]entity.Flag, error) {
+	gf.mu.Lock()
+	defer gf.mu.Unlock()
+
+	if gf.closed {
+		return nil, errGitFetcherClosed
+	}
+	commit := gf.commit
+	if commit == "" {
+		var err error
+		if commit, err = gf.sync(); err != nil {
+			return nil, err
+		}
+	}
+
+	flags, sources, err := gf.readFlags(commit)
+	if err != nil {
+		return nil, err
+	}
+	return prepareFlags(flags, ValidateFlagsWithSources(flags, sources))
+}
+
+// sync clones the repository on first use, fetches all branches and tags,
+// and resolves the ref to a commit SHA.
+func (gf *gitFetcher) sync() (string, error) {
+	if gf.closed {
+		return "", errGitFetcherClosed
+	}
+	if gf.cloneDir == "" {
+		dir, err := os.MkdirTemp("", "flagr-git-")
+		if err != nil {
+			return "", err
+		}
+		gf.cloneDir, gf.tempDir = dir, true
+	}
+	if _, err := os.Stat(filepath.Join(gf.cloneDir, "HEAD")); err != nil {
+		if _, err := gf.git("", "init", "--bare", "--quiet", gf.cloneDir); err != nil {
+			return "", err
+		}
+	}
+
+	if _, err := gf.git(gf.cloneDir, "fetch", "--quiet", "--force", "--prune", "--no-tags", gf.url,
+		"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*"); err != nil {
+		return "", err
+	}
+
+	commit, err := gf.resolve()
+	if err != nil {
+		return "", err
+	}
+	gf.commit = commit
+	return commit, nil
+}
+
+// close removes the clone if it is in a temporary directory. Later revision
+// and fetch calls fail.
+func (gf *gitFetcher) close() error {
+	gf.mu.Lock()
+	defer gf.mu.Unlock()
+	gf.closed = true
+	if !gf.tempDir {
+		return nil
+	}
+	return os.RemoveAll(gf.cloneDir)
+}
+
+// resolve turns the ref into a commit SHA, preferring a branch over a tag of
+// the same name and falling back to anything git can resolve (e.g. a SHA).
+func (gf *gitFetcher) resolve() (string, error) {
+	for _, candidate := range []string{"refs/heads/" + gf.ref, "refs/tags/" + gf.ref, gf.ref} {
+		out, err := gf.git(gf.cloneDir, "rev-parse", "--verify", "--quiet", candidate+"^{commit}")
+		if err == nil {
+			return strings.TrimSpace(string(out)), nil
+		}
+	}
+	return "", fmt.Errorf("git ref %q not found in %s", gf.ref, gf.url)
+}
+
+// readFlags reads the flag file(s) under the configured path at commit.
+// Sources are reported as "<abbreviated commit>:<path-in-repo>" so validation
+// messages point at the exact file version.
+func (gf *gitFetcher) readFlags(commit string) ([]entity.Flag, []FlagSource, error) {
+	p := strings.Trim(path.Clean("/"+gf.path), "/")
+	out, err := gf.git(gf.cloneDir, "ls-tree", "-r", "-z", "--name-only", "--full-tree", commit, "--", p)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var files []string
+	for name := range strings.SplitSeq(strings.TrimRight(string(out), "\x00"), "\x00") {
+		if name == "" {
+			continue
+		}
+		if name == p {
+			// the configured path is a single file
+			files = []string{name}
+			break
+		}
+		if isFlagFile(name) && !isHiddenPath(strings.TrimPrefix(name, p+"/")) {
+			files = append(files, name)
+		}
+	}
+	if len(files) == 0 {
+		return nil, nil, fmt.Errorf("no flag files found at %q in commit %s", gf.path, commit)
+	}
+	sort.Strings(files)
+
+	var flags []entity.Flag
+	var sources []FlagSource
+	for _, name := range files {
+		b, err := gf.git(gf.cloneDir, "cat-file", "blob", commit+":"+name)
+		if err != nil {
+			return nil, nil, err
+		}
+		fs, ss, err := parseFlagsFile(commit[:min(len(commit), 12)]+":"+name, b)
+		if err != nil {
+			return nil, nil, err
+		}
+		flags = append(flags, fs...)
+		sources = append(sources, ss...)
+	}
+	return flags, sources, nil
+}
+
+// git runs a git subcommand in dir (unless empty) and returns its stdout.
+// Terminal prompts are disabled so a missing credential fails fast instead of
+// hanging the reload.
+func (gf *gitFetcher) git(dir string, args ...string) ([]byte, error) {
+	ctx := context.Background()
+	if gf.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, gf.timeout)
+		defer cancel()
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("git %s: %v: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// isHiddenPath reports whether any element of a slash-separated path starts
+// with a dot, mirroring the directories file_dir skips.
+func isHiddenPath(p string) bool {
+	for elem := range strings.SplitSeq(p, "/") {
+		if strings.HasPrefix(elem, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// gitRemoteURL makes a local repository path absolute, since git runs inside
+// the clone directory. URLs (scheme://...) and scp-like remotes (host:path)
+// are returned unchanged.
+func gitRemoteURL(s string) string {
+	if strings.Contains(s, "://") || (strings.Contains(s, ":") && !filepath.IsAbs(s) && !strings.HasPrefix(s, ".")) {
+		return s
+	}
+	if abs, err := filepath.Abs(s); err == nil {
+		return abs
+	}
+	return s
+}
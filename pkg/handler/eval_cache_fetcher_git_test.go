@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestGitRepo creates a git repository with a main branch and returns its
+// path and a function that commits the given files and returns the SHA.
+func newTestGitRepo(t *testing.T) (string, func(files map[string]string) string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=flagr", "-c", "user.email=flagr@example.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	run("init", "--quiet", "--initial-branch=main")
+
+	return dir, func(files map[string]string) string {
+		for name, content := range files {
+			writeFlagFile(t, dir, name, content)
+		}
+		run("add", "-A")
+		run("commit", "--quiet", "-m", "update flags")
+		return run("rev-parse", "HEAD")
+	}
+}
+
+func TestGitFetcher(t *testing.T) {
+	t.Parallel()
+
+	t.Run("branch, tag and file:// url", func(t *testing.T) {
+		t.Parallel()
+		repo, commit := newTestGitRepo(t)
+		sha1 := commit(map[string]string{"flags.json": `{"Flags": [{"Key": "a", "Enabled": true}]}`})
+
+		cmd := exec.Command("git", "tag", "v1")
+		cmd.Dir = repo
+		require.NoError(t, cmd.Run())
+
+		sha2 := commit(map[string]string{"flags.json": `{"Flags": [{"Key": "b", "Enabled": true}]}`})
+
+		gf := &gitFetcher{url: "file://" + repo, ref: "main", path: "flags.json", cloneDir: t.TempDir()}
+		rev, err := gf.revision()
+		require.NoError(t, err)
+		assert.Equal(t, sha2, rev)
+		fs, err := gf.fetch()
+		require.NoError(t, err)
+		require.Len(t, fs, 1)
+		assert.Equal(t, "b", fs[0].Key)
+
+		gf = &gitFetcher{url: repo, ref: "v1", path: "/flags.json", cloneDir: t.TempDir()}
+		rev, err = gf.revision()
+		require.NoError(t, err)
+		assert.Equal(t, sha1, rev)
+		fs, err = gf.fetch()
+		require.NoError(t, err)
+		require.Len(t, fs, 1)
+		assert.Equal(t, "a", fs[0].Key)
+
+		gf = &gitFetcher{url: repo, ref: sha1, path: "flags.json", cloneDir: t.TempDir()}
+		fs, err = gf.fetch()
+		require.NoError(t, err)
+		assert.Equal(t, "a", fs[0].Key)
+	})
+
+	t.Run("directory path", func(t *testing.T) {
+		t.Parallel()
+		repo, commit := newTestGitRepo(t)
+		commit(map[string]string{
+			"flags/a.yaml":           "Key: a\n",
+			"flags/team/b.json":      `{"Key": "b"}`,
+			"flags/.hidden/c.yaml":   "Key: c\n",
+			"flags/README.md":        "docs",
+			"other/ignored.yaml":     "Key: ignored\n",
+			"flags-not-a-dir/d.yaml": "Key: d\n",
+		})
+
+		gf := &gitFetcher{url: repo, ref: "main", path: "flags", cloneDir: t.TempDir()}
+		fs, err := gf.fetch()
+		require.NoError(t, err)
+		require.Len(t, fs, 2)
+		assert.Equal(t, "a", fs[0].Key)
+		assert.Equal(t, "b", fs[1].Key)
+	})
+
+	t.Run("follows new commits", func(t *testing.T) {
+		t.Parallel()
+		repo, commit := newTestGitRepo(t)
+		sha1 := commit(map[string]string{"flags.yaml": "Key: a\n"})
+
+		gf := &gitFetcher{url: repo, ref: "main", path: "flags.yaml", cloneDir: t.TempDir()}
+		rev, err := gf.revision()
+		require.NoError(t, err)
+		assert.Equal(t, sha1, rev)
+		rev, err = gf.revision()
+		require.NoError(t, err)
+		assert.Equal(t, sha1, rev)
+
+		sha2 := commit(map[string]string{"flags.yaml": "Key: b\n"})
+		rev, err = gf.revision()
+		require.NoError(t, err)
+		assert.Equal(t, sha2, rev)
+		fs, err := gf.fetch()
+		require.NoError(t, err)
+		assert.Equal(t, "b", fs[0].Key)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		repo, commit := newTestGitRepo(t)
+		sha := commit(map[string]string{"flags.yaml": "Key: a\nSegments:\n  - RolloutPercent: 200\n"})
+
+		_, err := (&gitFetcher{url: repo, ref: "nope", path: "flags.yaml", cloneDir: t.TempDir()}).revision()
+		assert.ErrorContains(t, err, `git ref "nope" not found`)
+
+		_, err = (&gitFetcher{url: repo, ref: "main", path: "missing.json", cloneDir: t.TempDir()}).fetch()
+		assert.ErrorContains(t, err, "no flag files found")
+
+		_, err = (&gitFetcher{url: filepath.Join(repo, "nope"), ref: "main", path: "flags.yaml", cloneDir: t.TempDir()}).fetch()
+		assert.ErrorContains(t, err, "git fetch")
+
+		gf := &gitFetcher{url: repo, ref: "main", path: "flags.yaml", cloneDir: t.TempDir()}
+		_, err = gf.fetch()
+		assert.ErrorContains(t, err, "flag validation failed")
+		flags, sources, err := gf.readFlags(sha)
+		require.NoError(t, err)
+		result := ValidateFlagsWithSources(flags, sources)
+		assert.Equal(t, []string{sha[:12] + `:flags.yaml:1: flag "a", segment[0]: RolloutPercent 200 out of range (0-100)`}, result.Errors)
+	})
+
+	t.Run("close removes a temporary clone", func(t *testing.T) {
+		t.Parallel()
+		repo, commit := newTestGitRepo(t)
+		commit(map[string]string{"flags.json": `{"Flags": [{"Key": "a", "Enabled": true}]}`})
+
+		gf := &gitFetcher{url: repo, ref: "main", path: "flags.json"}
+		_, err := gf.revision()
+		require.NoError(t, err)
+		assert.DirExists(t, gf.cloneDir)
+		require.NoError(t, gf.close())
+		assert.NoDirExists(t, gf.cloneDir)
+		_, err = gf.fetch()
+		assert.ErrorContains(t, err, "git fetcher is closed")
+
+		// A configured clone dir is kept for the next start.
+		gf = &gitFetcher{url: repo, ref: "main", path: "flags.json", cloneDir: t.TempDir()}
+		_, err = gf.revision()
+		require.NoError(t, err)
+		require.NoError(t, gf.close())
+		assert.FileExists(t, filepath.Join(gf.cloneDir, "HEAD"))
+	})
+}
+
+func TestGitRemoteURL(t *testing.T) {
+	t.Parallel()
+	wd, _ := os.Getwd()
+	assert.Equal(t, "https://github.com/org/flags.git", gitRemoteURL("https://github.com/org/flags.git"))
+	assert.Equal(t, "file:///srv/flags", gitRemoteURL("file:///srv/flags"))
+	assert.Equal(t, "git@github.com:org/flags.git", gitRemoteURL("git@github.com:org/flags.git"))
+	assert.Equal(t, "/srv/flags", gitRemoteURL("/srv/flags"))
+	assert.Equal(t, filepath.Join(wd, "flags"), gitRemoteURL("./flags"))
+}
+
+func TestEvalCacheGitRevision(t *testing.T) {
+	defer setDBDriverConfig("json_git", true)()
+
+	repo, commit := newTestGitRepo(t)
+	sha := commit(map[string]string{"flags.yaml": `
+Key: a
+Enabled: true
+Variants:
+  - Key: "on"
+Segments:
+  - RolloutPercent: 100
+    Distributions:
+      - VariantKey: "on"
+        Percent: 100
+`})
+
+	ec := &EvalCache{cache: &cacheContainer{}, refreshTimeout: time.Second}
+	ec.fetcher = &gitFetcher{url: repo, ref: "main", path: "flags.yaml", cloneDir: t.TempDir()}
+	require.NoError(t, ec.reloadMapCache())
+	assert.Equal(t, sha, ec.revision())
+
+	f := ec.GetByFlagKeyOrID("a")
+	require.NotNil(t, f)
+	result := EvalFlagWithContext(f, models.EvalContext{EntityID: "e1"})
+	assert.Equal(t, sha, result.FlagSourceRevision)
+	assert.Equal(t, "on", result.VariantKey)
+}
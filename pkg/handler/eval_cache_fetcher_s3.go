@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/openflagr/flagr/pkg/config"
+	"github.com/openflagr/flagr/pkg/entity"
+)
+
+// s3Fetcher reads an EvalCacheJSON object from an S3-compatible bucket (the
+// "json_s3" DBDriver). Its revision is the object ETag, read with a HEAD
+// request, so the EvalCache only downloads the object when it changed. The
+// download is conditional on that ETag, so the flags are never tagged with
+// the revision of another version of the object.
+type s3Fetcher struct {
+	client  *s3.Client
+	bucket  string
+	key     string
+	timeout time.Duration
+
+	// etag is the ETag read by the last revision call, as sent by S3. The
+	// EvalCache calls revision and fetch in turn, never concurrently.
+	etag string
+}
+
+// newS3Fetcher creates an s3Fetcher for a s3://bucket/key connection string.
+// Credentials and region come from the standard AWS configuration chain
+// (environment, shared config/credentials files, web identity, instance
+// roles); DBS3Endpoint and DBS3UsePathStyle point it at MinIO and other
+// S3-compatible stores.
+func newS3Fetcher(connStr string) (*s3Fetcher, error) {
+	bucket, key, err := parseS3URL(connStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []func(*awsconfig.LoadOptions) error
+	if config.Config.DBS3Region != "" {
+		opts = append(opts, awsconfig.WithRegion(config.Config.DBS3Region))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if config.Config.DBS3Endpoint != "" {
+			o.BaseEndpoint = aws.String(config.Config.DBS3Endpoint)
+		}
+		o.UsePathStyle = config.Config.DBS3UsePathStyle
+	})
+
+	return &s3Fetcher{
+		client:  client,
+		bucket:  bucket,
+		key:     key,
+		timeout: config.Config.EvalCacheRefreshTimeout,
+	}, nil
+}
+
+// parseS3URL splits s3://bucket/path/to/key into bucket and key.
+func parseS3URL(s string) (bucket string, key string, err error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", "", err
+	}
+	bucket, key = u.Host, strings.TrimPrefix(u.Path, "/")
+	if u.Scheme != "s3" || bucket == "" || key == "" {
+		return "", "", fmt.Errorf("invalid S3 location %q, expected s3://bucket/key", s)
+	}
+	return bucket, key, nil
+}
+
+func (sf *s3Fetcher) revision() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sf.timeout)
+	defer cancel()
+
+	out, err := sf.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(sf.bucket),
+		Key:    aws.String(sf.key),
+	})
+	if err != nil {
+		return "", err
+	}
+	sf.etag = aws.ToString(out.ETag)
+	return strings.Trim(sf.etag, `"`), nil
+}
+
+func (sf *s3Fetcher) fetch() ([]entity.Flag, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sf.timeout)
+	defer cancel()
+
+	in := &s3.GetObjectInput{
+		Bucket: aws.String(sf.bucket),
+		Key:    aws.String(sf.key),
+	}
+	if sf.etag != "" {
+		in.IfMatch = aws.String(sf.etag)
+	}
+	out, err := sf.client.GetObject(ctx, in)
+	var re *awshttp.ResponseError
+	if errors.As(err, &re) && re.HTTPStatusCode() == http.StatusPreconditionFailed {
+		return nil, fmt.Errorf("s3://%s/%s changed since its revision was read, retrying on the next refresh", sf.bucket, sf.key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer out.Body.Close()
+	b, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalFlags(b)
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is a minimal path-style S3 stand-in serving HEAD and GET for objects,
+// the same way MinIO does for a single bucket.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte // "/bucket/key" -> content
+	gets    int
+	heads   int
+}
+
+func (f *fakeS3) put(path string, b []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.objects[path] = b
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	b, ok := f.objects[r.URL.Path]
+	if !ok {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusNotFound)
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+		}
+		return
+	}
+
+	sum := md5.Sum(b)
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+	if m := r.Header.Get("If-Match"); m != "" && m != etag {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Content-Length", strconv.Itoa(len(b)))
+	w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+	switch r.Method {
+	case http.MethodHead:
+		f.heads++
+	case http.MethodGet:
+		f.gets++
+		w.Write(b)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newTestS3Fetcher(t *testing.T, server *httptest.Server, bucket, key string) *s3Fetcher {
+	t.Helper()
+	client := s3.New(s3.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(server.URL),
+		UsePathStyle: true,
+		Credentials:  credentials.NewStaticCredentialsProvider("minio", "minio123", ""),
+	})
+	return &s3Fetcher{client: client, bucket: bucket, key: key, timeout: 5 * time.Second}
+}
+
+func TestS3Fetcher(t *testing.T) {
+	t.Parallel()
+
+	sample, err := os.ReadFile("./testdata/sample_eval_cache.json")
+	require.NoError(t, err)
+
+	t.Run("happy code path", func(t *testing.T) {
+		t.Parallel()
+		fake := &fakeS3{objects: map[string][]byte{"/flags/prod/flags.json": sample}}
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		sf := newTestS3Fetcher(t, server, "flags", "prod/flags.json")
+		rev, err := sf.revision()
+		require.NoError(t, err)
+		sum := md5.Sum(sample)
+		assert.Equal(t, hex.EncodeToString(sum[:]), rev)
+
+		fs, err := sf.fetch()
+		require.NoError(t, err)
+		assert.NotZero(t, len(fs))
+	})
+
+	t.Run("object replaced between revision and fetch", func(t *testing.T) {
+		t.Parallel()
+		fake := &fakeS3{objects: map[string][]byte{"/flags/flags.json": sample}}
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		sf := newTestS3Fetcher(t, server, "flags", "flags.json")
+		_, err := sf.revision()
+		require.NoError(t, err)
+		fake.put("/flags/flags.json", []byte(`{"Flags": [{"Key": "replaced", "Enabled": true}]}`))
+		_, err = sf.fetch()
+		assert.ErrorContains(t, err, "changed since its revision was read")
+
+		_, err = sf.revision()
+		require.NoError(t, err)
+		fs, err := sf.fetch()
+		require.NoError(t, err)
+		assert.Equal(t, "replaced", fs[0].Key)
+	})
+
+	t.Run("missing object", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(&fakeS3{objects: map[string][]byte{}})
+		defer server.Close()
+
+		sf := newTestS3Fetcher(t, server, "flags", "missing.json")
+		_, err := sf.revision()
+		assert.Error(t, err)
+		fs, err := sf.fetch()
+		assert.Error(t, err)
+		assert.Zero(t, fs)
+	})
+
+	t.Run("etag skips unchanged downloads", func(t *testing.T) {
+		defer setDBDriverConfig("json_s3", true)()
+
+		fake := &fakeS3{objects: map[string][]byte{"/flags/flags.json": sample}}
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		ec := &EvalCache{cache: &cacheContainer{}, refreshTimeout: 5 * time.Second}
+		ec.fetcher = newTestS3Fetcher(t, server, "flags", "flags.json")
+
+		require.NoError(t, ec.reloadMapCache())
+		require.NoError(t, ec.reloadMapCache())
+		require.NoError(t, ec.reloadMapCache())
+		assert.Equal(t, 1, fake.gets, "unchanged object should be downloaded once")
+		assert.Equal(t, 3, fake.heads)
+		assert.NotNil(t, ec.GetByFlagKeyOrID("kmmcd1nsd6"))
+
+		fake.put("/flags/flags.json", []byte(`{"Flags": [{"Key": "replaced", "Enabled": true}]}`))
+		require.NoError(t, ec.reloadMapCache())
+		assert.Equal(t, 2, fake.gets, "changed ETag should trigger a download")
+		assert.Nil(t, ec.GetByFlagKeyOrID("kmmcd1nsd6"))
+		assert.NotNil(t, ec.GetByFlagKeyOrID("replaced"))
+		assert.Equal(t, ec.revision(), ec.GetByFlagKeyOrID("replaced").FlagEvaluation.SourceRevision)
+	})
+}
+
+func TestParseS3URL(t *testing.T) {
+	t.Parallel()
+
+	bucket, key, err := parseS3URL("s3://my-bucket/path/to/flags.json")
+	assert.NoError(t, err)
+	assert.Equal(t, "my-bucket", bucket)
+	assert.Equal(t, "path/to/flags.json", key)
+
+	for _, s := range []string{"https://my-bucket/flags.json", "s3://my-bucket", "s3:///flags.json", "s3://my-bucket/"} {
+		_, _, err := parseS3URL(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -94,6 +94,39 @@ func TestNewFetcher(t *testing.T) {
 		assert.NotNil(t, fetcher)
 	})
 
+	t.Run("file dir", func(t *testing.T) {
+		reset := setDBDriverConfig("file_dir", true)
+		defer reset()
+
+		fetcher, err := newFetcher()
+		assert.NoError(t, err)
+		assert.IsType(t, &dirFetcher{}, fetcher)
+	})
+
+	t.Run("json git", func(t *testing.T) {
+		reset := setDBDriverConfig("json_git", true)
+		defer reset()
+
+		fetcher, err := newFetcher()
+		assert.NoError(t, err)
+		assert.IsType(t, &gitFetcher{}, fetcher)
+	})
+
+	t.Run("json s3", func(t *testing.T) {
+		reset := setDBDriverConfig("json_s3", true)
+		defer reset()
+
+		config.Config.DBConnectionStr = "s3://bucket/flags.json"
+		fetcher, err := newFetcher()
+		assert.NoError(t, err)
+		assert.IsType(t, &s3Fetcher{}, fetcher)
+
+		config.Config.DBConnectionStr = "flags.json"
+		fetcher, err = newFetcher()
+		assert.Error(t, err)
+		assert.Nil(t, fetcher)
+	})
+
 	t.Run("invalid driver", func(t *testing.T) {
 		reset := setDBDriverConfig("invalid_driver", true)
 		defer reset()
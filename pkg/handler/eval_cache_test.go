@@ -109,7 +109,7 @@ func TestReloadMapCacheShortCircuit(t *testing.T) {
 
 	// Create an initial snapshot so MAX(id) > 0 and the short-circuit
 	// guard (lastSnapshotMaxID > 0) can engage.
-	entity.SaveFlagSnapshot(db, fixtureFlag.ID, "test",
+	saveFlagSnapshot(db, fixtureFlag.ID, "test",
 		notification.OperationCreate, notification.ComponentFlag, fixtureFlag.ID, fixtureFlag.Key)
 
 	ec := GetEvalCache()
@@ -133,7 +133,7 @@ func TestReloadMapCacheShortCircuit(t *testing.T) {
 		"snapshot max ID must not change when no new snapshot exists")
 
 	// Create another snapshot to simulate a mutation via the API.
-	entity.SaveFlagSnapshot(db, fixtureFlag.ID, "test",
+	saveFlagSnapshot(db, fixtureFlag.ID, "test",
 		notification.OperationUpdate, notification.ComponentFlag, fixtureFlag.ID, fixtureFlag.Key)
 
 	// 3rd call: must fetch again (new snapshot invalidated the cache).
@@ -6,6 +6,7 @@ import (
 
 	"github.com/openflagr/flagr/pkg/entity"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // --- Basic structural tests ---
@@ -577,6 +578,65 @@ func TestValidateFlags_InvalidConstraintRegex(t *testing.T) {
 	assert.True(t, found, "should have constraint error: %v", r.Errors)
 }
 
+func TestValidateFlags_InvalidConstraintGroup(t *testing.T) {
+	t.Parallel()
+	flags := []entity.Flag{
+		{
+			Key: "my-flag",
+			Variants: []entity.Variant{
+				{Key: "on"},
+			},
+			Segments: []entity.Segment{
+				{
+					Description:    "all",
+					RolloutPercent: 100,
+					Distributions: []entity.Distribution{
+						{VariantKey: "on", Percent: 100},
+					},
+					ConstraintGroups: entity.ConstraintGroupArray{
+						{Operator: "OR", Constraints: []entity.GroupConstraint{{Property: "country", Operator: "EQ", Value: `"US"`}}},
+						{Operator: "NOT", Groups: []entity.ConstraintGroup{{Operator: "AND"}}},
+					},
+				},
+			},
+		},
+	}
+	r := ValidateFlags(flags)
+	assert.False(t, r.OK())
+	require.Len(t, r.Errors, 1)
+	assert.Contains(t, r.Errors[0], "all: constraint group[1]")
+	assert.Contains(t, r.Errors[0], "groups[0]: empty AND group")
+}
+
+func TestValidateFlags_NativeConstraintOperators(t *testing.T) {
+	t.Parallel()
+	flags := []entity.Flag{
+		{
+			Key: "my-flag",
+			Variants: []entity.Variant{
+				{Key: "on"},
+			},
+			Segments: []entity.Segment{
+				{
+					Description:    "all",
+					RolloutPercent: 100,
+					Distributions: []entity.Distribution{
+						{VariantKey: "on", Percent: 100},
+					},
+					Constraints: []entity.Constraint{
+						{Property: "email", Operator: "ENDS_WITH", Value: `"@example.com"`},
+						{Property: "country", Operator: "IN_CI", Value: `["us", "ca"]`},
+						{Property: "email", Operator: "STARTS_WITH", Value: `admin`},
+					},
+				},
+			},
+		},
+	}
+	r := ValidateFlags(flags)
+	require.Len(t, r.Errors, 1)
+	assert.Contains(t, r.Errors[0], `constraint "email" STARTS_WITH "admin" is invalid`)
+}
+
 func TestValidateFlags_ValidConstraintEQ(t *testing.T) {
 	t.Parallel()
 	flags := []entity.Flag{
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/pkg/evaluator"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/openflagr/flagr/swagger_gen/restapi/operations/evaluation"
+)
+
+func (e *eval) PostEvaluationPreview(params evaluation.PostEvaluationPreviewParams) middleware.Responder {
+	if params.Body == nil {
+		return evaluation.NewPostEvaluationPreviewDefault(400).WithPayload(
+			ErrorMessage("empty body"))
+	}
+	resp, errPayload := PreviewEvaluation(params.Body, params.HTTPRequest)
+	if errPayload != nil {
+		return evaluation.NewPostEvaluationPreviewDefault(400).WithPayload(errPayload)
+	}
+	return evaluation.NewPostEvaluationPreviewOK().WithPayload(resp)
+}
+
+// PreviewEvaluation evaluates a flag definition that is not stored against
+// every eval context, with debug logs forced on. It uses the same evaluation
+// core as EvalFlagWithContext, but results are not logged or sent to the data
+// recorders (and so not to Datar), and the EvalCache is not touched.
+func PreviewEvaluation(req *models.EvaluationPreviewRequest, r *http.Request) (*models.EvaluationPreviewResponse, *models.Error) {
+	f, warnings, errPayload := previewFlag(req.Flag, 0)
+	if errPayload != nil {
+		return nil, errPayload
+	}
+
+	e := evaluator.Evaluator{Debug: true}
+	resp := &models.EvaluationPreviewResponse{
+		EvalResults: make([]*models.EvalResult, 0, len(req.EvalContexts)),
+		Warnings:    warnings,
+	}
+	for _, c := range req.EvalContexts {
+		if c == nil {
+			continue
+		}
+		evalContext := *c
+		evalContext.EnableDebug = true
+		evalContext.EntityContext = InjectBuiltInContext(evalContext.EntityContext, r)
+		resp.EvalResults = append(resp.EvalResults, e.Evaluate(f, evalContext))
+	}
+	return resp, nil
+}
+
+// previewFlag decodes a flag definition in the JSON flag spec format, checks
+// it with ValidateFlags, assigns missing IDs and prepares it for evaluation.
+// A non-zero id replaces the ID of the flag.
+func previewFlag(v any, id uint) (*entity.Flag, []string, *models.Error) {
+	if _, ok := v.(map[string]any); !ok {
+		return nil, nil, ErrorMessage("flag must be an object")
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, nil, ErrorMessage("flag is not a valid flag definition: %v", err)
+	}
+	flags := make([]entity.Flag, 1)
+	if err := json.Unmarshal(b, &flags[0]); err != nil {
+		return nil, nil, ErrorMessage("flag is not a valid flag definition: %v", err)
+	}
+
+	if id != 0 {
+		flags[0].ID = id
+	}
+
+	vr := ValidateFlags(flags)
+	if !vr.OK() {
+		return nil, nil, ErrorMessage("flag is invalid: %s", strings.Join(vr.Errors, "; "))
+	}
+
+	normalizeIDs(flags)
+	f := &flags[0]
+	if err := f.PrepareEvaluation(); err != nil {
+		return nil, nil, ErrorMessage("flag is invalid: %v", err)
+	}
+	return f, vr.Warnings, nil
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/openflagr/flagr/pkg/config"
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/openflagr/flagr/swagger_gen/restapi/operations/evaluation"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const previewFlagSpec = `{
+  "Key": "draft",
+  "Enabled": true,
+  "Variants": [{"Key": "on", "Attachment": {"color": "blue"}}, {"Key": "off"}],
+  "Segments": [
+    {
+      "Description": "california",
+      "RolloutPercent": 100,
+      "Constraints": [{"Property": "state", "Operator": "EQ", "Value": "\"CA\""}],
+      "Distributions": [{"VariantKey": "on", "Percent": 100}]
+    },
+    {
+      "Description": "everyone else",
+      "RolloutPercent": 100,
+      "Distributions": [{"VariantKey": "off", "Percent": 100}]
+    }
+  ]
+}`
+
+// previewFlagAPI is the same flag in the shape of GET /flags/{flagID}.
+const previewFlagAPI = `{
+  "id": 42,
+  "key": "draft",
+  "enabled": true,
+  "variants": [{"id": 1, "key": "on", "attachment": {"color": "blue"}}, {"id": 2, "key": "off"}],
+  "segments": [
+    {
+      "id": 5, "description": "california", "rolloutPercent": 100, "rank": 0,
+      "constraints": [{"id": 9, "property": "state", "operator": "EQ", "value": "\"CA\""}],
+      "distributions": [{"id": 3, "variantID": 1, "variantKey": "on", "percent": 100}]
+    },
+    {
+      "id": 6, "description": "everyone else", "rolloutPercent": 100, "rank": 1,
+      "distributions": [{"id": 4, "variantID": 2, "variantKey": "off", "percent": 100}]
+    }
+  ]
+}`
+
+func previewRequest(t *testing.T, flagJSON string, contexts ...*models.EvalContext) *models.EvaluationPreviewRequest {
+	t.Helper()
+	var flag any
+	require.NoError(t, json.Unmarshal([]byte(flagJSON), &flag))
+	return &models.EvaluationPreviewRequest{Flag: flag, EvalContexts: contexts}
+}
+
+func TestPostEvaluationPreview(t *testing.T) {
+	recorded := 0
+	defer gostub.Stub(&logEvalResult, func(*models.EvalResult, *entity.Flag) { recorded++ }).Reset()
+	defer gostub.StubFunc(&GetEvalCache, GenFixtureEvalCache()).Reset()
+	defer gostub.Stub(&config.Config.EvalDebugEnabled, false).Reset()
+	e := NewEval()
+
+	for name, flagJSON := range map[string]string{"flag spec": previewFlagSpec, "API flag": previewFlagAPI} {
+		t.Run(name, func(t *testing.T) {
+			res := e.PostEvaluationPreview(evaluation.PostEvaluationPreviewParams{
+				Body: previewRequest(t, flagJSON,
+					&models.EvalContext{EntityID: "e1", EntityContext: map[string]any{"state": "CA"}},
+					&models.EvalContext{EntityID: "e2", EntityContext: map[string]any{"state": "NY"}},
+				),
+			})
+			ok, isOK := res.(*evaluation.PostEvaluationPreviewOK)
+			require.True(t, isOK)
+			require.Len(t, ok.Payload.EvalResults, 2)
+
+			ca, ny := ok.Payload.EvalResults[0], ok.Payload.EvalResults[1]
+			assert.Equal(t, "draft", ca.FlagKey)
+			assert.Equal(t, "on", ca.VariantKey)
+			assert.Equal(t, entity.Attachment{"color": "blue"}, ca.VariantAttachment)
+			assert.Equal(t, "off", ny.VariantKey)
+
+			// debug logs although both the server and the contexts have debug off
+			require.Len(t, ny.EvalDebugLog.SegmentDebugLogs, 2)
+			assert.Contains(t, ny.EvalDebugLog.SegmentDebugLogs[0].Msg, "constraint not match")
+		})
+	}
+
+	assert.Zero(t, recorded, "previews must not be recorded")
+	assert.Nil(t, GetEvalCache().GetByFlagKeyOrID("draft"), "previews must not change the EvalCache")
+
+	t.Run("warnings", func(t *testing.T) {
+		res := e.PostEvaluationPreview(evaluation.PostEvaluationPreviewParams{
+			Body: previewRequest(t, `{"Key": "empty", "Enabled": true}`, &models.EvalContext{EntityID: "e1"}),
+		})
+		ok := res.(*evaluation.PostEvaluationPreviewOK)
+		assert.Zero(t, ok.Payload.EvalResults[0].VariantID)
+		assert.Contains(t, ok.Payload.Warnings, `flag "empty": no segments defined`)
+	})
+
+	t.Run("invalid flag", func(t *testing.T) {
+		for _, flagJSON := range []string{
+			`"draft"`,
+			`{"Key": ""}`,
+			`{"Key": "k", "Segments": "nope"}`,
+			`{"Key": "k", "Variants": [{"Key": "on"}], "Segments": [{"Distributions": [{"VariantKey": "on", "Percent": 50}]}]}`,
+		} {
+			res := e.PostEvaluationPreview(evaluation.PostEvaluationPreviewParams{
+				Body: previewRequest(t, flagJSON, &models.EvalContext{EntityID: "e1"}),
+			})
+			_, isDefault := res.(*evaluation.PostEvaluationPreviewDefault)
+			assert.True(t, isDefault, flagJSON)
+		}
+	})
+}
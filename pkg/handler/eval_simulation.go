@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/openflagr/flagr/pkg/config"
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/pkg/evaluator"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/openflagr/flagr/swagger_gen/restapi/operations/evaluation"
+)
+
+const defaultSimulationSwitchedLimit = 100
+
+func (e *eval) PostEvaluationSimulation(params evaluation.PostEvaluationSimulationParams) middleware.Responder {
+	if params.Body == nil {
+		return evaluation.NewPostEvaluationSimulationDefault(400).WithPayload(
+			ErrorMessage("empty body"))
+	}
+	resp, errPayload := SimulateEvaluation(params.Body, GetEvalCache().GetByFlagKeyOrID)
+	if errPayload != nil {
+		return evaluation.NewPostEvaluationSimulationDefault(400).WithPayload(errPayload)
+	}
+	return evaluation.NewPostEvaluationSimulationOK().WithPayload(resp)
+}
+
+// SimulateEvaluation runs the same logic as POST /evaluation/simulation.
+// lookup finds the stored flag by ID or key, e.g. EvalCache.GetByFlagKeyOrID
+// or evaluator.FlagSet.Get.
+//
+// With only a stored flag, the stored config is simulated. With a proposed
+// flag, the proposed config is simulated, and compared with the stored one if
+// given. Built-in context keys are not injected: the corpus is used as is.
+func SimulateEvaluation(req *models.EvaluationSimulationRequest, lookup func(keyOrID any) *entity.Flag) (*models.EvaluationSimulationResponse, *models.Error) {
+	var current *entity.Flag
+	switch {
+	case req.FlagID != 0:
+		if current = lookup(req.FlagID); current == nil {
+			return nil, ErrorMessage("flagID %d not found", req.FlagID)
+		}
+	case req.FlagKey != "":
+		if current = lookup(req.FlagKey); current == nil {
+			return nil, ErrorMessage("flagKey %s not found", req.FlagKey)
+		}
+	case req.Flag == nil:
+		return nil, ErrorMessage("flagID, flagKey or flag is required")
+	}
+
+	contexts, errPayload := simulationContexts(req)
+	if errPayload != nil {
+		return nil, errPayload
+	}
+
+	switchedLimit := defaultSimulationSwitchedLimit
+	if req.SwitchedLimit != nil {
+		switchedLimit = int(*req.SwitchedLimit)
+	}
+
+	if req.Flag == nil {
+		return evaluator.Simulate(current, nil, contexts, switchedLimit), nil
+	}
+	var currentID uint
+	if current != nil {
+		currentID = current.ID
+	}
+	proposed, warnings, errPayload := previewFlag(req.Flag, currentID)
+	if errPayload != nil {
+		return nil, errPayload
+	}
+	resp := evaluator.Simulate(proposed, current, contexts, switchedLimit)
+	resp.Warnings = warnings
+	return resp, nil
+}
+
+// simulationContexts returns the corpus of a simulation request, either the
+// given contexts or synthetic ones, within FLAGR_EVAL_SIMULATION_MAX_CONTEXTS.
+func simulationContexts(req *models.EvaluationSimulationRequest) ([]models.EvalContext, *models.Error) {
+	if (len(req.Contexts) > 0) == (req.Synthetic != nil) {
+		return nil, ErrorMessage("exactly one of contexts and synthetic is required")
+	}
+
+	n := len(req.Contexts)
+	if req.Synthetic != nil {
+		if req.Synthetic.Count == nil || *req.Synthetic.Count < 1 {
+			return nil, ErrorMessage("synthetic.count must be at least 1")
+		}
+		n = int(*req.Synthetic.Count)
+	}
+	if limit := config.Config.EvalSimulationMaxContexts; limit > 0 && n > limit {
+		return nil, ErrorMessage("%d eval contexts exceed the maximum of %d", n, limit)
+	}
+
+	if s := req.Synthetic; s != nil {
+		return evaluator.SyntheticContexts(n, s.Seed, s.EntityType, s.EntityContext), nil
+	}
+	contexts := make([]models.EvalContext, 0, n)
+	for _, c := range req.Contexts {
+		if c != nil {
+			contexts = append(contexts, *c)
+		}
+	}
+	return contexts, nil
+}
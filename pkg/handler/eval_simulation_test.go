@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/openflagr/flagr/pkg/config"
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/openflagr/flagr/swagger_gen/restapi/operations/evaluation"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// simulationProposedFlag is flag_key_100 with everyone in CA on control.
+const simulationProposedFlag = `{
+  "Key": "flag_key_100",
+  "Enabled": true,
+  "Variants": [{"Key": "control"}, {"Key": "treatment"}],
+  "Segments": [{
+    "RolloutPercent": 100,
+    "Constraints": [{"Property": "dl_state", "Operator": "EQ", "Value": "\"CA\""}],
+    "Distributions": [{"VariantKey": "control", "Percent": 100}]
+  }]
+}`
+
+func TestPostEvaluationSimulation(t *testing.T) {
+	recorded := 0
+	defer gostub.Stub(&logEvalResult, func(*models.EvalResult, *entity.Flag) { recorded++ }).Reset()
+	defer gostub.StubFunc(&GetEvalCache, genFixtureOFREPEvalCache()).Reset()
+	e := NewEval()
+
+	simulate := func(req *models.EvaluationSimulationRequest) any {
+		return e.PostEvaluationSimulation(evaluation.PostEvaluationSimulationParams{Body: req})
+	}
+	var proposed any
+	require.NoError(t, json.Unmarshal([]byte(simulationProposedFlag), &proposed))
+	count := int64(200)
+	californians := &models.SimulationSyntheticCorpus{Count: &count, EntityContext: map[string]any{"dl_state": "CA"}}
+
+	t.Run("stored flag", func(t *testing.T) {
+		ok, isOK := simulate(&models.EvaluationSimulationRequest{
+			FlagID: 100,
+			Contexts: []*models.EvalContext{
+				{EntityID: "u1", EntityContext: map[string]any{"dl_state": "CA"}},
+				{EntityID: "u2", EntityContext: map[string]any{"dl_state": "NY"}},
+			},
+		}).(*evaluation.PostEvaluationSimulationOK)
+		require.True(t, isOK)
+		assert.Equal(t, int64(2), *ok.Payload.Total)
+		require.Len(t, ok.Payload.Segments, 1)
+		assert.Equal(t, int64(1), ok.Payload.Segments[0].Matched)
+		assert.Equal(t, int64(1), ok.Payload.Unassigned.Count)
+		assert.Nil(t, ok.Payload.CurrentSegments)
+		assert.Empty(t, ok.Payload.Switched)
+	})
+
+	t.Run("proposed flag compared with the stored one", func(t *testing.T) {
+		ok, isOK := simulate(&models.EvaluationSimulationRequest{
+			FlagKey:   "flag_key_100",
+			Flag:      proposed,
+			Synthetic: californians,
+		}).(*evaluation.PostEvaluationSimulationOK)
+		require.True(t, isOK)
+
+		require.Len(t, ok.Payload.Variants, 2)
+		control, treatment := ok.Payload.Variants[0], ok.Payload.Variants[1]
+		assert.Equal(t, int64(200), control.Count)
+		assert.Equal(t, float64(100), control.Percent)
+		assert.Zero(t, treatment.Count)
+		assert.Positive(t, treatment.CurrentCount)
+		assert.Equal(t, -treatment.CurrentCount, treatment.Delta)
+		assert.Equal(t, treatment.CurrentCount, control.Delta)
+
+		// the proposed flag takes the stored flag ID, so control keeps its entities
+		assert.Equal(t, treatment.CurrentCount, ok.Payload.SwitchedTotal)
+		require.Len(t, ok.Payload.Switched, defaultSimulationSwitchedLimit)
+		for _, s := range ok.Payload.Switched {
+			assert.Equal(t, "treatment", s.CurrentVariantKey)
+			assert.Equal(t, "control", s.VariantKey)
+			assert.Equal(t, int64(200), s.CurrentSegmentID)
+		}
+
+		limit := int64(3)
+		ok = simulate(&models.EvaluationSimulationRequest{
+			FlagKey: "flag_key_100", Flag: proposed, Synthetic: californians, SwitchedLimit: &limit,
+		}).(*evaluation.PostEvaluationSimulationOK)
+		assert.Len(t, ok.Payload.Switched, 3)
+		assert.Equal(t, treatment.CurrentCount, ok.Payload.SwitchedTotal)
+	})
+
+	t.Run("proposed flag only", func(t *testing.T) {
+		ok, isOK := simulate(&models.EvaluationSimulationRequest{
+			Flag: proposed, Synthetic: californians,
+		}).(*evaluation.PostEvaluationSimulationOK)
+		require.True(t, isOK)
+		assert.Equal(t, int64(200), ok.Payload.Variants[0].Count)
+		assert.Zero(t, ok.Payload.Variants[0].Delta)
+		assert.Nil(t, ok.Payload.CurrentSegments)
+	})
+
+	assert.Zero(t, recorded, "simulations must not be recorded")
+
+	t.Run("invalid requests", func(t *testing.T) {
+		defer gostub.Stub(&config.Config.EvalSimulationMaxContexts, 100).Reset()
+		for name, req := range map[string]*models.EvaluationSimulationRequest{
+			"no flag":            {Synthetic: californians},
+			"unknown flag":       {FlagKey: "missing", Synthetic: californians},
+			"no corpus":          {FlagID: 100},
+			"both corpora":       {FlagID: 100, Synthetic: californians, Contexts: []*models.EvalContext{{EntityID: "u1"}}},
+			"too many contexts":  {FlagID: 100, Synthetic: californians},
+			"invalid proposed":   {Flag: map[string]any{"Key": ""}, Contexts: []*models.EvalContext{{EntityID: "u1"}}},
+			"no synthetic count": {FlagID: 100, Synthetic: &models.SimulationSyntheticCorpus{}},
+		} {
+			_, isDefault := simulate(req).(*evaluation.PostEvaluationSimulationDefault)
+			assert.True(t, isDefault, name)
+		}
+		_, isDefault := simulate(nil).(*evaluation.PostEvaluationSimulationDefault)
+		assert.True(t, isDefault)
+	})
+}
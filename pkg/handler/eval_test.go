@@ -3,16 +3,21 @@ package handler
 import (
 	"fmt"
 	"math"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/dchest/uniuri"
 	"github.com/openflagr/flagr/pkg/config"
 	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/pkg/evaluator"
 	"github.com/openflagr/flagr/pkg/util"
 	"github.com/openflagr/flagr/swagger_gen/models"
 	"github.com/openflagr/flagr/swagger_gen/restapi/operations/evaluation"
 	"github.com/prashantv/gostub"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 )
 
@@ -1466,3 +1471,51 @@ func BenchmarkPostEvaluationBatchWithTags(b *testing.B) {
 		})
 	}
 }
+
+func TestEvalFlagMatchesEmbeddedEvaluator(t *testing.T) {
+	defer gostub.StubFunc(&logEvalResult).Reset()
+	defer setDBDriverConfig("json_file", true)()
+
+	noIDs := filepath.Join(t.TempDir(), "flags.json")
+	require.NoError(t, os.WriteFile(noIDs, []byte(`{"Flags": [{
+		"Key": "no-ids", "Enabled": true,
+		"Variants": [{"Key": "a"}, {"Key": "b"}, {"Key": "c"}],
+		"Segments": [
+			{"RolloutPercent": 50, "Constraints": [{"Property": "age", "Operator": "GTE", "Value": "18"}],
+			 "Distributions": [{"VariantKey": "a", "Percent": 30}, {"VariantKey": "b", "Percent": 70}]},
+			{"RolloutPercent": 80, "Distributions": [{"VariantKey": "c", "Percent": 100}]}
+		]
+	}]}`), 0o644))
+
+	for _, path := range []string{"./testdata/sample_eval_cache.json", noIDs} {
+		ec := &EvalCache{cache: &cacheContainer{}, refreshTimeout: time.Second}
+		ec.fetcher = &jsonFileFetcher{filePath: path}
+		require.NoError(t, ec.reloadMapCache())
+		defer gostub.StubFunc(&GetEvalCache, ec).Reset()
+
+		client, err := evaluator.New(evaluator.Config{Source: evaluator.FileSource(path)})
+		require.NoError(t, err)
+		defer client.Close()
+
+		contexts := []map[string]any{
+			{"env": "local", "state": "CA", "age": float64(30)},
+			{"env": "prod", "state": "NY", "age": float64(12)},
+		}
+		for _, key := range []string{"kmmcd1nsd6", "knkrkxnvfh8nk8aw4", "no-ids"} {
+			for i := range 300 {
+				evalContext := models.EvalContext{
+					FlagKey:       key,
+					EntityID:      fmt.Sprintf("entity%d", i),
+					EntityContext: contexts[i%len(contexts)],
+				}
+				want := EvalFlag(evalContext)
+				got := client.Evaluate(evalContext)
+				assert.Equal(t, want.FlagID, got.FlagID)
+				assert.Equal(t, want.SegmentID, got.SegmentID)
+				assert.Equal(t, want.VariantID, got.VariantID)
+				assert.Equal(t, want.VariantKey, got.VariantKey)
+				assert.Equal(t, want.VariantAttachment, got.VariantAttachment)
+			}
+		}
+	}
+}
@@ -56,7 +56,7 @@ func TestExportFlags(t *testing.T) {
 func TestExportFlagSnapshots(t *testing.T) {
 	f := entity.GenFixtureFlag()
 	db := entity.PopulateTestDB(f)
-	entity.SaveFlagSnapshot(db, f.ID, "flagr-test@example.com", notification.OperationUpdate, notification.ComponentFlag, f.ID, f.Key)
+	saveFlagSnapshot(db, f.ID, "flagr-test@example.com", notification.OperationUpdate, notification.ComponentFlag, f.ID, f.Key)
 
 	tmpDB1, dbErr1 := db.DB()
 	if dbErr1 != nil {
@@ -85,7 +85,7 @@ func TestExportFlagSnapshots(t *testing.T) {
 func TestExportSQLiteFile(t *testing.T) {
 	f := entity.GenFixtureFlag()
 	db := entity.PopulateTestDB(f)
-	entity.SaveFlagSnapshot(db, f.ID, "flagr-test@example.com", notification.OperationUpdate, notification.ComponentFlag, f.ID, f.Key)
+	saveFlagSnapshot(db, f.ID, "flagr-test@example.com", notification.OperationUpdate, notification.ComponentFlag, f.ID, f.Key)
 
 	tmpDB1, dbErr1 := db.DB()
 	if dbErr1 != nil {
@@ -115,7 +115,7 @@ func TestExportSQLiteFile(t *testing.T) {
 func TestExportSQLiteHandler(t *testing.T) {
 	f := entity.GenFixtureFlag()
 	db := entity.PopulateTestDB(f)
-	entity.SaveFlagSnapshot(db, f.ID, "flagr-test@example.com", notification.OperationUpdate, notification.ComponentFlag, f.ID, f.Key)
+	saveFlagSnapshot(db, f.ID, "flagr-test@example.com", notification.OperationUpdate, notification.ComponentFlag, f.ID, f.Key)
 
 	tmpDB1, dbErr1 := db.DB()
 	if dbErr1 != nil {
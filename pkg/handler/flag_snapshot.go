@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/openflagr/flagr/pkg/config"
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/pkg/notification"
+	"github.com/openflagr/flagr/pkg/util"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// snapshotNotificationPayload is populated by writeFlagSnapshot inside the caller's transaction
+// (flag key and optional detailed-diff fields).
+type snapshotNotificationPayload struct {
+	flagKey   string
+	preValue  string
+	postValue string
+	diff      string
+}
+
+// snapshotNotification is the handle returned from writeFlagSnapshot. Call
+// notifyAfterCommit only after the outer transaction commits.
+type snapshotNotification struct {
+	payload snapshotNotificationPayload
+}
+
+// writeFlagSnapshot records a flag snapshot using tx. The caller must Commit or Rollback.
+func writeFlagSnapshot(
+	tx *gorm.DB,
+	flagID uint,
+	updatedBy string,
+) (snapshotNotification, error) {
+	var out snapshotNotification
+	f := &entity.Flag{}
+	// Use Unscoped to include soft-deleted flags. This is necessary for:
+	// 1. Delete operations: we need to snapshot the flag after it's been soft-deleted
+	// 2. Restore operations: we need to update the flag that was previously soft-deleted
+	if err := tx.Unscoped().First(f, flagID).Error; err != nil {
+		logrus.WithFields(logrus.Fields{
+			"err":    err,
+			"flagID": flagID,
+		}).Error("failed to find the flag when writeFlagSnapshot")
+		return out, err
+	}
+	if err := entity.PreloadSegmentsVariantsTags(tx.Unscoped()).First(f, flagID).Error; err != nil {
+		return out, err
+	}
+
+	b, err := json.Marshal(f)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"err":    err,
+			"flagID": flagID,
+		}).Error("failed to marshal the flag into JSON when writeFlagSnapshot")
+		return out, err
+	}
+
+	preFS := &entity.FlagSnapshot{}
+	if err := tx.Unscoped().Where("flag_id = ?", flagID).Order("id desc").First(preFS).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logrus.WithError(err).WithField("flagID", flagID).Warn("failed to find previous flag snapshot")
+	}
+
+	fs := entity.FlagSnapshot{FlagID: f.ID, UpdatedBy: updatedBy, Flag: b}
+	if err := tx.Create(&fs).Error; err != nil {
+		logrus.WithFields(logrus.Fields{
+			"err":    err,
+			"flagID": f.Model.ID,
+		}).Error("failed to save FlagSnapshot")
+		return out, err
+	}
+
+	f.UpdatedBy = updatedBy
+	f.SnapshotID = fs.ID
+
+	if err := tx.Unscoped().Save(f).Error; err != nil {
+		logrus.WithFields(logrus.Fields{
+			"err":            err,
+			"flagID":         f.Model.ID,
+			"flagSnapshotID": fs.Model.ID,
+		}).Error("failed to save Flag's UpdatedBy and SnapshotID")
+		return out, err
+	}
+
+	out.payload.flagKey = f.Key
+	if config.Config.NotificationDetailedDiffEnabled {
+		out.payload.preValue = string(preFS.Flag)
+		out.payload.postValue = string(fs.Flag)
+		out.payload.diff = notification.CalculateDiff(out.payload.preValue, out.payload.postValue)
+	}
+	return out, nil
+}
+
+// notifyAfterCommit sends webhook/metrics after the outer transaction committed.
+func (n snapshotNotification) notifyAfterCommit(
+	flagID uint,
+	updatedBy string,
+	operation notification.Operation,
+	componentType notification.ComponentType,
+	componentID uint,
+	componentKey string,
+) {
+	logFlagSnapshotUpdate(flagID, updatedBy)
+	notification.SendNotification(notification.Notification{
+		Operation:     operation,
+		FlagID:        flagID,
+		FlagKey:       n.payload.flagKey,
+		ComponentType: componentType,
+		ComponentID:   componentID,
+		ComponentKey:  componentKey,
+		PreValue:      n.payload.preValue,
+		PostValue:     n.payload.postValue,
+		Diff:          n.payload.diff,
+		User:          updatedBy,
+	})
+}
+
+// saveFlagSnapshot saves the Flag Snapshot and sends a notification in its own transaction.
+func saveFlagSnapshot(db *gorm.DB, flagID uint, updatedBy string, operation notification.Operation, componentType notification.ComponentType, componentID uint, componentKey string) {
+	tx := db.Begin()
+	snap, err := writeFlagSnapshot(tx, flagID, updatedBy)
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		logrus.WithError(err).WithField("flagID", flagID).Error("failed to commit flag snapshot")
+		return
+	}
+	snap.notifyAfterCommit(flagID, updatedBy, operation, componentType, componentID, componentKey)
+}
+
+var logFlagSnapshotUpdate = func(flagID uint, updatedBy string) {
+	if config.Global.StatsdClient == nil {
+		return
+	}
+
+	config.Global.StatsdClient.Incr(
+		"flag.snapshot.updated",
+		[]string{
+			fmt.Sprintf("FlagID:%d", flagID),
+			fmt.Sprintf("UpdatedBy:%s", util.SafeStringWithDefault(updatedBy, "null")),
+		},
+		float64(1),
+	)
+}
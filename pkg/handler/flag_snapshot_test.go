@@ -1,15 +1,16 @@
-package entity
+package handler
 
 import (
 	"testing"
 
+	"github.com/openflagr/flagr/pkg/entity"
 	"github.com/openflagr/flagr/pkg/notification"
 )
 
 func TestSaveFlagSnapshot(t *testing.T) {
 	t.Parallel()
-	f := GenFixtureFlag()
-	db := PopulateTestDB(f)
+	f := entity.GenFixtureFlag()
+	db := entity.PopulateTestDB(f)
 
 	tmpDB, dbErr := db.DB()
 	if dbErr != nil {
@@ -19,10 +20,10 @@ func TestSaveFlagSnapshot(t *testing.T) {
 	defer tmpDB.Close()
 
 	t.Run("happy code path", func(t *testing.T) {
-		SaveFlagSnapshot(db, f.ID, "flagr-test@example.com", notification.OperationUpdate, notification.ComponentFlag, f.ID, f.Key)
+		saveFlagSnapshot(db, f.ID, "flagr-test@example.com", notification.OperationUpdate, notification.ComponentFlag, f.ID, f.Key)
 	})
 
 	t.Run("save on non-existing flag", func(t *testing.T) {
-		SaveFlagSnapshot(db, uint(999999), "flagr-test@example.com", notification.OperationUpdate, notification.ComponentFlag, 0, "")
+		saveFlagSnapshot(db, uint(999999), "flagr-test@example.com", notification.OperationUpdate, notification.ComponentFlag, 0, "")
 	})
 }
@@ -0,0 +1,329 @@
+package handler
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/openflagr/flagr/pkg/config"
+	"github.com/openflagr/flagr/pkg/entity"
+	flagrv1 "github.com/openflagr/flagr/proto_gen/flagr/v1"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/openflagr/flagr/swagger_gen/restapi/operations"
+	"github.com/openflagr/flagr/swagger_gen/restapi/operations/export"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// setupGRPC serves the gRPC evaluation service on its own port when
+// FLAGR_GRPC_ENABLED is set, and stops it with the REST server.
+func setupGRPC(api *operations.FlagrAPI) {
+	if !config.Config.GRPCEnabled {
+		return
+	}
+
+	host := config.Config.GRPCHost
+	if host == "" {
+		host = config.Config.Host
+	}
+	addr := net.JoinHostPort(host, fmt.Sprint(config.Config.GRPCPort))
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		logrus.WithField("err", err).Fatalf("failed to listen for gRPC on %s", addr)
+	}
+
+	s, e := newGRPCServer()
+	go func() {
+		logrus.Infof("Serving flagr gRPC at %s", lis.Addr())
+		if err := s.Serve(lis); err != nil {
+			logrus.WithField("err", err).Error("gRPC server error")
+		}
+	}()
+
+	existingShutdown := api.ServerShutdown
+	api.ServerShutdown = func() {
+		e.close()
+		s.GracefulStop()
+		if existingShutdown != nil {
+			existingShutdown()
+		}
+	}
+}
+
+// newGRPCServer creates a gRPC server with the evaluation, health and
+// reflection services registered.
+func newGRPCServer() (*grpc.Server, *grpcEval) {
+	s := grpc.NewServer()
+	e := &grpcEval{done: make(chan struct{})}
+	flagrv1.RegisterEvaluationServiceServer(s, e)
+
+	h := grpchealth.NewServer()
+	h.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	h.SetServingStatus(flagrv1.EvaluationService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(s, h)
+
+	reflection.Register(s)
+	return s, e
+}
+
+// grpcEval implements flagr.v1.EvaluationService on top of the same
+// evaluation path as the REST API (EvalFlag and EvaluateBatch).
+type grpcEval struct {
+	flagrv1.UnimplementedEvaluationServiceServer
+
+	// done is closed on shutdown so that WatchFlags streams return and
+	// GracefulStop does not wait for them forever.
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (e *grpcEval) close() {
+	e.closeOnce.Do(func() { close(e.done) })
+}
+
+func (e *grpcEval) Evaluate(ctx context.Context, req *flagrv1.EvaluateRequest) (*flagrv1.EvaluateResponse, error) {
+	if req.GetEvalContext() == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty eval_context")
+	}
+	evalContext := evalContextFromProto(req.GetEvalContext())
+	if errPayload := validateEvalContextAfterJSON(nil, &evalContext); errPayload != nil {
+		return nil, grpcErrorFromModel(errPayload)
+	}
+
+	evalContext.EntityContext = InjectBuiltInContext(evalContext.EntityContext, grpcHTTPRequest(ctx))
+	result, err := evalResultToProto(EvalFlag(evalContext))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &flagrv1.EvaluateResponse{Result: result}, nil
+}
+
+func (e *grpcEval) EvaluateBatch(ctx context.Context, req *flagrv1.EvaluateBatchRequest) (*flagrv1.EvaluateBatchResponse, error) {
+	batchReq := &models.EvaluationBatchRequest{
+		EnableDebug: req.GetEnableDebug(),
+		FlagIDs:     req.GetFlagIds(),
+		FlagKeys:    req.GetFlagKeys(),
+		FlagTags:    req.GetFlagTags(),
+	}
+	if op := req.GetFlagTagsOperator(); op != "" {
+		batchReq.FlagTagsOperator = &op
+	}
+	for _, en := range req.GetEntities() {
+		batchReq.Entities = append(batchReq.Entities, &models.EvaluationEntity{
+			EntityID:      en.GetEntityId(),
+			EntityType:    en.GetEntityType(),
+			EntityContext: structToMap(en.GetEntityContext()),
+		})
+	}
+	if errPayload := validateEvaluationBatchRequestAfterJSON(nil, batchReq); errPayload != nil {
+		return nil, grpcErrorFromModel(errPayload)
+	}
+
+	results, errPayload := EvaluateBatch(ctx, batchReq, grpcHTTPRequest(ctx))
+	if errPayload != nil {
+		return nil, grpcErrorFromModel(errPayload)
+	}
+
+	resp := &flagrv1.EvaluateBatchResponse{
+		Results: make([]*flagrv1.EvalResult, 0, len(results.EvaluationResults)),
+		Partial: results.Partial,
+	}
+	for _, r := range results.EvaluationResults {
+		result, err := evalResultToProto(r)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	return resp, nil
+}
+
+func (e *grpcEval) WatchFlags(req *flagrv1.WatchFlagsRequest, stream flagrv1.EvaluationService_WatchFlagsServer) error {
+	query := export.GetExportEvalCacheJSONParams{
+		Keys: req.GetFlagKeys(),
+		Tags: req.GetFlagTags(),
+	}
+	switch op := req.GetFlagTagsOperator(); op {
+	case "", models.EvaluationBatchRequestFlagTagsOperatorANY, models.EvaluationBatchRequestFlagTagsOperatorALL:
+		if op != "" {
+			query.TagsOperator = &op
+		}
+	default:
+		return status.Errorf(codes.InvalidArgument, "flag_tags_operator must be ANY or ALL, got %q", op)
+	}
+
+	ec := GetEvalCache()
+	var last []byte
+	for {
+		// Subscribe before reading the cache so an update in between is
+		// not missed.
+		updated := ec.updated()
+
+		j := ec.export(query)
+		slices.SortFunc(j.Flags, func(a, b entity.Flag) int { return cmp.Compare(a.ID, b.ID) })
+		b, err := json.Marshal(j)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		if !bytes.Equal(b, last) {
+			if err := stream.Send(&flagrv1.WatchFlagsResponse{
+				EvalCacheJson:      b,
+				FlagSourceRevision: ec.revision(),
+			}); err != nil {
+				return err
+			}
+			last = b
+		}
+
+		select {
+		case <-updated:
+		case <-stream.Context().Done():
+			return nil
+		case <-e.done:
+			return status.Error(codes.Unavailable, "server is shutting down")
+		}
+	}
+}
+
+// grpcHTTPRequest exposes the incoming gRPC metadata as HTTP headers so that
+// @http_* built-in context keys work the same as over REST. :authority is
+// used as the Host.
+func grpcHTTPRequest(ctx context.Context) *http.Request {
+	r := &http.Request{Header: http.Header{}}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for k, vs := range md {
+		if k == ":authority" && len(vs) > 0 {
+			r.Host = vs[0]
+			continue
+		}
+		if strings.HasPrefix(k, ":") {
+			continue
+		}
+		r.Header[http.CanonicalHeaderKey(k)] = vs
+	}
+	return r
+}
+
+func grpcErrorFromModel(e *models.Error) error {
+	msg := "invalid request"
+	if e.Message != nil {
+		msg = *e.Message
+	}
+	return status.Error(codes.InvalidArgument, msg)
+}
+
+func evalContextFromProto(c *flagrv1.EvalContext) models.EvalContext {
+	evalContext := models.EvalContext{
+		EnableDebug:   c.GetEnableDebug(),
+		EntityContext: structToMap(c.GetEntityContext()),
+		EntityID:      c.GetEntityId(),
+		EntityType:    c.GetEntityType(),
+		FlagID:        c.GetFlagId(),
+		FlagKey:       c.GetFlagKey(),
+		FlagTags:      c.GetFlagTags(),
+	}
+	if op := c.GetFlagTagsOperator(); op != "" {
+		evalContext.FlagTagsOperator = &op
+	}
+	return evalContext
+}
+
+func evalContextToProto(c *models.EvalContext) (*flagrv1.EvalContext, error) {
+	if c == nil {
+		return nil, nil
+	}
+	entityContext, err := structFromAny(c.EntityContext)
+	if err != nil {
+		return nil, err
+	}
+	pc := &flagrv1.EvalContext{
+		EntityId:      c.EntityID,
+		EntityType:    c.EntityType,
+		EntityContext: entityContext,
+		EnableDebug:   c.EnableDebug,
+		FlagId:        c.FlagID,
+		FlagKey:       c.FlagKey,
+		FlagTags:      c.FlagTags,
+	}
+	if c.FlagTagsOperator != nil {
+		pc.FlagTagsOperator = *c.FlagTagsOperator
+	}
+	return pc, nil
+}
+
+func evalResultToProto(r *models.EvalResult) (*flagrv1.EvalResult, error) {
+	attachment, err := structFromAny(r.VariantAttachment)
+	if err != nil {
+		return nil, err
+	}
+	evalContext, err := evalContextToProto(r.EvalContext)
+	if err != nil {
+		return nil, err
+	}
+	pr := &flagrv1.EvalResult{
+		FlagId:             r.FlagID,
+		FlagKey:            r.FlagKey,
+		FlagSnapshotId:     r.FlagSnapshotID,
+		FlagSourceRevision: r.FlagSourceRevision,
+		FlagTags:           r.FlagTags,
+		SegmentId:          r.SegmentID,
+		VariantId:          r.VariantID,
+		VariantKey:         r.VariantKey,
+		VariantAttachment:  attachment,
+		EvalContext:        evalContext,
+		Timestamp:          r.Timestamp,
+		DataRecordsEnabled: r.DataRecordsEnabled,
+		RecordSource:       r.RecordSource,
+		Error:              r.Error,
+	}
+	if r.EvalDebugLog != nil {
+		pr.EvalDebugLog = &flagrv1.EvalDebugLog{Msg: r.EvalDebugLog.Msg}
+		for _, l := range r.EvalDebugLog.SegmentDebugLogs {
+			pr.EvalDebugLog.SegmentDebugLogs = append(pr.EvalDebugLog.SegmentDebugLogs,
+				&flagrv1.SegmentDebugLog{SegmentId: l.SegmentID, Msg: l.Msg})
+		}
+	}
+	return pr, nil
+}
+
+// structToMap converts a protobuf Struct to the map[string]any a JSON request
+// body decodes to, so constraints see the same values as over REST.
+func structToMap(s *structpb.Struct) any {
+	if s == nil {
+		return nil
+	}
+	return s.AsMap()
+}
+
+// structFromAny converts a JSON object value (entityContext, attachments) to
+// a protobuf Struct through its JSON encoding. Empty and non-object values
+// are returned as nil.
+func structFromAny(v any) (*structpb.Struct, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil || len(m) == 0 {
+		return nil, nil
+	}
+	return structpb.NewStruct(m)
+}
@@ -0,0 +1,300 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openflagr/flagr/pkg/config"
+	"github.com/openflagr/flagr/pkg/entity"
+	flagrv1 "github.com/openflagr/flagr/proto_gen/flagr/v1"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/openflagr/flagr/swagger_gen/restapi/operations/evaluation"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// startTestGRPCServer serves newGRPCServer over an in-memory listener and
+// returns a client connection to it.
+func startTestGRPCServer(t *testing.T) (*grpc.ClientConn, *grpcEval) {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s, e := newGRPCServer()
+	go s.Serve(lis)
+	t.Cleanup(func() {
+		e.close()
+		s.GracefulStop()
+	})
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn, e
+}
+
+// recordedEvalResults stubs logEvalResult and returns the results it was
+// called with, to compare data recording between REST and gRPC.
+func recordedEvalResults(t *testing.T) func() []*models.EvalResult {
+	var mu sync.Mutex
+	var recorded []*models.EvalResult
+	stubs := gostub.Stub(&logEvalResult, func(r *models.EvalResult, flag *entity.Flag) {
+		mu.Lock()
+		defer mu.Unlock()
+		recorded = append(recorded, r)
+	})
+	t.Cleanup(stubs.Reset)
+	return func() []*models.EvalResult {
+		mu.Lock()
+		defer mu.Unlock()
+		out := recorded
+		recorded = nil
+		return out
+	}
+}
+
+func TestGRPCEvaluate(t *testing.T) {
+	defer gostub.StubFunc(&GetEvalCache, GenFixtureEvalCache()).Reset()
+	recorded := recordedEvalResults(t)
+	conn, _ := startTestGRPCServer(t)
+	client := flagrv1.NewEvaluationServiceClient(conn)
+
+	entityContext, err := structpb.NewStruct(map[string]any{"dl_state": "CA"})
+	require.NoError(t, err)
+
+	t.Run("matches REST", func(t *testing.T) {
+		for _, entityID := range []string{"e1", "e2", "e3", "e4", "e5"} {
+			res, err := client.Evaluate(context.Background(), &flagrv1.EvaluateRequest{
+				EvalContext: &flagrv1.EvalContext{
+					EntityId:      entityID,
+					EntityContext: entityContext,
+					FlagKey:       "flag_key_100",
+					EnableDebug:   true,
+				},
+			})
+			require.NoError(t, err)
+			grpcRecorded := recorded()
+
+			rest := NewEval().PostEvaluation(evaluation.PostEvaluationParams{
+				Body: &models.EvalContext{
+					EntityID:      entityID,
+					EntityContext: map[string]any{"dl_state": "CA"},
+					FlagKey:       "flag_key_100",
+					EnableDebug:   true,
+				},
+			}).(*evaluation.PostEvaluationOK).Payload
+			restRecorded := recorded()
+
+			r := res.GetResult()
+			assert.Equal(t, rest.FlagID, r.GetFlagId())
+			assert.Equal(t, rest.SegmentID, r.GetSegmentId())
+			assert.Equal(t, rest.VariantID, r.GetVariantId())
+			assert.Equal(t, rest.VariantKey, r.GetVariantKey())
+			assert.Equal(t, rest.FlagTags, r.GetFlagTags())
+			assert.Equal(t, rest.RecordSource, r.GetRecordSource())
+			assert.Equal(t, entityID, r.GetEvalContext().GetEntityId())
+			assert.Equal(t, rest.EvalDebugLog.Msg, r.GetEvalDebugLog().GetMsg())
+			assert.Len(t, r.GetEvalDebugLog().GetSegmentDebugLogs(), len(rest.EvalDebugLog.SegmentDebugLogs))
+			if rest.VariantKey == "treatment" {
+				assert.Equal(t, map[string]any{"value": "321"}, r.GetVariantAttachment().AsMap())
+			}
+
+			require.Len(t, grpcRecorded, 1)
+			require.Len(t, restRecorded, 1)
+			assert.Equal(t, restRecorded[0].VariantID, grpcRecorded[0].VariantID)
+		}
+	})
+
+	t.Run("metadata is injected as built-in context", func(t *testing.T) {
+		defer gostub.Stub(&config.Config.InjectedContextEnabled, true).Reset()
+		defer gostub.Stub(&config.Config.InjectedContextHTTPHeaders, []string{"X-Tenant-ID"}).Reset()
+		headerMatchOnce = sync.Once{}
+		defer func() { headerMatchOnce = sync.Once{} }()
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "x-tenant-id", "acme")
+		res, err := client.Evaluate(ctx, &flagrv1.EvaluateRequest{
+			EvalContext: &flagrv1.EvalContext{EntityId: "e1", FlagKey: "flag_key_100"},
+		})
+		require.NoError(t, err)
+		m := res.GetResult().GetEvalContext().GetEntityContext().AsMap()
+		assert.Equal(t, "acme", m["@http_x_tenant_id"])
+		assert.Contains(t, m, "@ts")
+		recorded()
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, err := client.Evaluate(context.Background(), &flagrv1.EvaluateRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = client.Evaluate(context.Background(), &flagrv1.EvaluateRequest{
+			EvalContext: &flagrv1.EvalContext{FlagKey: "flag_key_100", FlagTagsOperator: "SOME"},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Empty(t, recorded())
+	})
+}
+
+func TestGRPCEvaluateBatch(t *testing.T) {
+	defer gostub.StubFunc(&GetEvalCache, GenFixtureEvalCache()).Reset()
+	recorded := recordedEvalResults(t)
+	conn, _ := startTestGRPCServer(t)
+	client := flagrv1.NewEvaluationServiceClient(conn)
+
+	entityContext, err := structpb.NewStruct(map[string]any{"dl_state": "CA"})
+	require.NoError(t, err)
+	res, err := client.EvaluateBatch(context.Background(), &flagrv1.EvaluateBatchRequest{
+		Entities: []*flagrv1.EvaluationEntity{
+			{EntityId: "e1", EntityContext: entityContext},
+			{EntityId: "e2"},
+		},
+		FlagIds:          []int64{100},
+		FlagKeys:         []string{"flag_key_100", "missing"},
+		FlagTags:         []string{"tag1"},
+		FlagTagsOperator: models.EvaluationBatchRequestFlagTagsOperatorANY,
+	})
+	require.NoError(t, err)
+	grpcRecorded := recorded()
+
+	op := models.EvaluationBatchRequestFlagTagsOperatorANY
+	rest, errPayload := EvaluateBatch(context.Background(), &models.EvaluationBatchRequest{
+		Entities: []*models.EvaluationEntity{
+			{EntityID: "e1", EntityContext: map[string]any{"dl_state": "CA"}},
+			{EntityID: "e2"},
+		},
+		FlagIDs:          []int64{100},
+		FlagKeys:         []string{"flag_key_100", "missing"},
+		FlagTags:         []string{"tag1"},
+		FlagTagsOperator: &op,
+	}, nil)
+	require.Nil(t, errPayload)
+	restRecorded := recorded()
+
+	require.Len(t, res.GetResults(), len(rest.EvaluationResults))
+	for i, r := range res.GetResults() {
+		assert.Equal(t, rest.EvaluationResults[i].FlagKey, r.GetFlagKey())
+		assert.Equal(t, rest.EvaluationResults[i].SegmentID, r.GetSegmentId())
+		assert.Equal(t, rest.EvaluationResults[i].VariantID, r.GetVariantId())
+		assert.Equal(t, rest.EvaluationResults[i].EvalContext.EntityID, r.GetEvalContext().GetEntityId())
+	}
+	assert.Equal(t, []string{"tag1"}, res.GetResults()[0].GetEvalContext().GetFlagTags())
+	assert.Len(t, grpcRecorded, len(restRecorded))
+
+	t.Run("batch size limit", func(t *testing.T) {
+		defer gostub.Stub(&config.Config.EvalBatchSize, 1).Reset()
+		_, err := client.EvaluateBatch(context.Background(), &flagrv1.EvaluateBatchRequest{
+			Entities: []*flagrv1.EvaluationEntity{{EntityId: "e1"}, {EntityId: "e2"}},
+			FlagIds:  []int64{100},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "exceeds maximum allowed size")
+	})
+
+	t.Run("no entities", func(t *testing.T) {
+		_, err := client.EvaluateBatch(context.Background(), &flagrv1.EvaluateBatchRequest{FlagIds: []int64{100}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestGRPCWatchFlags(t *testing.T) {
+	defer gostub.Stub(&config.Config.EvalOnlyMode, true).Reset()
+
+	path := filepath.Join(t.TempDir(), "flags.json")
+	writeFlags := func(flags ...entity.Flag) {
+		b, err := json.Marshal(EvalCacheJSON{Flags: flags})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, b, 0o644))
+	}
+	a := GenFixtureFlagWithTags(1, "a", true, []string{"web"})
+	b := GenFixtureFlagWithTags(2, "b", true, []string{"api"})
+	writeFlags(a, b)
+
+	ec := &EvalCache{cache: &cacheContainer{}, refreshTimeout: time.Second}
+	ec.fetcher = &jsonFileFetcher{filePath: path}
+	require.NoError(t, ec.reloadMapCache())
+	defer gostub.StubFunc(&GetEvalCache, ec).Reset()
+
+	conn, e := startTestGRPCServer(t)
+	client := flagrv1.NewEvaluationServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.WatchFlags(ctx, &flagrv1.WatchFlagsRequest{FlagTags: []string{"web"}})
+	require.NoError(t, err)
+
+	recvKeys := func() []string {
+		res, err := stream.Recv()
+		require.NoError(t, err)
+		var j EvalCacheJSON
+		require.NoError(t, json.Unmarshal(res.GetEvalCacheJson(), &j))
+		keys := []string{}
+		for _, f := range j.Flags {
+			keys = append(keys, f.Key)
+		}
+		return keys
+	}
+	assert.Equal(t, []string{"a"}, recvKeys())
+
+	// b is not watched, so changing it sends nothing
+	b.Enabled = false
+	writeFlags(a, b)
+	require.NoError(t, ec.reloadMapCache())
+
+	c := GenFixtureFlagWithTags(3, "c", true, []string{"web"})
+	writeFlags(a, b, c)
+	require.NoError(t, ec.reloadMapCache())
+	assert.Equal(t, []string{"a", "c"}, recvKeys())
+
+	t.Run("invalid operator", func(t *testing.T) {
+		s, err := client.WatchFlags(ctx, &flagrv1.WatchFlagsRequest{FlagTagsOperator: "SOME"})
+		require.NoError(t, err)
+		_, err = s.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("shutdown ends the stream", func(t *testing.T) {
+		e.close()
+		_, err := stream.Recv()
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
+
+func TestGRPCHealthAndReflection(t *testing.T) {
+	conn, _ := startTestGRPCServer(t)
+
+	for _, service := range []string{"", flagrv1.EvaluationService_ServiceDesc.ServiceName} {
+		res, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.GetStatus())
+	}
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	var services []string
+	for _, s := range res.GetListServicesResponse().GetService() {
+		services = append(services, s.GetName())
+	}
+	assert.Contains(t, services, "flagr.v1.EvaluationService")
+	assert.Contains(t, services, "grpc.health.v1.Health")
+}
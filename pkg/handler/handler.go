@@ -5,7 +5,6 @@ import (
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/openflagr/flagr/pkg/config"
-	"github.com/openflagr/flagr/pkg/entity"
 	"github.com/openflagr/flagr/pkg/notification"
 	"github.com/openflagr/flagr/swagger_gen/models"
 	"github.com/openflagr/flagr/swagger_gen/restapi/operations"
@@ -17,12 +16,15 @@ import (
 	exposureapi "github.com/openflagr/flagr/swagger_gen/restapi/operations/exposure"
 	"github.com/openflagr/flagr/swagger_gen/restapi/operations/flag"
 	"github.com/openflagr/flagr/swagger_gen/restapi/operations/health"
+	metricapi "github.com/openflagr/flagr/swagger_gen/restapi/operations/metric"
+	"github.com/openflagr/flagr/swagger_gen/restapi/operations/ofrep"
 	"github.com/openflagr/flagr/swagger_gen/restapi/operations/segment"
 	"github.com/openflagr/flagr/swagger_gen/restapi/operations/tag"
 	"github.com/openflagr/flagr/swagger_gen/restapi/operations/variant"
+	"github.com/sirupsen/logrus"
 )
 
-var getDB = entity.GetDB
+var getDB = GetDB
 
 // Setup initialize all the handler functions
 func Setup(api *operations.FlagrAPI) {
@@ -32,6 +34,7 @@ func Setup(api *operations.FlagrAPI) {
 		setupHealth(api)
 		setupEvaluation(api)
 		setupExportEvalCache(api)
+		setupGRPC(api)
 		return
 	}
 
@@ -39,8 +42,10 @@ func Setup(api *operations.FlagrAPI) {
 	setupDatar(api)
 	setupEvaluation(api)
 	setupExposure(api)
+	setupMetrics(api)
 	setupCRUD(api)
 	setupExport(api)
+	setupGRPC(api)
 }
 
 func setupCRUD(api *operations.FlagrAPI) {
@@ -85,15 +90,43 @@ func setupCRUD(api *operations.FlagrAPI) {
 func setupEvaluation(api *operations.FlagrAPI) {
 	ec := GetEvalCache()
 	ec.Start()
+	existingShutdown := api.ServerShutdown
+	api.ServerShutdown = func() {
+		ec.close()
+		if existingShutdown != nil {
+			existingShutdown()
+		}
+	}
 
 	e := NewEval()
 	api.EvaluationGetEvaluationHandler = evaluation.GetEvaluationHandlerFunc(e.GetEvaluation)
 	api.EvaluationGetEvaluationBatchHandler = evaluation.GetEvaluationBatchHandlerFunc(e.GetEvaluationBatch)
 	api.EvaluationPostEvaluationHandler = evaluation.PostEvaluationHandlerFunc(e.PostEvaluation)
 	api.EvaluationPostEvaluationBatchHandler = evaluation.PostEvaluationBatchHandlerFunc(e.PostEvaluationBatch)
+	api.EvaluationGetEvaluationBootstrapHandler = evaluation.GetEvaluationBootstrapHandlerFunc(e.GetEvaluationBootstrap)
+	api.EvaluationPostEvaluationBootstrapHandler = evaluation.PostEvaluationBootstrapHandlerFunc(e.PostEvaluationBootstrap)
+	api.EvaluationPostEvaluationPreviewHandler = evaluation.PostEvaluationPreviewHandlerFunc(e.PostEvaluationPreview)
+	api.EvaluationPostEvaluationSimulationHandler = evaluation.PostEvaluationSimulationHandlerFunc(e.PostEvaluationSimulation)
+
+	o := NewOFREP()
+	api.OfrepOfrepEvaluateFlagHandler = ofrep.OfrepEvaluateFlagHandlerFunc(o.EvaluateFlag)
+	api.OfrepOfrepEvaluateFlagsBulkHandler = ofrep.OfrepEvaluateFlagsBulkHandlerFunc(o.EvaluateFlagsBulk)
 
 	// Force-init the data recorder (may be noop, external, Datar, or fan-out).
-	GetDataRecorder()
+	rec := GetDataRecorder()
+
+	// Register shutdown handler, e.g. to deliver spooled records.
+	if f, ok := rec.(fanOutRecorder); ok {
+		existingShutdown := api.ServerShutdown
+		api.ServerShutdown = func() {
+			if err := f.Close(); err != nil {
+				logrus.WithError(err).Error("failed to close data recorders")
+			}
+			if existingShutdown != nil {
+				existingShutdown()
+			}
+		}
+	}
 }
 
 func setupExposure(api *operations.FlagrAPI) {
@@ -101,6 +134,15 @@ func setupExposure(api *operations.FlagrAPI) {
 	api.ExposurePostExposuresHandler = exposureapi.PostExposuresHandlerFunc(ex.PostExposures)
 }
 
+func setupMetrics(api *operations.FlagrAPI) {
+	m := NewMetric()
+	api.MetricFindMetricsHandler = metricapi.FindMetricsHandlerFunc(m.FindMetrics)
+	api.MetricCreateMetricHandler = metricapi.CreateMetricHandlerFunc(m.CreateMetric)
+	api.MetricPutMetricHandler = metricapi.PutMetricHandlerFunc(m.PutMetric)
+	api.MetricDeleteMetricHandler = metricapi.DeleteMetricHandlerFunc(m.DeleteMetric)
+	api.MetricPostMetricEventsHandler = metricapi.PostMetricEventsHandlerFunc(m.PostMetricEvents)
+}
+
 func setupDatar(api *operations.FlagrAPI) {
 	if !config.Config.RecorderEnabled || !slices.Contains(config.Config.RecorderType, "datar") {
 		return
@@ -109,7 +151,16 @@ func setupDatar(api *operations.FlagrAPI) {
 	d := GetDatar()
 
 	api.DatarGetDatarSummaryHandler = datarapi.GetDatarSummaryHandlerFunc(HandleGetDatarSummary)
+	api.DatarGetDatarExportHandler = datarapi.GetDatarExportHandlerFunc(HandleGetDatarExport)
 	api.DatarGetDatarFlagSummaryHandler = datarapi.GetDatarFlagSummaryHandlerFunc(HandleGetDatarFlagSummary)
+	api.DatarGetDatarTagSummaryHandler = datarapi.GetDatarTagSummaryHandlerFunc(HandleGetDatarTagSummary)
+	api.DatarGetDatarFlagTimeSeriesHandler = datarapi.GetDatarFlagTimeSeriesHandlerFunc(HandleGetDatarFlagTimeSeries)
+	api.DatarGetDatarFlagMetricsHandler = datarapi.GetDatarFlagMetricsHandlerFunc(HandleGetDatarFlagMetrics)
+	api.DatarPostDatarFlagExperimentHandler = datarapi.PostDatarFlagExperimentHandlerFunc(HandlePostDatarFlagExperiment)
+
+	if config.Config.PrometheusEnabled && config.Config.RecorderDatarPrometheusEnabled {
+		registerDatarCollector(d)
+	}
 
 	// Register shutdown handler.
 	existingShutdown := api.ServerShutdown
@@ -124,7 +175,10 @@ func setupDatar(api *operations.FlagrAPI) {
 func setupHealth(api *operations.FlagrAPI) {
 	api.HealthGetHealthHandler = health.GetHealthHandlerFunc(
 		func(health.GetHealthParams) middleware.Responder {
-			return health.NewGetHealthOK().WithPayload(&models.Health{Status: "OK"})
+			return health.NewGetHealthOK().WithPayload(&models.Health{
+				Status:             "OK",
+				FlagSourceRevision: GetEvalCache().revision(),
+			})
 		},
 	)
 }
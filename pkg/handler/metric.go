@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/openflagr/flagr/pkg/config"
+	"github.com/openflagr/flagr/pkg/datar"
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/pkg/mapper/entity_restapi/e2r"
+	"github.com/openflagr/flagr/pkg/util"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	metricapi "github.com/openflagr/flagr/swagger_gen/restapi/operations/metric"
+)
+
+// Metric handles metric definitions and POST /metrics/events.
+type Metric interface {
+	FindMetrics(metricapi.FindMetricsParams) middleware.Responder
+	CreateMetric(metricapi.CreateMetricParams) middleware.Responder
+	PutMetric(metricapi.PutMetricParams) middleware.Responder
+	DeleteMetric(metricapi.DeleteMetricParams) middleware.Responder
+	PostMetricEvents(metricapi.PostMetricEventsParams) middleware.Responder
+}
+
+// NewMetric creates a Metric handler.
+func NewMetric() Metric {
+	return &metricHandler{}
+}
+
+type metricHandler struct{}
+
+func (h *metricHandler) FindMetrics(params metricapi.FindMetricsParams) middleware.Responder {
+	ms := []entity.Metric{}
+	if err := getDB().Order("id").Find(&ms).Error; err != nil {
+		return metricapi.NewFindMetricsDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	resp := metricapi.NewFindMetricsOK()
+	resp.SetPayload(e2r.MapMetrics(ms))
+	return resp
+}
+
+func (h *metricHandler) CreateMetric(params metricapi.CreateMetricParams) middleware.Responder {
+	m := &entity.Metric{
+		Key:         util.SafeString(params.Body.Key),
+		Description: params.Body.Description,
+		Kind:        string(params.Body.Kind),
+	}
+	if m.Kind == "" {
+		m.Kind = entity.MetricKindConversion
+	}
+	if err := m.Validate(); err != nil {
+		return metricapi.NewCreateMetricDefault(400).WithPayload(ErrorMessage("%s", err))
+	}
+	if err := getDB().Create(m).Error; err != nil {
+		return metricapi.NewCreateMetricDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	resp := metricapi.NewCreateMetricOK()
+	resp.SetPayload(e2r.MapMetric(m))
+	return resp
+}
+
+func (h *metricHandler) PutMetric(params metricapi.PutMetricParams) middleware.Responder {
+	m := &entity.Metric{}
+	if err := getDB().First(m, params.MetricID).Error; err != nil {
+		return metricapi.NewPutMetricDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+	m.Description = params.Body.Description
+	if params.Body.Kind != "" {
+		m.Kind = string(params.Body.Kind)
+	}
+	if err := m.Validate(); err != nil {
+		return metricapi.NewPutMetricDefault(400).WithPayload(ErrorMessage("%s", err))
+	}
+	if err := getDB().Save(m).Error; err != nil {
+		return metricapi.NewPutMetricDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	resp := metricapi.NewPutMetricOK()
+	resp.SetPayload(e2r.MapMetric(m))
+	return resp
+}
+
+// DeleteMetric deletes the definition for good, so that its key can be
+// defined again. Aggregates already attributed to the metric are kept.
+func (h *metricHandler) DeleteMetric(params metricapi.DeleteMetricParams) middleware.Responder {
+	if err := getDB().Unscoped().Delete(&entity.Metric{}, params.MetricID).Error; err != nil {
+		return metricapi.NewDeleteMetricDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	return metricapi.NewDeleteMetricOK()
+}
+
+func (h *metricHandler) PostMetricEvents(params metricapi.PostMetricEventsParams) middleware.Responder {
+	if params.Body == nil || len(params.Body.Events) == 0 {
+		return metricapi.NewPostMetricEventsDefault(400).WithPayload(
+			ErrorMessage("events array is required and must not be empty"))
+	}
+
+	events := params.Body.Events
+	if batchMax := config.Config.MetricEventBatchSize; batchMax > 0 && len(events) > batchMax {
+		return metricapi.NewPostMetricEventsDefault(400).WithPayload(
+			ErrorMessage("metric event batch size %d exceeds maximum allowed size of %d", len(events), batchMax))
+	}
+
+	d := GetDatar()
+	if d == nil {
+		return metricapi.NewPostMetricEventsDefault(503).WithPayload(ErrorMessage("Datar is not enabled"))
+	}
+
+	ms := []entity.Metric{}
+	if err := getDB().Find(&ms).Error; err != nil {
+		return metricapi.NewPostMetricEventsDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	kinds := make(map[string]string, len(ms))
+	for _, m := range ms {
+		kinds[m.Key] = m.Kind
+	}
+
+	var accepted int64
+	var rowErrors []*models.MetricEventRowError
+	for i, row := range events {
+		ev, err := buildMetricEvent(row, kinds)
+		if err != nil {
+			rowErrors = append(rowErrors, &models.MetricEventRowError{Index: int64(i), Message: err.Error()})
+			continue
+		}
+		if !d.RecordMetricEvent(ev) {
+			rowErrors = append(rowErrors, &models.MetricEventRowError{
+				Index: int64(i), Message: "metric event buffer is full, retry later"})
+			continue
+		}
+		accepted++
+	}
+
+	resp := metricapi.NewPostMetricEventsOK()
+	resp.SetPayload(&models.MetricEventsResponse{
+		AcceptedCount: accepted,
+		Errors:        rowErrors,
+	})
+	return resp
+}
+
+// buildMetricEvent validates a metric event row against the kinds of the
+// defined metrics by key.
+func buildMetricEvent(row *models.MetricEvent, kinds map[string]string) (datar.MetricEvent, error) {
+	if row == nil {
+		return datar.MetricEvent{}, fmt.Errorf("metric event row is null")
+	}
+	if row.EntityID == nil || *row.EntityID == "" {
+		return datar.MetricEvent{}, fmt.Errorf("entityID is required")
+	}
+	if len(*row.EntityID) > datar.MaxEntityIDLength {
+		return datar.MetricEvent{}, fmt.Errorf("entityID is longer than %d bytes", datar.MaxEntityIDLength)
+	}
+	key := util.SafeString(row.MetricKey)
+	kind, ok := kinds[key]
+	if !ok {
+		return datar.MetricEvent{}, fmt.Errorf("metric %q is not defined", key)
+	}
+	if kind == entity.MetricKindValue && row.Value == nil {
+		return datar.MetricEvent{}, fmt.Errorf("value is required for value metric %q", key)
+	}
+
+	ts := time.Now().UTC()
+	if !time.Time(row.Timestamp).IsZero() {
+		ts = time.Time(row.Timestamp).UTC()
+	}
+	var value float64
+	if row.Value != nil {
+		value = *row.Value
+	}
+	return datar.MetricEvent{EntityID: *row.EntityID, MetricKey: key, Value: value, Time: ts}, nil
+}
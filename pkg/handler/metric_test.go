@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/openflagr/flagr/pkg/config"
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	datarapi "github.com/openflagr/flagr/swagger_gen/restapi/operations/datar"
+	metricapi "github.com/openflagr/flagr/swagger_gen/restapi/operations/metric"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricCRUD(t *testing.T) {
+	db := entity.NewTestDB()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	h := NewMetric()
+
+	res := h.CreateMetric(metricapi.CreateMetricParams{Body: &models.CreateMetricRequest{Key: new("purchase")}})
+	created, ok := res.(*metricapi.CreateMetricOK)
+	require.True(t, ok, "got %T", res)
+	assert.Equal(t, "purchase", *created.Payload.Key)
+	assert.Equal(t, models.MetricKind(entity.MetricKindConversion), *created.Payload.Kind)
+
+	res = h.CreateMetric(metricapi.CreateMetricParams{Body: &models.CreateMetricRequest{Key: new("purchase")}})
+	_, ok = res.(*metricapi.CreateMetricDefault)
+	assert.True(t, ok, "duplicate key")
+
+	res = h.CreateMetric(metricapi.CreateMetricParams{Body: &models.CreateMetricRequest{Key: new("bad key!")}})
+	if def, ok := res.(*metricapi.CreateMetricDefault); assert.True(t, ok) {
+		assert.Contains(t, *def.Payload.Message, "key")
+	}
+
+	res = h.CreateMetric(metricapi.CreateMetricParams{Body: &models.CreateMetricRequest{Key: new("revenue"), Kind: "sum"}})
+	if def, ok := res.(*metricapi.CreateMetricDefault); assert.True(t, ok) {
+		assert.Contains(t, *def.Payload.Message, "metric kind")
+	}
+
+	res = h.PutMetric(metricapi.PutMetricParams{
+		MetricID: created.Payload.ID,
+		Body:     &models.PutMetricRequest{Description: "checkout", Kind: models.MetricKindValue},
+	})
+	if put, ok := res.(*metricapi.PutMetricOK); assert.True(t, ok, "got %T", res) {
+		assert.Equal(t, "checkout", put.Payload.Description)
+		assert.Equal(t, models.MetricKindValue, *put.Payload.Kind)
+	}
+
+	res = h.PutMetric(metricapi.PutMetricParams{MetricID: 999, Body: &models.PutMetricRequest{}})
+	_, ok = res.(*metricapi.PutMetricDefault)
+	assert.True(t, ok)
+
+	res = h.FindMetrics(metricapi.FindMetricsParams{})
+	if found, ok := res.(*metricapi.FindMetricsOK); assert.True(t, ok) && assert.Len(t, found.Payload, 1) {
+		assert.Equal(t, "purchase", *found.Payload[0].Key)
+	}
+
+	res = h.DeleteMetric(metricapi.DeleteMetricParams{MetricID: created.Payload.ID})
+	_, ok = res.(*metricapi.DeleteMetricOK)
+	assert.True(t, ok)
+
+	// The key of a deleted metric can be defined again.
+	res = h.CreateMetric(metricapi.CreateMetricParams{Body: &models.CreateMetricRequest{Key: new("purchase")}})
+	_, ok = res.(*metricapi.CreateMetricOK)
+	assert.True(t, ok, "got %T", res)
+}
+
+func TestPostMetricEvents_Validation(t *testing.T) {
+	h := NewMetric()
+
+	res := h.PostMetricEvents(metricapi.PostMetricEventsParams{})
+	_, ok := res.(*metricapi.PostMetricEventsDefault)
+	assert.True(t, ok)
+
+	defer gostub.Stub(&config.Config.MetricEventBatchSize, 1).Reset()
+	res = h.PostMetricEvents(metricapi.PostMetricEventsParams{Body: &models.MetricEventsRequest{
+		Events: []*models.MetricEvent{{}, {}},
+	}})
+	if def, ok := res.(*metricapi.PostMetricEventsDefault); assert.True(t, ok) {
+		assert.Contains(t, *def.Payload.Message, "exceeds maximum allowed size of 1")
+	}
+
+	defer gostub.Stub(&config.Config.RecorderType, []string{"kafka"}).Reset()
+	res = h.PostMetricEvents(metricapi.PostMetricEventsParams{Body: &models.MetricEventsRequest{
+		Events: []*models.MetricEvent{{}},
+	}})
+	if def, ok := res.(*metricapi.PostMetricEventsDefault); assert.True(t, ok) {
+		assert.Equal(t, "Datar is not enabled", *def.Payload.Message)
+	}
+}
+
+func TestPostMetricEvents_AttributedToVariants(t *testing.T) {
+	defer ResetDatar()
+	defer gostub.Stub(&config.Config.RecorderType, []string{"datar"}).Reset()
+	defer gostub.Stub(&config.Config.RecorderEnabled, true).Reset()
+	defer gostub.Stub(&config.Config.RecorderDatarFlushInterval, 24*time.Hour).Reset()
+
+	db := entity.NewTestDB()
+	defer gostub.StubFunc(&getDB, db).Reset()
+	require.NoError(t, db.AutoMigrate(entity.AutoMigrateTables...))
+
+	h := NewMetric()
+	h.CreateMetric(metricapi.CreateMetricParams{Body: &models.CreateMetricRequest{Key: new("signup")}})
+	h.CreateMetric(metricapi.CreateMetricParams{Body: &models.CreateMetricRequest{Key: new("revenue"), Kind: models.MetricKindValue}})
+
+	now := time.Now().UTC()
+	r := NewDatarRecorder()
+	r.AsyncRecord(models.EvalResult{FlagID: 1, VariantID: 10, SegmentID: 1, EvalContext: &models.EvalContext{EntityID: "u1"}})
+	r.AsyncRecord(models.EvalResult{FlagID: 1, VariantID: 20, SegmentID: 1, EvalContext: &models.EvalContext{EntityID: "u2"}})
+
+	res := h.PostMetricEvents(metricapi.PostMetricEventsParams{Body: &models.MetricEventsRequest{
+		Events: []*models.MetricEvent{
+			{EntityID: new("u1"), MetricKey: new("signup"), Timestamp: strfmt.DateTime(now)},
+			{EntityID: new("u1"), MetricKey: new("revenue"), Value: new(12.5)},
+			{EntityID: new("u2"), MetricKey: new("revenue")},
+			{EntityID: new("u2"), MetricKey: new("unknown")},
+			{MetricKey: new("signup")},
+			nil,
+			{EntityID: new(strings.Repeat("x", 256)), MetricKey: new("signup")},
+		},
+	}})
+	accepted, ok := res.(*metricapi.PostMetricEventsOK)
+	require.True(t, ok, "got %T", res)
+	assert.Equal(t, int64(2), accepted.Payload.AcceptedCount)
+	if assert.Len(t, accepted.Payload.Errors, 5) {
+		assert.Equal(t, int64(2), accepted.Payload.Errors[0].Index)
+		assert.Equal(t, `value is required for value metric "revenue"`, accepted.Payload.Errors[0].Message)
+		assert.Equal(t, `metric "unknown" is not defined`, accepted.Payload.Errors[1].Message)
+		assert.Equal(t, "entityID is required", accepted.Payload.Errors[2].Message)
+		assert.Equal(t, "metric event row is null", accepted.Payload.Errors[3].Message)
+		assert.Equal(t, "entityID is longer than 255 bytes", accepted.Payload.Errors[4].Message)
+	}
+
+	d := GetDatar()
+	require.NoError(t, d.Shutdown())
+
+	resp := HandleGetDatarFlagMetrics(datarapi.GetDatarFlagMetricsParams{FlagID: 1})
+	ok2, ok := resp.(*datarapi.GetDatarFlagMetricsOK)
+	require.True(t, ok, "got %T", resp)
+	require.Len(t, ok2.Payload.Variants, 2)
+
+	v10 := ok2.Payload.Variants[0]
+	assert.Equal(t, int64(10), v10.VariantID)
+	assert.Equal(t, int64(1), v10.Exposures)
+	assert.Equal(t, []*models.DatarMetricEntry{
+		{MetricKey: "revenue", Events: 1, Conversions: 1, ValueSum: 12.5, ValueSumSquares: 156.25},
+		{MetricKey: "signup", Events: 1, Conversions: 1},
+	}, v10.Metrics)
+
+	v20 := ok2.Payload.Variants[1]
+	assert.Equal(t, int64(20), v20.VariantID)
+	assert.Equal(t, int64(1), v20.Exposures)
+	assert.Empty(t, v20.Metrics)
+
+	// Events the engine drops are reported instead of accepted.
+	res = h.PostMetricEvents(metricapi.PostMetricEventsParams{Body: &models.MetricEventsRequest{
+		Events: []*models.MetricEvent{{EntityID: new("u1"), MetricKey: new("signup")}},
+	}})
+	accepted, ok = res.(*metricapi.PostMetricEventsOK)
+	require.True(t, ok, "got %T", res)
+	assert.Zero(t, accepted.Payload.AcceptedCount)
+	if assert.Len(t, accepted.Payload.Errors, 1) {
+		assert.Equal(t, "metric event buffer is full, retry later", accepted.Payload.Errors[0].Message)
+	}
+}
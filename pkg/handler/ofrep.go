@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/openflagr/flagr/swagger_gen/restapi/operations/ofrep"
+)
+
+// OFREP reason codes, see https://openfeature.dev/specification/types#resolution-reason
+const (
+	ofrepReasonTargetingMatch = "TARGETING_MATCH"
+	ofrepReasonSplit          = "SPLIT"
+	ofrepReasonDisabled       = "DISABLED"
+	ofrepReasonDefault        = "DEFAULT"
+	ofrepReasonError          = "ERROR"
+)
+
+// OFREP error codes
+const (
+	ofrepErrorInvalidContext = "INVALID_CONTEXT"
+	ofrepErrorFlagNotFound   = "FLAG_NOT_FOUND"
+)
+
+// ofrepTargetingKey is the OpenFeature evaluation context attribute mapped
+// to entityID.
+const ofrepTargetingKey = "targetingKey"
+
+// OFREP is the OpenFeature Remote Evaluation Protocol interface
+type OFREP interface {
+	EvaluateFlag(ofrep.OfrepEvaluateFlagParams) middleware.Responder
+	EvaluateFlagsBulk(ofrep.OfrepEvaluateFlagsBulkParams) middleware.Responder
+}
+
+// NewOFREP creates a new OFREP instance
+func NewOFREP() OFREP {
+	return &ofrepHandler{}
+}
+
+type ofrepHandler struct{}
+
+func (o *ofrepHandler) EvaluateFlag(params ofrep.OfrepEvaluateFlagParams) middleware.Responder {
+	evalContext, err := ofrepEvalContext(params.Body, params.HTTPRequest)
+	if err != nil {
+		return ofrep.NewOfrepEvaluateFlagBadRequest().WithPayload(&models.OfrepEvaluationFailure{
+			Key:          params.Key,
+			Reason:       ofrepReasonError,
+			ErrorCode:    ofrepErrorInvalidContext,
+			ErrorDetails: err.Error(),
+		})
+	}
+
+	f := GetEvalCache().GetByFlagKeyOrID(params.Key)
+	if f == nil || f.Key != params.Key {
+		return ofrep.NewOfrepEvaluateFlagNotFound().WithPayload(&models.OfrepEvaluationFailure{
+			Key:          params.Key,
+			Reason:       ofrepReasonError,
+			ErrorCode:    ofrepErrorFlagNotFound,
+			ErrorDetails: fmt.Sprintf("flag %q not found", params.Key),
+		})
+	}
+
+	evalContext.FlagKey = f.Key
+	r := EvalFlagWithContext(f, evalContext)
+	return ofrep.NewOfrepEvaluateFlagOK().WithPayload(ofrepResult(f, r))
+}
+
+func (o *ofrepHandler) EvaluateFlagsBulk(params ofrep.OfrepEvaluateFlagsBulkParams) middleware.Responder {
+	evalContext, err := ofrepEvalContext(params.Body, params.HTTPRequest)
+	if err != nil {
+		return ofrep.NewOfrepEvaluateFlagsBulkBadRequest().WithPayload(&models.OfrepBulkEvaluationFailure{
+			ErrorCode:    ofrepErrorInvalidContext,
+			ErrorDetails: err.Error(),
+		})
+	}
+
+	// Like for bootstrap, the ETag is checked before evaluating when it can be
+	// derived from the version of the flags, so a 304 records nothing.
+	ec := GetEvalCache()
+	etag, err := versionETag(ec, params.Body)
+	if err != nil {
+		return ofrep.NewOfrepEvaluateFlagsBulkDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	if etag != "" && params.IfNoneMatch != nil && etagMatches(*params.IfNoneMatch, etag) {
+		return ofrep.NewOfrepEvaluateFlagsBulkNotModified().WithETag(etag)
+	}
+
+	fs := ec.getAllByKey()
+	payload := &models.OfrepBulkEvaluationSuccess{
+		Flags: make([]*models.OfrepEvaluationSuccess, 0, len(fs)),
+	}
+	for _, f := range fs {
+		ctx := evalContext
+		ctx.FlagKey = f.Key
+		payload.Flags = append(payload.Flags, ofrepResult(f, EvalFlagWithContext(f, ctx)))
+	}
+
+	if etag == "" {
+		if etag, err = jsonETag(payload); err != nil {
+			return ofrep.NewOfrepEvaluateFlagsBulkDefault(500).WithPayload(ErrorMessage("%s", err))
+		}
+		if params.IfNoneMatch != nil && etagMatches(*params.IfNoneMatch, etag) {
+			return ofrep.NewOfrepEvaluateFlagsBulkNotModified().WithETag(etag)
+		}
+	}
+	return ofrep.NewOfrepEvaluateFlagsBulkOK().WithETag(etag).WithPayload(payload)
+}
+
+// ofrepEvalContext maps an OpenFeature evaluation context onto an
+// EvalContext: targetingKey becomes the entityID and every other attribute
+// is kept in entityContext. Built-in context keys are injected like for
+// POST /evaluation.
+func ofrepEvalContext(body *models.OfrepEvaluationRequest, r *http.Request) (models.EvalContext, error) {
+	entityContext := map[string]any{}
+	if body != nil && body.Context != nil {
+		m, ok := body.Context.(map[string]any)
+		if !ok {
+			return models.EvalContext{}, fmt.Errorf("context must be an object")
+		}
+		maps.Copy(entityContext, m)
+	}
+
+	var entityID string
+	if v, ok := entityContext[ofrepTargetingKey]; ok {
+		s, ok := v.(string)
+		if !ok {
+			return models.EvalContext{}, fmt.Errorf("%s must be a string", ofrepTargetingKey)
+		}
+		entityID = s
+		delete(entityContext, ofrepTargetingKey)
+	}
+
+	return models.EvalContext{
+		EntityID:      entityID,
+		EntityContext: InjectBuiltInContext(entityContext, r),
+	}, nil
+}
+
+// ofrepResult converts an eval result to an OFREP evaluation. The value is
+// the variant attachment, or the variant key when the attachment is empty;
+// it is omitted when no variant was assigned so the OpenFeature SDK returns
+// the code default.
+func ofrepResult(f *entity.Flag, r *models.EvalResult) *models.OfrepEvaluationSuccess {
+	metadata := map[string]any{
+		"flagID":         r.FlagID,
+		"flagSnapshotID": r.FlagSnapshotID,
+		"segmentID":      r.SegmentID,
+		"variantID":      r.VariantID,
+	}
+	if r.FlagSourceRevision != "" {
+		metadata["flagSourceRevision"] = r.FlagSourceRevision
+	}
+	res := &models.OfrepEvaluationSuccess{
+		Key:      f.Key,
+		Reason:   ofrepReason(f, r),
+		Metadata: metadata,
+	}
+	if r.VariantID == 0 {
+		return res
+	}
+
+	res.Variant = r.VariantKey
+	res.Value = r.VariantKey
+	if a, ok := r.VariantAttachment.(entity.Attachment); ok && len(a) > 0 {
+		res.Value = a
+	}
+	return res
+}
+
+// ofrepReason tells a deterministic targeting match from a percentage based
+// assignment: the entity is SPLIT when the matched segment rolls out to
+// less than 100% or distributes over more than one variant.
+func ofrepReason(f *entity.Flag, r *models.EvalResult) string {
+	if !f.Enabled {
+		return ofrepReasonDisabled
+	}
+	if r.VariantID == 0 {
+		return ofrepReasonDefault
+	}
+	for _, s := range f.Segments {
+		if int64(s.ID) != r.SegmentID {
+			continue
+		}
+		if s.RolloutPercent < 100 {
+			return ofrepReasonSplit
+		}
+		n := 0
+		for _, d := range s.Distributions {
+			if d.Percent > 0 {
+				n++
+			}
+		}
+		if n > 1 {
+			return ofrepReasonSplit
+		}
+	}
+	return ofrepReasonTargetingMatch
+}
+
+// jsonETag returns a strong ETag for the JSON encoding of v.
+func jsonETag(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// etagMatches reports whether an If-None-Match header value matches etag,
+// using the weak comparison RFC 9110 prescribes for If-None-Match.
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for candidate := range strings.SplitSeq(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openflagr/flagr/pkg/entity"
+	"github.com/openflagr/flagr/swagger_gen/models"
+	"github.com/openflagr/flagr/swagger_gen/restapi/operations/ofrep"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func genFixtureOFREPEvalCache() *EvalCache {
+	split := entity.GenFixtureFlag()
+
+	single := entity.GenFixtureFlag()
+	single.ID = 101
+	single.Key = "single_variant"
+	single.Segments[0].Constraints = nil
+	single.Segments[0].Distributions = []entity.Distribution{
+		{Model: gorm.Model{ID: 402}, VariantID: 300, VariantKey: "control", Percent: 100},
+	}
+	single.PrepareEvaluation()
+
+	disabled := entity.GenFixtureFlag()
+	disabled.ID = 102
+	disabled.Key = "disabled"
+	disabled.Enabled = false
+	disabled.PrepareEvaluation()
+
+	return GenFixtureEvalCacheWithFlags([]entity.Flag{split, single, disabled})
+}
+
+func TestOFREPEvaluateFlag(t *testing.T) {
+	defer gostub.StubFunc(&logEvalResult).Reset()
+	defer gostub.StubFunc(&GetEvalCache, genFixtureOFREPEvalCache()).Reset()
+	o := NewOFREP()
+
+	evaluate := func(key string, context any) any {
+		return o.EvaluateFlag(ofrep.OfrepEvaluateFlagParams{
+			HTTPRequest: httptest.NewRequest("POST", "/api/v1/ofrep/v1/evaluate/flags/"+key, nil),
+			Key:         key,
+			Body:        &models.OfrepEvaluationRequest{Context: context},
+		})
+	}
+
+	t.Run("split", func(t *testing.T) {
+		res := evaluate("flag_key_100", map[string]any{"targetingKey": "user1", "dl_state": "CA"})
+		ok, isOK := res.(*ofrep.OfrepEvaluateFlagOK)
+		require.True(t, isOK)
+		assert.Equal(t, "flag_key_100", ok.Payload.Key)
+		assert.Equal(t, ofrepReasonSplit, ok.Payload.Reason)
+		assert.Contains(t, []string{"control", "treatment"}, ok.Payload.Variant)
+		if ok.Payload.Variant == "treatment" {
+			assert.Equal(t, entity.Attachment{"value": "321"}, ok.Payload.Value)
+		} else {
+			assert.Equal(t, "control", ok.Payload.Value)
+		}
+		assert.Equal(t, int64(100), ok.Payload.Metadata.(map[string]any)["flagID"])
+		assert.Equal(t, int64(200), ok.Payload.Metadata.(map[string]any)["segmentID"])
+	})
+
+	t.Run("targeting match", func(t *testing.T) {
+		res := evaluate("single_variant", map[string]any{"targetingKey": "user1"})
+		ok := res.(*ofrep.OfrepEvaluateFlagOK)
+		assert.Equal(t, ofrepReasonTargetingMatch, ok.Payload.Reason)
+		assert.Equal(t, "control", ok.Payload.Variant)
+		assert.Equal(t, "control", ok.Payload.Value)
+	})
+
+	t.Run("default when no segment matches", func(t *testing.T) {
+		res := evaluate("flag_key_100", map[string]any{"targetingKey": "user1", "dl_state": "NY"})
+		ok := res.(*ofrep.OfrepEvaluateFlagOK)
+		assert.Equal(t, ofrepReasonDefault, ok.Payload.Reason)
+		assert.Empty(t, ok.Payload.Variant)
+		assert.Nil(t, ok.Payload.Value)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		res := evaluate("disabled", nil)
+		ok := res.(*ofrep.OfrepEvaluateFlagOK)
+		assert.Equal(t, ofrepReasonDisabled, ok.Payload.Reason)
+		assert.Nil(t, ok.Payload.Value)
+	})
+
+	t.Run("flag not found", func(t *testing.T) {
+		for _, key := range []string{"missing", "100"} {
+			res := evaluate(key, nil)
+			nf, isNotFound := res.(*ofrep.OfrepEvaluateFlagNotFound)
+			require.True(t, isNotFound, key)
+			assert.Equal(t, ofrepErrorFlagNotFound, nf.Payload.ErrorCode)
+			assert.Equal(t, ofrepReasonError, nf.Payload.Reason)
+			assert.Equal(t, key, nf.Payload.Key)
+		}
+	})
+
+	t.Run("invalid context", func(t *testing.T) {
+		for _, context := range []any{"not an object", map[string]any{"targetingKey": 1}} {
+			res := evaluate("flag_key_100", context)
+			br, isBadRequest := res.(*ofrep.OfrepEvaluateFlagBadRequest)
+			require.True(t, isBadRequest)
+			assert.Equal(t, ofrepErrorInvalidContext, br.Payload.ErrorCode)
+		}
+	})
+}
+
+func TestOFREPEvaluateFlagsBulk(t *testing.T) {
+	recorded := 0
+	defer gostub.Stub(&logEvalResult, func(*models.EvalResult, *entity.Flag) { recorded++ }).Reset()
+	ec := genFixtureOFREPEvalCache()
+	defer gostub.StubFunc(&GetEvalCache, ec).Reset()
+	o := NewOFREP()
+
+	evaluate := func(context any, ifNoneMatch *string) any {
+		return o.EvaluateFlagsBulk(ofrep.OfrepEvaluateFlagsBulkParams{
+			HTTPRequest: httptest.NewRequest("POST", "/api/v1/ofrep/v1/evaluate/flags", nil),
+			IfNoneMatch: ifNoneMatch,
+			Body:        &models.OfrepEvaluationRequest{Context: context},
+		})
+	}
+
+	context := map[string]any{"targetingKey": "user1", "dl_state": "CA"}
+	res := evaluate(context, nil)
+	ok, isOK := res.(*ofrep.OfrepEvaluateFlagsBulkOK)
+	require.True(t, isOK)
+	require.Len(t, ok.Payload.Flags, 3)
+	assert.Equal(t, "disabled", ok.Payload.Flags[0].Key)
+	assert.Equal(t, ofrepReasonDisabled, ok.Payload.Flags[0].Reason)
+	assert.Equal(t, "flag_key_100", ok.Payload.Flags[1].Key)
+	assert.Equal(t, ofrepReasonSplit, ok.Payload.Flags[1].Reason)
+	assert.Equal(t, "single_variant", ok.Payload.Flags[2].Key)
+	assert.Equal(t, ofrepReasonTargetingMatch, ok.Payload.Flags[2].Reason)
+	require.NotEmpty(t, ok.ETag)
+
+	etag := ok.ETag
+	res = evaluate(context, &etag)
+	nm, isNotModified := res.(*ofrep.OfrepEvaluateFlagsBulkNotModified)
+	require.True(t, isNotModified)
+	assert.Equal(t, etag, nm.ETag)
+
+	weak := `"other", W/` + etag
+	_, isNotModified = evaluate(context, &weak).(*ofrep.OfrepEvaluateFlagsBulkNotModified)
+	assert.True(t, isNotModified)
+
+	res = evaluate(map[string]any{"targetingKey": "user1", "dl_state": "NY"}, &etag)
+	ok, isOK = res.(*ofrep.OfrepEvaluateFlagsBulkOK)
+	require.True(t, isOK, "changed results must not match the old etag")
+	assert.NotEqual(t, etag, ok.ETag)
+
+	_, isBadRequest := evaluate([]any{}, nil).(*ofrep.OfrepEvaluateFlagsBulkBadRequest)
+	assert.True(t, isBadRequest)
+
+	t.Run("etag from the snapshot max ID skips evaluation", func(t *testing.T) {
+		ec.lastSnapshotMaxID = 7
+		defer func() { ec.lastSnapshotMaxID = 0 }()
+
+		// Without a targetingKey every evaluation is for a random entity.
+		anonymous := map[string]any{"dl_state": "CA"}
+		etag := evaluate(anonymous, nil).(*ofrep.OfrepEvaluateFlagsBulkOK).ETag
+		recorded = 0
+		nm, isNotModified := evaluate(anonymous, &etag).(*ofrep.OfrepEvaluateFlagsBulkNotModified)
+		require.True(t, isNotModified)
+		assert.Equal(t, etag, nm.ETag)
+		assert.Zero(t, recorded, "a 304 records nothing")
+
+		ok, isOK := evaluate(map[string]any{"dl_state": "NY"}, &etag).(*ofrep.OfrepEvaluateFlagsBulkOK)
+		require.True(t, isOK, "a different context must not match")
+		assert.NotEqual(t, etag, ok.ETag)
+
+		ec.lastSnapshotMaxID = 8
+		_, isOK = evaluate(anonymous, &etag).(*ofrep.OfrepEvaluateFlagsBulkOK)
+		assert.True(t, isOK, "a new snapshot must not match")
+	})
+}
+
+func TestEtagMatches(t *testing.T) {
+	t.Parallel()
+	assert.True(t, etagMatches(`"abc"`, `"abc"`))
+	assert.True(t, etagMatches(`W/"abc"`, `"abc"`))
+	assert.True(t, etagMatches(`"x", "abc"`, `"abc"`))
+	assert.True(t, etagMatches(`*`, `"abc"`))
+	assert.False(t, etagMatches(`"abcd"`, `"abc"`))
+	assert.False(t, etagMatches(``, `"abc"`))
+}
@@ -84,6 +84,7 @@ func MapSegment(e *entity.Segment) *models.Segment {
 	r.Rank = new(int64(e.Rank))
 	r.RolloutPercent = new(int64(e.RolloutPercent))
 	r.Constraints = MapConstraints(e.Constraints)
+	r.ConstraintGroups = MapConstraintGroups(e.ConstraintGroups)
 	r.Distributions = MapDistributions(e.Distributions)
 	return r
 }
@@ -133,6 +134,30 @@ func MapConstraints(e []entity.Constraint) []*models.Constraint {
 	return ret
 }
 
+// MapConstraintGroups maps constraint groups
+func MapConstraintGroups(e []entity.ConstraintGroup) []*models.ConstraintGroup {
+	if len(e) == 0 {
+		return nil
+	}
+	ret := make([]*models.ConstraintGroup, len(e))
+	for i, g := range e {
+		r := &models.ConstraintGroup{
+			Operator:    new(g.Operator),
+			Constraints: make([]*models.Constraint, len(g.Constraints)),
+			Groups:      MapConstraintGroups(g.Groups),
+		}
+		for j, c := range g.Constraints {
+			r.Constraints[j] = &models.Constraint{
+				Property: new(c.Property),
+				Operator: new(c.Operator),
+				Value:    new(c.Value),
+			}
+		}
+		ret[i] = r
+	}
+	return ret
+}
+
 // MapDistribution maps to a distribution
 func MapDistribution(e *entity.Distribution) *models.Distribution {
 	r := &models.Distribution{
@@ -171,3 +196,24 @@ func MapVariants(e []entity.Variant) []*models.Variant {
 	}
 	return ret
 }
+
+// MapMetric maps metric
+func MapMetric(e *entity.Metric) *models.Metric {
+	kind := models.MetricKind(e.Kind)
+	r := &models.Metric{
+		ID:          int64(e.ID),
+		Key:         new(e.Key),
+		Description: e.Description,
+		Kind:        &kind,
+	}
+	return r
+}
+
+// MapMetrics maps metrics
+func MapMetrics(e []entity.Metric) []*models.Metric {
+	ret := make([]*models.Metric, len(e))
+	for i, m := range e {
+		ret[i] = MapMetric(&m)
+	}
+	return ret
+}
@@ -29,6 +29,36 @@ func MapDistribution(r *models.Distribution, segmentID uint) entity.Distribution
 	return e
 }
 
+// MapConstraintGroups maps constraint groups
+func MapConstraintGroups(r []*models.ConstraintGroup) entity.ConstraintGroupArray {
+	if len(r) == 0 {
+		return nil
+	}
+	e := make(entity.ConstraintGroupArray, 0, len(r))
+	for _, g := range r {
+		if g == nil {
+			continue
+		}
+		eg := entity.ConstraintGroup{
+			Operator:    util.SafeString(g.Operator),
+			Constraints: make([]entity.GroupConstraint, 0, len(g.Constraints)),
+			Groups:      MapConstraintGroups(g.Groups),
+		}
+		for _, c := range g.Constraints {
+			if c == nil {
+				continue
+			}
+			eg.Constraints = append(eg.Constraints, entity.GroupConstraint{
+				Property: util.SafeString(c.Property),
+				Operator: util.SafeString(c.Operator),
+				Value:    util.SafeString(c.Value),
+			})
+		}
+		e = append(e, eg)
+	}
+	return e
+}
+
 // MapAttachment maps attachment
 func MapAttachment(a any) (entity.Attachment, error) {
 	e := entity.Attachment{}
@@ -20,6 +20,8 @@ const (
 	OperationUpdate  Operation = "update"
 	OperationDelete  Operation = "delete"
 	OperationRestore Operation = "restore"
+	// OperationAlert reports a problem with a flag's traffic rather than a change.
+	OperationAlert Operation = "alert"
 )
 
 // ComponentType identifies which part of a flag was modified.
@@ -32,6 +34,8 @@ const (
 	ComponentConstraint   ComponentType = "constraint"
 	ComponentDistribution ComponentType = "distribution"
 	ComponentTag          ComponentType = "tag"
+	// ComponentSampleRatio is the split of a flag's evaluations across its variants.
+	ComponentSampleRatio ComponentType = "sample_ratio"
 )
 
 type Notification struct {
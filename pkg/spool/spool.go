@@ -0,0 +1,572 @@
+// Package spool is a write-ahead spool of records on disk. Records are
+// appended to segment files and read back in order until they are
+// acknowledged, also across restarts. Acknowledged segments are removed, and
+// the oldest segments are dropped when the spool outgrows its caps.
+package spool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Reasons records are dropped, see Options.OnDrop.
+const (
+	DropMaxBytes = "max_bytes"
+	DropMaxAge   = "max_age"
+	DropCorrupt  = "corrupt"
+)
+
+const (
+	segmentExt     = ".seg"
+	checkpointName = "checkpoint"
+	headerSize     = 8 // length and CRC-32C of the record
+	maxRecordSize  = 64 << 20
+)
+
+// ErrClosed is returned by the methods of a closed Spool.
+var ErrClosed = errors.New("spool: closed")
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Options configures a Spool.
+type Options struct {
+	SegmentBytes int64         // size of a segment before the next one is started
+	MaxBytes     int64         // size beyond which the oldest segments are dropped, 0 for no cap; a few segments or more
+	MaxAge       time.Duration // age of the last write after which a segment is dropped, 0 for no cap
+	SyncInterval time.Duration // how often appended records are synced to disk, 0 syncs every append
+
+	// OnDrop, when set, is called with the records dropped before they were
+	// acknowledged. It is called with the Spool locked.
+	OnDrop func(reason string, records int)
+}
+
+// Stats is the records of a Spool not acknowledged yet.
+type Stats struct {
+	Records int64
+	Bytes   int64
+}
+
+type segment struct {
+	seq     uint64
+	size    int64
+	records int
+	modTime time.Time
+}
+
+// position is the first record not acknowledged, in segment seq.
+type position struct {
+	seq    uint64
+	offset int64
+	n      int // records before offset
+}
+
+// Spool is a write-ahead spool in a directory. Appends are safe from
+// concurrent goroutines; records are meant to be read by one consumer.
+type Spool struct {
+	dir  string
+	opts Options
+
+	mu       sync.Mutex
+	segments []*segment // oldest first, the last one is written to
+	w        *os.File
+	bw       *bufio.Writer
+	dirty    bool     // appended since the last sync
+	pos      position // in the oldest segment
+	saved    position // in the checkpoint
+	rf       *os.File // of segment pos.seq
+	peeked   []int64  // end offsets of the records of the last Peek
+	closed   bool
+
+	notify  chan struct{}
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+}
+
+// Open opens the spool in dir, creating dir if needed, and starts syncing it
+// in the background. Records not acknowledged before are read first; a record
+// cut short by a crash is dropped.
+func Open(dir string, opts Options) (*Spool, error) {
+	if opts.SegmentBytes <= 0 {
+		return nil, fmt.Errorf("spool: segment size must be positive")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	s := &Spool{
+		dir:     dir,
+		opts:    opts,
+		notify:  make(chan struct{}, 1),
+		closeCh: make(chan struct{}),
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	if err := s.rotate(); err != nil {
+		return nil, err
+	}
+
+	interval := opts.SyncInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	s.wg.Add(1)
+	go s.syncLoop(interval)
+	return s, nil
+}
+
+// load reads the segments and the checkpoint of the directory.
+func (s *Spool) load() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		var seq uint64
+		if e.IsDir() || !strings.HasSuffix(e.Name(), segmentExt) {
+			continue
+		}
+		if _, err := fmt.Sscanf(e.Name(), "%d"+segmentExt, &seq); err != nil {
+			continue
+		}
+		seg, err := s.scan(seq)
+		if err != nil {
+			return err
+		}
+		s.segments = append(s.segments, seg)
+	}
+	sort.Slice(s.segments, func(i, j int) bool { return s.segments[i].seq < s.segments[j].seq })
+
+	cp, err := s.readCheckpoint()
+	if err != nil {
+		return err
+	}
+	// Segments before the checkpoint were acknowledged, but not removed yet.
+	for len(s.segments) > 0 && s.segments[0].seq < cp.seq {
+		if err := os.Remove(s.path(s.segments[0].seq)); err != nil {
+			return err
+		}
+		s.segments = s.segments[1:]
+	}
+	if len(s.segments) > 0 {
+		s.pos = position{seq: s.segments[0].seq}
+		if seg := s.segments[0]; seg.seq == cp.seq && cp.offset <= seg.size && cp.n <= seg.records {
+			s.pos = cp
+		}
+	}
+	s.saved = cp
+	return nil
+}
+
+// scan counts the records of a segment, and truncates the segment at the
+// first record that is cut short or corrupt.
+func (s *Spool) scan(seq uint64) (*segment, error) {
+	f, err := os.OpenFile(s.path(seq), os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	seg := &segment{seq: seq, modTime: info.ModTime()}
+	r := bufio.NewReader(f)
+	for {
+		n, err := readRecord(r, nil)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			logrus.WithError(err).WithFields(logrus.Fields{
+				"segment": f.Name(),
+				"bytes":   info.Size() - seg.size,
+			}).Warn("spool: truncating a segment at a damaged record")
+			if s.opts.OnDrop != nil {
+				s.opts.OnDrop(DropCorrupt, 1)
+			}
+			if err := f.Truncate(seg.size); err != nil {
+				return nil, err
+			}
+			break
+		}
+		seg.size += int64(n)
+		seg.records++
+	}
+	return seg, nil
+}
+
+// readRecord reads a record from r into buf if it is not nil, and returns the
+// bytes it took in the segment.
+func readRecord(r io.Reader, buf *[]byte) (int, error) {
+	var h [headerSize]byte
+	if _, err := io.ReadFull(r, h[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return 0, fmt.Errorf("record header cut short")
+		}
+		return 0, err
+	}
+	length := binary.BigEndian.Uint32(h[:4])
+	if length > maxRecordSize {
+		return 0, fmt.Errorf("record of %d bytes", length)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return 0, fmt.Errorf("record cut short: %w", err)
+	}
+	if crc32.Checksum(data, crcTable) != binary.BigEndian.Uint32(h[4:]) {
+		return 0, fmt.Errorf("record checksum mismatch")
+	}
+	if buf != nil {
+		*buf = data
+	}
+	return headerSize + int(length), nil
+}
+
+func (s *Spool) path(seq uint64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%020d%s", seq, segmentExt))
+}
+
+// Append writes a record at the end of the spool. It is synced to disk within
+// the sync interval.
+func (s *Spool) Append(record []byte) error {
+	if len(record) > maxRecordSize {
+		return fmt.Errorf("spool: record of %d bytes is too large", len(record))
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	active := s.segments[len(s.segments)-1]
+	size := int64(headerSize + len(record))
+	if active.size > 0 && active.size+size > s.opts.SegmentBytes {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+		active = s.segments[len(s.segments)-1]
+	}
+
+	var h [headerSize]byte
+	binary.BigEndian.PutUint32(h[:4], uint32(len(record)))
+	binary.BigEndian.PutUint32(h[4:], crc32.Checksum(record, crcTable))
+	if _, err := s.bw.Write(h[:]); err != nil {
+		return err
+	}
+	if _, err := s.bw.Write(record); err != nil {
+		return err
+	}
+	active.size += size
+	active.records++
+	active.modTime = time.Now()
+	s.dirty = true
+	if s.opts.SyncInterval <= 0 {
+		if err := s.sync(); err != nil {
+			return err
+		}
+	}
+	s.enforceMaxBytes()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// rotate syncs and closes the segment written to, and starts the next one.
+func (s *Spool) rotate() error {
+	var seq uint64 = 1
+	if n := len(s.segments); n > 0 {
+		seq = s.segments[n-1].seq + 1
+	}
+	if s.w != nil {
+		if err := s.sync(); err != nil {
+			return err
+		}
+		if err := s.w.Close(); err != nil {
+			return err
+		}
+	}
+	f, err := os.OpenFile(s.path(seq), os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	s.w, s.bw = f, bufio.NewWriter(f)
+	s.segments = append(s.segments, &segment{seq: seq, modTime: time.Now()})
+	if len(s.segments) == 1 {
+		s.pos = position{seq: seq}
+	}
+	return nil
+}
+
+// sync writes the buffered records to the segment and syncs it to disk.
+func (s *Spool) sync() error {
+	if !s.dirty {
+		return nil
+	}
+	if err := s.bw.Flush(); err != nil {
+		return err
+	}
+	if err := s.w.Sync(); err != nil {
+		return err
+	}
+	s.dirty = false
+	return nil
+}
+
+// enforceMaxBytes drops the oldest segments while the spool is larger than
+// the cap. The segment written to is kept.
+func (s *Spool) enforceMaxBytes() {
+	if s.opts.MaxBytes <= 0 {
+		return
+	}
+	var total int64
+	for _, seg := range s.segments {
+		total += seg.size
+	}
+	for total > s.opts.MaxBytes && len(s.segments) > 1 {
+		total -= s.segments[0].size
+		s.dropOldest(DropMaxBytes)
+	}
+}
+
+// enforceMaxAge drops the segments last written before the age cap. A
+// segment written to is rotated first, when it has records.
+func (s *Spool) enforceMaxAge(now time.Time) error {
+	if s.opts.MaxAge <= 0 {
+		return nil
+	}
+	cutoff := now.Add(-s.opts.MaxAge)
+	if active := s.segments[len(s.segments)-1]; active.records > 0 && active.modTime.Before(cutoff) {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	for len(s.segments) > 1 && s.segments[0].modTime.Before(cutoff) {
+		s.dropOldest(DropMaxAge)
+	}
+	return nil
+}
+
+// dropOldest removes the oldest segment, which is not written to, with the
+// records not acknowledged in it.
+func (s *Spool) dropOldest(reason string) {
+	seg := s.segments[0]
+	dropped := seg.records - s.pos.n
+	s.removeOldest()
+	if dropped > 0 {
+		logrus.WithFields(logrus.Fields{"dir": s.dir, "records": dropped, "reason": reason}).
+			Warn("spool: dropped records that were not delivered")
+		if s.opts.OnDrop != nil {
+			s.opts.OnDrop(reason, dropped)
+		}
+	}
+}
+
+// removeOldest removes the oldest segment, which is not written to, and moves
+// the position to the next one.
+func (s *Spool) removeOldest() {
+	seg := s.segments[0]
+	if s.rf != nil {
+		s.rf.Close()
+		s.rf = nil
+	}
+	s.pos = position{seq: s.segments[1].seq}
+	s.peeked = nil
+	s.segments = s.segments[1:]
+	if err := os.Remove(s.path(seg.seq)); err != nil {
+		logrus.WithError(err).WithField("segment", s.path(seg.seq)).Error("spool: failed to remove segment")
+	}
+}
+
+// Notify returns a channel that receives after records are appended.
+func (s *Spool) Notify() <-chan struct{} {
+	return s.notify
+}
+
+// Peek returns up to n of the oldest records not acknowledged, without
+// acknowledging them. Records are returned from one segment at a time, so
+// fewer than n are returned at the end of a segment.
+func (s *Spool) Peek(n int) ([][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, ErrClosed
+	}
+	s.peeked = s.peeked[:0]
+	for s.pos.n >= s.segments[0].records && len(s.segments) > 1 {
+		s.removeOldest()
+	}
+	seg := s.segments[0]
+	if s.pos.n >= seg.records {
+		return nil, nil
+	}
+	if seg == s.segments[len(s.segments)-1] && s.dirty {
+		// Records still in the write buffer are read from the file.
+		if err := s.bw.Flush(); err != nil {
+			return nil, err
+		}
+	}
+	if s.rf == nil {
+		f, err := os.Open(s.path(seg.seq))
+		if err != nil {
+			return nil, err
+		}
+		s.rf = f
+	}
+
+	r := bufio.NewReader(io.NewSectionReader(s.rf, s.pos.offset, seg.size-s.pos.offset))
+	offset := s.pos.offset
+	var records [][]byte
+	for len(records) < n && s.pos.n+len(records) < seg.records {
+		var data []byte
+		size, err := readRecord(r, &data)
+		if err != nil {
+			// Damaged after it was written: the rest of the segment is lost.
+			if len(records) > 0 {
+				break
+			}
+			logrus.WithError(err).WithField("segment", s.rf.Name()).Warn("spool: dropping a segment at a damaged record")
+			if seg == s.segments[len(s.segments)-1] {
+				// Appends go to a new segment, so the damaged one can go.
+				if err := s.rotate(); err != nil {
+					return nil, err
+				}
+			}
+			s.dropOldest(DropCorrupt)
+			return nil, nil
+		}
+		offset += int64(size)
+		records = append(records, data)
+		s.peeked = append(s.peeked, offset)
+	}
+	return records, nil
+}
+
+// Ack acknowledges the first n records of the last Peek. Records dropped
+// since are not acknowledged again.
+func (s *Spool) Ack(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n <= 0 || n > len(s.peeked) {
+		return
+	}
+	s.pos.offset = s.peeked[n-1]
+	s.pos.n += n
+	s.peeked = s.peeked[:0]
+}
+
+// Stats returns the records not acknowledged.
+func (s *Spool) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var st Stats
+	for _, seg := range s.segments {
+		st.Records += int64(seg.records)
+		st.Bytes += seg.size
+	}
+	st.Records -= int64(s.pos.n)
+	st.Bytes -= s.pos.offset
+	return st
+}
+
+// syncLoop syncs the spool, applies the age cap and writes the checkpoint
+// every interval.
+func (s *Spool) syncLoop(interval time.Duration) {
+	defer s.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.closeCh:
+			return
+		case now := <-ticker.C:
+			s.mu.Lock()
+			err := errors.Join(s.sync(), s.enforceMaxAge(now), s.writeCheckpoint())
+			s.mu.Unlock()
+			if err != nil {
+				logrus.WithError(err).WithField("dir", s.dir).Error("spool: sync failed")
+			}
+		}
+	}
+}
+
+// readCheckpoint reads the position written by writeCheckpoint, the zero
+// position when there is none.
+func (s *Spool) readCheckpoint() (position, error) {
+	var cp position
+	b, err := os.ReadFile(filepath.Join(s.dir, checkpointName))
+	if errors.Is(err, os.ErrNotExist) {
+		return cp, nil
+	}
+	if err != nil {
+		return cp, err
+	}
+	if _, err := fmt.Sscan(string(b), &cp.seq, &cp.offset, &cp.n); err != nil {
+		logrus.WithError(err).WithField("dir", s.dir).Warn("spool: ignoring an unreadable checkpoint")
+		return position{}, nil
+	}
+	return cp, nil
+}
+
+// writeCheckpoint saves the position, so that a restart replays the records
+// acknowledged since the last checkpoint only.
+func (s *Spool) writeCheckpoint() error {
+	if s.pos == s.saved {
+		return nil
+	}
+	tmp := filepath.Join(s.dir, checkpointName+".tmp")
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(f, "%d %d %d\n", s.pos.seq, s.pos.offset, s.pos.n)
+	// Synced before the rename, so that a crash does not leave an empty
+	// checkpoint in place of the last one.
+	if err := errors.Join(err, f.Sync(), f.Close()); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(s.dir, checkpointName)); err != nil {
+		return err
+	}
+	s.saved = s.pos
+	return nil
+}
+
+// Close syncs the spool to disk and writes the checkpoint. The records not
+// acknowledged are read first when the spool is opened again.
+func (s *Spool) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.closeCh)
+	s.mu.Unlock()
+	s.wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.sync()
+	if s.rf != nil {
+		s.rf.Close()
+	}
+	err = errors.Join(err, s.w.Close())
+	if active := s.segments[len(s.segments)-1]; active.records == 0 {
+		err = errors.Join(err, os.Remove(s.path(active.seq)))
+	}
+	return errors.Join(err, s.writeCheckpoint())
+}
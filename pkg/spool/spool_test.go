@@ -0,0 +1,227 @@
+package spool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func appendRecords(t *testing.T, s *Spool, from, to int) {
+	t.Helper()
+	for i := from; i < to; i++ {
+		require.NoError(t, s.Append(fmt.Appendf(nil, "record-%03d", i)))
+	}
+}
+
+// drain reads and acknowledges all records, n at a time.
+func drain(t *testing.T, s *Spool, n int) []string {
+	t.Helper()
+	var got []string
+	for {
+		records, err := s.Peek(n)
+		require.NoError(t, err)
+		if len(records) == 0 {
+			return got
+		}
+		for _, r := range records {
+			got = append(got, string(r))
+		}
+		s.Ack(len(records))
+	}
+}
+
+func names(from, to int) []string {
+	var n []string
+	for i := from; i < to; i++ {
+		n = append(n, fmt.Sprintf("record-%03d", i))
+	}
+	return n
+}
+
+func segmentFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*"+segmentExt))
+	require.NoError(t, err)
+	return files
+}
+
+func TestSpool_InOrder(t *testing.T) {
+	t.Parallel()
+	s, err := Open(t.TempDir(), Options{SegmentBytes: 64})
+	require.NoError(t, err)
+	defer s.Close()
+
+	records, err := s.Peek(10)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	appendRecords(t, s, 0, 10)
+	assert.Equal(t, Stats{Records: 10, Bytes: 10 * (headerSize + 10)}, s.Stats())
+	select {
+	case <-s.Notify():
+	default:
+		t.Fatal("appends notify")
+	}
+
+	// Peek without Ack returns the same records.
+	first, err := s.Peek(2)
+	require.NoError(t, err)
+	again, err := s.Peek(2)
+	require.NoError(t, err)
+	assert.Equal(t, first, again)
+
+	assert.Equal(t, names(0, 10), drain(t, s, 3))
+	assert.Equal(t, Stats{}, s.Stats())
+	assert.Len(t, segmentFiles(t, s.dir), 1, "delivered segments are removed")
+}
+
+func TestSpool_Reopen(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	s, err := Open(dir, Options{SegmentBytes: 64, SyncInterval: time.Hour})
+	require.NoError(t, err)
+	appendRecords(t, s, 0, 10)
+	records, err := s.Peek(4)
+	require.NoError(t, err)
+	require.Len(t, records, 3, "a segment at a time")
+	s.Ack(2)
+	require.NoError(t, s.Close())
+	_, err = s.Peek(1)
+	assert.ErrorIs(t, err, ErrClosed)
+	assert.ErrorIs(t, s.Append([]byte("late")), ErrClosed)
+
+	s, err = Open(dir, Options{SegmentBytes: 64})
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), s.Stats().Records)
+	appendRecords(t, s, 10, 12)
+	assert.Equal(t, names(2, 12), drain(t, s, 5), "acknowledged records are not replayed")
+	require.NoError(t, s.Close())
+
+	s, err = Open(dir, Options{SegmentBytes: 64})
+	require.NoError(t, err)
+	defer s.Close()
+	assert.Empty(t, drain(t, s, 5))
+}
+
+func TestSpool_TornTail(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	s, err := Open(dir, Options{SegmentBytes: 1 << 20})
+	require.NoError(t, err)
+	appendRecords(t, s, 0, 3)
+	require.NoError(t, s.Close())
+
+	// A crash in the middle of an append leaves part of a record.
+	files := segmentFiles(t, dir)
+	require.Len(t, files, 1)
+	f, err := os.OpenFile(files[0], os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0, 0, 0, 20, 1, 2, 3, 4, 'r', 'e'})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	var drops []string
+	s, err = Open(dir, Options{SegmentBytes: 1 << 20, OnDrop: func(reason string, records int) {
+		drops = append(drops, fmt.Sprintf("%s:%d", reason, records))
+	}})
+	require.NoError(t, err)
+	defer s.Close()
+	assert.Equal(t, []string{"corrupt:1"}, drops)
+	appendRecords(t, s, 3, 4)
+	assert.Equal(t, names(0, 4), drain(t, s, 10))
+}
+
+func TestSpool_DamagedActiveSegment(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	dropped := map[string]int{}
+	s, err := Open(dir, Options{
+		SegmentBytes: 1 << 20,
+		OnDrop:       func(reason string, records int) { dropped[reason] += records },
+	})
+	require.NoError(t, err)
+	defer s.Close()
+	appendRecords(t, s, 0, 3)
+
+	// The second record is damaged on disk after it was written.
+	files := segmentFiles(t, dir)
+	require.Len(t, files, 1)
+	f, err := os.OpenFile(files[0], os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteAt([]byte("X"), 2*headerSize+10)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	records, err := s.Peek(10)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("record-000")}, records)
+	s.Ack(1)
+
+	// The rest of the segment is dropped, and appends go to the next one.
+	records, err = s.Peek(10)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	assert.Equal(t, map[string]int{DropCorrupt: 2}, dropped)
+	assert.NotEqual(t, files, segmentFiles(t, dir))
+	appendRecords(t, s, 3, 5)
+	assert.Equal(t, names(3, 5), drain(t, s, 10))
+}
+
+func TestSpool_MaxBytes(t *testing.T) {
+	t.Parallel()
+	dropped := map[string]int{}
+	s, err := Open(t.TempDir(), Options{
+		SegmentBytes: 3 * (headerSize + 10),
+		MaxBytes:     7 * (headerSize + 10),
+		OnDrop:       func(reason string, records int) { dropped[reason] += records },
+	})
+	require.NoError(t, err)
+	defer s.Close()
+
+	appendRecords(t, s, 0, 2)
+	records, err := s.Peek(1)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	s.Ack(1)
+	appendRecords(t, s, 2, 10)
+
+	// Records 0-2 and 3-5 filled two segments, the first of which was
+	// dropped with 2 records not acknowledged.
+	assert.Equal(t, map[string]int{DropMaxBytes: 2}, dropped)
+	assert.Equal(t, Stats{Records: 7, Bytes: 7 * (headerSize + 10)}, s.Stats())
+	s.Ack(1) // of a dropped segment
+	assert.Equal(t, names(3, 10), drain(t, s, 10))
+}
+
+func TestSpool_MaxAge(t *testing.T) {
+	t.Parallel()
+	dropped := map[string]int{}
+	s, err := Open(t.TempDir(), Options{
+		SegmentBytes: 3 * (headerSize + 10),
+		MaxAge:       time.Hour,
+		OnDrop:       func(reason string, records int) { dropped[reason] += records },
+	})
+	require.NoError(t, err)
+	defer s.Close()
+
+	appendRecords(t, s, 0, 4)
+	s.mu.Lock()
+	require.NoError(t, s.enforceMaxAge(time.Now().Add(30*time.Minute)))
+	s.mu.Unlock()
+	assert.Empty(t, dropped)
+
+	// The segment written to is rotated to drop its records too.
+	s.mu.Lock()
+	require.NoError(t, s.enforceMaxAge(time.Now().Add(2*time.Hour)))
+	s.mu.Unlock()
+	assert.Equal(t, map[string]int{DropMaxAge: 4}, dropped)
+	assert.Equal(t, Stats{}, s.Stats())
+
+	appendRecords(t, s, 4, 5)
+	assert.Equal(t, names(4, 5), drain(t, s, 10))
+}
@@ -0,0 +1,944 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: flagr/v1/evaluation.proto
+
+package flagrv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EvalContext struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// entity_id is used to deterministically at random to evaluate the flag
+	// result. If it's empty, flagr will randomly generate one.
+	EntityId      string           `protobuf:"bytes,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	EntityType    string           `protobuf:"bytes,2,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	EntityContext *structpb.Struct `protobuf:"bytes,3,opt,name=entity_context,json=entityContext,proto3" json:"entity_context,omitempty"`
+	EnableDebug   bool             `protobuf:"varint,4,opt,name=enable_debug,json=enableDebug,proto3" json:"enable_debug,omitempty"`
+	// flag_id or flag_key will resolve to the same flag. Either works.
+	FlagId  int64  `protobuf:"varint,5,opt,name=flag_id,json=flagId,proto3" json:"flag_id,omitempty"`
+	FlagKey string `protobuf:"bytes,6,opt,name=flag_key,json=flagKey,proto3" json:"flag_key,omitempty"`
+	// flag_tags is set on the results of a batch evaluation by tags.
+	FlagTags         []string `protobuf:"bytes,7,rep,name=flag_tags,json=flagTags,proto3" json:"flag_tags,omitempty"`
+	FlagTagsOperator string   `protobuf:"bytes,8,opt,name=flag_tags_operator,json=flagTagsOperator,proto3" json:"flag_tags_operator,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *EvalContext) Reset() {
+	*x = EvalContext{}
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvalContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvalContext) ProtoMessage() {}
+
+func (x *EvalContext) ProtoReflect() protoreflect.Message {
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvalContext.ProtoReflect.Descriptor instead.
+func (*EvalContext) Descriptor() ([]byte, []int) {
+	return file_flagr_v1_evaluation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EvalContext) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *EvalContext) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *EvalContext) GetEntityContext() *structpb.Struct {
+	if x != nil {
+		return x.EntityContext
+	}
+	return nil
+}
+
+func (x *EvalContext) GetEnableDebug() bool {
+	if x != nil {
+		return x.EnableDebug
+	}
+	return false
+}
+
+func (x *EvalContext) GetFlagId() int64 {
+	if x != nil {
+		return x.FlagId
+	}
+	return 0
+}
+
+func (x *EvalContext) GetFlagKey() string {
+	if x != nil {
+		return x.FlagKey
+	}
+	return ""
+}
+
+func (x *EvalContext) GetFlagTags() []string {
+	if x != nil {
+		return x.FlagTags
+	}
+	return nil
+}
+
+func (x *EvalContext) GetFlagTagsOperator() string {
+	if x != nil {
+		return x.FlagTagsOperator
+	}
+	return ""
+}
+
+type EvalResult struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	FlagId             int64                  `protobuf:"varint,1,opt,name=flag_id,json=flagId,proto3" json:"flag_id,omitempty"`
+	FlagKey            string                 `protobuf:"bytes,2,opt,name=flag_key,json=flagKey,proto3" json:"flag_key,omitempty"`
+	FlagSnapshotId     int64                  `protobuf:"varint,3,opt,name=flag_snapshot_id,json=flagSnapshotId,proto3" json:"flag_snapshot_id,omitempty"`
+	FlagSourceRevision string                 `protobuf:"bytes,4,opt,name=flag_source_revision,json=flagSourceRevision,proto3" json:"flag_source_revision,omitempty"`
+	FlagTags           []string               `protobuf:"bytes,5,rep,name=flag_tags,json=flagTags,proto3" json:"flag_tags,omitempty"`
+	SegmentId          int64                  `protobuf:"varint,6,opt,name=segment_id,json=segmentId,proto3" json:"segment_id,omitempty"`
+	VariantId          int64                  `protobuf:"varint,7,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	VariantKey         string                 `protobuf:"bytes,8,opt,name=variant_key,json=variantKey,proto3" json:"variant_key,omitempty"`
+	VariantAttachment  *structpb.Struct       `protobuf:"bytes,9,opt,name=variant_attachment,json=variantAttachment,proto3" json:"variant_attachment,omitempty"`
+	EvalContext        *EvalContext           `protobuf:"bytes,10,opt,name=eval_context,json=evalContext,proto3" json:"eval_context,omitempty"`
+	Timestamp          string                 `protobuf:"bytes,11,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	EvalDebugLog       *EvalDebugLog          `protobuf:"bytes,12,opt,name=eval_debug_log,json=evalDebugLog,proto3" json:"eval_debug_log,omitempty"`
+	DataRecordsEnabled bool                   `protobuf:"varint,13,opt,name=data_records_enabled,json=dataRecordsEnabled,proto3" json:"data_records_enabled,omitempty"`
+	// evaluation for eval API results
+	RecordSource string `protobuf:"bytes,14,opt,name=record_source,json=recordSource,proto3" json:"record_source,omitempty"`
+	// set on a batch result that was not evaluated, e.g. after the batch deadline
+	Error         string `protobuf:"bytes,15,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvalResult) Reset() {
+	*x = EvalResult{}
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvalResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvalResult) ProtoMessage() {}
+
+func (x *EvalResult) ProtoReflect() protoreflect.Message {
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvalResult.ProtoReflect.Descriptor instead.
+func (*EvalResult) Descriptor() ([]byte, []int) {
+	return file_flagr_v1_evaluation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EvalResult) GetFlagId() int64 {
+	if x != nil {
+		return x.FlagId
+	}
+	return 0
+}
+
+func (x *EvalResult) GetFlagKey() string {
+	if x != nil {
+		return x.FlagKey
+	}
+	return ""
+}
+
+func (x *EvalResult) GetFlagSnapshotId() int64 {
+	if x != nil {
+		return x.FlagSnapshotId
+	}
+	return 0
+}
+
+func (x *EvalResult) GetFlagSourceRevision() string {
+	if x != nil {
+		return x.FlagSourceRevision
+	}
+	return ""
+}
+
+func (x *EvalResult) GetFlagTags() []string {
+	if x != nil {
+		return x.FlagTags
+	}
+	return nil
+}
+
+func (x *EvalResult) GetSegmentId() int64 {
+	if x != nil {
+		return x.SegmentId
+	}
+	return 0
+}
+
+func (x *EvalResult) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+func (x *EvalResult) GetVariantKey() string {
+	if x != nil {
+		return x.VariantKey
+	}
+	return ""
+}
+
+func (x *EvalResult) GetVariantAttachment() *structpb.Struct {
+	if x != nil {
+		return x.VariantAttachment
+	}
+	return nil
+}
+
+func (x *EvalResult) GetEvalContext() *EvalContext {
+	if x != nil {
+		return x.EvalContext
+	}
+	return nil
+}
+
+func (x *EvalResult) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *EvalResult) GetEvalDebugLog() *EvalDebugLog {
+	if x != nil {
+		return x.EvalDebugLog
+	}
+	return nil
+}
+
+func (x *EvalResult) GetDataRecordsEnabled() bool {
+	if x != nil {
+		return x.DataRecordsEnabled
+	}
+	return false
+}
+
+func (x *EvalResult) GetRecordSource() string {
+	if x != nil {
+		return x.RecordSource
+	}
+	return ""
+}
+
+func (x *EvalResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type EvalDebugLog struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	SegmentDebugLogs []*SegmentDebugLog     `protobuf:"bytes,1,rep,name=segment_debug_logs,json=segmentDebugLogs,proto3" json:"segment_debug_logs,omitempty"`
+	Msg              string                 `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *EvalDebugLog) Reset() {
+	*x = EvalDebugLog{}
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvalDebugLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvalDebugLog) ProtoMessage() {}
+
+func (x *EvalDebugLog) ProtoReflect() protoreflect.Message {
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvalDebugLog.ProtoReflect.Descriptor instead.
+func (*EvalDebugLog) Descriptor() ([]byte, []int) {
+	return file_flagr_v1_evaluation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EvalDebugLog) GetSegmentDebugLogs() []*SegmentDebugLog {
+	if x != nil {
+		return x.SegmentDebugLogs
+	}
+	return nil
+}
+
+func (x *EvalDebugLog) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+type SegmentDebugLog struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SegmentId     int64                  `protobuf:"varint,1,opt,name=segment_id,json=segmentId,proto3" json:"segment_id,omitempty"`
+	Msg           string                 `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SegmentDebugLog) Reset() {
+	*x = SegmentDebugLog{}
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SegmentDebugLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SegmentDebugLog) ProtoMessage() {}
+
+func (x *SegmentDebugLog) ProtoReflect() protoreflect.Message {
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SegmentDebugLog.ProtoReflect.Descriptor instead.
+func (*SegmentDebugLog) Descriptor() ([]byte, []int) {
+	return file_flagr_v1_evaluation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SegmentDebugLog) GetSegmentId() int64 {
+	if x != nil {
+		return x.SegmentId
+	}
+	return 0
+}
+
+func (x *SegmentDebugLog) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+type EvaluateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EvalContext   *EvalContext           `protobuf:"bytes,1,opt,name=eval_context,json=evalContext,proto3" json:"eval_context,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateRequest) Reset() {
+	*x = EvaluateRequest{}
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateRequest) ProtoMessage() {}
+
+func (x *EvaluateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateRequest) Descriptor() ([]byte, []int) {
+	return file_flagr_v1_evaluation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EvaluateRequest) GetEvalContext() *EvalContext {
+	if x != nil {
+		return x.EvalContext
+	}
+	return nil
+}
+
+type EvaluateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *EvalResult            `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateResponse) Reset() {
+	*x = EvaluateResponse{}
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateResponse) ProtoMessage() {}
+
+func (x *EvaluateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateResponse.ProtoReflect.Descriptor instead.
+func (*EvaluateResponse) Descriptor() ([]byte, []int) {
+	return file_flagr_v1_evaluation_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EvaluateResponse) GetResult() *EvalResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type EvaluationEntity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntityId      string                 `protobuf:"bytes,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	EntityType    string                 `protobuf:"bytes,2,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	EntityContext *structpb.Struct       `protobuf:"bytes,3,opt,name=entity_context,json=entityContext,proto3" json:"entity_context,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluationEntity) Reset() {
+	*x = EvaluationEntity{}
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluationEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluationEntity) ProtoMessage() {}
+
+func (x *EvaluationEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluationEntity.ProtoReflect.Descriptor instead.
+func (*EvaluationEntity) Descriptor() ([]byte, []int) {
+	return file_flagr_v1_evaluation_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EvaluationEntity) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *EvaluationEntity) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *EvaluationEntity) GetEntityContext() *structpb.Struct {
+	if x != nil {
+		return x.EntityContext
+	}
+	return nil
+}
+
+type EvaluateBatchRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Entities    []*EvaluationEntity    `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	EnableDebug bool                   `protobuf:"varint,2,opt,name=enable_debug,json=enableDebug,proto3" json:"enable_debug,omitempty"`
+	FlagIds     []int64                `protobuf:"varint,3,rep,packed,name=flag_ids,json=flagIds,proto3" json:"flag_ids,omitempty"`
+	FlagKeys    []string               `protobuf:"bytes,4,rep,name=flag_keys,json=flagKeys,proto3" json:"flag_keys,omitempty"`
+	FlagTags    []string               `protobuf:"bytes,5,rep,name=flag_tags,json=flagTags,proto3" json:"flag_tags,omitempty"`
+	// ANY (default) or ALL
+	FlagTagsOperator string `protobuf:"bytes,6,opt,name=flag_tags_operator,json=flagTagsOperator,proto3" json:"flag_tags_operator,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *EvaluateBatchRequest) Reset() {
+	*x = EvaluateBatchRequest{}
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateBatchRequest) ProtoMessage() {}
+
+func (x *EvaluateBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateBatchRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateBatchRequest) Descriptor() ([]byte, []int) {
+	return file_flagr_v1_evaluation_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EvaluateBatchRequest) GetEntities() []*EvaluationEntity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+func (x *EvaluateBatchRequest) GetEnableDebug() bool {
+	if x != nil {
+		return x.EnableDebug
+	}
+	return false
+}
+
+func (x *EvaluateBatchRequest) GetFlagIds() []int64 {
+	if x != nil {
+		return x.FlagIds
+	}
+	return nil
+}
+
+func (x *EvaluateBatchRequest) GetFlagKeys() []string {
+	if x != nil {
+		return x.FlagKeys
+	}
+	return nil
+}
+
+func (x *EvaluateBatchRequest) GetFlagTags() []string {
+	if x != nil {
+		return x.FlagTags
+	}
+	return nil
+}
+
+func (x *EvaluateBatchRequest) GetFlagTagsOperator() string {
+	if x != nil {
+		return x.FlagTagsOperator
+	}
+	return ""
+}
+
+type EvaluateBatchResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Results []*EvalResult          `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// true if some results were not evaluated because the batch deadline passed
+	Partial       bool `protobuf:"varint,2,opt,name=partial,proto3" json:"partial,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateBatchResponse) Reset() {
+	*x = EvaluateBatchResponse{}
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateBatchResponse) ProtoMessage() {}
+
+func (x *EvaluateBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateBatchResponse.ProtoReflect.Descriptor instead.
+func (*EvaluateBatchResponse) Descriptor() ([]byte, []int) {
+	return file_flagr_v1_evaluation_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EvaluateBatchResponse) GetResults() []*EvalResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *EvaluateBatchResponse) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+type WatchFlagsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Flag selection, with the same precedence as
+	// GET /export/eval_cache/json: keys, then tags. Empty selects every flag.
+	FlagKeys []string `protobuf:"bytes,1,rep,name=flag_keys,json=flagKeys,proto3" json:"flag_keys,omitempty"`
+	FlagTags []string `protobuf:"bytes,2,rep,name=flag_tags,json=flagTags,proto3" json:"flag_tags,omitempty"`
+	// ANY (default) or ALL
+	FlagTagsOperator string `protobuf:"bytes,3,opt,name=flag_tags_operator,json=flagTagsOperator,proto3" json:"flag_tags_operator,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *WatchFlagsRequest) Reset() {
+	*x = WatchFlagsRequest{}
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchFlagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchFlagsRequest) ProtoMessage() {}
+
+func (x *WatchFlagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchFlagsRequest.ProtoReflect.Descriptor instead.
+func (*WatchFlagsRequest) Descriptor() ([]byte, []int) {
+	return file_flagr_v1_evaluation_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WatchFlagsRequest) GetFlagKeys() []string {
+	if x != nil {
+		return x.FlagKeys
+	}
+	return nil
+}
+
+func (x *WatchFlagsRequest) GetFlagTags() []string {
+	if x != nil {
+		return x.FlagTags
+	}
+	return nil
+}
+
+func (x *WatchFlagsRequest) GetFlagTagsOperator() string {
+	if x != nil {
+		return x.FlagTagsOperator
+	}
+	return ""
+}
+
+type WatchFlagsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// eval_cache_json is the EvalCacheJSON document ({"Flags": [...]}) of the
+	// selected flags, as served by GET /export/eval_cache/json.
+	EvalCacheJson []byte `protobuf:"bytes,1,opt,name=eval_cache_json,json=evalCacheJson,proto3" json:"eval_cache_json,omitempty"`
+	// flag_source_revision is the revision of eval-only flag sources, see
+	// GET /health.
+	FlagSourceRevision string `protobuf:"bytes,2,opt,name=flag_source_revision,json=flagSourceRevision,proto3" json:"flag_source_revision,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *WatchFlagsResponse) Reset() {
+	*x = WatchFlagsResponse{}
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchFlagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchFlagsResponse) ProtoMessage() {}
+
+func (x *WatchFlagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flagr_v1_evaluation_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchFlagsResponse.ProtoReflect.Descriptor instead.
+func (*WatchFlagsResponse) Descriptor() ([]byte, []int) {
+	return file_flagr_v1_evaluation_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WatchFlagsResponse) GetEvalCacheJson() []byte {
+	if x != nil {
+		return x.EvalCacheJson
+	}
+	return nil
+}
+
+func (x *WatchFlagsResponse) GetFlagSourceRevision() string {
+	if x != nil {
+		return x.FlagSourceRevision
+	}
+	return ""
+}
+
+var File_flagr_v1_evaluation_proto protoreflect.FileDescriptor
+
+const file_flagr_v1_evaluation_proto_rawDesc = "" +
+	"\n" +
+	"\x19flagr/v1/evaluation.proto\x12\bflagr.v1\x1a\x1cgoogle/protobuf/struct.proto\"\xad\x02\n" +
+	"\vEvalContext\x12\x1b\n" +
+	"\tentity_id\x18\x01 \x01(\tR\bentityId\x12\x1f\n" +
+	"\ventity_type\x18\x02 \x01(\tR\n" +
+	"entityType\x12>\n" +
+	"\x0eentity_context\x18\x03 \x01(\v2\x17.google.protobuf.StructR\rentityContext\x12!\n" +
+	"\fenable_debug\x18\x04 \x01(\bR\venableDebug\x12\x17\n" +
+	"\aflag_id\x18\x05 \x01(\x03R\x06flagId\x12\x19\n" +
+	"\bflag_key\x18\x06 \x01(\tR\aflagKey\x12\x1b\n" +
+	"\tflag_tags\x18\a \x03(\tR\bflagTags\x12,\n" +
+	"\x12flag_tags_operator\x18\b \x01(\tR\x10flagTagsOperator\"\xe3\x04\n" +
+	"\n" +
+	"EvalResult\x12\x17\n" +
+	"\aflag_id\x18\x01 \x01(\x03R\x06flagId\x12\x19\n" +
+	"\bflag_key\x18\x02 \x01(\tR\aflagKey\x12(\n" +
+	"\x10flag_snapshot_id\x18\x03 \x01(\x03R\x0eflagSnapshotId\x120\n" +
+	"\x14flag_source_revision\x18\x04 \x01(\tR\x12flagSourceRevision\x12\x1b\n" +
+	"\tflag_tags\x18\x05 \x03(\tR\bflagTags\x12\x1d\n" +
+	"\n" +
+	"segment_id\x18\x06 \x01(\x03R\tsegmentId\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\a \x01(\x03R\tvariantId\x12\x1f\n" +
+	"\vvariant_key\x18\b \x01(\tR\n" +
+	"variantKey\x12F\n" +
+	"\x12variant_attachment\x18\t \x01(\v2\x17.google.protobuf.StructR\x11variantAttachment\x128\n" +
+	"\feval_context\x18\n" +
+	" \x01(\v2\x15.flagr.v1.EvalContextR\vevalContext\x12\x1c\n" +
+	"\ttimestamp\x18\v \x01(\tR\ttimestamp\x12<\n" +
+	"\x0eeval_debug_log\x18\f \x01(\v2\x16.flagr.v1.EvalDebugLogR\fevalDebugLog\x120\n" +
+	"\x14data_records_enabled\x18\r \x01(\bR\x12dataRecordsEnabled\x12#\n" +
+	"\rrecord_source\x18\x0e \x01(\tR\frecordSource\x12\x14\n" +
+	"\x05error\x18\x0f \x01(\tR\x05error\"i\n" +
+	"\fEvalDebugLog\x12G\n" +
+	"\x12segment_debug_logs\x18\x01 \x03(\v2\x19.flagr.v1.SegmentDebugLogR\x10segmentDebugLogs\x12\x10\n" +
+	"\x03msg\x18\x02 \x01(\tR\x03msg\"B\n" +
+	"\x0fSegmentDebugLog\x12\x1d\n" +
+	"\n" +
+	"segment_id\x18\x01 \x01(\x03R\tsegmentId\x12\x10\n" +
+	"\x03msg\x18\x02 \x01(\tR\x03msg\"K\n" +
+	"\x0fEvaluateRequest\x128\n" +
+	"\feval_context\x18\x01 \x01(\v2\x15.flagr.v1.EvalContextR\vevalContext\"@\n" +
+	"\x10EvaluateResponse\x12,\n" +
+	"\x06result\x18\x01 \x01(\v2\x14.flagr.v1.EvalResultR\x06result\"\x90\x01\n" +
+	"\x10EvaluationEntity\x12\x1b\n" +
+	"\tentity_id\x18\x01 \x01(\tR\bentityId\x12\x1f\n" +
+	"\ventity_type\x18\x02 \x01(\tR\n" +
+	"entityType\x12>\n" +
+	"\x0eentity_context\x18\x03 \x01(\v2\x17.google.protobuf.StructR\rentityContext\"\xf4\x01\n" +
+	"\x14EvaluateBatchRequest\x126\n" +
+	"\bentities\x18\x01 \x03(\v2\x1a.flagr.v1.EvaluationEntityR\bentities\x12!\n" +
+	"\fenable_debug\x18\x02 \x01(\bR\venableDebug\x12\x19\n" +
+	"\bflag_ids\x18\x03 \x03(\x03R\aflagIds\x12\x1b\n" +
+	"\tflag_keys\x18\x04 \x03(\tR\bflagKeys\x12\x1b\n" +
+	"\tflag_tags\x18\x05 \x03(\tR\bflagTags\x12,\n" +
+	"\x12flag_tags_operator\x18\x06 \x01(\tR\x10flagTagsOperator\"a\n" +
+	"\x15EvaluateBatchResponse\x12.\n" +
+	"\aresults\x18\x01 \x03(\v2\x14.flagr.v1.EvalResultR\aresults\x12\x18\n" +
+	"\apartial\x18\x02 \x01(\bR\apartial\"{\n" +
+	"\x11WatchFlagsRequest\x12\x1b\n" +
+	"\tflag_keys\x18\x01 \x03(\tR\bflagKeys\x12\x1b\n" +
+	"\tflag_tags\x18\x02 \x03(\tR\bflagTags\x12,\n" +
+	"\x12flag_tags_operator\x18\x03 \x01(\tR\x10flagTagsOperator\"n\n" +
+	"\x12WatchFlagsResponse\x12&\n" +
+	"\x0feval_cache_json\x18\x01 \x01(\fR\revalCacheJson\x120\n" +
+	"\x14flag_source_revision\x18\x02 \x01(\tR\x12flagSourceRevision2\xf3\x01\n" +
+	"\x11EvaluationService\x12A\n" +
+	"\bEvaluate\x12\x19.flagr.v1.EvaluateRequest\x1a\x1a.flagr.v1.EvaluateResponse\x12P\n" +
+	"\rEvaluateBatch\x12\x1e.flagr.v1.EvaluateBatchRequest\x1a\x1f.flagr.v1.EvaluateBatchResponse\x12I\n" +
+	"\n" +
+	"WatchFlags\x12\x1b.flagr.v1.WatchFlagsRequest\x1a\x1c.flagr.v1.WatchFlagsResponse0\x01B7Z5github.com/openflagr/flagr/proto_gen/flagr/v1;flagrv1b\x06proto3"
+
+var (
+	file_flagr_v1_evaluation_proto_rawDescOnce sync.Once
+	file_flagr_v1_evaluation_proto_rawDescData []byte
+)
+
+func file_flagr_v1_evaluation_proto_rawDescGZIP() []byte {
+	file_flagr_v1_evaluation_proto_rawDescOnce.Do(func() {
+		file_flagr_v1_evaluation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_flagr_v1_evaluation_proto_rawDesc), len(file_flagr_v1_evaluation_proto_rawDesc)))
+	})
+	return file_flagr_v1_evaluation_proto_rawDescData
+}
+
+var file_flagr_v1_evaluation_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_flagr_v1_evaluation_proto_goTypes = []any{
+	(*EvalContext)(nil),           // 0: flagr.v1.EvalContext
+	(*EvalResult)(nil),            // 1: flagr.v1.EvalResult
+	(*EvalDebugLog)(nil),          // 2: flagr.v1.EvalDebugLog
+	(*SegmentDebugLog)(nil),       // 3: flagr.v1.SegmentDebugLog
+	(*EvaluateRequest)(nil),       // 4: flagr.v1.EvaluateRequest
+	(*EvaluateResponse)(nil),      // 5: flagr.v1.EvaluateResponse
+	(*EvaluationEntity)(nil),      // 6: flagr.v1.EvaluationEntity
+	(*EvaluateBatchRequest)(nil),  // 7: flagr.v1.EvaluateBatchRequest
+	(*EvaluateBatchResponse)(nil), // 8: flagr.v1.EvaluateBatchResponse
+	(*WatchFlagsRequest)(nil),     // 9: flagr.v1.WatchFlagsRequest
+	(*WatchFlagsResponse)(nil),    // 10: flagr.v1.WatchFlagsResponse
+	(*structpb.Struct)(nil),       // 11: google.protobuf.Struct
+}
+var file_flagr_v1_evaluation_proto_depIdxs = []int32{
+	11, // 0: flagr.v1.EvalContext.entity_context:type_name -> google.protobuf.Struct
+	11, // 1: flagr.v1.EvalResult.variant_attachment:type_name -> google.protobuf.Struct
+	0,  // 2: flagr.v1.EvalResult.eval_context:type_name -> flagr.v1.EvalContext
+	2,  // 3: flagr.v1.EvalResult.eval_debug_log:type_name -> flagr.v1.EvalDebugLog
+	3,  // 4: flagr.v1.EvalDebugLog.segment_debug_logs:type_name -> flagr.v1.SegmentDebugLog
+	0,  // 5: flagr.v1.EvaluateRequest.eval_context:type_name -> flagr.v1.EvalContext
+	1,  // 6: flagr.v1.EvaluateResponse.result:type_name -> flagr.v1.EvalResult
+	11, // 7: flagr.v1.EvaluationEntity.entity_context:type_name -> google.protobuf.Struct
+	6,  // 8: flagr.v1.EvaluateBatchRequest.entities:type_name -> flagr.v1.EvaluationEntity
+	1,  // 9: flagr.v1.EvaluateBatchResponse.results:type_name -> flagr.v1.EvalResult
+	4,  // 10: flagr.v1.EvaluationService.Evaluate:input_type -> flagr.v1.EvaluateRequest
+	7,  // 11: flagr.v1.EvaluationService.EvaluateBatch:input_type -> flagr.v1.EvaluateBatchRequest
+	9,  // 12: flagr.v1.EvaluationService.WatchFlags:input_type -> flagr.v1.WatchFlagsRequest
+	5,  // 13: flagr.v1.EvaluationService.Evaluate:output_type -> flagr.v1.EvaluateResponse
+	8,  // 14: flagr.v1.EvaluationService.EvaluateBatch:output_type -> flagr.v1.EvaluateBatchResponse
+	10, // 15: flagr.v1.EvaluationService.WatchFlags:output_type -> flagr.v1.WatchFlagsResponse
+	13, // [13:16] is the sub-list for method output_type
+	10, // [10:13] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_flagr_v1_evaluation_proto_init() }
+func file_flagr_v1_evaluation_proto_init() {
+	if File_flagr_v1_evaluation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_flagr_v1_evaluation_proto_rawDesc), len(file_flagr_v1_evaluation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_flagr_v1_evaluation_proto_goTypes,
+		DependencyIndexes: file_flagr_v1_evaluation_proto_depIdxs,
+		MessageInfos:      file_flagr_v1_evaluation_proto_msgTypes,
+	}.Build()
+	File_flagr_v1_evaluation_proto = out.File
+	file_flagr_v1_evaluation_proto_goTypes = nil
+	file_flagr_v1_evaluation_proto_depIdxs = nil
+}
@@ -0,0 +1,217 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: flagr/v1/evaluation.proto
+
+package flagrv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	EvaluationService_Evaluate_FullMethodName      = "/flagr.v1.EvaluationService/Evaluate"
+	EvaluationService_EvaluateBatch_FullMethodName = "/flagr.v1.EvaluationService/EvaluateBatch"
+	EvaluationService_WatchFlags_FullMethodName    = "/flagr.v1.EvaluationService/WatchFlags"
+)
+
+// EvaluationServiceClient is the client API for EvaluationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// EvaluationService is the gRPC counterpart of POST /evaluation and
+// POST /evaluation/batch. It runs the same evaluation code, so results, data
+// records and Datar counts are the same as for the REST API.
+type EvaluationServiceClient interface {
+	// Evaluate evaluates one flag for one entity, like POST /evaluation.
+	Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error)
+	// EvaluateBatch evaluates flags for many entities, like POST /evaluation/batch.
+	EvaluateBatch(ctx context.Context, in *EvaluateBatchRequest, opts ...grpc.CallOption) (*EvaluateBatchResponse, error)
+	// WatchFlags streams the EvalCacheJSON of the selected flags, first the
+	// current one and then again whenever the evaluation cache picks up a change.
+	WatchFlags(ctx context.Context, in *WatchFlagsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchFlagsResponse], error)
+}
+
+type evaluationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEvaluationServiceClient(cc grpc.ClientConnInterface) EvaluationServiceClient {
+	return &evaluationServiceClient{cc}
+}
+
+func (c *evaluationServiceClient) Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EvaluateResponse)
+	err := c.cc.Invoke(ctx, EvaluationService_Evaluate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *evaluationServiceClient) EvaluateBatch(ctx context.Context, in *EvaluateBatchRequest, opts ...grpc.CallOption) (*EvaluateBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EvaluateBatchResponse)
+	err := c.cc.Invoke(ctx, EvaluationService_EvaluateBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *evaluationServiceClient) WatchFlags(ctx context.Context, in *WatchFlagsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchFlagsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EvaluationService_ServiceDesc.Streams[0], EvaluationService_WatchFlags_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchFlagsRequest, WatchFlagsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EvaluationService_WatchFlagsClient = grpc.ServerStreamingClient[WatchFlagsResponse]
+
+// EvaluationServiceServer is the server API for EvaluationService service.
+// All implementations must embed UnimplementedEvaluationServiceServer
+// for forward compatibility.
+//
+// EvaluationService is the gRPC counterpart of POST /evaluation and
+// POST /evaluation/batch. It runs the same evaluation code, so results, data
+// records and Datar counts are the same as for the REST API.
+type EvaluationServiceServer interface {
+	// Evaluate evaluates one flag for one entity, like POST /evaluation.
+	Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error)
+	// EvaluateBatch evaluates flags for many entities, like POST /evaluation/batch.
+	EvaluateBatch(context.Context, *EvaluateBatchRequest) (*EvaluateBatchResponse, error)
+	// WatchFlags streams the EvalCacheJSON of the selected flags, first the
+	// current one and then again whenever the evaluation cache picks up a change.
+	WatchFlags(*WatchFlagsRequest, grpc.ServerStreamingServer[WatchFlagsResponse]) error
+	mustEmbedUnimplementedEvaluationServiceServer()
+}
+
+// UnimplementedEvaluationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEvaluationServiceServer struct{}
+
+func (UnimplementedEvaluationServiceServer) Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Evaluate not implemented")
+}
+func (UnimplementedEvaluationServiceServer) EvaluateBatch(context.Context, *EvaluateBatchRequest) (*EvaluateBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EvaluateBatch not implemented")
+}
+func (UnimplementedEvaluationServiceServer) WatchFlags(*WatchFlagsRequest, grpc.ServerStreamingServer[WatchFlagsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchFlags not implemented")
+}
+func (UnimplementedEvaluationServiceServer) mustEmbedUnimplementedEvaluationServiceServer() {}
+func (UnimplementedEvaluationServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeEvaluationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EvaluationServiceServer will
+// result in compilation errors.
+type UnsafeEvaluationServiceServer interface {
+	mustEmbedUnimplementedEvaluationServiceServer()
+}
+
+func RegisterEvaluationServiceServer(s grpc.ServiceRegistrar, srv EvaluationServiceServer) {
+	// If the following call pancis, it indicates UnimplementedEvaluationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EvaluationService_ServiceDesc, srv)
+}
+
+func _EvaluationService_Evaluate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EvaluationServiceServer).Evaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EvaluationService_Evaluate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EvaluationServiceServer).Evaluate(ctx, req.(*EvaluateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EvaluationService_EvaluateBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EvaluationServiceServer).EvaluateBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EvaluationService_EvaluateBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EvaluationServiceServer).EvaluateBatch(ctx, req.(*EvaluateBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EvaluationService_WatchFlags_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchFlagsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EvaluationServiceServer).WatchFlags(m, &grpc.GenericServerStream[WatchFlagsRequest, WatchFlagsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EvaluationService_WatchFlagsServer = grpc.ServerStreamingServer[WatchFlagsResponse]
+
+// EvaluationService_ServiceDesc is the grpc.ServiceDesc for EvaluationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EvaluationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flagr.v1.EvaluationService",
+	HandlerType: (*EvaluationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Evaluate",
+			Handler:    _EvaluationService_Evaluate_Handler,
+		},
+		{
+			MethodName: "EvaluateBatch",
+			Handler:    _EvaluationService_EvaluateBatch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchFlags",
+			Handler:       _EvaluationService_WatchFlags_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "flagr/v1/evaluation.proto",
+}
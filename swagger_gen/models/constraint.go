@@ -26,10 +26,10 @@ type Constraint struct {
 	// operator
 	// Required: true
 	// Min Length: 1
-	// Enum: ["EQ","NEQ","LT","LTE","GT","GTE","EREG","NEREG","IN","NOTIN","CONTAINS","NOTCONTAINS"]
+	// Enum: ["EQ","NEQ","LT","LTE","GT","GTE","EREG","NEREG","IN","NOTIN","CONTAINS","NOTCONTAINS","ANY_IN","ALL_IN","NONE_IN","STARTS_WITH","ENDS_WITH","EQ_CI","IN_CI","CONTAINS_CI"]
 	Operator *string `json:"operator"`
 
-	// The property name from the entity context to evaluate. Supports nested field access: use dots (e.g., `user.name`) for nested objects and brackets (e.g., `users[0]`) for array indices.
+	// The property name from the entity context to evaluate. Supports nested field access: use dots (e.g., `user.name`) for nested objects and brackets (e.g., `users[0]`) for array indices, or a JSON pointer (e.g., `/user/name`, `/users/0`). `@http.x_country` and `/@http/x_country` read the injected HTTP header key `@http_x_country`.
 	//
 	// Required: true
 	// Min Length: 1
@@ -83,7 +83,7 @@ var constraintTypeOperatorPropEnum []any
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["EQ","NEQ","LT","LTE","GT","GTE","EREG","NEREG","IN","NOTIN","CONTAINS","NOTCONTAINS"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["EQ","NEQ","LT","LTE","GT","GTE","EREG","NEREG","IN","NOTIN","CONTAINS","NOTCONTAINS","ANY_IN","ALL_IN","NONE_IN","STARTS_WITH","ENDS_WITH","EQ_CI","IN_CI","CONTAINS_CI"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -128,6 +128,30 @@ const (
 
 	// ConstraintOperatorNOTCONTAINS captures enum value "NOTCONTAINS"
 	ConstraintOperatorNOTCONTAINS string = "NOTCONTAINS"
+
+	// ConstraintOperatorANYIN captures enum value "ANY_IN"
+	ConstraintOperatorANYIN string = "ANY_IN"
+
+	// ConstraintOperatorALLIN captures enum value "ALL_IN"
+	ConstraintOperatorALLIN string = "ALL_IN"
+
+	// ConstraintOperatorNONEIN captures enum value "NONE_IN"
+	ConstraintOperatorNONEIN string = "NONE_IN"
+
+	// ConstraintOperatorSTARTSWITH captures enum value "STARTS_WITH"
+	ConstraintOperatorSTARTSWITH string = "STARTS_WITH"
+
+	// ConstraintOperatorENDSWITH captures enum value "ENDS_WITH"
+	ConstraintOperatorENDSWITH string = "ENDS_WITH"
+
+	// ConstraintOperatorEQCI captures enum value "EQ_CI"
+	ConstraintOperatorEQCI string = "EQ_CI"
+
+	// ConstraintOperatorINCI captures enum value "IN_CI"
+	ConstraintOperatorINCI string = "IN_CI"
+
+	// ConstraintOperatorCONTAINSCI captures enum value "CONTAINS_CI"
+	ConstraintOperatorCONTAINSCI string = "CONTAINS_CI"
 )
 
 // prop value enum
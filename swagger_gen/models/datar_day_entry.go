@@ -23,6 +23,9 @@ type DatarDayEntry struct {
 	// date
 	// Format: date
 	Date strfmt.Date `json:"date,omitempty"`
+
+	// estimated distinct entities evaluated, from HyperLogLog sketches
+	UniqueEntities int64 `json:"uniqueEntities,omitempty"`
 }
 
 // Validate validates this datar day entry
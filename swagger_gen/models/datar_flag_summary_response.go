@@ -21,6 +21,9 @@ type DatarFlagSummaryResponse struct {
 	// flag ID
 	FlagID int64 `json:"flagID,omitempty"`
 
+	// sample ratio
+	SampleRatio *DatarSampleRatio `json:"sampleRatio,omitempty"`
+
 	// traffic by day
 	TrafficByDay []*DatarDayEntry `json:"trafficByDay"`
 
@@ -29,12 +32,19 @@ type DatarFlagSummaryResponse struct {
 
 	// traffic by variant
 	TrafficByVariant []*DatarVariantEntry `json:"trafficByVariant"`
+
+	// estimated distinct entities evaluated, from HyperLogLog sketches
+	UniqueEntities int64 `json:"uniqueEntities,omitempty"`
 }
 
 // Validate validates this datar flag summary response
 func (m *DatarFlagSummaryResponse) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateSampleRatio(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateTrafficByDay(formats); err != nil {
 		res = append(res, err)
 	}
@@ -53,6 +63,29 @@ func (m *DatarFlagSummaryResponse) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *DatarFlagSummaryResponse) validateSampleRatio(formats strfmt.Registry) error {
+	if typeutils.IsZero(m.SampleRatio) { // not required
+		return nil
+	}
+
+	if m.SampleRatio != nil {
+		if err := m.SampleRatio.Validate(formats); err != nil {
+			ve := new(errors.Validation)
+			if stderrors.As(err, &ve) {
+				return ve.ValidateName("sampleRatio")
+			}
+			ce := new(errors.CompositeError)
+			if stderrors.As(err, &ce) {
+				return ce.ValidateName("sampleRatio")
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *DatarFlagSummaryResponse) validateTrafficByDay(formats strfmt.Registry) error {
 	if typeutils.IsZero(m.TrafficByDay) { // not required
 		return nil
@@ -147,6 +180,10 @@ func (m *DatarFlagSummaryResponse) validateTrafficByVariant(formats strfmt.Regis
 func (m *DatarFlagSummaryResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.contextValidateSampleRatio(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateTrafficByDay(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -165,6 +202,31 @@ func (m *DatarFlagSummaryResponse) ContextValidate(ctx context.Context, formats
 	return nil
 }
 
+func (m *DatarFlagSummaryResponse) contextValidateSampleRatio(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.SampleRatio != nil {
+
+		if typeutils.IsZero(m.SampleRatio) { // not required
+			return nil
+		}
+
+		if err := m.SampleRatio.ContextValidate(ctx, formats); err != nil {
+			ve := new(errors.Validation)
+			if stderrors.As(err, &ve) {
+				return ve.ValidateName("sampleRatio")
+			}
+			ce := new(errors.CompositeError)
+			if stderrors.As(err, &ce) {
+				return ce.ValidateName("sampleRatio")
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *DatarFlagSummaryResponse) contextValidateTrafficByDay(ctx context.Context, formats strfmt.Registry) error {
 
 	for i := 0; i < len(m.TrafficByDay); i++ {
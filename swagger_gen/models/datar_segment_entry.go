@@ -19,6 +19,9 @@ type DatarSegmentEntry struct {
 
 	// segment ID
 	SegmentID int64 `json:"segmentID,omitempty"`
+
+	// estimated distinct entities evaluated, from HyperLogLog sketches
+	UniqueEntities int64 `json:"uniqueEntities,omitempty"`
 }
 
 // Validate validates this datar segment entry
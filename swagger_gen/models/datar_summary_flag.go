@@ -35,6 +35,9 @@ type DatarSummaryFlag struct {
 
 	// total eval count
 	TotalEvalCount int64 `json:"totalEvalCount,omitempty"`
+
+	// estimated distinct entities evaluated, from HyperLogLog sketches
+	UniqueEntities int64 `json:"uniqueEntities,omitempty"`
 }
 
 // Validate validates this datar summary flag
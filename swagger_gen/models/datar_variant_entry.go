@@ -17,6 +17,9 @@ type DatarVariantEntry struct {
 	// count
 	Count int64 `json:"count,omitempty"`
 
+	// estimated distinct entities evaluated, from HyperLogLog sketches
+	UniqueEntities int64 `json:"uniqueEntities,omitempty"`
+
 	// variant ID
 	VariantID int64 `json:"variantID,omitempty"`
 }
@@ -22,6 +22,9 @@ type EvalResult struct {
 	// Whether data records (impression logging) are enabled for this flag.
 	DataRecordsEnabled bool `json:"dataRecordsEnabled,omitempty"`
 
+	// set on a batch evaluation result that was not evaluated, e.g. because the batch deadline (FLAGR_EVAL_BATCH_TIMEOUT) passed. The result then only identifies the entity and the flag ID, key or tags.
+	Error string `json:"error,omitempty"`
+
 	// eval context
 	EvalContext *EvalContext `json:"evalContext,omitempty"`
 
@@ -37,6 +40,9 @@ type EvalResult struct {
 	// flag snapshot ID
 	FlagSnapshotID int64 `json:"flagSnapshotID,omitempty"`
 
+	// revision of the eval-only flag source the flag was loaded from, e.g. the git commit SHA for the json_git driver. Empty when the source has no revision.
+	FlagSourceRevision string `json:"flagSourceRevision,omitempty"`
+
 	// flagTags. flagTags looks up flags by tag. Either works.
 	FlagTags []string `json:"flagTags,omitempty"`
 
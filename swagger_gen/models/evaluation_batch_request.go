@@ -43,6 +43,10 @@ type EvaluationBatchRequest struct {
 	// determine how flagTags is used to filter flags to be evaluated. OR extends the evaluation to those which contains at least one of the provided flagTags or AND limit the evaluation to those which contains all the flagTags.
 	// Enum: ["ANY","ALL"]
 	FlagTagsOperator *string `json:"flagTagsOperator,omitempty"`
+
+	// number of workers evaluating the entity and flag pairs of this request in parallel, the request goroutine included. Defaults to FLAGR_EVAL_BATCH_WORKERS, and is capped by FLAGR_EVAL_BATCH_MAX_WORKERS extra workers shared by all batch requests.
+	// Minimum: 1
+	Workers int64 `json:"workers,omitempty"`
 }
 
 // Validate validates this evaluation batch request
@@ -69,6 +73,10 @@ func (m *EvaluationBatchRequest) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateWorkers(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -220,6 +228,18 @@ func (m *EvaluationBatchRequest) validateFlagTagsOperator(formats strfmt.Registr
 	return nil
 }
 
+func (m *EvaluationBatchRequest) validateWorkers(formats strfmt.Registry) error {
+	if typeutils.IsZero(m.Workers) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("workers", "body", m.Workers, 1, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // ContextValidate validate this evaluation batch request based on the context it is used
 func (m *EvaluationBatchRequest) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
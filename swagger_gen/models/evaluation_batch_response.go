@@ -22,6 +22,9 @@ type EvaluationBatchResponse struct {
 	// evaluation results
 	// Required: true
 	EvaluationResults []*EvalResult `json:"evaluationResults"`
+
+	// true if some results were not evaluated because the batch deadline passed; those results have an error
+	Partial bool `json:"partial,omitempty"`
 }
 
 // Validate validates this evaluation batch response
@@ -14,6 +14,9 @@ import (
 // swagger:model health
 type Health struct {
 
+	// revision of the flag source currently loaded into the evaluation cache, e.g. the git commit SHA for the json_git driver. Empty when the source has no revision.
+	FlagSourceRevision string `json:"flagSourceRevision,omitempty"`
+
 	// status
 	Status string `json:"status,omitempty"`
 }
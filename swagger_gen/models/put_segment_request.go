@@ -4,10 +4,13 @@ package models
 
 import (
 	"context"
+	stderrors "errors"
+	"strconv"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag/jsonutils"
+	"github.com/go-openapi/swag/typeutils"
 	"github.com/go-openapi/validate"
 )
 
@@ -16,6 +19,9 @@ import (
 // swagger:model putSegmentRequest
 type PutSegmentRequest struct {
 
+	// replaces the constraint groups of the segment if set; an empty array removes them
+	ConstraintGroups []*ConstraintGroup `json:"constraintGroups"`
+
 	// description
 	// Required: true
 	// Min Length: 1
@@ -32,6 +38,10 @@ type PutSegmentRequest struct {
 func (m *PutSegmentRequest) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateConstraintGroups(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateDescription(formats); err != nil {
 		res = append(res, err)
 	}
@@ -46,6 +56,36 @@ func (m *PutSegmentRequest) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *PutSegmentRequest) validateConstraintGroups(formats strfmt.Registry) error {
+	if typeutils.IsZero(m.ConstraintGroups) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.ConstraintGroups); i++ {
+		if typeutils.IsZero(m.ConstraintGroups[i]) { // not required
+			continue
+		}
+
+		if m.ConstraintGroups[i] != nil {
+			if err := m.ConstraintGroups[i].Validate(formats); err != nil {
+				ve := new(errors.Validation)
+				if stderrors.As(err, &ve) {
+					return ve.ValidateName("constraintGroups" + "." + strconv.Itoa(i))
+				}
+				ce := new(errors.CompositeError)
+				if stderrors.As(err, &ce) {
+					return ce.ValidateName("constraintGroups" + "." + strconv.Itoa(i))
+				}
+
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *PutSegmentRequest) validateDescription(formats strfmt.Registry) error {
 
 	if err := validate.Required("description", "body", m.Description); err != nil {
@@ -76,8 +116,46 @@ func (m *PutSegmentRequest) validateRolloutPercent(formats strfmt.Registry) erro
 	return nil
 }
 
-// ContextValidate validates this put segment request based on context it is used
+// ContextValidate validate this put segment request based on the context it is used
 func (m *PutSegmentRequest) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateConstraintGroups(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *PutSegmentRequest) contextValidateConstraintGroups(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.ConstraintGroups); i++ {
+
+		if m.ConstraintGroups[i] != nil {
+
+			if typeutils.IsZero(m.ConstraintGroups[i]) { // not required
+				return nil
+			}
+
+			if err := m.ConstraintGroups[i].ContextValidate(ctx, formats); err != nil {
+				ve := new(errors.Validation)
+				if stderrors.As(err, &ve) {
+					return ve.ValidateName("constraintGroups" + "." + strconv.Itoa(i))
+				}
+				ce := new(errors.CompositeError)
+				if stderrors.As(err, &ce) {
+					return ce.ValidateName("constraintGroups" + "." + strconv.Itoa(i))
+				}
+
+				return err
+			}
+		}
+
+	}
+
 	return nil
 }
 
@@ -19,6 +19,9 @@ import (
 // swagger:model segment
 type Segment struct {
 
+	// constraint groups combining constraints with AND, OR and NOT. The segment matches when all its constraints and all its constraint groups match.
+	ConstraintGroups []*ConstraintGroup `json:"constraintGroups"`
+
 	// constraints
 	Constraints []*Constraint `json:"constraints"`
 
@@ -51,6 +54,10 @@ type Segment struct {
 func (m *Segment) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateConstraintGroups(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateConstraints(formats); err != nil {
 		res = append(res, err)
 	}
@@ -81,6 +88,36 @@ func (m *Segment) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Segment) validateConstraintGroups(formats strfmt.Registry) error {
+	if typeutils.IsZero(m.ConstraintGroups) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.ConstraintGroups); i++ {
+		if typeutils.IsZero(m.ConstraintGroups[i]) { // not required
+			continue
+		}
+
+		if m.ConstraintGroups[i] != nil {
+			if err := m.ConstraintGroups[i].Validate(formats); err != nil {
+				ve := new(errors.Validation)
+				if stderrors.As(err, &ve) {
+					return ve.ValidateName("constraintGroups" + "." + strconv.Itoa(i))
+				}
+				ce := new(errors.CompositeError)
+				if stderrors.As(err, &ce) {
+					return ce.ValidateName("constraintGroups" + "." + strconv.Itoa(i))
+				}
+
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *Segment) validateConstraints(formats strfmt.Registry) error {
 	if typeutils.IsZero(m.Constraints) { // not required
 		return nil
@@ -200,6 +237,10 @@ func (m *Segment) validateRolloutPercent(formats strfmt.Registry) error {
 func (m *Segment) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.contextValidateConstraintGroups(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateConstraints(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -218,6 +259,35 @@ func (m *Segment) ContextValidate(ctx context.Context, formats strfmt.Registry)
 	return nil
 }
 
+func (m *Segment) contextValidateConstraintGroups(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.ConstraintGroups); i++ {
+
+		if m.ConstraintGroups[i] != nil {
+
+			if typeutils.IsZero(m.ConstraintGroups[i]) { // not required
+				return nil
+			}
+
+			if err := m.ConstraintGroups[i].ContextValidate(ctx, formats); err != nil {
+				ve := new(errors.Validation)
+				if stderrors.As(err, &ve) {
+					return ve.ValidateName("constraintGroups" + "." + strconv.Itoa(i))
+				}
+				ce := new(errors.CompositeError)
+				if stderrors.As(err, &ce) {
+					return ce.ValidateName("constraintGroups" + "." + strconv.Itoa(i))
+				}
+
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *Segment) contextValidateConstraints(ctx context.Context, formats strfmt.Registry) error {
 
 	for i := 0; i < len(m.Constraints); i++ {
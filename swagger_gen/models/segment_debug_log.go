@@ -4,6 +4,8 @@ package models
 
 import (
 	"context"
+	stderrors "errors"
+	"strconv"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
@@ -17,9 +19,21 @@ import (
 // swagger:model segmentDebugLog
 type SegmentDebugLog struct {
 
+	// each constraint of the segment and its result
+	Constraints []*ConstraintDebugLog `json:"constraints"`
+
+	// each constraint group of the segment and its result
+	Groups []*ConstraintGroupDebugLog `json:"groups"`
+
+	// whether the entity context matched all constraints of the segment
+	Matched bool `json:"matched"`
+
 	// msg
 	Msg string `json:"msg,omitempty"`
 
+	// rollout
+	Rollout *RolloutDebugLog `json:"rollout,omitempty"`
+
 	// segment ID
 	// Minimum: 1
 	SegmentID int64 `json:"segmentID,omitempty"`
@@ -29,6 +43,18 @@ type SegmentDebugLog struct {
 func (m *SegmentDebugLog) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateConstraints(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateGroups(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateRollout(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateSegmentID(formats); err != nil {
 		res = append(res, err)
 	}
@@ -39,6 +65,89 @@ func (m *SegmentDebugLog) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *SegmentDebugLog) validateConstraints(formats strfmt.Registry) error {
+	if typeutils.IsZero(m.Constraints) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Constraints); i++ {
+		if typeutils.IsZero(m.Constraints[i]) { // not required
+			continue
+		}
+
+		if m.Constraints[i] != nil {
+			if err := m.Constraints[i].Validate(formats); err != nil {
+				ve := new(errors.Validation)
+				if stderrors.As(err, &ve) {
+					return ve.ValidateName("constraints" + "." + strconv.Itoa(i))
+				}
+				ce := new(errors.CompositeError)
+				if stderrors.As(err, &ce) {
+					return ce.ValidateName("constraints" + "." + strconv.Itoa(i))
+				}
+
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *SegmentDebugLog) validateGroups(formats strfmt.Registry) error {
+	if typeutils.IsZero(m.Groups) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Groups); i++ {
+		if typeutils.IsZero(m.Groups[i]) { // not required
+			continue
+		}
+
+		if m.Groups[i] != nil {
+			if err := m.Groups[i].Validate(formats); err != nil {
+				ve := new(errors.Validation)
+				if stderrors.As(err, &ve) {
+					return ve.ValidateName("groups" + "." + strconv.Itoa(i))
+				}
+				ce := new(errors.CompositeError)
+				if stderrors.As(err, &ce) {
+					return ce.ValidateName("groups" + "." + strconv.Itoa(i))
+				}
+
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *SegmentDebugLog) validateRollout(formats strfmt.Registry) error {
+	if typeutils.IsZero(m.Rollout) { // not required
+		return nil
+	}
+
+	if m.Rollout != nil {
+		if err := m.Rollout.Validate(formats); err != nil {
+			ve := new(errors.Validation)
+			if stderrors.As(err, &ve) {
+				return ve.ValidateName("rollout")
+			}
+			ce := new(errors.CompositeError)
+			if stderrors.As(err, &ce) {
+				return ce.ValidateName("rollout")
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *SegmentDebugLog) validateSegmentID(formats strfmt.Registry) error {
 	if typeutils.IsZero(m.SegmentID) { // not required
 		return nil
@@ -51,8 +160,108 @@ func (m *SegmentDebugLog) validateSegmentID(formats strfmt.Registry) error {
 	return nil
 }
 
-// ContextValidate validates this segment debug log based on context it is used
+// ContextValidate validate this segment debug log based on the context it is used
 func (m *SegmentDebugLog) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateConstraints(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateGroups(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateRollout(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *SegmentDebugLog) contextValidateConstraints(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Constraints); i++ {
+
+		if m.Constraints[i] != nil {
+
+			if typeutils.IsZero(m.Constraints[i]) { // not required
+				return nil
+			}
+
+			if err := m.Constraints[i].ContextValidate(ctx, formats); err != nil {
+				ve := new(errors.Validation)
+				if stderrors.As(err, &ve) {
+					return ve.ValidateName("constraints" + "." + strconv.Itoa(i))
+				}
+				ce := new(errors.CompositeError)
+				if stderrors.As(err, &ce) {
+					return ce.ValidateName("constraints" + "." + strconv.Itoa(i))
+				}
+
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *SegmentDebugLog) contextValidateGroups(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Groups); i++ {
+
+		if m.Groups[i] != nil {
+
+			if typeutils.IsZero(m.Groups[i]) { // not required
+				return nil
+			}
+
+			if err := m.Groups[i].ContextValidate(ctx, formats); err != nil {
+				ve := new(errors.Validation)
+				if stderrors.As(err, &ve) {
+					return ve.ValidateName("groups" + "." + strconv.Itoa(i))
+				}
+				ce := new(errors.CompositeError)
+				if stderrors.As(err, &ce) {
+					return ce.ValidateName("groups" + "." + strconv.Itoa(i))
+				}
+
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *SegmentDebugLog) contextValidateRollout(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Rollout != nil {
+
+		if typeutils.IsZero(m.Rollout) { // not required
+			return nil
+		}
+
+		if err := m.Rollout.ContextValidate(ctx, formats); err != nil {
+			ve := new(errors.Validation)
+			if stderrors.As(err, &ve) {
+				return ve.ValidateName("rollout")
+			}
+			ce := new(errors.CompositeError)
+			if stderrors.As(err, &ce) {
+				return ce.ValidateName("rollout")
+			}
+
+			return err
+		}
+	}
+
 	return nil
 }
 
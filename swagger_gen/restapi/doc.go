@@ -15,7 +15,9 @@
 //
 //	Produces:
 //	  - application/octet-stream
+//	  - text/csv
 //	  - application/json
+//	  - application/x-ndjson
 //
 // swagger:meta
 package restapi
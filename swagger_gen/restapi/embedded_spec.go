@@ -32,6 +32,149 @@ func init() {
   },
   "basePath": "/api/v1",
   "paths": {
+    "/datar/export": {
+      "get": {
+        "description": "Evaluation counts of all flags by hour as CSV or newline-delimited JSON, with the keys of flags and variants. Days rolled up by the retention are exported as one row at their midnight (UTC)\n",
+        "produces": [
+          "text/csv",
+          "application/x-ndjson",
+          "application/json"
+        ],
+        "tags": [
+          "datar"
+        ],
+        "operationId": "getDatarExport",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Start time (RFC 3339, default 7 days ago)",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "End time (RFC 3339, default now)",
+            "name": "to",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "csv",
+              "ndjson"
+            ],
+            "type": "string",
+            "default": "csv",
+            "description": "Format of the rows, text/csv or application/x-ndjson",
+            "name": "format",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "evaluation counts, one per row",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/datar/flags/{flagID}/experiment": {
+      "post": {
+        "description": "Experiment readout of a flag: every variant against a control variant, with\nDatar's per-variant counts as trials. Outcomes are the recorded events of a\nmetric (metricKey) or success counts and value sums posted per variant.\n",
+        "tags": [
+          "datar"
+        ],
+        "operationId": "postDatarFlagExperiment",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Flag ID",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/datarExperimentRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "experiment readout",
+            "schema": {
+              "$ref": "#/definitions/datarExperimentResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/datar/flags/{flagID}/metrics": {
+      "get": {
+        "description": "Assigned entities and metric event aggregates of a flag by variant",
+        "tags": [
+          "datar"
+        ],
+        "operationId": "getDatarFlagMetrics",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Flag ID",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Start time (RFC 3339, default 7 days ago)",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "End time (RFC 3339, default now)",
+            "name": "to",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "flag metrics by variant",
+            "schema": {
+              "$ref": "#/definitions/datarFlagMetricsResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/datar/flags/{flagID}/summary": {
       "get": {
         "description": "All-in-one analytics summary for a single flag",
@@ -79,6 +222,85 @@ func init() {
         }
       }
     },
+    "/datar/flags/{flagID}/timeseries": {
+      "get": {
+        "description": "Evaluations of a flag by hour or day, broken down by variant and/or segment",
+        "tags": [
+          "datar"
+        ],
+        "operationId": "getDatarFlagTimeSeries",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Flag ID",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Start time (RFC 3339, default 7 days ago), rounded down to the start of its bucket",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "End time (RFC 3339, default now)",
+            "name": "to",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "hour",
+              "day"
+            ],
+            "type": "string",
+            "default": "day",
+            "description": "Size of the time buckets",
+            "name": "granularity",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "enum": [
+                "variant",
+                "segment"
+              ],
+              "type": "string"
+            },
+            "collectionFormat": "csv",
+            "description": "Dimensions to split the series by. Without a breakdown there is one series of all evaluations",
+            "name": "breakdown",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "default": "UTC",
+            "description": "IANA time zone of day boundaries and bucket starts, e.g. Europe/Berlin",
+            "name": "timezone",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "flag evaluation time series",
+            "schema": {
+              "$ref": "#/definitions/datarTimeSeriesResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/datar/summary": {
       "get": {
         "description": "Aggregate traffic summary for all flags",
@@ -132,6 +354,68 @@ func init() {
         }
       }
     },
+    "/datar/tags/{tag}/summary": {
+      "get": {
+        "description": "Records of the flags of a tag by flag, record source and entity type. Requires FLAGR_RECORDER_DATAR_DIMENSIONS",
+        "tags": [
+          "datar"
+        ],
+        "operationId": "getDatarTagSummary",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Tag value",
+            "name": "tag",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Start time (RFC 3339, default 7 days ago)",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "End time (RFC 3339, default now)",
+            "name": "to",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "evaluation",
+              "exposure"
+            ],
+            "type": "string",
+            "description": "Only count records of the record source",
+            "name": "recordSource",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only count records of the entity type",
+            "name": "entityType",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "tag analytics summary",
+            "schema": {
+              "$ref": "#/definitions/datarTagSummaryResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/evaluation": {
       "get": {
         "tags": [
@@ -256,35 +540,209 @@ func init() {
         }
       }
     },
-    "/export/eval_cache/json": {
+    "/evaluation/bootstrap": {
       "get": {
-        "description": "Export JSON format of the eval cache dump, with optional filtering",
-        "produces": [
-          "application/json"
-        ],
+        "description": "Same as POST /evaluation/bootstrap with the request in a query parameter, so CDNs and browsers can cache it.\n",
         "tags": [
-          "export"
+          "evaluation"
         ],
-        "operationId": "getExportEvalCacheJSON",
+        "summary": "Evaluate all enabled flags for one entity (browser and edge friendly)",
+        "operationId": "getEvaluationBootstrap",
         "parameters": [
           {
-            "type": "array",
-            "items": {
-              "minimum": 1,
-              "type": "integer",
-              "format": "int64"
-            },
-            "collectionFormat": "csv",
-            "description": "CSV of flag IDs to include (e.g. 1,2,3). When provided, keys/enabled/tags are ignored.",
-            "name": "ids",
-            "in": "query"
+            "type": "string",
+            "description": "Percent-encoded (URI-encoded) JSON matching POST /evaluation/bootstrap body (evaluationBootstrapRequest). Example: ?json=%7B%22entityID%22%3A%22u1%22%7D decodes to {\"entityID\":\"u1\"}.",
+            "name": "json",
+            "in": "query",
+            "required": true
           },
           {
-            "type": "array",
-            "items": {
-              "minLength": 1,
-              "type": "string"
-            },
+            "type": "string",
+            "description": "ETag of a previous bootstrap response",
+            "name": "If-None-Match",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "variant assignments by flag key",
+            "schema": {
+              "$ref": "#/definitions/evaluationBootstrapResponse"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string"
+              }
+            }
+          },
+          "304": {
+            "description": "neither the flags nor the request changed since the ETag in If-None-Match",
+            "headers": {
+              "ETag": {
+                "type": "string"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Evaluates every enabled flag with a key, optionally filtered by flag tags or flag entity type, for a single\nentity and returns a compact map of flag key to variant. Flags that assign no variant are left out. The ETag\nis derived from the flag snapshot max ID (or the eval-only source revision) and a hash of the request, so it\nchanges when a flag changes or the entity/context differs; send it back as If-None-Match to get 304 Not\nModified without evaluating again.\n",
+        "tags": [
+          "evaluation"
+        ],
+        "summary": "Evaluate all enabled flags for one entity",
+        "operationId": "postEvaluationBootstrap",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ETag of a previous bootstrap response",
+            "name": "If-None-Match",
+            "in": "header"
+          },
+          {
+            "description": "bootstrap request",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/evaluationBootstrapRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "variant assignments by flag key",
+            "schema": {
+              "$ref": "#/definitions/evaluationBootstrapResponse"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string"
+              }
+            }
+          },
+          "304": {
+            "description": "neither the flags nor the request changed since the ETag in If-None-Match",
+            "headers": {
+              "ETag": {
+                "type": "string"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/evaluation/preview": {
+      "post": {
+        "description": "Validates a full flag definition in the JSON flag spec format and evaluates it against each eval context with\ndebug logs on, e.g. to test a segment change before saving it. Nothing is stored: results are not sent to\ndata recorders or Datar, and the evaluation cache is not changed.\n",
+        "tags": [
+          "evaluation"
+        ],
+        "summary": "Evaluate a flag definition that is not saved",
+        "operationId": "postEvaluationPreview",
+        "parameters": [
+          {
+            "description": "evaluation preview request",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/evaluationPreviewRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "evaluation results, one per eval context",
+            "schema": {
+              "$ref": "#/definitions/evaluationPreviewResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/evaluation/simulation": {
+      "post": {
+        "description": "Evaluates a flag, stored or proposed, against a corpus of eval contexts and returns how many contexts match\neach segment and land in each variant. When both a stored flag (flagID or flagKey) and a proposed flag are\ngiven, counts are compared with the stored config and the entities that would switch variants are listed.\nNothing is recorded: results are not sent to data recorders or Datar.\n",
+        "tags": [
+          "evaluation"
+        ],
+        "summary": "Simulate a flag config over a corpus of eval contexts",
+        "operationId": "postEvaluationSimulation",
+        "parameters": [
+          {
+            "description": "evaluation simulation request",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/evaluationSimulationRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "simulation result",
+            "schema": {
+              "$ref": "#/definitions/evaluationSimulationResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/export/eval_cache/json": {
+      "get": {
+        "description": "Export JSON format of the eval cache dump, with optional filtering",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "export"
+        ],
+        "operationId": "getExportEvalCacheJSON",
+        "parameters": [
+          {
+            "type": "array",
+            "items": {
+              "minimum": 1,
+              "type": "integer",
+              "format": "int64"
+            },
+            "collectionFormat": "csv",
+            "description": "CSV of flag IDs to include (e.g. 1,2,3). When provided, keys/enabled/tags are ignored.",
+            "name": "ids",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "minLength": 1,
+              "type": "string"
+            },
             "collectionFormat": "csv",
             "description": "CSV of flag keys to include (e.g. one,two). When provided, enabled/tags are ignored.",
             "name": "keys",
@@ -1653,42 +2111,135 @@ func init() {
         }
       }
     },
-    "/tags": {
+    "/metrics": {
       "get": {
+        "description": "List all metric definitions",
         "tags": [
-          "tag"
+          "metric"
         ],
-        "operationId": "findAllTags",
+        "operationId": "findMetrics",
+        "responses": {
+          "200": {
+            "description": "list all the metrics",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/metric"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Define a metric that events can be sent for with POST /metrics/events",
+        "tags": [
+          "metric"
+        ],
+        "operationId": "createMetric",
         "parameters": [
           {
-            "type": "integer",
-            "format": "int64",
-            "description": "the numbers of tags to return",
-            "name": "limit",
-            "in": "query"
+            "description": "create a metric",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createMetricRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "metric just created",
+            "schema": {
+              "$ref": "#/definitions/metric"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/metrics/events": {
+      "post": {
+        "description": "Log outcomes of entities, e.g. conversions or purchase amounts, for defined metrics.\nDatar attributes each event to the variants the entity was assigned by evaluations\nor exposures of flags with dataRecordsEnabled. Requires the datar data recorder.\n",
+        "tags": [
+          "metric"
+        ],
+        "summary": "Log metric events (batch)",
+        "operationId": "postMetricEvents",
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/metricEventsRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "metric events accepted (partial success possible)",
+            "schema": {
+              "$ref": "#/definitions/metricEventsResponse"
+            }
+          },
+          "400": {
+            "description": "invalid request",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/metrics/{metricID}": {
+      "put": {
+        "tags": [
+          "metric"
+        ],
+        "operationId": "putMetric",
+        "parameters": [
           {
+            "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "return tags given the offset, it should usually set together with limit",
-            "name": "offset",
-            "in": "query"
+            "description": "numeric ID of the metric",
+            "name": "metricID",
+            "in": "path",
+            "required": true
           },
           {
-            "type": "string",
-            "description": "return tags partially matching given value",
-            "name": "value_like",
-            "in": "query"
+            "description": "update a metric",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/putMetricRequest"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "list all the tags",
+            "description": "metric just updated",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/tag"
-              }
+              "$ref": "#/definitions/metric"
             }
           },
           "default": {
@@ -1698,20 +2249,208 @@ func init() {
             }
           }
         }
-      }
-    }
-  },
-  "definitions": {
-    "constraint": {
-      "type": "object",
-      "required": [
-        "property",
-        "operator",
-        "value"
-      ],
-      "properties": {
-        "id": {
-          "type": "integer",
+      },
+      "delete": {
+        "tags": [
+          "metric"
+        ],
+        "operationId": "deleteMetric",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the metric",
+            "name": "metricID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "deleted"
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/ofrep/v1/evaluate/flags": {
+      "post": {
+        "description": "OpenFeature Remote Evaluation Protocol (OFREP) evaluation of every flag for one evaluation context, used by\nclient-side providers. The response carries an ETag; send it back as If-None-Match to get 304 Not Modified\nwhile the results are unchanged.\n",
+        "tags": [
+          "ofrep"
+        ],
+        "summary": "OFREP bulk flag evaluation",
+        "operationId": "ofrepEvaluateFlagsBulk",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ETag of a previous bulk response",
+            "name": "If-None-Match",
+            "in": "header"
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/ofrepEvaluationRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "all flags evaluated",
+            "schema": {
+              "$ref": "#/definitions/ofrepBulkEvaluationSuccess"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string"
+              }
+            }
+          },
+          "304": {
+            "description": "results did not change since the ETag in If-None-Match",
+            "headers": {
+              "ETag": {
+                "type": "string"
+              }
+            }
+          },
+          "400": {
+            "description": "invalid evaluation context",
+            "schema": {
+              "$ref": "#/definitions/ofrepBulkEvaluationFailure"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/ofrep/v1/evaluate/flags/{key}": {
+      "post": {
+        "description": "OpenFeature Remote Evaluation Protocol (OFREP) evaluation of a single flag by key.\nPoint an OFREP provider at the Flagr API base URL (e.g. http://flagr:18000/api/v1).\ncontext.targetingKey is used as the entityID; every other context attribute is passed as entityContext.\n",
+        "tags": [
+          "ofrep"
+        ],
+        "summary": "OFREP single flag evaluation",
+        "operationId": "ofrepEvaluateFlag",
+        "parameters": [
+          {
+            "minLength": 1,
+            "type": "string",
+            "description": "flag key",
+            "name": "key",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/ofrepEvaluationRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "flag evaluated",
+            "schema": {
+              "$ref": "#/definitions/ofrepEvaluationSuccess"
+            }
+          },
+          "400": {
+            "description": "invalid evaluation context",
+            "schema": {
+              "$ref": "#/definitions/ofrepEvaluationFailure"
+            }
+          },
+          "404": {
+            "description": "flag not found",
+            "schema": {
+              "$ref": "#/definitions/ofrepEvaluationFailure"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/tags": {
+      "get": {
+        "tags": [
+          "tag"
+        ],
+        "operationId": "findAllTags",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "the numbers of tags to return",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "return tags given the offset, it should usually set together with limit",
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "return tags partially matching given value",
+            "name": "value_like",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "list all the tags",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/tag"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "constraint": {
+      "type": "object",
+      "required": [
+        "property",
+        "operator",
+        "value"
+      ],
+      "properties": {
+        "id": {
+          "type": "integer",
           "format": "int64",
           "minimum": 1,
           "readOnly": true
@@ -1731,11 +2470,19 @@ func init() {
             "IN",
             "NOTIN",
             "CONTAINS",
-            "NOTCONTAINS"
+            "NOTCONTAINS",
+            "ANY_IN",
+            "ALL_IN",
+            "NONE_IN",
+            "STARTS_WITH",
+            "ENDS_WITH",
+            "EQ_CI",
+            "IN_CI",
+            "CONTAINS_CI"
           ]
         },
         "property": {
-          "description": "The property name from the entity context to evaluate. Supports nested field access: use dots (e.g., ` + "`" + `user.name` + "`" + `) for nested objects and brackets (e.g., ` + "`" + `users[0]` + "`" + `) for array indices.\n",
+          "description": "The property name from the entity context to evaluate. Supports nested field access: use dots (e.g., ` + "`" + `user.name` + "`" + `) for nested objects and brackets (e.g., ` + "`" + `users[0]` + "`" + `) for array indices, or a JSON pointer (e.g., ` + "`" + `/user/name` + "`" + `, ` + "`" + `/users/0` + "`" + `). ` + "`" + `@http.x_country` + "`" + ` and ` + "`" + `/@http/x_country` + "`" + ` read the injected HTTP header key ` + "`" + `@http_x_country` + "`" + `.\n",
           "type": "string",
           "minLength": 1
         },
@@ -1745,6 +2492,88 @@ func init() {
         }
       }
     },
+    "constraintDebugLog": {
+      "type": "object",
+      "properties": {
+        "actual": {
+          "description": "the entity context value of property, absent if the property is not in the entity context"
+        },
+        "constraintID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "error": {
+          "description": "why the constraint could not be evaluated, e.g. a type mismatch",
+          "type": "string"
+        },
+        "expected": {
+          "description": "the constraint value, decoded if it is JSON, e.g. the string CA for the value \"CA\""
+        },
+        "matched": {
+          "type": "boolean",
+          "x-omitempty": false
+        },
+        "operator": {
+          "type": "string"
+        },
+        "property": {
+          "type": "string"
+        }
+      }
+    },
+    "constraintGroup": {
+      "type": "object",
+      "required": [
+        "operator"
+      ],
+      "properties": {
+        "constraints": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraint"
+          }
+        },
+        "groups": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraintGroup"
+          }
+        },
+        "operator": {
+          "description": "AND and OR join the constraints and groups; NOT matches when they do not all match",
+          "type": "string",
+          "enum": [
+            "AND",
+            "OR",
+            "NOT"
+          ]
+        }
+      }
+    },
+    "constraintGroupDebugLog": {
+      "type": "object",
+      "properties": {
+        "constraints": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraintDebugLog"
+          }
+        },
+        "groups": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraintGroupDebugLog"
+          }
+        },
+        "matched": {
+          "type": "boolean",
+          "x-omitempty": false
+        },
+        "operator": {
+          "type": "string"
+        }
+      }
+    },
     "createConstraintRequest": {
       "type": "object",
       "required": [
@@ -1758,7 +2587,7 @@ func init() {
           "minLength": 1
         },
         "property": {
-          "description": "The property name from the entity context to evaluate. Supports nested field access: use dots (e.g., ` + "`" + `user.name` + "`" + `) for nested objects and brackets (e.g., ` + "`" + `users[0]` + "`" + `) for array indices.\n",
+          "description": "The property name from the entity context to evaluate. Supports nested field access: use dots (e.g., ` + "`" + `user.name` + "`" + `) for nested objects and brackets (e.g., ` + "`" + `users[0]` + "`" + `) for array indices, or a JSON pointer (e.g., ` + "`" + `/user/name` + "`" + `, ` + "`" + `/users/0` + "`" + `). ` + "`" + `@http.x_country` + "`" + ` and ` + "`" + `/@http/x_country` + "`" + ` read the injected HTTP header key ` + "`" + `@http_x_country` + "`" + `.\n",
           "type": "string",
           "minLength": 1
         },
@@ -1788,6 +2617,24 @@ func init() {
         }
       }
     },
+    "createMetricRequest": {
+      "type": "object",
+      "required": [
+        "key"
+      ],
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "key": {
+          "type": "string",
+          "minLength": 1
+        },
+        "kind": {
+          "$ref": "#/definitions/metricKind"
+        }
+      }
+    },
     "createSegmentRequest": {
       "type": "object",
       "required": [
@@ -1795,6 +2642,13 @@ func init() {
         "rolloutPercent"
       ],
       "properties": {
+        "constraintGroups": {
+          "description": "constraint groups combining constraints with AND, OR and NOT. The segment matches when all its constraints and all its constraint groups match.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraintGroup"
+          }
+        },
         "description": {
           "type": "string",
           "minLength": 1
@@ -1843,90 +2697,226 @@ func init() {
         "date": {
           "type": "string",
           "format": "date"
+        },
+        "uniqueEntities": {
+          "description": "estimated distinct entities evaluated, from HyperLogLog sketches",
+          "type": "integer",
+          "format": "int64"
         }
       }
     },
-    "datarFlagSummaryResponse": {
+    "datarDimensionEntry": {
       "type": "object",
       "properties": {
-        "flagID": {
+        "count": {
           "type": "integer",
           "format": "int64"
         },
-        "trafficByDay": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/datarDayEntry"
-          }
-        },
-        "trafficBySegment": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/datarSegmentEntry"
-          }
-        },
-        "trafficByVariant": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/datarVariantEntry"
-          }
+        "value": {
+          "type": "string"
         }
       }
     },
-    "datarSegmentEntry": {
+    "datarExperimentOutcome": {
       "type": "object",
+      "required": [
+        "variantID"
+      ],
       "properties": {
-        "count": {
+        "successes": {
+          "description": "trials with a success, for conversion metrics",
           "type": "integer",
           "format": "int64"
         },
-        "segmentID": {
+        "valueSum": {
+          "description": "sum of values, for value metrics",
+          "type": "number",
+          "format": "double"
+        },
+        "valueSumSquares": {
+          "description": "sum of the squared value of each trial, for value metrics",
+          "type": "number",
+          "format": "double"
+        },
+        "variantID": {
           "type": "integer",
           "format": "int64"
         }
       }
     },
-    "datarSummaryFlag": {
+    "datarExperimentRequest": {
       "type": "object",
+      "required": [
+        "controlVariantID"
+      ],
       "properties": {
-        "description": {
-          "type": "string"
+        "alpha": {
+          "description": "Significance level, intervals are at level 1-alpha (default 0.05)",
+          "type": "number",
+          "format": "double"
         },
-        "enabled": {
+        "bayesian": {
+          "description": "Add the posterior probability of each variant to beat the control",
           "type": "boolean"
         },
-        "flagID": {
+        "controlVariantID": {
           "type": "integer",
-          "format": "int64"
+          "format": "int64",
+          "minimum": 1
         },
-        "flagKey": {
+        "denominator": {
+          "description": "Trials of each variant. Default assignments with metricKey, evaluations with variants.",
+          "type": "string",
+          "enum": [
+            "evaluations",
+            "assignments"
+          ]
+        },
+        "from": {
+          "description": "Start time (RFC 3339, default 7 days ago)",
+          "type": "string",
+          "format": "date-time"
+        },
+        "kind": {
+          "$ref": "#/definitions/metricKind"
+        },
+        "metricKey": {
+          "description": "Analyze the recorded events of this metric. Exclusive with variants.",
           "type": "string"
         },
-        "lastEvaluatedAt": {
+        "sequential": {
+          "description": "Return always-valid p-values and intervals, safe to check repeatedly",
+          "type": "boolean"
+        },
+        "to": {
+          "description": "End time (RFC 3339, default now)",
           "type": "string",
           "format": "date-time"
         },
-        "totalEvalCount": {
-          "type": "integer",
-          "format": "int64"
+        "variants": {
+          "description": "Outcomes per variant, e.g. from a pipeline. Exclusive with metricKey.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/datarExperimentOutcome"
+          }
         }
       }
     },
-    "datarSummaryResponse": {
+    "datarExperimentResponse": {
       "type": "object",
       "properties": {
-        "flags": {
+        "alpha": {
+          "type": "number",
+          "format": "double"
+        },
+        "bayesian": {
+          "type": "boolean"
+        },
+        "controlVariantID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "denominator": {
+          "type": "string"
+        },
+        "flagID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "kind": {
+          "$ref": "#/definitions/metricKind"
+        },
+        "metricKey": {
+          "type": "string"
+        },
+        "sequential": {
+          "type": "boolean"
+        },
+        "test": {
+          "type": "string",
+          "enum": [
+            "two_proportion_z",
+            "welch_t"
+          ]
+        },
+        "variants": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/datarSummaryFlag"
+            "$ref": "#/definitions/datarExperimentVariant"
           }
         }
       }
     },
-    "datarVariantEntry": {
+    "datarExperimentVariant": {
       "type": "object",
       "properties": {
-        "count": {
+        "control": {
+          "type": "boolean"
+        },
+        "credibleInterval": {
+          "$ref": "#/definitions/datarInterval"
+        },
+        "degreesOfFreedom": {
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
+        },
+        "difference": {
+          "description": "mean minus the mean of the control",
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
+        },
+        "differenceCI": {
+          "$ref": "#/definitions/datarInterval"
+        },
+        "error": {
+          "description": "why the variant could not be compared to the control",
+          "type": "string",
+          "x-omitempty": true
+        },
+        "lift": {
+          "description": "difference relative to the mean of the control",
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
+        },
+        "liftCI": {
+          "$ref": "#/definitions/datarInterval"
+        },
+        "mean": {
+          "description": "success rate, or mean value per trial",
+          "type": "number",
+          "format": "double"
+        },
+        "meanCI": {
+          "$ref": "#/definitions/datarInterval"
+        },
+        "pValue": {
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
+        },
+        "probabilityToBeatControl": {
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
+        },
+        "significant": {
+          "description": "pValue below alpha",
+          "type": "boolean"
+        },
+        "statistic": {
+          "description": "z or t statistic",
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
+        },
+        "successes": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "trials": {
           "type": "integer",
           "format": "int64"
         },
@@ -1936,791 +2926,2554 @@ func init() {
         }
       }
     },
-    "distribution": {
+    "datarFlagMetricsResponse": {
       "type": "object",
-      "required": [
-        "percent",
-        "variantID",
-        "variantKey"
-      ],
       "properties": {
-        "id": {
-          "type": "integer",
-          "format": "int64",
-          "minimum": 1,
-          "readOnly": true
-        },
-        "percent": {
-          "type": "integer",
-          "format": "int64",
-          "maximum": 100
-        },
-        "variantID": {
+        "flagID": {
           "type": "integer",
-          "format": "int64",
-          "minimum": 1
+          "format": "int64"
         },
-        "variantKey": {
-          "type": "string",
-          "minLength": 1
+        "variants": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/datarVariantMetrics"
+          }
         }
       }
     },
-    "duplicateFlagRequest": {
+    "datarFlagSummaryResponse": {
       "type": "object",
       "properties": {
-        "description": {
-          "description": "description for the new flag; defaults to source description with \" (cloned)\" suffix if omitted or empty",
-          "type": "string"
+        "flagID": {
+          "type": "integer",
+          "format": "int64"
         },
-        "key": {
-          "description": "unique key for the new flag; auto-generated if omitted or empty",
-          "type": "string"
+        "sampleRatio": {
+          "$ref": "#/definitions/datarSampleRatio"
+        },
+        "trafficByDay": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/datarDayEntry"
+          }
+        },
+        "trafficBySegment": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/datarSegmentEntry"
+          }
+        },
+        "trafficByVariant": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/datarVariantEntry"
+          }
+        },
+        "uniqueEntities": {
+          "description": "estimated distinct entities evaluated, from HyperLogLog sketches",
+          "type": "integer",
+          "format": "int64"
         }
       }
     },
-    "error": {
+    "datarInterval": {
       "type": "object",
-      "required": [
-        "message"
-      ],
       "properties": {
-        "message": {
-          "type": "string",
-          "minLength": 1
+        "lower": {
+          "type": "number",
+          "format": "double"
+        },
+        "upper": {
+          "type": "number",
+          "format": "double"
         }
       }
     },
-    "evalContext": {
+    "datarMetricEntry": {
       "type": "object",
       "properties": {
-        "enableDebug": {
-          "type": "boolean"
-        },
-        "entityContext": {
-          "type": "object"
-        },
-        "entityID": {
-          "description": "entityID is used to deterministically at random to evaluate the flag result. If it's empty, flagr will randomly generate one.",
-          "type": "string"
-        },
-        "entityType": {
-          "type": "string"
+        "conversions": {
+          "description": "entities with at least one event",
+          "type": "integer",
+          "format": "int64"
         },
-        "flagID": {
-          "description": "flagID",
+        "events": {
           "type": "integer",
-          "format": "int64",
-          "minimum": 1
+          "format": "int64"
         },
-        "flagKey": {
-          "description": "flagKey. flagID or flagKey will resolve to the same flag. Either works.",
+        "metricKey": {
           "type": "string"
         },
-        "flagTags": {
-          "description": "flagTags. flagTags looks up flags by tag. Either works.",
-          "type": "array",
-          "items": {
-            "type": "string"
-          },
-          "x-omitempty": true
+        "valueSum": {
+          "type": "number",
+          "format": "double"
         },
-        "flagTagsOperator": {
-          "description": "determine how flagTags is used to filter flags to be evaluated. OR extends the evaluation to those which contains at least one of the provided flagTags or AND limit the evaluation to those which contains all the flagTags.",
-          "type": "string",
-          "default": "ANY",
-          "enum": [
-            "ANY",
-            "ALL"
-          ]
+        "valueSumSquares": {
+          "description": "sum of the squared total value of each converting entity in the time range",
+          "type": "number",
+          "format": "double"
         }
       }
     },
-    "evalDebugLog": {
+    "datarSampleRatio": {
+      "description": "sample ratio mismatch check of the evaluations of each segment against its distribution and rollout",
       "type": "object",
       "properties": {
-        "msg": {
-          "type": "string"
+        "alpha": {
+          "type": "number",
+          "format": "double"
         },
-        "segmentDebugLogs": {
+        "degreesOfFreedom": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "mismatch": {
+          "description": "pValue below alpha",
+          "type": "boolean"
+        },
+        "pValue": {
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
+        },
+        "segments": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/segmentDebugLog"
+            "$ref": "#/definitions/datarSampleRatioSegment"
           }
+        },
+        "statistic": {
+          "description": "chi-squared statistic summed over the tested segments",
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
         }
       }
     },
-    "evalResult": {
+    "datarSampleRatioSegment": {
       "type": "object",
       "properties": {
-        "dataRecordsEnabled": {
-          "description": "Whether data records (impression logging) are enabled for this flag.",
-          "type": "boolean",
+        "degreesOfFreedom": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "error": {
+          "description": "why the segment was not tested",
+          "type": "string",
           "x-omitempty": true
         },
-        "evalContext": {
-          "$ref": "#/definitions/evalContext"
+        "mismatch": {
+          "type": "boolean"
         },
-        "evalDebugLog": {
-          "$ref": "#/definitions/evalDebugLog"
+        "pValue": {
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
         },
-        "flagID": {
+        "rolloutPercent": {
           "type": "integer",
           "format": "int64"
         },
-        "flagKey": {
-          "type": "string"
+        "segmentID": {
+          "type": "integer",
+          "format": "int64"
         },
-        "flagSnapshotID": {
+        "statistic": {
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
+        },
+        "total": {
           "type": "integer",
           "format": "int64"
         },
-        "flagTags": {
-          "description": "flagTags. flagTags looks up flags by tag. Either works.",
+        "variants": {
           "type": "array",
           "items": {
-            "type": "string"
-          },
-          "x-omitempty": true
+            "$ref": "#/definitions/datarSampleRatioVariant"
+          }
+        }
+      }
+    },
+    "datarSampleRatioVariant": {
+      "type": "object",
+      "properties": {
+        "expected": {
+          "type": "number",
+          "format": "double"
         },
-        "recordSource": {
-          "description": "evaluation for eval API results; exposure for client-reported impressions via POST /exposures (same data recorders as eval)",
-          "type": "string",
-          "enum": [
-            "evaluation",
-            "exposure"
-          ]
+        "expectedShare": {
+          "type": "number",
+          "format": "double"
+        },
+        "observed": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "variantID": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "datarSegmentEntry": {
+      "type": "object",
+      "properties": {
+        "count": {
+          "type": "integer",
+          "format": "int64"
         },
         "segmentID": {
           "type": "integer",
           "format": "int64"
         },
-        "timestamp": {
+        "uniqueEntities": {
+          "description": "estimated distinct entities evaluated, from HyperLogLog sketches",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "datarSummaryFlag": {
+      "type": "object",
+      "properties": {
+        "description": {
           "type": "string"
         },
-        "variantAttachment": {
-          "type": "object"
+        "enabled": {
+          "type": "boolean"
         },
-        "variantID": {
+        "flagID": {
           "type": "integer",
           "format": "int64"
         },
-        "variantKey": {
+        "flagKey": {
           "type": "string"
+        },
+        "lastEvaluatedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "totalEvalCount": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "uniqueEntities": {
+          "description": "estimated distinct entities evaluated, from HyperLogLog sketches",
+          "type": "integer",
+          "format": "int64"
         }
       }
     },
-    "evaluationBatchRequest": {
+    "datarSummaryResponse": {
       "type": "object",
-      "required": [
-        "entities"
-      ],
       "properties": {
-        "enableDebug": {
-          "type": "boolean"
-        },
-        "entities": {
+        "flags": {
           "type": "array",
-          "minItems": 1,
           "items": {
-            "$ref": "#/definitions/evaluationEntity"
+            "$ref": "#/definitions/datarSummaryFlag"
           }
+        }
+      }
+    },
+    "datarTagFlagEntry": {
+      "type": "object",
+      "properties": {
+        "count": {
+          "type": "integer",
+          "format": "int64"
         },
-        "flagIDs": {
-          "description": "flagIDs",
+        "flagID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "flagKey": {
+          "type": "string"
+        }
+      }
+    },
+    "datarTagSummaryResponse": {
+      "type": "object",
+      "properties": {
+        "byEntityType": {
+          "description": "records by entity type, empty when the entity_type dimension is not tracked",
           "type": "array",
-          "minItems": 1,
           "items": {
-            "type": "integer",
-            "format": "int64",
-            "minimum": 1
+            "$ref": "#/definitions/datarDimensionEntry"
           }
         },
-        "flagKeys": {
-          "description": "flagKeys. Either flagIDs, flagKeys or flagTags works. If pass in multiples, Flagr may return duplicate results.",
+        "byRecordSource": {
+          "description": "records by record source, empty when the record_source dimension is not tracked",
           "type": "array",
-          "minItems": 1,
           "items": {
-            "type": "string",
-            "minLength": 1
+            "$ref": "#/definitions/datarDimensionEntry"
           }
         },
-        "flagTags": {
-          "description": "flagTags. Either flagIDs, flagKeys or flagTags works. If pass in multiples, Flagr may return duplicate results.",
+        "flags": {
+          "description": "tagged flags with records, by count descending",
           "type": "array",
-          "minItems": 1,
           "items": {
-            "type": "string",
-            "minLength": 1
+            "$ref": "#/definitions/datarTagFlagEntry"
           }
         },
-        "flagTagsOperator": {
-          "description": "determine how flagTags is used to filter flags to be evaluated. OR extends the evaluation to those which contains at least one of the provided flagTags or AND limit the evaluation to those which contains all the flagTags.",
-          "type": "string",
-          "default": "ANY",
-          "enum": [
-            "ANY",
-            "ALL"
-          ]
+        "tag": {
+          "type": "string"
+        },
+        "total": {
+          "type": "integer",
+          "format": "int64"
         }
       }
     },
-    "evaluationBatchResponse": {
+    "datarTimeSeries": {
       "type": "object",
-      "required": [
-        "evaluationResults"
-      ],
       "properties": {
-        "evaluationResults": {
+        "counts": {
+          "description": "evaluations by bucket, 0 for buckets without evaluations",
           "type": "array",
           "items": {
-            "$ref": "#/definitions/evalResult"
+            "type": "integer",
+            "format": "int64"
           }
+        },
+        "segmentID": {
+          "description": "set when broken down by segment, 0 for evaluations without a segment",
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        },
+        "total": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "variantID": {
+          "description": "set when broken down by variant",
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
         }
       }
     },
-    "evaluationEntity": {
+    "datarTimeSeriesResponse": {
       "type": "object",
       "properties": {
-        "entityContext": {
-          "type": "object"
+        "buckets": {
+          "description": "start of each time bucket, in the time zone",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "format": "date-time"
+          }
         },
-        "entityID": {
-          "type": "string"
+        "flagID": {
+          "type": "integer",
+          "format": "int64"
         },
-        "entityType": {
+        "granularity": {
+          "type": "string",
+          "enum": [
+            "hour",
+            "day"
+          ]
+        },
+        "series": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/datarTimeSeries"
+          }
+        },
+        "timezone": {
           "type": "string"
         }
       }
     },
-    "exposure": {
+    "datarVariantEntry": {
       "type": "object",
-      "required": [
-        "entityID"
-      ],
       "properties": {
-        "entityContext": {
-          "description": "Optional attributes recorded on evalContext.entityContext (same field as POST /evaluation). Include impression-specific keys here (e.g. page path); exposure does not re-run segment constraints.",
-          "type": "object",
-          "additionalProperties": true
-        },
-        "entityID": {
-          "type": "string"
-        },
-        "entityType": {
-          "type": "string"
-        },
-        "flagID": {
+        "count": {
           "type": "integer",
           "format": "int64"
         },
-        "flagKey": {
-          "type": "string"
-        },
-        "flagSnapshotID": {
+        "uniqueEntities": {
+          "description": "estimated distinct entities evaluated, from HyperLogLog sketches",
           "type": "integer",
           "format": "int64"
         },
-        "timestamp": {
-          "type": "string",
-          "format": "date-time"
-        },
         "variantID": {
           "type": "integer",
           "format": "int64"
-        },
-        "variantKey": {
-          "type": "string"
         }
       }
     },
-    "exposureRowError": {
+    "datarVariantMetrics": {
       "type": "object",
       "properties": {
-        "index": {
+        "exposures": {
+          "description": "entities first assigned the variant in the time range",
           "type": "integer",
           "format": "int64"
         },
-        "message": {
-          "type": "string"
+        "metrics": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/datarMetricEntry"
+          }
+        },
+        "variantID": {
+          "type": "integer",
+          "format": "int64"
         }
       }
     },
-    "exposuresRequest": {
+    "distribution": {
       "type": "object",
       "required": [
-        "exposures"
+        "percent",
+        "variantID",
+        "variantKey"
       ],
       "properties": {
-        "exposures": {
-          "type": "array",
-          "minItems": 1,
-          "items": {
-            "$ref": "#/definitions/exposure"
-          }
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "percent": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 100
+        },
+        "variantID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "variantKey": {
+          "type": "string",
+          "minLength": 1
         }
       }
     },
-    "exposuresResponse": {
+    "distributionDebugLog": {
       "type": "object",
       "properties": {
-        "errors": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/exposureRowError"
-          }
+        "bucketEnd": {
+          "description": "last bucket of the variant, exclusive",
+          "type": "integer",
+          "format": "int64",
+          "x-omitempty": false
         },
-        "loggedCount": {
+        "bucketStart": {
+          "description": "first bucket of the variant, inclusive",
+          "type": "integer",
+          "format": "int64",
+          "x-omitempty": false
+        },
+        "percent": {
+          "type": "integer",
+          "format": "int64",
+          "x-omitempty": false
+        },
+        "variantID": {
           "type": "integer",
           "format": "int64"
         },
-        "message": {
+        "variantKey": {
           "type": "string"
         }
       }
     },
-    "flag": {
+    "duplicateFlagRequest": {
       "type": "object",
-      "required": [
-        "description",
-        "enabled",
-        "dataRecordsEnabled"
-      ],
       "properties": {
-        "createdBy": {
+        "description": {
+          "description": "description for the new flag; defaults to source description with \" (cloned)\" suffix if omitted or empty",
           "type": "string"
         },
-        "dataRecordsEnabled": {
-          "description": "when true and FLAGR_RECORDER_ENABLED is set, evaluation and exposure rows are written to configured data recorders (e.g. kafka).",
-          "type": "boolean"
-        },
-        "description": {
+        "key": {
+          "description": "unique key for the new flag; auto-generated if omitted or empty",
+          "type": "string"
+        }
+      }
+    },
+    "error": {
+      "type": "object",
+      "required": [
+        "message"
+      ],
+      "properties": {
+        "message": {
           "type": "string",
           "minLength": 1
-        },
-        "enabled": {
+        }
+      }
+    },
+    "evalContext": {
+      "type": "object",
+      "properties": {
+        "enableDebug": {
           "type": "boolean"
         },
+        "entityContext": {
+          "type": "object"
+        },
+        "entityID": {
+          "description": "entityID is used to deterministically at random to evaluate the flag result. If it's empty, flagr will randomly generate one.",
+          "type": "string"
+        },
         "entityType": {
-          "description": "it will override the entityType in the evaluation logs if it's not empty",
           "type": "string"
         },
-        "id": {
+        "flagID": {
+          "description": "flagID",
           "type": "integer",
           "format": "int64",
-          "minimum": 1,
-          "readOnly": true
-        },
-        "key": {
-          "description": "unique key representation of the flag",
-          "type": "string",
-          "minLength": 1
+          "minimum": 1
         },
-        "notes": {
-          "description": "flag usage details in markdown format",
+        "flagKey": {
+          "description": "flagKey. flagID or flagKey will resolve to the same flag. Either works.",
           "type": "string"
         },
-        "segments": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/segment"
-          }
-        },
-        "tags": {
+        "flagTags": {
+          "description": "flagTags. flagTags looks up flags by tag. Either works.",
           "type": "array",
           "items": {
-            "$ref": "#/definitions/tag"
-          }
+            "type": "string"
+          },
+          "x-omitempty": true
         },
-        "updatedAt": {
+        "flagTagsOperator": {
+          "description": "determine how flagTags is used to filter flags to be evaluated. OR extends the evaluation to those which contains at least one of the provided flagTags or AND limit the evaluation to those which contains all the flagTags.",
           "type": "string",
-          "format": "date-time"
-        },
-        "updatedBy": {
+          "default": "ANY",
+          "enum": [
+            "ANY",
+            "ALL"
+          ]
+        }
+      }
+    },
+    "evalDebugLog": {
+      "type": "object",
+      "properties": {
+        "msg": {
           "type": "string"
         },
-        "variants": {
+        "segmentDebugLogs": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/variant"
+            "$ref": "#/definitions/segmentDebugLog"
           }
         }
       }
     },
-    "flagSnapshot": {
+    "evalResult": {
       "type": "object",
-      "required": [
-        "flag",
-        "updatedAt"
-      ],
       "properties": {
-        "flag": {
-          "$ref": "#/definitions/flag"
+        "dataRecordsEnabled": {
+          "description": "Whether data records (impression logging) are enabled for this flag.",
+          "type": "boolean",
+          "x-omitempty": true
         },
-        "id": {
+        "error": {
+          "description": "set on a batch evaluation result that was not evaluated, e.g. because the batch deadline (FLAGR_EVAL_BATCH_TIMEOUT) passed. The result then only identifies the entity and the flag ID, key or tags.",
+          "type": "string",
+          "x-omitempty": true
+        },
+        "evalContext": {
+          "$ref": "#/definitions/evalContext"
+        },
+        "evalDebugLog": {
+          "$ref": "#/definitions/evalDebugLog"
+        },
+        "flagID": {
           "type": "integer",
-          "format": "int64",
-          "minimum": 1,
-          "readOnly": true
+          "format": "int64"
         },
-        "updatedAt": {
+        "flagKey": {
+          "type": "string"
+        },
+        "flagSnapshotID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "flagSourceRevision": {
+          "description": "revision of the eval-only flag source the flag was loaded from, e.g. the git commit SHA for the json_git driver. Empty when the source has no revision.",
+          "type": "string"
+        },
+        "flagTags": {
+          "description": "flagTags. flagTags looks up flags by tag. Either works.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": true
+        },
+        "recordSource": {
+          "description": "evaluation for eval API results; exposure for client-reported impressions via POST /exposures (same data recorders as eval)",
           "type": "string",
-          "minLength": 1
+          "enum": [
+            "evaluation",
+            "exposure"
+          ]
         },
-        "updatedBy": {
+        "segmentID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "timestamp": {
           "type": "string"
-        }
-      }
-    },
-    "flagSnapshotMaxID": {
-      "type": "object",
-      "properties": {
-        "maxID": {
+        },
+        "variantAttachment": {
+          "type": "object"
+        },
+        "variantID": {
           "type": "integer",
           "format": "int64"
-        }
-      }
-    },
-    "health": {
-      "type": "object",
-      "properties": {
-        "status": {
+        },
+        "variantKey": {
           "type": "string"
         }
       }
     },
-    "putDistributionsRequest": {
+    "evaluationBatchRequest": {
       "type": "object",
       "required": [
-        "distributions"
+        "entities"
       ],
       "properties": {
-        "distributions": {
+        "enableDebug": {
+          "type": "boolean"
+        },
+        "entities": {
           "type": "array",
+          "minItems": 1,
           "items": {
-            "$ref": "#/definitions/distribution"
+            "$ref": "#/definitions/evaluationEntity"
           }
-        }
-      }
-    },
-    "putFlagRequest": {
-      "type": "object",
-      "properties": {
-        "dataRecordsEnabled": {
-          "description": "when true and FLAGR_RECORDER_ENABLED is set, evaluation and exposure rows are written to configured data recorders (e.g. kafka).",
-          "type": "boolean",
-          "x-nullable": true
         },
-        "description": {
-          "type": "string",
-          "minLength": 1,
-          "x-nullable": true
+        "flagIDs": {
+          "description": "flagIDs",
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "integer",
+            "format": "int64",
+            "minimum": 1
+          }
         },
-        "enabled": {
-          "type": "boolean",
-          "x-nullable": true
+        "flagKeys": {
+          "description": "flagKeys. Either flagIDs, flagKeys or flagTags works. If pass in multiples, Flagr may return duplicate results.",
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "string",
+            "minLength": 1
+          }
         },
-        "entityType": {
-          "description": "it will overwrite entityType into evaluation logs if it's not empty",
-          "type": "string",
-          "x-nullable": true
+        "flagTags": {
+          "description": "flagTags. Either flagIDs, flagKeys or flagTags works. If pass in multiples, Flagr may return duplicate results.",
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "string",
+            "minLength": 1
+          }
         },
-        "key": {
+        "flagTagsOperator": {
+          "description": "determine how flagTags is used to filter flags to be evaluated. OR extends the evaluation to those which contains at least one of the provided flagTags or AND limit the evaluation to those which contains all the flagTags.",
           "type": "string",
-          "x-nullable": true
+          "default": "ANY",
+          "enum": [
+            "ANY",
+            "ALL"
+          ]
         },
-        "notes": {
-          "type": "string",
-          "x-nullable": true
+        "workers": {
+          "description": "number of workers evaluating the entity and flag pairs of this request in parallel, the request goroutine included. Defaults to FLAGR_EVAL_BATCH_WORKERS, and is capped by FLAGR_EVAL_BATCH_MAX_WORKERS extra workers shared by all batch requests.",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
         }
       }
     },
-    "putSegmentReorderRequest": {
+    "evaluationBatchResponse": {
       "type": "object",
       "required": [
-        "segmentIDs"
+        "evaluationResults"
       ],
       "properties": {
-        "segmentIDs": {
+        "evaluationResults": {
           "type": "array",
-          "minItems": 1,
           "items": {
-            "type": "integer",
-            "format": "int64",
-            "minimum": 1
+            "$ref": "#/definitions/evalResult"
           }
+        },
+        "partial": {
+          "description": "true if some results were not evaluated because the batch deadline passed; those results have an error",
+          "type": "boolean",
+          "x-omitempty": true
         }
       }
     },
-    "putSegmentRequest": {
+    "evaluationBootstrapFlag": {
       "type": "object",
-      "required": [
-        "description",
-        "rolloutPercent"
-      ],
       "properties": {
-        "description": {
-          "type": "string",
-          "minLength": 1
+        "variantAttachment": {
+          "type": "object"
         },
-        "rolloutPercent": {
-          "type": "integer",
-          "format": "int64",
-          "maximum": 100
+        "variantKey": {
+          "type": "string"
         }
       }
     },
-    "putVariantRequest": {
+    "evaluationBootstrapRequest": {
       "type": "object",
       "required": [
-        "key"
+        "entityID"
       ],
       "properties": {
-        "attachment": {
+        "entityContext": {
           "type": "object"
         },
-        "key": {
+        "entityID": {
+          "description": "the entity to evaluate; it's required so that the assignments (and the ETag) are stable",
+          "type": "string",
+          "minLength": 1
+        },
+        "entityType": {
+          "type": "string"
+        },
+        "flagEntityType": {
+          "description": "only evaluate flags with this entityType",
           "type": "string",
           "minLength": 1
+        },
+        "flagTags": {
+          "description": "only evaluate flags with these tags",
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "string",
+            "minLength": 1
+          }
+        },
+        "flagTagsOperator": {
+          "description": "determine how flagTags is used to filter flags to be evaluated. ANY keeps flags which contain at least one of the provided flagTags, ALL keeps flags which contain all of them.",
+          "type": "string",
+          "default": "ANY",
+          "enum": [
+            "ANY",
+            "ALL"
+          ]
         }
       }
     },
-    "segment": {
+    "evaluationBootstrapResponse": {
       "type": "object",
       "required": [
-        "description",
-        "rank",
-        "rolloutPercent"
+        "flags"
       ],
       "properties": {
-        "constraints": {
+        "flags": {
+          "description": "variant assignments by flag key",
+          "type": "object",
+          "additionalProperties": {
+            "$ref": "#/definitions/evaluationBootstrapFlag"
+          }
+        }
+      }
+    },
+    "evaluationEntity": {
+      "type": "object",
+      "properties": {
+        "entityContext": {
+          "type": "object"
+        },
+        "entityID": {
+          "type": "string"
+        },
+        "entityType": {
+          "type": "string"
+        }
+      }
+    },
+    "evaluationPreviewRequest": {
+      "type": "object",
+      "required": [
+        "flag",
+        "evalContexts"
+      ],
+      "properties": {
+        "evalContexts": {
           "type": "array",
+          "minItems": 1,
           "items": {
-            "$ref": "#/definitions/constraint"
+            "$ref": "#/definitions/evalContext"
           }
         },
-        "description": {
-          "type": "string",
-          "minLength": 1
+        "flag": {
+          "description": "flag definition in the JSON flag spec format, as used by the json_file and file_dir drivers and GET /export/eval_cache/json. A flag as returned by GET /flags/{flagID} works too. Missing IDs are assigned.",
+          "type": "object"
+        }
+      }
+    },
+    "evaluationPreviewResponse": {
+      "type": "object",
+      "required": [
+        "evalResults"
+      ],
+      "properties": {
+        "evalResults": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/evalResult"
+          }
         },
-        "distributions": {
+        "warnings": {
+          "description": "validation warnings about the flag definition, e.g. segments without distributions",
           "type": "array",
           "items": {
-            "$ref": "#/definitions/distribution"
+            "type": "string"
+          }
+        }
+      }
+    },
+    "evaluationSimulationRequest": {
+      "type": "object",
+      "properties": {
+        "contexts": {
+          "description": "corpus of eval contexts, e.g. sampled from production. Set either contexts or synthetic.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/evalContext"
           }
         },
-        "id": {
+        "flag": {
+          "description": "proposed flag definition, in the same formats as evaluationPreviewRequest.flag. If a stored flag is given too, the proposed flag takes its ID, so entities keep their buckets.",
+          "type": "object"
+        },
+        "flagID": {
+          "description": "stored flag to simulate, or to compare the proposed flag with",
           "type": "integer",
           "format": "int64",
-          "minimum": 1,
-          "readOnly": true
+          "minimum": 1
         },
-        "rank": {
-          "type": "integer",
-          "format": "int64"
+        "flagKey": {
+          "description": "stored flag to simulate, or to compare the proposed flag with",
+          "type": "string"
         },
-        "rolloutPercent": {
+        "switchedLimit": {
+          "description": "maximum number of entities listed in switched",
           "type": "integer",
           "format": "int64",
-          "maximum": 100
+          "default": 100
+        },
+        "synthetic": {
+          "$ref": "#/definitions/simulationSyntheticCorpus"
         }
       }
     },
-    "segmentDebugLog": {
+    "evaluationSimulationResponse": {
       "type": "object",
+      "required": [
+        "total",
+        "segments",
+        "variants",
+        "unassigned"
+      ],
       "properties": {
-        "msg": {
-          "type": "string"
+        "currentSegments": {
+          "description": "segments of the stored flag, when a proposed flag is compared with it",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/simulationSegment"
+          }
         },
-        "segmentID": {
+        "segments": {
+          "description": "segments of the simulated flag in rank order, with the contexts that matched their constraints",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/simulationSegment"
+          }
+        },
+        "switched": {
+          "description": "entities assigned a different variant than by the stored flag, up to switchedLimit",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/simulationSwitch"
+          }
+        },
+        "switchedTotal": {
           "type": "integer",
-          "format": "int64",
-          "minimum": 1
+          "format": "int64"
+        },
+        "total": {
+          "description": "number of eval contexts simulated",
+          "type": "integer",
+          "format": "int64"
+        },
+        "unassigned": {
+          "$ref": "#/definitions/simulationVariant"
+        },
+        "variants": {
+          "description": "assignments per variant key, including keys only present in the stored flag",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/simulationVariant"
+          }
+        },
+        "warnings": {
+          "description": "validation warnings about the proposed flag",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
         }
       }
     },
-    "setFlagEnabledRequest": {
+    "exposure": {
       "type": "object",
       "required": [
-        "enabled"
+        "entityID"
       ],
       "properties": {
-        "enabled": {
-          "type": "boolean"
+        "entityContext": {
+          "description": "Optional attributes recorded on evalContext.entityContext (same field as POST /evaluation). Include impression-specific keys here (e.g. page path); exposure does not re-run segment constraints.",
+          "type": "object",
+          "additionalProperties": true
+        },
+        "entityID": {
+          "type": "string"
+        },
+        "entityType": {
+          "type": "string"
+        },
+        "flagID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "flagKey": {
+          "type": "string"
+        },
+        "flagSnapshotID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "timestamp": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "variantID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "variantKey": {
+          "type": "string"
         }
       }
     },
-    "tag": {
+    "exposureRowError": {
       "type": "object",
-      "required": [
-        "value"
-      ],
       "properties": {
-        "id": {
+        "index": {
           "type": "integer",
-          "format": "int64",
-          "minimum": 1,
-          "readOnly": true
+          "format": "int64"
         },
-        "value": {
-          "type": "string",
-          "minLength": 1
+        "message": {
+          "type": "string"
         }
       }
     },
-    "variant": {
+    "exposuresRequest": {
       "type": "object",
       "required": [
-        "key"
+        "exposures"
       ],
       "properties": {
-        "attachment": {
-          "type": "object"
+        "exposures": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "$ref": "#/definitions/exposure"
+          }
+        }
+      }
+    },
+    "exposuresResponse": {
+      "type": "object",
+      "properties": {
+        "errors": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/exposureRowError"
+          }
         },
-        "id": {
+        "loggedCount": {
           "type": "integer",
-          "format": "int64",
-          "minimum": 1,
-          "readOnly": true
+          "format": "int64"
         },
-        "key": {
-          "type": "string",
-          "minLength": 1
+        "message": {
+          "type": "string"
         }
       }
-    }
-  },
-  "tags": [
-    {
-      "description": "Everything about the flag",
-      "name": "flag"
-    },
-    {
-      "description": "Segment defines the audience of the flag, it's the user segmentation",
-      "name": "segment"
-    },
-    {
-      "description": "Constraint is the unit of defining a small subset of users",
-      "name": "constraint"
-    },
-    {
-      "description": "Distribution is the percent distribution of variants within that segment",
-      "name": "distribution"
     },
-    {
-      "description": "Variants are the possible outcomes of flag evaluation",
-      "name": "variant"
+    "flag": {
+      "type": "object",
+      "required": [
+        "description",
+        "enabled",
+        "dataRecordsEnabled"
+      ],
+      "properties": {
+        "createdBy": {
+          "type": "string"
+        },
+        "dataRecordsEnabled": {
+          "description": "when true and FLAGR_RECORDER_ENABLED is set, evaluation and exposure rows are written to configured data recorders (e.g. kafka).",
+          "type": "boolean"
+        },
+        "description": {
+          "type": "string",
+          "minLength": 1
+        },
+        "enabled": {
+          "type": "boolean"
+        },
+        "entityType": {
+          "description": "it will override the entityType in the evaluation logs if it's not empty",
+          "type": "string"
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "key": {
+          "description": "unique key representation of the flag",
+          "type": "string",
+          "minLength": 1
+        },
+        "notes": {
+          "description": "flag usage details in markdown format",
+          "type": "string"
+        },
+        "segments": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/segment"
+          }
+        },
+        "tags": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/tag"
+          }
+        },
+        "updatedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "updatedBy": {
+          "type": "string"
+        },
+        "variants": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/variant"
+          }
+        }
+      }
     },
-    {
-      "description": "Evaluation is the process of evaluating a flag given the entity context",
-      "name": "evaluation"
+    "flagSnapshot": {
+      "type": "object",
+      "required": [
+        "flag",
+        "updatedAt"
+      ],
+      "properties": {
+        "flag": {
+          "$ref": "#/definitions/flag"
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "updatedAt": {
+          "type": "string",
+          "minLength": 1
+        },
+        "updatedBy": {
+          "type": "string"
+        }
+      }
     },
-    {
-      "description": "Client-reported impressions when a user saw a flag or variant",
-      "name": "exposure"
+    "flagSnapshotMaxID": {
+      "type": "object",
+      "properties": {
+        "maxID": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
     },
-    {
-      "description": "Check if Flagr is healthy",
-      "name": "health"
+    "health": {
+      "type": "object",
+      "properties": {
+        "flagSourceRevision": {
+          "description": "revision of the flag source currently loaded into the evaluation cache, e.g. the git commit SHA for the json_git driver. Empty when the source has no revision.",
+          "type": "string"
+        },
+        "status": {
+          "type": "string"
+        }
+      }
     },
-    {
-      "description": "Datar aggregate analytics",
-      "name": "datar"
-    }
-  ],
-  "x-tagGroups": [
-    {
-      "name": "Flag Management",
-      "tags": [
-        "flag",
-        "segment",
-        "constraint",
-        "distribution",
-        "variant",
-        "tag"
-      ]
+    "metric": {
+      "type": "object",
+      "required": [
+        "key",
+        "kind"
+      ],
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "key": {
+          "type": "string",
+          "minLength": 1
+        },
+        "kind": {
+          "$ref": "#/definitions/metricKind"
+        }
+      }
     },
-    {
-      "name": "Flag Evaluation",
-      "tags": [
-        "evaluation",
-        "exposure"
+    "metricEvent": {
+      "type": "object",
+      "required": [
+        "entityID",
+        "metricKey"
+      ],
+      "properties": {
+        "entityID": {
+          "type": "string"
+        },
+        "metricKey": {
+          "type": "string"
+        },
+        "timestamp": {
+          "description": "Time of the event (default now)",
+          "type": "string",
+          "format": "date-time"
+        },
+        "value": {
+          "description": "Numeric value of the event, required for value metrics",
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
+        }
+      }
+    },
+    "metricEventRowError": {
+      "type": "object",
+      "properties": {
+        "index": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "message": {
+          "type": "string"
+        }
+      }
+    },
+    "metricEventsRequest": {
+      "type": "object",
+      "required": [
+        "events"
+      ],
+      "properties": {
+        "events": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "$ref": "#/definitions/metricEvent"
+          }
+        }
+      }
+    },
+    "metricEventsResponse": {
+      "type": "object",
+      "properties": {
+        "acceptedCount": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "errors": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/metricEventRowError"
+          }
+        }
+      }
+    },
+    "metricKind": {
+      "description": "conversion for events that count whether an entity converted, value for events with a numeric value, e.g. revenue",
+      "type": "string",
+      "enum": [
+        "conversion",
+        "value"
       ]
     },
-    {
-      "name": "Health Check",
-      "tags": [
-        "health"
-      ]
+    "ofrepBulkEvaluationFailure": {
+      "type": "object",
+      "properties": {
+        "errorCode": {
+          "type": "string",
+          "enum": [
+            "PARSE_ERROR",
+            "INVALID_CONTEXT",
+            "GENERAL"
+          ]
+        },
+        "errorDetails": {
+          "type": "string"
+        }
+      }
+    },
+    "ofrepBulkEvaluationSuccess": {
+      "type": "object",
+      "properties": {
+        "flags": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ofrepEvaluationSuccess"
+          }
+        }
+      }
+    },
+    "ofrepEvaluationFailure": {
+      "type": "object",
+      "properties": {
+        "errorCode": {
+          "type": "string",
+          "enum": [
+            "PARSE_ERROR",
+            "TARGETING_KEY_MISSING",
+            "INVALID_CONTEXT",
+            "FLAG_NOT_FOUND",
+            "GENERAL"
+          ]
+        },
+        "errorDetails": {
+          "type": "string"
+        },
+        "key": {
+          "type": "string"
+        },
+        "reason": {
+          "type": "string",
+          "enum": [
+            "ERROR"
+          ]
+        }
+      }
+    },
+    "ofrepEvaluationRequest": {
+      "type": "object",
+      "properties": {
+        "context": {
+          "description": "OpenFeature evaluation context. targetingKey maps to entityID, all other attributes to entityContext.",
+          "type": "object"
+        }
+      }
+    },
+    "ofrepEvaluationSuccess": {
+      "type": "object",
+      "properties": {
+        "key": {
+          "type": "string"
+        },
+        "metadata": {
+          "type": "object",
+          "additionalProperties": true,
+          "x-omitempty": true
+        },
+        "reason": {
+          "type": "string",
+          "enum": [
+            "TARGETING_MATCH",
+            "SPLIT",
+            "DISABLED",
+            "DEFAULT"
+          ]
+        },
+        "value": {
+          "description": "the variant attachment, or the variant key when the attachment is empty. Omitted when no variant was assigned (reason DISABLED or DEFAULT), so the provider falls back to the code default.",
+          "x-omitempty": true
+        },
+        "variant": {
+          "type": "string",
+          "x-omitempty": true
+        }
+      }
+    },
+    "putDistributionsRequest": {
+      "type": "object",
+      "required": [
+        "distributions"
+      ],
+      "properties": {
+        "distributions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/distribution"
+          }
+        }
+      }
+    },
+    "putFlagRequest": {
+      "type": "object",
+      "properties": {
+        "dataRecordsEnabled": {
+          "description": "when true and FLAGR_RECORDER_ENABLED is set, evaluation and exposure rows are written to configured data recorders (e.g. kafka).",
+          "type": "boolean",
+          "x-nullable": true
+        },
+        "description": {
+          "type": "string",
+          "minLength": 1,
+          "x-nullable": true
+        },
+        "enabled": {
+          "type": "boolean",
+          "x-nullable": true
+        },
+        "entityType": {
+          "description": "it will overwrite entityType into evaluation logs if it's not empty",
+          "type": "string",
+          "x-nullable": true
+        },
+        "key": {
+          "type": "string",
+          "x-nullable": true
+        },
+        "notes": {
+          "type": "string",
+          "x-nullable": true
+        }
+      }
+    },
+    "putMetricRequest": {
+      "type": "object",
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "kind": {
+          "$ref": "#/definitions/metricKind"
+        }
+      }
+    },
+    "putSegmentReorderRequest": {
+      "type": "object",
+      "required": [
+        "segmentIDs"
+      ],
+      "properties": {
+        "segmentIDs": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "integer",
+            "format": "int64",
+            "minimum": 1
+          }
+        }
+      }
+    },
+    "putSegmentRequest": {
+      "type": "object",
+      "required": [
+        "description",
+        "rolloutPercent"
+      ],
+      "properties": {
+        "constraintGroups": {
+          "description": "replaces the constraint groups of the segment if set; an empty array removes them",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraintGroup"
+          }
+        },
+        "description": {
+          "type": "string",
+          "minLength": 1
+        },
+        "rolloutPercent": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 100
+        }
+      }
+    },
+    "putVariantRequest": {
+      "type": "object",
+      "required": [
+        "key"
+      ],
+      "properties": {
+        "attachment": {
+          "type": "object"
+        },
+        "key": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "rolloutDebugLog": {
+      "description": "the rollout decision of a segment whose constraints matched",
+      "type": "object",
+      "properties": {
+        "bucketNum": {
+          "description": "the bucket of the entity, from 0 to 999",
+          "type": "integer",
+          "format": "int64",
+          "x-omitempty": false
+        },
+        "distributions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/distributionDebugLog"
+          }
+        },
+        "reason": {
+          "description": "why the entity was not bucketed, e.g. an empty entityID or no distribution",
+          "type": "string"
+        },
+        "rolledOut": {
+          "description": "whether the entity was assigned a variant",
+          "type": "boolean",
+          "x-omitempty": false
+        },
+        "rolloutPercent": {
+          "type": "integer",
+          "format": "int64",
+          "x-omitempty": false
+        },
+        "variantID": {
+          "description": "the variant of the bucket, assigned if rolledOut",
+          "type": "integer",
+          "format": "int64"
+        },
+        "variantKey": {
+          "type": "string"
+        }
+      }
+    },
+    "segment": {
+      "type": "object",
+      "required": [
+        "description",
+        "rank",
+        "rolloutPercent"
+      ],
+      "properties": {
+        "constraintGroups": {
+          "description": "constraint groups combining constraints with AND, OR and NOT. The segment matches when all its constraints and all its constraint groups match.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraintGroup"
+          }
+        },
+        "constraints": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraint"
+          }
+        },
+        "description": {
+          "type": "string",
+          "minLength": 1
+        },
+        "distributions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/distribution"
+          }
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "rank": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "rolloutPercent": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 100
+        }
+      }
+    },
+    "segmentDebugLog": {
+      "type": "object",
+      "properties": {
+        "constraints": {
+          "description": "each constraint of the segment and its result",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraintDebugLog"
+          }
+        },
+        "groups": {
+          "description": "each constraint group of the segment and its result",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraintGroupDebugLog"
+          }
+        },
+        "matched": {
+          "description": "whether the entity context matched all constraints of the segment",
+          "type": "boolean",
+          "x-omitempty": false
+        },
+        "msg": {
+          "type": "string"
+        },
+        "rollout": {
+          "$ref": "#/definitions/rolloutDebugLog"
+        },
+        "segmentID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        }
+      }
+    },
+    "setFlagEnabledRequest": {
+      "type": "object",
+      "required": [
+        "enabled"
+      ],
+      "properties": {
+        "enabled": {
+          "type": "boolean"
+        }
+      }
+    },
+    "simulationSegment": {
+      "type": "object",
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "matched": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "percent": {
+          "type": "number",
+          "format": "double"
+        },
+        "rolloutPercent": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "segmentID": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "simulationSwitch": {
+      "type": "object",
+      "properties": {
+        "currentSegmentID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "currentVariantKey": {
+          "type": "string"
+        },
+        "entityID": {
+          "type": "string"
+        },
+        "segmentID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "variantKey": {
+          "type": "string"
+        }
+      }
+    },
+    "simulationSyntheticCorpus": {
+      "description": "a corpus of random entity IDs, all with the same entityType and entityContext",
+      "type": "object",
+      "required": [
+        "count"
+      ],
+      "properties": {
+        "count": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "entityContext": {
+          "type": "object"
+        },
+        "entityType": {
+          "type": "string"
+        },
+        "seed": {
+          "description": "seed for the entity IDs; the same seed gives the same corpus",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "simulationVariant": {
+      "description": "contexts assigned to a variant (or to none, for unassigned); current fields are for the stored flag",
+      "type": "object",
+      "properties": {
+        "count": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "currentCount": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "currentPercent": {
+          "type": "number",
+          "format": "double"
+        },
+        "delta": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "percent": {
+          "type": "number",
+          "format": "double"
+        },
+        "percentDelta": {
+          "type": "number",
+          "format": "double"
+        },
+        "variantKey": {
+          "type": "string"
+        }
+      }
+    },
+    "tag": {
+      "type": "object",
+      "required": [
+        "value"
+      ],
+      "properties": {
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "value": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "variant": {
+      "type": "object",
+      "required": [
+        "key"
+      ],
+      "properties": {
+        "attachment": {
+          "type": "object"
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "key": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    }
+  },
+  "tags": [
+    {
+      "description": "Everything about the flag",
+      "name": "flag"
+    },
+    {
+      "description": "Segment defines the audience of the flag, it's the user segmentation",
+      "name": "segment"
+    },
+    {
+      "description": "Constraint is the unit of defining a small subset of users",
+      "name": "constraint"
+    },
+    {
+      "description": "Distribution is the percent distribution of variants within that segment",
+      "name": "distribution"
+    },
+    {
+      "description": "Variants are the possible outcomes of flag evaluation",
+      "name": "variant"
+    },
+    {
+      "description": "Evaluation is the process of evaluating a flag given the entity context",
+      "name": "evaluation"
+    },
+    {
+      "description": "Client-reported impressions when a user saw a flag or variant",
+      "name": "exposure"
+    },
+    {
+      "description": "Metrics are outcomes of entities, attributed to the variants they were assigned",
+      "name": "metric"
+    },
+    {
+      "description": "OpenFeature Remote Evaluation Protocol (OFREP) endpoints",
+      "name": "ofrep"
+    },
+    {
+      "description": "Check if Flagr is healthy",
+      "name": "health"
+    },
+    {
+      "description": "Datar aggregate analytics",
+      "name": "datar"
+    }
+  ],
+  "x-tagGroups": [
+    {
+      "name": "Flag Management",
+      "tags": [
+        "flag",
+        "segment",
+        "constraint",
+        "distribution",
+        "variant",
+        "tag"
+      ]
+    },
+    {
+      "name": "Flag Evaluation",
+      "tags": [
+        "evaluation",
+        "exposure",
+        "metric",
+        "ofrep"
+      ]
+    },
+    {
+      "name": "Health Check",
+      "tags": [
+        "health"
+      ]
+    },
+    {
+      "name": "Export",
+      "tags": [
+        "export"
+      ]
+    },
+    {
+      "name": "Datar",
+      "tags": [
+        "datar"
+      ]
+    }
+  ]
+}`))
+	FlatSwaggerJSON = json.RawMessage([]byte(`{
+  "consumes": [
+    "application/json"
+  ],
+  "produces": [
+    "application/json"
+  ],
+  "schemes": [
+    "http"
+  ],
+  "swagger": "2.0",
+  "info": {
+    "description": "Flagr is a feature flagging, A/B testing and dynamic configuration microservice. The base path for all the APIs is \"/api/v1\".\n",
+    "title": "Flagr",
+    "version": "1.2.3"
+  },
+  "basePath": "/api/v1",
+  "paths": {
+    "/datar/export": {
+      "get": {
+        "description": "Evaluation counts of all flags by hour as CSV or newline-delimited JSON, with the keys of flags and variants. Days rolled up by the retention are exported as one row at their midnight (UTC)\n",
+        "produces": [
+          "text/csv",
+          "application/x-ndjson",
+          "application/json"
+        ],
+        "tags": [
+          "datar"
+        ],
+        "operationId": "getDatarExport",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Start time (RFC 3339, default 7 days ago)",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "End time (RFC 3339, default now)",
+            "name": "to",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "csv",
+              "ndjson"
+            ],
+            "type": "string",
+            "default": "csv",
+            "description": "Format of the rows, text/csv or application/x-ndjson",
+            "name": "format",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "evaluation counts, one per row",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/datar/flags/{flagID}/experiment": {
+      "post": {
+        "description": "Experiment readout of a flag: every variant against a control variant, with\nDatar's per-variant counts as trials. Outcomes are the recorded events of a\nmetric (metricKey) or success counts and value sums posted per variant.\n",
+        "tags": [
+          "datar"
+        ],
+        "operationId": "postDatarFlagExperiment",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Flag ID",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/datarExperimentRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "experiment readout",
+            "schema": {
+              "$ref": "#/definitions/datarExperimentResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/datar/flags/{flagID}/metrics": {
+      "get": {
+        "description": "Assigned entities and metric event aggregates of a flag by variant",
+        "tags": [
+          "datar"
+        ],
+        "operationId": "getDatarFlagMetrics",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Flag ID",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Start time (RFC 3339, default 7 days ago)",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "End time (RFC 3339, default now)",
+            "name": "to",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "flag metrics by variant",
+            "schema": {
+              "$ref": "#/definitions/datarFlagMetricsResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/datar/flags/{flagID}/summary": {
+      "get": {
+        "description": "All-in-one analytics summary for a single flag",
+        "tags": [
+          "datar"
+        ],
+        "operationId": "getDatarFlagSummary",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Flag ID",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Start time (RFC 3339, default 7 days ago)",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "End time (RFC 3339, default now)",
+            "name": "to",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "flag analytics summary",
+            "schema": {
+              "$ref": "#/definitions/datarFlagSummaryResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/datar/flags/{flagID}/timeseries": {
+      "get": {
+        "description": "Evaluations of a flag by hour or day, broken down by variant and/or segment",
+        "tags": [
+          "datar"
+        ],
+        "operationId": "getDatarFlagTimeSeries",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Flag ID",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Start time (RFC 3339, default 7 days ago), rounded down to the start of its bucket",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "End time (RFC 3339, default now)",
+            "name": "to",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "hour",
+              "day"
+            ],
+            "type": "string",
+            "default": "day",
+            "description": "Size of the time buckets",
+            "name": "granularity",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "enum": [
+                "variant",
+                "segment"
+              ],
+              "type": "string"
+            },
+            "collectionFormat": "csv",
+            "description": "Dimensions to split the series by. Without a breakdown there is one series of all evaluations",
+            "name": "breakdown",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "default": "UTC",
+            "description": "IANA time zone of day boundaries and bucket starts, e.g. Europe/Berlin",
+            "name": "timezone",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "flag evaluation time series",
+            "schema": {
+              "$ref": "#/definitions/datarTimeSeriesResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/datar/summary": {
+      "get": {
+        "description": "Aggregate traffic summary for all flags",
+        "tags": [
+          "datar"
+        ],
+        "operationId": "getDatarSummary",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Start time (RFC 3339, default 7 days ago)",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "End time (RFC 3339, default now)",
+            "name": "to",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int32",
+            "description": "Max results (default 100)",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int32",
+            "description": "Result offset (default 0)",
+            "name": "offset",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "summary of all flag traffic",
+            "schema": {
+              "$ref": "#/definitions/datarSummaryResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/datar/tags/{tag}/summary": {
+      "get": {
+        "description": "Records of the flags of a tag by flag, record source and entity type. Requires FLAGR_RECORDER_DATAR_DIMENSIONS",
+        "tags": [
+          "datar"
+        ],
+        "operationId": "getDatarTagSummary",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Tag value",
+            "name": "tag",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Start time (RFC 3339, default 7 days ago)",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "End time (RFC 3339, default now)",
+            "name": "to",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "evaluation",
+              "exposure"
+            ],
+            "type": "string",
+            "description": "Only count records of the record source",
+            "name": "recordSource",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only count records of the entity type",
+            "name": "entityType",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "tag analytics summary",
+            "schema": {
+              "$ref": "#/definitions/datarTagSummaryResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/evaluation": {
+      "get": {
+        "tags": [
+          "evaluation"
+        ],
+        "operationId": "getEvaluation",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Percent-encoded (URI-encoded) JSON matching POST /evaluation body (evalContext). Example: ?json=%7B%22entityID%22%3A%22u1%22%2C%22flagID%22%3A1%7D decodes to {\"entityID\":\"u1\",\"flagID\":1}.",
+            "name": "json",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "evaluation result",
+            "schema": {
+              "$ref": "#/definitions/evalResult"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "tags": [
+          "evaluation"
+        ],
+        "operationId": "postEvaluation",
+        "parameters": [
+          {
+            "description": "evaluation context",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/evalContext"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "evaluation result",
+            "schema": {
+              "$ref": "#/definitions/evalResult"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/evaluation/batch": {
+      "get": {
+        "tags": [
+          "evaluation"
+        ],
+        "operationId": "getEvaluationBatch",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Percent-encoded (URI-encoded) JSON matching POST /evaluation/batch body (evaluationBatchRequest). Example: ?json=%7B%22entities%22%3A%5B%7B%22entityID%22%3A%22u1%22%7D%5D%2C%22flagIDs%22%3A%5B1%5D%7D decodes to {\"entities\":[{\"entityID\":\"u1\"}],\"flagIDs\":[1]}.",
+            "name": "json",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "evaluation batch result",
+            "schema": {
+              "$ref": "#/definitions/evaluationBatchResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "tags": [
+          "evaluation"
+        ],
+        "operationId": "postEvaluationBatch",
+        "parameters": [
+          {
+            "description": "evaluation batch request",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/evaluationBatchRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "evaluation batch result",
+            "schema": {
+              "$ref": "#/definitions/evaluationBatchResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/evaluation/bootstrap": {
+      "get": {
+        "description": "Same as POST /evaluation/bootstrap with the request in a query parameter, so CDNs and browsers can cache it.\n",
+        "tags": [
+          "evaluation"
+        ],
+        "summary": "Evaluate all enabled flags for one entity (browser and edge friendly)",
+        "operationId": "getEvaluationBootstrap",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Percent-encoded (URI-encoded) JSON matching POST /evaluation/bootstrap body (evaluationBootstrapRequest). Example: ?json=%7B%22entityID%22%3A%22u1%22%7D decodes to {\"entityID\":\"u1\"}.",
+            "name": "json",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "ETag of a previous bootstrap response",
+            "name": "If-None-Match",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "variant assignments by flag key",
+            "schema": {
+              "$ref": "#/definitions/evaluationBootstrapResponse"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string"
+              }
+            }
+          },
+          "304": {
+            "description": "neither the flags nor the request changed since the ETag in If-None-Match",
+            "headers": {
+              "ETag": {
+                "type": "string"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Evaluates every enabled flag with a key, optionally filtered by flag tags or flag entity type, for a single\nentity and returns a compact map of flag key to variant. Flags that assign no variant are left out. The ETag\nis derived from the flag snapshot max ID (or the eval-only source revision) and a hash of the request, so it\nchanges when a flag changes or the entity/context differs; send it back as If-None-Match to get 304 Not\nModified without evaluating again.\n",
+        "tags": [
+          "evaluation"
+        ],
+        "summary": "Evaluate all enabled flags for one entity",
+        "operationId": "postEvaluationBootstrap",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ETag of a previous bootstrap response",
+            "name": "If-None-Match",
+            "in": "header"
+          },
+          {
+            "description": "bootstrap request",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/evaluationBootstrapRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "variant assignments by flag key",
+            "schema": {
+              "$ref": "#/definitions/evaluationBootstrapResponse"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string"
+              }
+            }
+          },
+          "304": {
+            "description": "neither the flags nor the request changed since the ETag in If-None-Match",
+            "headers": {
+              "ETag": {
+                "type": "string"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/evaluation/preview": {
+      "post": {
+        "description": "Validates a full flag definition in the JSON flag spec format and evaluates it against each eval context with\ndebug logs on, e.g. to test a segment change before saving it. Nothing is stored: results are not sent to\ndata recorders or Datar, and the evaluation cache is not changed.\n",
+        "tags": [
+          "evaluation"
+        ],
+        "summary": "Evaluate a flag definition that is not saved",
+        "operationId": "postEvaluationPreview",
+        "parameters": [
+          {
+            "description": "evaluation preview request",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/evaluationPreviewRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "evaluation results, one per eval context",
+            "schema": {
+              "$ref": "#/definitions/evaluationPreviewResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
     },
-    {
-      "name": "Export",
-      "tags": [
-        "export"
-      ]
+    "/evaluation/simulation": {
+      "post": {
+        "description": "Evaluates a flag, stored or proposed, against a corpus of eval contexts and returns how many contexts match\neach segment and land in each variant. When both a stored flag (flagID or flagKey) and a proposed flag are\ngiven, counts are compared with the stored config and the entities that would switch variants are listed.\nNothing is recorded: results are not sent to data recorders or Datar.\n",
+        "tags": [
+          "evaluation"
+        ],
+        "summary": "Simulate a flag config over a corpus of eval contexts",
+        "operationId": "postEvaluationSimulation",
+        "parameters": [
+          {
+            "description": "evaluation simulation request",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/evaluationSimulationRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "simulation result",
+            "schema": {
+              "$ref": "#/definitions/evaluationSimulationResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
     },
-    {
-      "name": "Datar",
-      "tags": [
-        "datar"
-      ]
-    }
-  ]
-}`))
-	FlatSwaggerJSON = json.RawMessage([]byte(`{
-  "consumes": [
-    "application/json"
-  ],
-  "produces": [
-    "application/json"
-  ],
-  "schemes": [
-    "http"
-  ],
-  "swagger": "2.0",
-  "info": {
-    "description": "Flagr is a feature flagging, A/B testing and dynamic configuration microservice. The base path for all the APIs is \"/api/v1\".\n",
-    "title": "Flagr",
-    "version": "1.2.3"
-  },
-  "basePath": "/api/v1",
-  "paths": {
-    "/datar/flags/{flagID}/summary": {
+    "/export/eval_cache/json": {
       "get": {
-        "description": "All-in-one analytics summary for a single flag",
+        "description": "Export JSON format of the eval cache dump, with optional filtering",
+        "produces": [
+          "application/json"
+        ],
         "tags": [
-          "datar"
+          "export"
         ],
-        "operationId": "getDatarFlagSummary",
+        "operationId": "getExportEvalCacheJSON",
         "parameters": [
           {
-            "type": "integer",
-            "format": "int64",
-            "description": "Flag ID",
-            "name": "flagID",
-            "in": "path",
-            "required": true
+            "type": "array",
+            "items": {
+              "minimum": 1,
+              "type": "integer",
+              "format": "int64"
+            },
+            "collectionFormat": "csv",
+            "description": "CSV of flag IDs to include (e.g. 1,2,3). When provided, keys/enabled/tags are ignored.",
+            "name": "ids",
+            "in": "query"
           },
           {
-            "type": "string",
-            "format": "date-time",
-            "description": "Start time (RFC 3339, default 7 days ago)",
-            "name": "from",
+            "type": "array",
+            "items": {
+              "minLength": 1,
+              "type": "string"
+            },
+            "collectionFormat": "csv",
+            "description": "CSV of flag keys to include (e.g. one,two). When provided, enabled/tags are ignored.",
+            "name": "keys",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "Filter by enabled status (omit to return all)",
+            "name": "enabled",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "minLength": 1,
+              "type": "string"
+            },
+            "collectionFormat": "csv",
+            "description": "CSV of tag values to filter by (e.g. foo,bar)",
+            "name": "tags",
             "in": "query"
           },
           {
+            "enum": [
+              "ANY",
+              "ALL"
+            ],
             "type": "string",
-            "format": "date-time",
-            "description": "End time (RFC 3339, default now)",
-            "name": "to",
+            "default": "ANY",
+            "description": "Tag matching operator: ANY (default) returns flags with any of the tags, ALL returns flags with all tags",
+            "name": "tagsOperator",
             "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "flag analytics summary",
+            "description": "OK",
             "schema": {
-              "$ref": "#/definitions/datarFlagSummaryResponse"
+              "type": "object"
             }
           },
           "default": {
@@ -2732,48 +5485,152 @@ func init() {
         }
       }
     },
-    "/datar/summary": {
+    "/export/sqlite": {
+      "get": {
+        "description": "Export sqlite3 format of the db dump, which is converted from the main database.",
+        "produces": [
+          "application/octet-stream"
+        ],
+        "tags": [
+          "export"
+        ],
+        "operationId": "getExportSqlite",
+        "parameters": [
+          {
+            "type": "boolean",
+            "description": "export without snapshots data - useful for smaller db without snapshots\n",
+            "name": "exclude_snapshots",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/exposures": {
+      "post": {
+        "description": "Log when a user actually saw a flag or variant (impression), typically after POST /evaluation.\nIntegrates with the data recorder when FLAGR_RECORDER_ENABLED and per-flag dataRecordsEnabled are set.\n",
+        "tags": [
+          "exposure"
+        ],
+        "summary": "Log exposures (batch)",
+        "operationId": "postExposures",
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/exposuresRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "exposures accepted (partial success possible)",
+            "schema": {
+              "$ref": "#/definitions/exposuresResponse"
+            }
+          },
+          "400": {
+            "description": "invalid request",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags": {
       "get": {
-        "description": "Aggregate traffic summary for all flags",
         "tags": [
-          "datar"
+          "flag"
         ],
-        "operationId": "getDatarSummary",
+        "operationId": "findFlags",
         "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "the numbers of flags to return",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "return flags having given enabled status",
+            "name": "enabled",
+            "in": "query"
+          },
           {
             "type": "string",
-            "format": "date-time",
-            "description": "Start time (RFC 3339, default 7 days ago)",
-            "name": "from",
+            "description": "return flags exactly matching given description",
+            "name": "description",
             "in": "query"
           },
           {
             "type": "string",
-            "format": "date-time",
-            "description": "End time (RFC 3339, default now)",
-            "name": "to",
+            "description": "return flags with the given tags (comma separated)",
+            "name": "tags",
             "in": "query"
           },
           {
-            "type": "integer",
-            "format": "int32",
-            "description": "Max results (default 100)",
-            "name": "limit",
+            "type": "string",
+            "description": "return flags partially matching given description",
+            "name": "description_like",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "return flags matching given key",
+            "name": "key",
             "in": "query"
           },
           {
             "type": "integer",
-            "format": "int32",
-            "description": "Result offset (default 0)",
+            "format": "int64",
+            "description": "return flags given the offset, it should usually set together with limit",
             "name": "offset",
             "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "return flags with preloaded segments and variants",
+            "name": "preload",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "return all deleted flags",
+            "name": "deleted",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "summary of all flag traffic",
+            "description": "list all the flags",
             "schema": {
-              "$ref": "#/definitions/datarSummaryResponse"
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/flag"
+              }
             }
           },
           "default": {
@@ -2783,28 +5640,28 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/evaluation": {
-      "get": {
+      },
+      "post": {
         "tags": [
-          "evaluation"
+          "flag"
         ],
-        "operationId": "getEvaluation",
+        "operationId": "createFlag",
         "parameters": [
           {
-            "type": "string",
-            "description": "Percent-encoded (URI-encoded) JSON matching POST /evaluation body (evalContext). Example: ?json=%7B%22entityID%22%3A%22u1%22%2C%22flagID%22%3A1%7D decodes to {\"entityID\":\"u1\",\"flagID\":1}.",
-            "name": "json",
-            "in": "query",
-            "required": true
+            "description": "create a flag",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createFlagRequest"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "evaluation result",
+            "description": "returns the created flag",
             "schema": {
-              "$ref": "#/definitions/evalResult"
+              "$ref": "#/definitions/flag"
             }
           },
           "default": {
@@ -2814,28 +5671,23 @@ func init() {
             }
           }
         }
-      },
-      "post": {
+      }
+    },
+    "/flags/entity_types": {
+      "get": {
         "tags": [
-          "evaluation"
-        ],
-        "operationId": "postEvaluation",
-        "parameters": [
-          {
-            "description": "evaluation context",
-            "name": "body",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/evalContext"
-            }
-          }
+          "flag"
         ],
+        "operationId": "getFlagEntityTypes",
         "responses": {
           "200": {
-            "description": "evaluation result",
+            "description": "returns all the FlagEntityTypes",
             "schema": {
-              "$ref": "#/definitions/evalResult"
+              "type": "array",
+              "items": {
+                "type": "string",
+                "minLength": 1
+              }
             }
           },
           "default": {
@@ -2847,26 +5699,19 @@ func init() {
         }
       }
     },
-    "/evaluation/batch": {
+    "/flags/snapshots/max_id": {
       "get": {
+        "description": "Returns the highest (most recent) flag_snapshot id. flag_snapshot is an append-only changelog — every flag configuration mutation (create, update, delete, restore of flags, segments, variants, constraints, distributions, or tags) inserts a new row with a monotonically increasing id. This makes the max id a lightweight single source of truth for detecting any configuration change.\nConsumers can store this value and poll it periodically: if the value changes, at least one flag's configuration has been modified and caches should be invalidated. This is vastly more efficient than polling the flags list or individual flags.\n",
         "tags": [
-          "evaluation"
-        ],
-        "operationId": "getEvaluationBatch",
-        "parameters": [
-          {
-            "type": "string",
-            "description": "Percent-encoded (URI-encoded) JSON matching POST /evaluation/batch body (evaluationBatchRequest). Example: ?json=%7B%22entities%22%3A%5B%7B%22entityID%22%3A%22u1%22%7D%5D%2C%22flagIDs%22%3A%5B1%5D%7D decodes to {\"entities\":[{\"entityID\":\"u1\"}],\"flagIDs\":[1]}.",
-            "name": "json",
-            "in": "query",
-            "required": true
-          }
+          "flag"
         ],
+        "summary": "Get the max flag snapshot ID (config change version)",
+        "operationId": "getFlagSnapshotMaxID",
         "responses": {
           "200": {
-            "description": "evaluation batch result",
+            "description": "returns the current max flag_snapshot id; poll this value to detect flag configuration changes for cache invalidation",
             "schema": {
-              "$ref": "#/definitions/evaluationBatchResponse"
+              "$ref": "#/definitions/flagSnapshotMaxID"
             }
           },
           "default": {
@@ -2876,28 +5721,30 @@ func init() {
             }
           }
         }
-      },
-      "post": {
+      }
+    },
+    "/flags/{flagID}": {
+      "get": {
         "tags": [
-          "evaluation"
+          "flag"
         ],
-        "operationId": "postEvaluationBatch",
+        "operationId": "getFlag",
         "parameters": [
           {
-            "description": "evaluation batch request",
-            "name": "body",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/evaluationBatchRequest"
-            }
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to get",
+            "name": "flagID",
+            "in": "path",
+            "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "evaluation batch result",
+            "description": "returns the flag",
             "schema": {
-              "$ref": "#/definitions/evaluationBatchResponse"
+              "$ref": "#/definitions/flag"
             }
           },
           "default": {
@@ -2907,76 +5754,37 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/export/eval_cache/json": {
-      "get": {
-        "description": "Export JSON format of the eval cache dump, with optional filtering",
-        "produces": [
-          "application/json"
-        ],
+      },
+      "put": {
         "tags": [
-          "export"
+          "flag"
         ],
-        "operationId": "getExportEvalCacheJSON",
+        "operationId": "putFlag",
         "parameters": [
           {
-            "type": "array",
-            "items": {
-              "minimum": 1,
-              "type": "integer",
-              "format": "int64"
-            },
-            "collectionFormat": "csv",
-            "description": "CSV of flag IDs to include (e.g. 1,2,3). When provided, keys/enabled/tags are ignored.",
-            "name": "ids",
-            "in": "query"
-          },
-          {
-            "type": "array",
-            "items": {
-              "minLength": 1,
-              "type": "string"
-            },
-            "collectionFormat": "csv",
-            "description": "CSV of flag keys to include (e.g. one,two). When provided, enabled/tags are ignored.",
-            "name": "keys",
-            "in": "query"
-          },
-          {
-            "type": "boolean",
-            "description": "Filter by enabled status (omit to return all)",
-            "name": "enabled",
-            "in": "query"
-          },
-          {
-            "type": "array",
-            "items": {
-              "minLength": 1,
-              "type": "string"
-            },
-            "collectionFormat": "csv",
-            "description": "CSV of tag values to filter by (e.g. foo,bar)",
-            "name": "tags",
-            "in": "query"
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to get",
+            "name": "flagID",
+            "in": "path",
+            "required": true
           },
           {
-            "enum": [
-              "ANY",
-              "ALL"
-            ],
-            "type": "string",
-            "default": "ANY",
-            "description": "Tag matching operator: ANY (default) returns flags with any of the tags, ALL returns flags with all tags",
-            "name": "tagsOperator",
-            "in": "query"
+            "description": "update a flag",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/putFlagRequest"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "OK",
+            "description": "returns the flag",
             "schema": {
-              "type": "object"
+              "$ref": "#/definitions/flag"
             }
           },
           "default": {
@@ -2985,33 +5793,27 @@ func init() {
               "$ref": "#/definitions/error"
             }
           }
-        }
-      }
-    },
-    "/export/sqlite": {
-      "get": {
-        "description": "Export sqlite3 format of the db dump, which is converted from the main database.",
-        "produces": [
-          "application/octet-stream"
-        ],
+        }
+      },
+      "delete": {
         "tags": [
-          "export"
+          "flag"
         ],
-        "operationId": "getExportSqlite",
+        "operationId": "deleteFlag",
         "parameters": [
           {
-            "type": "boolean",
-            "description": "export without snapshots data - useful for smaller db without snapshots\n",
-            "name": "exclude_snapshots",
-            "in": "query"
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "OK",
-            "schema": {
-              "type": "file"
-            }
+            "description": "OK deleted"
           },
           "default": {
             "description": "generic error response",
@@ -3022,35 +5824,36 @@ func init() {
         }
       }
     },
-    "/exposures": {
+    "/flags/{flagID}/duplicate": {
       "post": {
-        "description": "Log when a user actually saw a flag or variant (impression), typically after POST /evaluation.\nIntegrates with the data recorder when FLAGR_RECORDER_ENABLED and per-flag dataRecordsEnabled are set.\n",
         "tags": [
-          "exposure"
+          "flag"
         ],
-        "summary": "Log exposures (batch)",
-        "operationId": "postExposures",
+        "operationId": "duplicateFlag",
         "parameters": [
           {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to duplicate",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "optional overrides for the new flag",
             "name": "body",
             "in": "body",
-            "required": true,
             "schema": {
-              "$ref": "#/definitions/exposuresRequest"
+              "$ref": "#/definitions/duplicateFlagRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "exposures accepted (partial success possible)",
-            "schema": {
-              "$ref": "#/definitions/exposuresResponse"
-            }
-          },
-          "400": {
-            "description": "invalid request",
+            "description": "returns the duplicated flag with preloaded segments, variants, and tags",
             "schema": {
-              "$ref": "#/definitions/error"
+              "$ref": "#/definitions/flag"
             }
           },
           "default": {
@@ -3062,78 +5865,37 @@ func init() {
         }
       }
     },
-    "/flags": {
-      "get": {
+    "/flags/{flagID}/enabled": {
+      "put": {
         "tags": [
           "flag"
         ],
-        "operationId": "findFlags",
+        "operationId": "setFlagEnabled",
         "parameters": [
           {
+            "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "the numbers of flags to return",
-            "name": "limit",
-            "in": "query"
-          },
-          {
-            "type": "boolean",
-            "description": "return flags having given enabled status",
-            "name": "enabled",
-            "in": "query"
-          },
-          {
-            "type": "string",
-            "description": "return flags exactly matching given description",
-            "name": "description",
-            "in": "query"
-          },
-          {
-            "type": "string",
-            "description": "return flags with the given tags (comma separated)",
-            "name": "tags",
-            "in": "query"
-          },
-          {
-            "type": "string",
-            "description": "return flags partially matching given description",
-            "name": "description_like",
-            "in": "query"
-          },
-          {
-            "type": "string",
-            "description": "return flags matching given key",
-            "name": "key",
-            "in": "query"
-          },
-          {
-            "type": "integer",
-            "format": "int64",
-            "description": "return flags given the offset, it should usually set together with limit",
-            "name": "offset",
-            "in": "query"
-          },
-          {
-            "type": "boolean",
-            "description": "return flags with preloaded segments and variants",
-            "name": "preload",
-            "in": "query"
+            "description": "numeric ID of the flag to get",
+            "name": "flagID",
+            "in": "path",
+            "required": true
           },
           {
-            "type": "boolean",
-            "description": "return all deleted flags",
-            "name": "deleted",
-            "in": "query"
+            "description": "set flag enabled state",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/setFlagEnabledRequest"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "list all the flags",
+            "description": "returns the flag",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/flag"
-              }
+              "$ref": "#/definitions/flag"
             }
           },
           "default": {
@@ -3143,26 +5905,28 @@ func init() {
             }
           }
         }
-      },
-      "post": {
+      }
+    },
+    "/flags/{flagID}/restore": {
+      "put": {
         "tags": [
           "flag"
         ],
-        "operationId": "createFlag",
+        "operationId": "restoreFlag",
         "parameters": [
           {
-            "description": "create a flag",
-            "name": "body",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/createFlagRequest"
-            }
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to get",
+            "name": "flagID",
+            "in": "path",
+            "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "returns the created flag",
+            "description": "returns the flag",
             "schema": {
               "$ref": "#/definitions/flag"
             }
@@ -3176,20 +5940,30 @@ func init() {
         }
       }
     },
-    "/flags/entity_types": {
+    "/flags/{flagID}/segments": {
       "get": {
         "tags": [
-          "flag"
+          "segment"
+        ],
+        "operationId": "findSegments",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to get",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          }
         ],
-        "operationId": "getFlagEntityTypes",
         "responses": {
           "200": {
-            "description": "returns all the FlagEntityTypes",
+            "description": "segments ordered by rank of the flag",
             "schema": {
               "type": "array",
               "items": {
-                "type": "string",
-                "minLength": 1
+                "$ref": "#/definitions/segment"
               }
             }
           },
@@ -3200,21 +5974,37 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/flags/snapshots/max_id": {
-      "get": {
-        "description": "Returns the highest (most recent) flag_snapshot id. flag_snapshot is an append-only changelog — every flag configuration mutation (create, update, delete, restore of flags, segments, variants, constraints, distributions, or tags) inserts a new row with a monotonically increasing id. This makes the max id a lightweight single source of truth for detecting any configuration change.\nConsumers can store this value and poll it periodically: if the value changes, at least one flag's configuration has been modified and caches should be invalidated. This is vastly more efficient than polling the flags list or individual flags.\n",
+      },
+      "post": {
         "tags": [
-          "flag"
+          "segment"
+        ],
+        "operationId": "createSegment",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to get",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "create a segment under a flag",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createSegmentRequest"
+            }
+          }
         ],
-        "summary": "Get the max flag snapshot ID (config change version)",
-        "operationId": "getFlagSnapshotMaxID",
         "responses": {
           "200": {
-            "description": "returns the current max flag_snapshot id; poll this value to detect flag configuration changes for cache invalidation",
+            "description": "segment created",
             "schema": {
-              "$ref": "#/definitions/flagSnapshotMaxID"
+              "$ref": "#/definitions/segment"
             }
           },
           "default": {
@@ -3226,29 +6016,35 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}": {
-      "get": {
+    "/flags/{flagID}/segments/reorder": {
+      "put": {
         "tags": [
-          "flag"
+          "segment"
         ],
-        "operationId": "getFlag",
+        "operationId": "putSegmentsReorder",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag to get",
+            "description": "numeric ID of the flag",
             "name": "flagID",
             "in": "path",
             "required": true
+          },
+          {
+            "description": "reorder segments",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/putSegmentReorderRequest"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "returns the flag",
-            "schema": {
-              "$ref": "#/definitions/flag"
-            }
+            "description": "segments reordered"
           },
           "default": {
             "description": "generic error response",
@@ -3257,37 +6053,48 @@ func init() {
             }
           }
         }
-      },
+      }
+    },
+    "/flags/{flagID}/segments/{segmentID}": {
       "put": {
         "tags": [
-          "flag"
+          "segment"
         ],
-        "operationId": "putFlag",
+        "operationId": "putSegment",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag to get",
+            "description": "numeric ID of the flag",
             "name": "flagID",
             "in": "path",
             "required": true
           },
           {
-            "description": "update a flag",
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "update a segment",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/putFlagRequest"
+              "$ref": "#/definitions/putSegmentRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "returns the flag",
+            "description": "segment updated",
             "schema": {
-              "$ref": "#/definitions/flag"
+              "$ref": "#/definitions/segment"
             }
           },
           "default": {
@@ -3300,9 +6107,9 @@ func init() {
       },
       "delete": {
         "tags": [
-          "flag"
+          "segment"
         ],
-        "operationId": "deleteFlag",
+        "operationId": "deleteSegment",
         "parameters": [
           {
             "minimum": 1,
@@ -3312,11 +6119,20 @@ func init() {
             "name": "flagID",
             "in": "path",
             "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "OK deleted"
+            "description": "deleted"
           },
           "default": {
             "description": "generic error response",
@@ -3327,36 +6143,40 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/duplicate": {
-      "post": {
+    "/flags/{flagID}/segments/{segmentID}/constraints": {
+      "get": {
         "tags": [
-          "flag"
+          "constraint"
         ],
-        "operationId": "duplicateFlag",
+        "operationId": "findConstraints",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag to duplicate",
+            "description": "numeric ID of the flag",
             "name": "flagID",
             "in": "path",
             "required": true
           },
           {
-            "description": "optional overrides for the new flag",
-            "name": "body",
-            "in": "body",
-            "schema": {
-              "$ref": "#/definitions/duplicateFlagRequest"
-            }
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "returns the duplicated flag with preloaded segments, variants, and tags",
+            "description": "constraints under the segment",
             "schema": {
-              "$ref": "#/definitions/flag"
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/constraint"
+              }
             }
           },
           "default": {
@@ -3366,39 +6186,46 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/flags/{flagID}/enabled": {
-      "put": {
+      },
+      "post": {
         "tags": [
-          "flag"
+          "constraint"
         ],
-        "operationId": "setFlagEnabled",
+        "operationId": "createConstraint",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag to get",
+            "description": "numeric ID of the flag",
             "name": "flagID",
             "in": "path",
             "required": true
           },
           {
-            "description": "set flag enabled state",
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "create a constraint",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/setFlagEnabledRequest"
+              "$ref": "#/definitions/createConstraintRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "returns the flag",
+            "description": "the constraint created",
             "schema": {
-              "$ref": "#/definitions/flag"
+              "$ref": "#/definitions/constraint"
             }
           },
           "default": {
@@ -3410,64 +6237,55 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/restore": {
+    "/flags/{flagID}/segments/{segmentID}/constraints/{constraintID}": {
       "put": {
         "tags": [
-          "flag"
+          "constraint"
         ],
-        "operationId": "restoreFlag",
+        "operationId": "putConstraint",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag to get",
+            "description": "numeric ID of the flag",
             "name": "flagID",
             "in": "path",
             "required": true
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "returns the flag",
-            "schema": {
-              "$ref": "#/definitions/flag"
-            }
           },
-          "default": {
-            "description": "generic error response",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
-          }
-        }
-      }
-    },
-    "/flags/{flagID}/segments": {
-      "get": {
-        "tags": [
-          "segment"
-        ],
-        "operationId": "findSegments",
-        "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag to get",
-            "name": "flagID",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the constraint",
+            "name": "constraintID",
             "in": "path",
             "required": true
+          },
+          {
+            "description": "create a constraint",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createConstraintRequest"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "segments ordered by rank of the flag",
+            "description": "constraint just updated",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/segment"
-              }
+              "$ref": "#/definitions/constraint"
             }
           },
           "default": {
@@ -3478,37 +6296,43 @@ func init() {
           }
         }
       },
-      "post": {
+      "delete": {
         "tags": [
-          "segment"
+          "constraint"
         ],
-        "operationId": "createSegment",
+        "operationId": "deleteConstraint",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag to get",
+            "description": "numeric ID of the flag",
             "name": "flagID",
             "in": "path",
             "required": true
           },
           {
-            "description": "create a segment under a flag",
-            "name": "body",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/createSegmentRequest"
-            }
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the constraint",
+            "name": "constraintID",
+            "in": "path",
+            "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "segment created",
-            "schema": {
-              "$ref": "#/definitions/segment"
-            }
+            "description": "deleted"
           },
           "default": {
             "description": "generic error response",
@@ -3519,12 +6343,12 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/segments/reorder": {
-      "put": {
+    "/flags/{flagID}/segments/{segmentID}/distributions": {
+      "get": {
         "tags": [
-          "segment"
+          "distribution"
         ],
-        "operationId": "putSegmentsReorder",
+        "operationId": "findDistributions",
         "parameters": [
           {
             "minimum": 1,
@@ -3536,18 +6360,24 @@ func init() {
             "required": true
           },
           {
-            "description": "reorder segments",
-            "name": "body",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/putSegmentReorderRequest"
-            }
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "segments reordered"
+            "description": "distribution under the segment",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/distribution"
+              }
+            }
           },
           "default": {
             "description": "generic error response",
@@ -3556,14 +6386,13 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/flags/{flagID}/segments/{segmentID}": {
+      },
       "put": {
+        "description": "replace the distribution with the new setting",
         "tags": [
-          "segment"
+          "distribution"
         ],
-        "operationId": "putSegment",
+        "operationId": "putDistributions",
         "parameters": [
           {
             "minimum": 1,
@@ -3584,20 +6413,23 @@ func init() {
             "required": true
           },
           {
-            "description": "update a segment",
+            "description": "array of distributions",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/putSegmentRequest"
+              "$ref": "#/definitions/putDistributionsRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "segment updated",
+            "description": "distribution under the segment",
             "schema": {
-              "$ref": "#/definitions/segment"
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/distribution"
+              }
             }
           },
           "default": {
@@ -3607,35 +6439,58 @@ func init() {
             }
           }
         }
-      },
-      "delete": {
+      }
+    },
+    "/flags/{flagID}/snapshots": {
+      "get": {
         "tags": [
-          "segment"
+          "flag"
         ],
-        "operationId": "deleteSegment",
+        "operationId": "getFlagSnapshots",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag",
+            "description": "numeric ID of the flag to get",
             "name": "flagID",
             "in": "path",
             "required": true
           },
           {
-            "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
-            "in": "path",
-            "required": true
+            "description": "the number of snapshots to return",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "return snapshots given the offset, it should usually set together with limit",
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "ASC",
+              "DESC"
+            ],
+            "type": "string",
+            "description": "sort order",
+            "name": "sort",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "deleted"
+            "description": "returns the flag snapshots",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/flagSnapshot"
+              }
+            }
           },
           "default": {
             "description": "generic error response",
@@ -3646,12 +6501,12 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/segments/{segmentID}/constraints": {
+    "/flags/{flagID}/tags": {
       "get": {
         "tags": [
-          "constraint"
+          "tag"
         ],
-        "operationId": "findConstraints",
+        "operationId": "findTags",
         "parameters": [
           {
             "minimum": 1,
@@ -3661,24 +6516,15 @@ func init() {
             "name": "flagID",
             "in": "path",
             "required": true
-          },
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
-            "in": "path",
-            "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "constraints under the segment",
+            "description": "tag ordered by tagID",
             "schema": {
               "type": "array",
               "items": {
-                "$ref": "#/definitions/constraint"
+                "$ref": "#/definitions/tag"
               }
             }
           },
@@ -3692,9 +6538,9 @@ func init() {
       },
       "post": {
         "tags": [
-          "constraint"
+          "tag"
         ],
-        "operationId": "createConstraint",
+        "operationId": "createTag",
         "parameters": [
           {
             "minimum": 1,
@@ -3706,29 +6552,20 @@ func init() {
             "required": true
           },
           {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "description": "create a constraint",
+            "description": "create a tag",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/createConstraintRequest"
+              "$ref": "#/definitions/createTagRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "the constraint created",
+            "description": "tag just created",
             "schema": {
-              "$ref": "#/definitions/constraint"
+              "$ref": "#/definitions/tag"
             }
           },
           "default": {
@@ -3740,12 +6577,12 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/segments/{segmentID}/constraints/{constraintID}": {
-      "put": {
+    "/flags/{flagID}/tags/{tagID}": {
+      "delete": {
         "tags": [
-          "constraint"
+          "tag"
         ],
-        "operationId": "putConstraint",
+        "operationId": "deleteTag",
         "parameters": [
           {
             "minimum": 1,
@@ -3760,36 +6597,15 @@ func init() {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the constraint",
-            "name": "constraintID",
+            "description": "numeric ID of the tag",
+            "name": "tagID",
             "in": "path",
             "required": true
-          },
-          {
-            "description": "create a constraint",
-            "name": "body",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/createConstraintRequest"
-            }
           }
         ],
         "responses": {
           "200": {
-            "description": "constraint just updated",
-            "schema": {
-              "$ref": "#/definitions/constraint"
-            }
+            "description": "deleted"
           },
           "default": {
             "description": "generic error response",
@@ -3798,12 +6614,14 @@ func init() {
             }
           }
         }
-      },
-      "delete": {
+      }
+    },
+    "/flags/{flagID}/variants": {
+      "get": {
         "tags": [
-          "constraint"
+          "variant"
         ],
-        "operationId": "deleteConstraint",
+        "operationId": "findVariants",
         "parameters": [
           {
             "minimum": 1,
@@ -3813,29 +6631,17 @@ func init() {
             "name": "flagID",
             "in": "path",
             "required": true
-          },
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the constraint",
-            "name": "constraintID",
-            "in": "path",
-            "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "deleted"
+            "description": "variant ordered by variantID",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/variant"
+              }
+            }
           },
           "default": {
             "description": "generic error response",
@@ -3844,14 +6650,12 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/flags/{flagID}/segments/{segmentID}/distributions": {
-      "get": {
+      },
+      "post": {
         "tags": [
-          "distribution"
+          "variant"
         ],
-        "operationId": "findDistributions",
+        "operationId": "createVariant",
         "parameters": [
           {
             "minimum": 1,
@@ -3863,23 +6667,20 @@ func init() {
             "required": true
           },
           {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
-            "in": "path",
-            "required": true
+            "description": "create a variant",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createVariantRequest"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "distribution under the segment",
+            "description": "variant just created",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/distribution"
-              }
+              "$ref": "#/definitions/variant"
             }
           },
           "default": {
@@ -3889,13 +6690,14 @@ func init() {
             }
           }
         }
-      },
+      }
+    },
+    "/flags/{flagID}/variants/{variantID}": {
       "put": {
-        "description": "replace the distribution with the new setting",
         "tags": [
-          "distribution"
+          "variant"
         ],
-        "operationId": "putDistributions",
+        "operationId": "putVariant",
         "parameters": [
           {
             "minimum": 1,
@@ -3910,29 +6712,26 @@ func init() {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
+            "description": "numeric ID of the variant",
+            "name": "variantID",
             "in": "path",
             "required": true
           },
           {
-            "description": "array of distributions",
+            "description": "update a variant",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/putDistributionsRequest"
+              "$ref": "#/definitions/putVariantRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "distribution under the segment",
+            "description": "variant just updated",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/distribution"
-              }
+              "$ref": "#/definitions/variant"
             }
           },
           "default": {
@@ -3942,58 +6741,35 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/flags/{flagID}/snapshots": {
-      "get": {
+      },
+      "delete": {
         "tags": [
-          "flag"
+          "variant"
         ],
-        "operationId": "getFlagSnapshots",
+        "operationId": "deleteVariant",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag to get",
+            "description": "numeric ID of the flag",
             "name": "flagID",
             "in": "path",
             "required": true
           },
           {
+            "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "the number of snapshots to return",
-            "name": "limit",
-            "in": "query"
-          },
-          {
-            "type": "integer",
-            "format": "int64",
-            "description": "return snapshots given the offset, it should usually set together with limit",
-            "name": "offset",
-            "in": "query"
-          },
-          {
-            "enum": [
-              "ASC",
-              "DESC"
-            ],
-            "type": "string",
-            "description": "sort order",
-            "name": "sort",
-            "in": "query"
+            "description": "numeric ID of the variant",
+            "name": "variantID",
+            "in": "path",
+            "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "returns the flag snapshots",
-            "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/flagSnapshot"
-              }
-            }
+            "description": "deleted"
           },
           "default": {
             "description": "generic error response",
@@ -4004,30 +6780,43 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/tags": {
+    "/health": {
       "get": {
+        "description": "Check if Flagr is healthy",
         "tags": [
-          "tag"
+          "health"
         ],
-        "operationId": "findTags",
-        "parameters": [
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
-            "in": "path",
-            "required": true
+        "operationId": "getHealth",
+        "responses": {
+          "200": {
+            "description": "status of health check",
+            "schema": {
+              "$ref": "#/definitions/health"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
+        }
+      }
+    },
+    "/metrics": {
+      "get": {
+        "description": "List all metric definitions",
+        "tags": [
+          "metric"
         ],
+        "operationId": "findMetrics",
         "responses": {
           "200": {
-            "description": "tag ordered by tagID",
+            "description": "list all the metrics",
             "schema": {
               "type": "array",
               "items": {
-                "$ref": "#/definitions/tag"
+                "$ref": "#/definitions/metric"
               }
             }
           },
@@ -4040,35 +6829,27 @@ func init() {
         }
       },
       "post": {
+        "description": "Define a metric that events can be sent for with POST /metrics/events",
         "tags": [
-          "tag"
+          "metric"
         ],
-        "operationId": "createTag",
+        "operationId": "createMetric",
         "parameters": [
           {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "description": "create a tag",
+            "description": "create a metric",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/createTagRequest"
+              "$ref": "#/definitions/createMetricRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "tag just created",
+            "description": "metric just created",
             "schema": {
-              "$ref": "#/definitions/tag"
+              "$ref": "#/definitions/metric"
             }
           },
           "default": {
@@ -4080,35 +6861,36 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/tags/{tagID}": {
-      "delete": {
+    "/metrics/events": {
+      "post": {
+        "description": "Log outcomes of entities, e.g. conversions or purchase amounts, for defined metrics.\nDatar attributes each event to the variants the entity was assigned by evaluations\nor exposures of flags with dataRecordsEnabled. Requires the datar data recorder.\n",
         "tags": [
-          "tag"
+          "metric"
         ],
-        "operationId": "deleteTag",
+        "summary": "Log metric events (batch)",
+        "operationId": "postMetricEvents",
         "parameters": [
           {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the tag",
-            "name": "tagID",
-            "in": "path",
-            "required": true
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/metricEventsRequest"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "deleted"
+            "description": "metric events accepted (partial success possible)",
+            "schema": {
+              "$ref": "#/definitions/metricEventsResponse"
+            }
+          },
+          "400": {
+            "description": "invalid request",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           },
           "default": {
             "description": "generic error response",
@@ -4119,31 +6901,37 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/variants": {
-      "get": {
+    "/metrics/{metricID}": {
+      "put": {
         "tags": [
-          "variant"
+          "metric"
         ],
-        "operationId": "findVariants",
+        "operationId": "putMetric",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
+            "description": "numeric ID of the metric",
+            "name": "metricID",
             "in": "path",
             "required": true
+          },
+          {
+            "description": "update a metric",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/putMetricRequest"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "variant ordered by variantID",
+            "description": "metric just updated",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/variant"
-              }
+              "$ref": "#/definitions/metric"
             }
           },
           "default": {
@@ -4154,37 +6942,25 @@ func init() {
           }
         }
       },
-      "post": {
+      "delete": {
         "tags": [
-          "variant"
+          "metric"
         ],
-        "operationId": "createVariant",
+        "operationId": "deleteMetric",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
+            "description": "numeric ID of the metric",
+            "name": "metricID",
             "in": "path",
             "required": true
-          },
-          {
-            "description": "create a variant",
-            "name": "body",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/createVariantRequest"
-            }
           }
         ],
         "responses": {
           "200": {
-            "description": "variant just created",
-            "schema": {
-              "$ref": "#/definitions/variant"
-            }
+            "description": "deleted"
           },
           "default": {
             "description": "generic error response",
@@ -4195,46 +6971,54 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/variants/{variantID}": {
-      "put": {
+    "/ofrep/v1/evaluate/flags": {
+      "post": {
+        "description": "OpenFeature Remote Evaluation Protocol (OFREP) evaluation of every flag for one evaluation context, used by\nclient-side providers. The response carries an ETag; send it back as If-None-Match to get 304 Not Modified\nwhile the results are unchanged.\n",
         "tags": [
-          "variant"
+          "ofrep"
         ],
-        "operationId": "putVariant",
+        "summary": "OFREP bulk flag evaluation",
+        "operationId": "ofrepEvaluateFlagsBulk",
         "parameters": [
           {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the variant",
-            "name": "variantID",
-            "in": "path",
-            "required": true
+            "type": "string",
+            "description": "ETag of a previous bulk response",
+            "name": "If-None-Match",
+            "in": "header"
           },
           {
-            "description": "update a variant",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/putVariantRequest"
+              "$ref": "#/definitions/ofrepEvaluationRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "variant just updated",
+            "description": "all flags evaluated",
             "schema": {
-              "$ref": "#/definitions/variant"
+              "$ref": "#/definitions/ofrepBulkEvaluationSuccess"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string"
+              }
+            }
+          },
+          "304": {
+            "description": "results did not change since the ETag in If-None-Match",
+            "headers": {
+              "ETag": {
+                "type": "string"
+              }
+            }
+          },
+          "400": {
+            "description": "invalid evaluation context",
+            "schema": {
+              "$ref": "#/definitions/ofrepBulkEvaluationFailure"
             }
           },
           "default": {
@@ -4244,57 +7028,51 @@ func init() {
             }
           }
         }
-      },
-      "delete": {
+      }
+    },
+    "/ofrep/v1/evaluate/flags/{key}": {
+      "post": {
+        "description": "OpenFeature Remote Evaluation Protocol (OFREP) evaluation of a single flag by key.\nPoint an OFREP provider at the Flagr API base URL (e.g. http://flagr:18000/api/v1).\ncontext.targetingKey is used as the entityID; every other context attribute is passed as entityContext.\n",
         "tags": [
-          "variant"
+          "ofrep"
         ],
-        "operationId": "deleteVariant",
+        "summary": "OFREP single flag evaluation",
+        "operationId": "ofrepEvaluateFlag",
         "parameters": [
           {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
+            "minLength": 1,
+            "type": "string",
+            "description": "flag key",
+            "name": "key",
             "in": "path",
             "required": true
           },
           {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the variant",
-            "name": "variantID",
-            "in": "path",
-            "required": true
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/ofrepEvaluationRequest"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "deleted"
+            "description": "flag evaluated",
+            "schema": {
+              "$ref": "#/definitions/ofrepEvaluationSuccess"
+            }
           },
-          "default": {
-            "description": "generic error response",
+          "400": {
+            "description": "invalid evaluation context",
             "schema": {
-              "$ref": "#/definitions/error"
+              "$ref": "#/definitions/ofrepEvaluationFailure"
             }
-          }
-        }
-      }
-    },
-    "/health": {
-      "get": {
-        "description": "Check if Flagr is healthy",
-        "tags": [
-          "health"
-        ],
-        "operationId": "getHealth",
-        "responses": {
-          "200": {
-            "description": "status of health check",
+          },
+          "404": {
+            "description": "flag not found",
             "schema": {
-              "$ref": "#/definitions/health"
+              "$ref": "#/definitions/ofrepEvaluationFailure"
             }
           },
           "default": {
@@ -4384,11 +7162,19 @@ func init() {
             "IN",
             "NOTIN",
             "CONTAINS",
-            "NOTCONTAINS"
+            "NOTCONTAINS",
+            "ANY_IN",
+            "ALL_IN",
+            "NONE_IN",
+            "STARTS_WITH",
+            "ENDS_WITH",
+            "EQ_CI",
+            "IN_CI",
+            "CONTAINS_CI"
           ]
         },
         "property": {
-          "description": "The property name from the entity context to evaluate. Supports nested field access: use dots (e.g., ` + "`" + `user.name` + "`" + `) for nested objects and brackets (e.g., ` + "`" + `users[0]` + "`" + `) for array indices.\n",
+          "description": "The property name from the entity context to evaluate. Supports nested field access: use dots (e.g., ` + "`" + `user.name` + "`" + `) for nested objects and brackets (e.g., ` + "`" + `users[0]` + "`" + `) for array indices, or a JSON pointer (e.g., ` + "`" + `/user/name` + "`" + `, ` + "`" + `/users/0` + "`" + `). ` + "`" + `@http.x_country` + "`" + ` and ` + "`" + `/@http/x_country` + "`" + ` read the injected HTTP header key ` + "`" + `@http_x_country` + "`" + `.\n",
           "type": "string",
           "minLength": 1
         },
@@ -4398,6 +7184,88 @@ func init() {
         }
       }
     },
+    "constraintDebugLog": {
+      "type": "object",
+      "properties": {
+        "actual": {
+          "description": "the entity context value of property, absent if the property is not in the entity context"
+        },
+        "constraintID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "error": {
+          "description": "why the constraint could not be evaluated, e.g. a type mismatch",
+          "type": "string"
+        },
+        "expected": {
+          "description": "the constraint value, decoded if it is JSON, e.g. the string CA for the value \"CA\""
+        },
+        "matched": {
+          "type": "boolean",
+          "x-omitempty": false
+        },
+        "operator": {
+          "type": "string"
+        },
+        "property": {
+          "type": "string"
+        }
+      }
+    },
+    "constraintGroup": {
+      "type": "object",
+      "required": [
+        "operator"
+      ],
+      "properties": {
+        "constraints": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraint"
+          }
+        },
+        "groups": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraintGroup"
+          }
+        },
+        "operator": {
+          "description": "AND and OR join the constraints and groups; NOT matches when they do not all match",
+          "type": "string",
+          "enum": [
+            "AND",
+            "OR",
+            "NOT"
+          ]
+        }
+      }
+    },
+    "constraintGroupDebugLog": {
+      "type": "object",
+      "properties": {
+        "constraints": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraintDebugLog"
+          }
+        },
+        "groups": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraintGroupDebugLog"
+          }
+        },
+        "matched": {
+          "type": "boolean",
+          "x-omitempty": false
+        },
+        "operator": {
+          "type": "string"
+        }
+      }
+    },
     "createConstraintRequest": {
       "type": "object",
       "required": [
@@ -4411,7 +7279,7 @@ func init() {
           "minLength": 1
         },
         "property": {
-          "description": "The property name from the entity context to evaluate. Supports nested field access: use dots (e.g., ` + "`" + `user.name` + "`" + `) for nested objects and brackets (e.g., ` + "`" + `users[0]` + "`" + `) for array indices.\n",
+          "description": "The property name from the entity context to evaluate. Supports nested field access: use dots (e.g., ` + "`" + `user.name` + "`" + `) for nested objects and brackets (e.g., ` + "`" + `users[0]` + "`" + `) for array indices, or a JSON pointer (e.g., ` + "`" + `/user/name` + "`" + `, ` + "`" + `/users/0` + "`" + `). ` + "`" + `@http.x_country` + "`" + ` and ` + "`" + `/@http/x_country` + "`" + ` read the injected HTTP header key ` + "`" + `@http_x_country` + "`" + `.\n",
           "type": "string",
           "minLength": 1
         },
@@ -4424,20 +7292,38 @@ func init() {
     "createFlagRequest": {
       "type": "object",
       "required": [
-        "description"
+        "description"
+      ],
+      "properties": {
+        "description": {
+          "type": "string",
+          "minLength": 1
+        },
+        "key": {
+          "description": "unique key representation of the flag",
+          "type": "string"
+        },
+        "template": {
+          "description": "template for flag creation",
+          "type": "string"
+        }
+      }
+    },
+    "createMetricRequest": {
+      "type": "object",
+      "required": [
+        "key"
       ],
       "properties": {
         "description": {
-          "type": "string",
-          "minLength": 1
+          "type": "string"
         },
         "key": {
-          "description": "unique key representation of the flag",
-          "type": "string"
+          "type": "string",
+          "minLength": 1
         },
-        "template": {
-          "description": "template for flag creation",
-          "type": "string"
+        "kind": {
+          "$ref": "#/definitions/metricKind"
         }
       }
     },
@@ -4448,6 +7334,13 @@ func init() {
         "rolloutPercent"
       ],
       "properties": {
+        "constraintGroups": {
+          "description": "constraint groups combining constraints with AND, OR and NOT. The segment matches when all its constraints and all its constraint groups match.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraintGroup"
+          }
+        },
         "description": {
           "type": "string",
           "minLength": 1
@@ -4494,36 +7387,425 @@ func init() {
           "type": "integer",
           "format": "int64"
         },
-        "date": {
-          "type": "string",
-          "format": "date"
+        "date": {
+          "type": "string",
+          "format": "date"
+        },
+        "uniqueEntities": {
+          "description": "estimated distinct entities evaluated, from HyperLogLog sketches",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "datarDimensionEntry": {
+      "type": "object",
+      "properties": {
+        "count": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "value": {
+          "type": "string"
+        }
+      }
+    },
+    "datarExperimentOutcome": {
+      "type": "object",
+      "required": [
+        "variantID"
+      ],
+      "properties": {
+        "successes": {
+          "description": "trials with a success, for conversion metrics",
+          "type": "integer",
+          "format": "int64"
+        },
+        "valueSum": {
+          "description": "sum of values, for value metrics",
+          "type": "number",
+          "format": "double"
+        },
+        "valueSumSquares": {
+          "description": "sum of the squared value of each trial, for value metrics",
+          "type": "number",
+          "format": "double"
+        },
+        "variantID": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "datarExperimentRequest": {
+      "type": "object",
+      "required": [
+        "controlVariantID"
+      ],
+      "properties": {
+        "alpha": {
+          "description": "Significance level, intervals are at level 1-alpha (default 0.05)",
+          "type": "number",
+          "format": "double"
+        },
+        "bayesian": {
+          "description": "Add the posterior probability of each variant to beat the control",
+          "type": "boolean"
+        },
+        "controlVariantID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "denominator": {
+          "description": "Trials of each variant. Default assignments with metricKey, evaluations with variants.",
+          "type": "string",
+          "enum": [
+            "evaluations",
+            "assignments"
+          ]
+        },
+        "from": {
+          "description": "Start time (RFC 3339, default 7 days ago)",
+          "type": "string",
+          "format": "date-time"
+        },
+        "kind": {
+          "$ref": "#/definitions/metricKind"
+        },
+        "metricKey": {
+          "description": "Analyze the recorded events of this metric. Exclusive with variants.",
+          "type": "string"
+        },
+        "sequential": {
+          "description": "Return always-valid p-values and intervals, safe to check repeatedly",
+          "type": "boolean"
+        },
+        "to": {
+          "description": "End time (RFC 3339, default now)",
+          "type": "string",
+          "format": "date-time"
+        },
+        "variants": {
+          "description": "Outcomes per variant, e.g. from a pipeline. Exclusive with metricKey.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/datarExperimentOutcome"
+          }
+        }
+      }
+    },
+    "datarExperimentResponse": {
+      "type": "object",
+      "properties": {
+        "alpha": {
+          "type": "number",
+          "format": "double"
+        },
+        "bayesian": {
+          "type": "boolean"
+        },
+        "controlVariantID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "denominator": {
+          "type": "string"
+        },
+        "flagID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "kind": {
+          "$ref": "#/definitions/metricKind"
+        },
+        "metricKey": {
+          "type": "string"
+        },
+        "sequential": {
+          "type": "boolean"
+        },
+        "test": {
+          "type": "string",
+          "enum": [
+            "two_proportion_z",
+            "welch_t"
+          ]
+        },
+        "variants": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/datarExperimentVariant"
+          }
+        }
+      }
+    },
+    "datarExperimentVariant": {
+      "type": "object",
+      "properties": {
+        "control": {
+          "type": "boolean"
+        },
+        "credibleInterval": {
+          "$ref": "#/definitions/datarInterval"
+        },
+        "degreesOfFreedom": {
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
+        },
+        "difference": {
+          "description": "mean minus the mean of the control",
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
+        },
+        "differenceCI": {
+          "$ref": "#/definitions/datarInterval"
+        },
+        "error": {
+          "description": "why the variant could not be compared to the control",
+          "type": "string",
+          "x-omitempty": true
+        },
+        "lift": {
+          "description": "difference relative to the mean of the control",
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
+        },
+        "liftCI": {
+          "$ref": "#/definitions/datarInterval"
+        },
+        "mean": {
+          "description": "success rate, or mean value per trial",
+          "type": "number",
+          "format": "double"
+        },
+        "meanCI": {
+          "$ref": "#/definitions/datarInterval"
+        },
+        "pValue": {
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
+        },
+        "probabilityToBeatControl": {
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
+        },
+        "significant": {
+          "description": "pValue below alpha",
+          "type": "boolean"
+        },
+        "statistic": {
+          "description": "z or t statistic",
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
+        },
+        "successes": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "trials": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "variantID": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "datarFlagMetricsResponse": {
+      "type": "object",
+      "properties": {
+        "flagID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "variants": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/datarVariantMetrics"
+          }
+        }
+      }
+    },
+    "datarFlagSummaryResponse": {
+      "type": "object",
+      "properties": {
+        "flagID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "sampleRatio": {
+          "$ref": "#/definitions/datarSampleRatio"
+        },
+        "trafficByDay": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/datarDayEntry"
+          }
+        },
+        "trafficBySegment": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/datarSegmentEntry"
+          }
+        },
+        "trafficByVariant": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/datarVariantEntry"
+          }
+        },
+        "uniqueEntities": {
+          "description": "estimated distinct entities evaluated, from HyperLogLog sketches",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "datarInterval": {
+      "type": "object",
+      "properties": {
+        "lower": {
+          "type": "number",
+          "format": "double"
+        },
+        "upper": {
+          "type": "number",
+          "format": "double"
+        }
+      }
+    },
+    "datarMetricEntry": {
+      "type": "object",
+      "properties": {
+        "conversions": {
+          "description": "entities with at least one event",
+          "type": "integer",
+          "format": "int64"
+        },
+        "events": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "metricKey": {
+          "type": "string"
+        },
+        "valueSum": {
+          "type": "number",
+          "format": "double"
+        },
+        "valueSumSquares": {
+          "description": "sum of the squared total value of each converting entity in the time range",
+          "type": "number",
+          "format": "double"
+        }
+      }
+    },
+    "datarSampleRatio": {
+      "description": "sample ratio mismatch check of the evaluations of each segment against its distribution and rollout",
+      "type": "object",
+      "properties": {
+        "alpha": {
+          "type": "number",
+          "format": "double"
+        },
+        "degreesOfFreedom": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "mismatch": {
+          "description": "pValue below alpha",
+          "type": "boolean"
+        },
+        "pValue": {
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
+        },
+        "segments": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/datarSampleRatioSegment"
+          }
+        },
+        "statistic": {
+          "description": "chi-squared statistic summed over the tested segments",
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
+        }
+      }
+    },
+    "datarSampleRatioSegment": {
+      "type": "object",
+      "properties": {
+        "degreesOfFreedom": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "error": {
+          "description": "why the segment was not tested",
+          "type": "string",
+          "x-omitempty": true
+        },
+        "mismatch": {
+          "type": "boolean"
+        },
+        "pValue": {
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
+        },
+        "rolloutPercent": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "segmentID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "statistic": {
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
+        },
+        "total": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "variants": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/datarSampleRatioVariant"
+          }
         }
       }
     },
-    "datarFlagSummaryResponse": {
+    "datarSampleRatioVariant": {
       "type": "object",
       "properties": {
-        "flagID": {
-          "type": "integer",
-          "format": "int64"
+        "expected": {
+          "type": "number",
+          "format": "double"
         },
-        "trafficByDay": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/datarDayEntry"
-          }
+        "expectedShare": {
+          "type": "number",
+          "format": "double"
         },
-        "trafficBySegment": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/datarSegmentEntry"
-          }
+        "observed": {
+          "type": "integer",
+          "format": "int64"
         },
-        "trafficByVariant": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/datarVariantEntry"
-          }
+        "variantID": {
+          "type": "integer",
+          "format": "int64"
         }
       }
     },
@@ -4537,6 +7819,11 @@ func init() {
         "segmentID": {
           "type": "integer",
           "format": "int64"
+        },
+        "uniqueEntities": {
+          "description": "estimated distinct entities evaluated, from HyperLogLog sketches",
+          "type": "integer",
+          "format": "int64"
         }
       }
     },
@@ -4563,6 +7850,11 @@ func init() {
         "totalEvalCount": {
           "type": "integer",
           "format": "int64"
+        },
+        "uniqueEntities": {
+          "description": "estimated distinct entities evaluated, from HyperLogLog sketches",
+          "type": "integer",
+          "format": "int64"
         }
       }
     },
@@ -4577,6 +7869,117 @@ func init() {
         }
       }
     },
+    "datarTagFlagEntry": {
+      "type": "object",
+      "properties": {
+        "count": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "flagID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "flagKey": {
+          "type": "string"
+        }
+      }
+    },
+    "datarTagSummaryResponse": {
+      "type": "object",
+      "properties": {
+        "byEntityType": {
+          "description": "records by entity type, empty when the entity_type dimension is not tracked",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/datarDimensionEntry"
+          }
+        },
+        "byRecordSource": {
+          "description": "records by record source, empty when the record_source dimension is not tracked",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/datarDimensionEntry"
+          }
+        },
+        "flags": {
+          "description": "tagged flags with records, by count descending",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/datarTagFlagEntry"
+          }
+        },
+        "tag": {
+          "type": "string"
+        },
+        "total": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "datarTimeSeries": {
+      "type": "object",
+      "properties": {
+        "counts": {
+          "description": "evaluations by bucket, 0 for buckets without evaluations",
+          "type": "array",
+          "items": {
+            "type": "integer",
+            "format": "int64"
+          }
+        },
+        "segmentID": {
+          "description": "set when broken down by segment, 0 for evaluations without a segment",
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        },
+        "total": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "variantID": {
+          "description": "set when broken down by variant",
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        }
+      }
+    },
+    "datarTimeSeriesResponse": {
+      "type": "object",
+      "properties": {
+        "buckets": {
+          "description": "start of each time bucket, in the time zone",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "format": "date-time"
+          }
+        },
+        "flagID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "granularity": {
+          "type": "string",
+          "enum": [
+            "hour",
+            "day"
+          ]
+        },
+        "series": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/datarTimeSeries"
+          }
+        },
+        "timezone": {
+          "type": "string"
+        }
+      }
+    },
     "datarVariantEntry": {
       "type": "object",
       "properties": {
@@ -4584,6 +7987,31 @@ func init() {
           "type": "integer",
           "format": "int64"
         },
+        "uniqueEntities": {
+          "description": "estimated distinct entities evaluated, from HyperLogLog sketches",
+          "type": "integer",
+          "format": "int64"
+        },
+        "variantID": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "datarVariantMetrics": {
+      "type": "object",
+      "properties": {
+        "exposures": {
+          "description": "entities first assigned the variant in the time range",
+          "type": "integer",
+          "format": "int64"
+        },
+        "metrics": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/datarMetricEntry"
+          }
+        },
         "variantID": {
           "type": "integer",
           "format": "int64"
@@ -4621,6 +8049,35 @@ func init() {
         }
       }
     },
+    "distributionDebugLog": {
+      "type": "object",
+      "properties": {
+        "bucketEnd": {
+          "description": "last bucket of the variant, exclusive",
+          "type": "integer",
+          "format": "int64",
+          "x-omitempty": false
+        },
+        "bucketStart": {
+          "description": "first bucket of the variant, inclusive",
+          "type": "integer",
+          "format": "int64",
+          "x-omitempty": false
+        },
+        "percent": {
+          "type": "integer",
+          "format": "int64",
+          "x-omitempty": false
+        },
+        "variantID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "variantKey": {
+          "type": "string"
+        }
+      }
+    },
     "duplicateFlagRequest": {
       "type": "object",
       "properties": {
@@ -4713,6 +8170,11 @@ func init() {
           "type": "boolean",
           "x-omitempty": true
         },
+        "error": {
+          "description": "set on a batch evaluation result that was not evaluated, e.g. because the batch deadline (FLAGR_EVAL_BATCH_TIMEOUT) passed. The result then only identifies the entity and the flag ID, key or tags.",
+          "type": "string",
+          "x-omitempty": true
+        },
         "evalContext": {
           "$ref": "#/definitions/evalContext"
         },
@@ -4730,6 +8192,10 @@ func init() {
           "type": "integer",
           "format": "int64"
         },
+        "flagSourceRevision": {
+          "description": "revision of the eval-only flag source the flag was loaded from, e.g. the git commit SHA for the json_git driver. Empty when the source has no revision.",
+          "type": "string"
+        },
         "flagTags": {
           "description": "flagTags. flagTags looks up flags by tag. Either works.",
           "type": "array",
@@ -4756,52 +8222,130 @@ func init() {
         "variantAttachment": {
           "type": "object"
         },
-        "variantID": {
-          "type": "integer",
-          "format": "int64"
-        },
+        "variantID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "variantKey": {
+          "type": "string"
+        }
+      }
+    },
+    "evaluationBatchRequest": {
+      "type": "object",
+      "required": [
+        "entities"
+      ],
+      "properties": {
+        "enableDebug": {
+          "type": "boolean"
+        },
+        "entities": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "$ref": "#/definitions/evaluationEntity"
+          }
+        },
+        "flagIDs": {
+          "description": "flagIDs",
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "integer",
+            "format": "int64",
+            "minimum": 1
+          }
+        },
+        "flagKeys": {
+          "description": "flagKeys. Either flagIDs, flagKeys or flagTags works. If pass in multiples, Flagr may return duplicate results.",
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "string",
+            "minLength": 1
+          }
+        },
+        "flagTags": {
+          "description": "flagTags. Either flagIDs, flagKeys or flagTags works. If pass in multiples, Flagr may return duplicate results.",
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "string",
+            "minLength": 1
+          }
+        },
+        "flagTagsOperator": {
+          "description": "determine how flagTags is used to filter flags to be evaluated. OR extends the evaluation to those which contains at least one of the provided flagTags or AND limit the evaluation to those which contains all the flagTags.",
+          "type": "string",
+          "default": "ANY",
+          "enum": [
+            "ANY",
+            "ALL"
+          ]
+        },
+        "workers": {
+          "description": "number of workers evaluating the entity and flag pairs of this request in parallel, the request goroutine included. Defaults to FLAGR_EVAL_BATCH_WORKERS, and is capped by FLAGR_EVAL_BATCH_MAX_WORKERS extra workers shared by all batch requests.",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        }
+      }
+    },
+    "evaluationBatchResponse": {
+      "type": "object",
+      "required": [
+        "evaluationResults"
+      ],
+      "properties": {
+        "evaluationResults": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/evalResult"
+          }
+        },
+        "partial": {
+          "description": "true if some results were not evaluated because the batch deadline passed; those results have an error",
+          "type": "boolean",
+          "x-omitempty": true
+        }
+      }
+    },
+    "evaluationBootstrapFlag": {
+      "type": "object",
+      "properties": {
+        "variantAttachment": {
+          "type": "object"
+        },
         "variantKey": {
           "type": "string"
         }
       }
     },
-    "evaluationBatchRequest": {
+    "evaluationBootstrapRequest": {
       "type": "object",
       "required": [
-        "entities"
+        "entityID"
       ],
       "properties": {
-        "enableDebug": {
-          "type": "boolean"
+        "entityContext": {
+          "type": "object"
         },
-        "entities": {
-          "type": "array",
-          "minItems": 1,
-          "items": {
-            "$ref": "#/definitions/evaluationEntity"
-          }
+        "entityID": {
+          "description": "the entity to evaluate; it's required so that the assignments (and the ETag) are stable",
+          "type": "string",
+          "minLength": 1
         },
-        "flagIDs": {
-          "description": "flagIDs",
-          "type": "array",
-          "minItems": 1,
-          "items": {
-            "type": "integer",
-            "format": "int64",
-            "minimum": 1
-          }
+        "entityType": {
+          "type": "string"
         },
-        "flagKeys": {
-          "description": "flagKeys. Either flagIDs, flagKeys or flagTags works. If pass in multiples, Flagr may return duplicate results.",
-          "type": "array",
-          "minItems": 1,
-          "items": {
-            "type": "string",
-            "minLength": 1
-          }
+        "flagEntityType": {
+          "description": "only evaluate flags with this entityType",
+          "type": "string",
+          "minLength": 1
         },
         "flagTags": {
-          "description": "flagTags. Either flagIDs, flagKeys or flagTags works. If pass in multiples, Flagr may return duplicate results.",
+          "description": "only evaluate flags with these tags",
           "type": "array",
           "minItems": 1,
           "items": {
@@ -4810,7 +8354,7 @@ func init() {
           }
         },
         "flagTagsOperator": {
-          "description": "determine how flagTags is used to filter flags to be evaluated. OR extends the evaluation to those which contains at least one of the provided flagTags or AND limit the evaluation to those which contains all the flagTags.",
+          "description": "determine how flagTags is used to filter flags to be evaluated. ANY keeps flags which contain at least one of the provided flagTags, ALL keeps flags which contain all of them.",
           "type": "string",
           "default": "ANY",
           "enum": [
@@ -4820,16 +8364,17 @@ func init() {
         }
       }
     },
-    "evaluationBatchResponse": {
+    "evaluationBootstrapResponse": {
       "type": "object",
       "required": [
-        "evaluationResults"
+        "flags"
       ],
       "properties": {
-        "evaluationResults": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/evalResult"
+        "flags": {
+          "description": "variant assignments by flag key",
+          "type": "object",
+          "additionalProperties": {
+            "$ref": "#/definitions/evaluationBootstrapFlag"
           }
         }
       }
@@ -4848,6 +8393,141 @@ func init() {
         }
       }
     },
+    "evaluationPreviewRequest": {
+      "type": "object",
+      "required": [
+        "flag",
+        "evalContexts"
+      ],
+      "properties": {
+        "evalContexts": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "$ref": "#/definitions/evalContext"
+          }
+        },
+        "flag": {
+          "description": "flag definition in the JSON flag spec format, as used by the json_file and file_dir drivers and GET /export/eval_cache/json. A flag as returned by GET /flags/{flagID} works too. Missing IDs are assigned.",
+          "type": "object"
+        }
+      }
+    },
+    "evaluationPreviewResponse": {
+      "type": "object",
+      "required": [
+        "evalResults"
+      ],
+      "properties": {
+        "evalResults": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/evalResult"
+          }
+        },
+        "warnings": {
+          "description": "validation warnings about the flag definition, e.g. segments without distributions",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "evaluationSimulationRequest": {
+      "type": "object",
+      "properties": {
+        "contexts": {
+          "description": "corpus of eval contexts, e.g. sampled from production. Set either contexts or synthetic.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/evalContext"
+          }
+        },
+        "flag": {
+          "description": "proposed flag definition, in the same formats as evaluationPreviewRequest.flag. If a stored flag is given too, the proposed flag takes its ID, so entities keep their buckets.",
+          "type": "object"
+        },
+        "flagID": {
+          "description": "stored flag to simulate, or to compare the proposed flag with",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "flagKey": {
+          "description": "stored flag to simulate, or to compare the proposed flag with",
+          "type": "string"
+        },
+        "switchedLimit": {
+          "description": "maximum number of entities listed in switched",
+          "type": "integer",
+          "format": "int64",
+          "default": 100,
+          "minimum": 0
+        },
+        "synthetic": {
+          "$ref": "#/definitions/simulationSyntheticCorpus"
+        }
+      }
+    },
+    "evaluationSimulationResponse": {
+      "type": "object",
+      "required": [
+        "total",
+        "segments",
+        "variants",
+        "unassigned"
+      ],
+      "properties": {
+        "currentSegments": {
+          "description": "segments of the stored flag, when a proposed flag is compared with it",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/simulationSegment"
+          }
+        },
+        "segments": {
+          "description": "segments of the simulated flag in rank order, with the contexts that matched their constraints",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/simulationSegment"
+          }
+        },
+        "switched": {
+          "description": "entities assigned a different variant than by the stored flag, up to switchedLimit",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/simulationSwitch"
+          }
+        },
+        "switchedTotal": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "total": {
+          "description": "number of eval contexts simulated",
+          "type": "integer",
+          "format": "int64"
+        },
+        "unassigned": {
+          "$ref": "#/definitions/simulationVariant"
+        },
+        "variants": {
+          "description": "assignments per variant key, including keys only present in the stored flag",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/simulationVariant"
+          }
+        },
+        "warnings": {
+          "description": "validation warnings about the proposed flag",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
     "exposure": {
       "type": "object",
       "required": [
@@ -4960,87 +8640,285 @@ func init() {
           "description": "it will override the entityType in the evaluation logs if it's not empty",
           "type": "string"
         },
-        "id": {
-          "type": "integer",
-          "format": "int64",
-          "minimum": 1,
-          "readOnly": true
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "key": {
+          "description": "unique key representation of the flag",
+          "type": "string",
+          "minLength": 1
+        },
+        "notes": {
+          "description": "flag usage details in markdown format",
+          "type": "string"
+        },
+        "segments": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/segment"
+          }
+        },
+        "tags": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/tag"
+          }
+        },
+        "updatedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "updatedBy": {
+          "type": "string"
+        },
+        "variants": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/variant"
+          }
+        }
+      }
+    },
+    "flagSnapshot": {
+      "type": "object",
+      "required": [
+        "flag",
+        "updatedAt"
+      ],
+      "properties": {
+        "flag": {
+          "$ref": "#/definitions/flag"
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "updatedAt": {
+          "type": "string",
+          "minLength": 1
+        },
+        "updatedBy": {
+          "type": "string"
+        }
+      }
+    },
+    "flagSnapshotMaxID": {
+      "type": "object",
+      "properties": {
+        "maxID": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "health": {
+      "type": "object",
+      "properties": {
+        "flagSourceRevision": {
+          "description": "revision of the flag source currently loaded into the evaluation cache, e.g. the git commit SHA for the json_git driver. Empty when the source has no revision.",
+          "type": "string"
+        },
+        "status": {
+          "type": "string"
+        }
+      }
+    },
+    "metric": {
+      "type": "object",
+      "required": [
+        "key",
+        "kind"
+      ],
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "key": {
+          "type": "string",
+          "minLength": 1
+        },
+        "kind": {
+          "$ref": "#/definitions/metricKind"
+        }
+      }
+    },
+    "metricEvent": {
+      "type": "object",
+      "required": [
+        "entityID",
+        "metricKey"
+      ],
+      "properties": {
+        "entityID": {
+          "type": "string"
+        },
+        "metricKey": {
+          "type": "string"
         },
-        "key": {
-          "description": "unique key representation of the flag",
+        "timestamp": {
+          "description": "Time of the event (default now)",
           "type": "string",
-          "minLength": 1
+          "format": "date-time"
         },
-        "notes": {
-          "description": "flag usage details in markdown format",
-          "type": "string"
+        "value": {
+          "description": "Numeric value of the event, required for value metrics",
+          "type": "number",
+          "format": "double",
+          "x-nullable": true
+        }
+      }
+    },
+    "metricEventRowError": {
+      "type": "object",
+      "properties": {
+        "index": {
+          "type": "integer",
+          "format": "int64"
         },
-        "segments": {
+        "message": {
+          "type": "string"
+        }
+      }
+    },
+    "metricEventsRequest": {
+      "type": "object",
+      "required": [
+        "events"
+      ],
+      "properties": {
+        "events": {
           "type": "array",
+          "minItems": 1,
           "items": {
-            "$ref": "#/definitions/segment"
+            "$ref": "#/definitions/metricEvent"
           }
+        }
+      }
+    },
+    "metricEventsResponse": {
+      "type": "object",
+      "properties": {
+        "acceptedCount": {
+          "type": "integer",
+          "format": "int64"
         },
-        "tags": {
+        "errors": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/tag"
+            "$ref": "#/definitions/metricEventRowError"
           }
-        },
-        "updatedAt": {
+        }
+      }
+    },
+    "metricKind": {
+      "description": "conversion for events that count whether an entity converted, value for events with a numeric value, e.g. revenue",
+      "type": "string",
+      "enum": [
+        "conversion",
+        "value"
+      ]
+    },
+    "ofrepBulkEvaluationFailure": {
+      "type": "object",
+      "properties": {
+        "errorCode": {
           "type": "string",
-          "format": "date-time"
+          "enum": [
+            "PARSE_ERROR",
+            "INVALID_CONTEXT",
+            "GENERAL"
+          ]
         },
-        "updatedBy": {
+        "errorDetails": {
           "type": "string"
-        },
-        "variants": {
+        }
+      }
+    },
+    "ofrepBulkEvaluationSuccess": {
+      "type": "object",
+      "properties": {
+        "flags": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/variant"
+            "$ref": "#/definitions/ofrepEvaluationSuccess"
           }
         }
       }
     },
-    "flagSnapshot": {
+    "ofrepEvaluationFailure": {
       "type": "object",
-      "required": [
-        "flag",
-        "updatedAt"
-      ],
       "properties": {
-        "flag": {
-          "$ref": "#/definitions/flag"
-        },
-        "id": {
-          "type": "integer",
-          "format": "int64",
-          "minimum": 1,
-          "readOnly": true
-        },
-        "updatedAt": {
+        "errorCode": {
           "type": "string",
-          "minLength": 1
+          "enum": [
+            "PARSE_ERROR",
+            "TARGETING_KEY_MISSING",
+            "INVALID_CONTEXT",
+            "FLAG_NOT_FOUND",
+            "GENERAL"
+          ]
         },
-        "updatedBy": {
+        "errorDetails": {
+          "type": "string"
+        },
+        "key": {
           "type": "string"
+        },
+        "reason": {
+          "type": "string",
+          "enum": [
+            "ERROR"
+          ]
         }
       }
     },
-    "flagSnapshotMaxID": {
+    "ofrepEvaluationRequest": {
       "type": "object",
       "properties": {
-        "maxID": {
-          "type": "integer",
-          "format": "int64"
+        "context": {
+          "description": "OpenFeature evaluation context. targetingKey maps to entityID, all other attributes to entityContext.",
+          "type": "object"
         }
       }
     },
-    "health": {
+    "ofrepEvaluationSuccess": {
       "type": "object",
       "properties": {
-        "status": {
+        "key": {
           "type": "string"
+        },
+        "metadata": {
+          "type": "object",
+          "additionalProperties": true,
+          "x-omitempty": true
+        },
+        "reason": {
+          "type": "string",
+          "enum": [
+            "TARGETING_MATCH",
+            "SPLIT",
+            "DISABLED",
+            "DEFAULT"
+          ]
+        },
+        "value": {
+          "description": "the variant attachment, or the variant key when the attachment is empty. Omitted when no variant was assigned (reason DISABLED or DEFAULT), so the provider falls back to the code default.",
+          "x-omitempty": true
+        },
+        "variant": {
+          "type": "string",
+          "x-omitempty": true
         }
       }
     },
@@ -5090,6 +8968,17 @@ func init() {
         }
       }
     },
+    "putMetricRequest": {
+      "type": "object",
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "kind": {
+          "$ref": "#/definitions/metricKind"
+        }
+      }
+    },
     "putSegmentReorderRequest": {
       "type": "object",
       "required": [
@@ -5114,6 +9003,13 @@ func init() {
         "rolloutPercent"
       ],
       "properties": {
+        "constraintGroups": {
+          "description": "replaces the constraint groups of the segment if set; an empty array removes them",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraintGroup"
+          }
+        },
         "description": {
           "type": "string",
           "minLength": 1
@@ -5141,6 +9037,46 @@ func init() {
         }
       }
     },
+    "rolloutDebugLog": {
+      "description": "the rollout decision of a segment whose constraints matched",
+      "type": "object",
+      "properties": {
+        "bucketNum": {
+          "description": "the bucket of the entity, from 0 to 999",
+          "type": "integer",
+          "format": "int64",
+          "x-omitempty": false
+        },
+        "distributions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/distributionDebugLog"
+          }
+        },
+        "reason": {
+          "description": "why the entity was not bucketed, e.g. an empty entityID or no distribution",
+          "type": "string"
+        },
+        "rolledOut": {
+          "description": "whether the entity was assigned a variant",
+          "type": "boolean",
+          "x-omitempty": false
+        },
+        "rolloutPercent": {
+          "type": "integer",
+          "format": "int64",
+          "x-omitempty": false
+        },
+        "variantID": {
+          "description": "the variant of the bucket, assigned if rolledOut",
+          "type": "integer",
+          "format": "int64"
+        },
+        "variantKey": {
+          "type": "string"
+        }
+      }
+    },
     "segment": {
       "type": "object",
       "required": [
@@ -5149,6 +9085,13 @@ func init() {
         "rolloutPercent"
       ],
       "properties": {
+        "constraintGroups": {
+          "description": "constraint groups combining constraints with AND, OR and NOT. The segment matches when all its constraints and all its constraint groups match.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraintGroup"
+          }
+        },
         "constraints": {
           "type": "array",
           "items": {
@@ -5187,9 +9130,31 @@ func init() {
     "segmentDebugLog": {
       "type": "object",
       "properties": {
+        "constraints": {
+          "description": "each constraint of the segment and its result",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraintDebugLog"
+          }
+        },
+        "groups": {
+          "description": "each constraint group of the segment and its result",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraintGroupDebugLog"
+          }
+        },
+        "matched": {
+          "description": "whether the entity context matched all constraints of the segment",
+          "type": "boolean",
+          "x-omitempty": false
+        },
         "msg": {
           "type": "string"
         },
+        "rollout": {
+          "$ref": "#/definitions/rolloutDebugLog"
+        },
         "segmentID": {
           "type": "integer",
           "format": "int64",
@@ -5208,6 +9173,110 @@ func init() {
         }
       }
     },
+    "simulationSegment": {
+      "type": "object",
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "matched": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "percent": {
+          "type": "number",
+          "format": "double"
+        },
+        "rolloutPercent": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "segmentID": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "simulationSwitch": {
+      "type": "object",
+      "properties": {
+        "currentSegmentID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "currentVariantKey": {
+          "type": "string"
+        },
+        "entityID": {
+          "type": "string"
+        },
+        "segmentID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "variantKey": {
+          "type": "string"
+        }
+      }
+    },
+    "simulationSyntheticCorpus": {
+      "description": "a corpus of random entity IDs, all with the same entityType and entityContext",
+      "type": "object",
+      "required": [
+        "count"
+      ],
+      "properties": {
+        "count": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "entityContext": {
+          "type": "object"
+        },
+        "entityType": {
+          "type": "string"
+        },
+        "seed": {
+          "description": "seed for the entity IDs; the same seed gives the same corpus",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "simulationVariant": {
+      "description": "contexts assigned to a variant (or to none, for unassigned); current fields are for the stored flag",
+      "type": "object",
+      "properties": {
+        "count": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "currentCount": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "currentPercent": {
+          "type": "number",
+          "format": "double"
+        },
+        "delta": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "percent": {
+          "type": "number",
+          "format": "double"
+        },
+        "percentDelta": {
+          "type": "number",
+          "format": "double"
+        },
+        "variantKey": {
+          "type": "string"
+        }
+      }
+    },
     "tag": {
       "type": "object",
       "required": [
@@ -5277,6 +9346,14 @@ func init() {
       "description": "Client-reported impressions when a user saw a flag or variant",
       "name": "exposure"
     },
+    {
+      "description": "Metrics are outcomes of entities, attributed to the variants they were assigned",
+      "name": "metric"
+    },
+    {
+      "description": "OpenFeature Remote Evaluation Protocol (OFREP) endpoints",
+      "name": "ofrep"
+    },
     {
       "description": "Check if Flagr is healthy",
       "name": "health"
@@ -5302,7 +9379,9 @@ func init() {
       "name": "Flag Evaluation",
       "tags": [
         "evaluation",
-        "exposure"
+        "exposure",
+        "metric",
+        "ofrep"
       ]
     },
     {
@@ -23,6 +23,8 @@ import (
 	"github.com/openflagr/flagr/swagger_gen/restapi/operations/exposure"
 	"github.com/openflagr/flagr/swagger_gen/restapi/operations/flag"
 	"github.com/openflagr/flagr/swagger_gen/restapi/operations/health"
+	"github.com/openflagr/flagr/swagger_gen/restapi/operations/metric"
+	"github.com/openflagr/flagr/swagger_gen/restapi/operations/ofrep"
 	"github.com/openflagr/flagr/swagger_gen/restapi/operations/segment"
 	"github.com/openflagr/flagr/swagger_gen/restapi/operations/tag"
 	"github.com/openflagr/flagr/swagger_gen/restapi/operations/variant"
@@ -49,6 +51,7 @@ func NewFlagrAPI(spec *loads.Document) *FlagrAPI {
 		JSONConsumer: runtime.JSONConsumer(),
 
 		BinProducer:  runtime.ByteStreamProducer(),
+		CsvProducer:  runtime.CSVProducer(),
 		JSONProducer: runtime.JSONProducer(),
 
 		ConstraintCreateConstraintHandler: constraint.CreateConstraintHandlerFunc(func(params constraint.CreateConstraintParams) middleware.Responder {
@@ -63,6 +66,12 @@ func NewFlagrAPI(spec *loads.Document) *FlagrAPI {
 			return middleware.NotImplemented("operation flag.CreateFlag has not yet been implemented")
 		}),
 
+		MetricCreateMetricHandler: metric.CreateMetricHandlerFunc(func(params metric.CreateMetricParams) middleware.Responder {
+			_ = params
+
+			return middleware.NotImplemented("operation metric.CreateMetric has not yet been implemented")
+		}),
+
 		SegmentCreateSegmentHandler: segment.CreateSegmentHandlerFunc(func(params segment.CreateSegmentParams) middleware.Responder {
 			_ = params
 
@@ -93,6 +102,12 @@ func NewFlagrAPI(spec *loads.Document) *FlagrAPI {
 			return middleware.NotImplemented("operation flag.DeleteFlag has not yet been implemented")
 		}),
 
+		MetricDeleteMetricHandler: metric.DeleteMetricHandlerFunc(func(params metric.DeleteMetricParams) middleware.Responder {
+			_ = params
+
+			return middleware.NotImplemented("operation metric.DeleteMetric has not yet been implemented")
+		}),
+
 		SegmentDeleteSegmentHandler: segment.DeleteSegmentHandlerFunc(func(params segment.DeleteSegmentParams) middleware.Responder {
 			_ = params
 
@@ -141,6 +156,12 @@ func NewFlagrAPI(spec *loads.Document) *FlagrAPI {
 			return middleware.NotImplemented("operation flag.FindFlags has not yet been implemented")
 		}),
 
+		MetricFindMetricsHandler: metric.FindMetricsHandlerFunc(func(params metric.FindMetricsParams) middleware.Responder {
+			_ = params
+
+			return middleware.NotImplemented("operation metric.FindMetrics has not yet been implemented")
+		}),
+
 		SegmentFindSegmentsHandler: segment.FindSegmentsHandlerFunc(func(params segment.FindSegmentsParams) middleware.Responder {
 			_ = params
 
@@ -159,18 +180,42 @@ func NewFlagrAPI(spec *loads.Document) *FlagrAPI {
 			return middleware.NotImplemented("operation variant.FindVariants has not yet been implemented")
 		}),
 
+		DatarGetDatarExportHandler: datar.GetDatarExportHandlerFunc(func(params datar.GetDatarExportParams) middleware.Responder {
+			_ = params
+
+			return middleware.NotImplemented("operation datar.GetDatarExport has not yet been implemented")
+		}),
+
+		DatarGetDatarFlagMetricsHandler: datar.GetDatarFlagMetricsHandlerFunc(func(params datar.GetDatarFlagMetricsParams) middleware.Responder {
+			_ = params
+
+			return middleware.NotImplemented("operation datar.GetDatarFlagMetrics has not yet been implemented")
+		}),
+
 		DatarGetDatarFlagSummaryHandler: datar.GetDatarFlagSummaryHandlerFunc(func(params datar.GetDatarFlagSummaryParams) middleware.Responder {
 			_ = params
 
 			return middleware.NotImplemented("operation datar.GetDatarFlagSummary has not yet been implemented")
 		}),
 
+		DatarGetDatarFlagTimeSeriesHandler: datar.GetDatarFlagTimeSeriesHandlerFunc(func(params datar.GetDatarFlagTimeSeriesParams) middleware.Responder {
+			_ = params
+
+			return middleware.NotImplemented("operation datar.GetDatarFlagTimeSeries has not yet been implemented")
+		}),
+
 		DatarGetDatarSummaryHandler: datar.GetDatarSummaryHandlerFunc(func(params datar.GetDatarSummaryParams) middleware.Responder {
 			_ = params
 
 			return middleware.NotImplemented("operation datar.GetDatarSummary has not yet been implemented")
 		}),
 
+		DatarGetDatarTagSummaryHandler: datar.GetDatarTagSummaryHandlerFunc(func(params datar.GetDatarTagSummaryParams) middleware.Responder {
+			_ = params
+
+			return middleware.NotImplemented("operation datar.GetDatarTagSummary has not yet been implemented")
+		}),
+
 		EvaluationGetEvaluationHandler: evaluation.GetEvaluationHandlerFunc(func(params evaluation.GetEvaluationParams) middleware.Responder {
 			_ = params
 
@@ -183,6 +228,12 @@ func NewFlagrAPI(spec *loads.Document) *FlagrAPI {
 			return middleware.NotImplemented("operation evaluation.GetEvaluationBatch has not yet been implemented")
 		}),
 
+		EvaluationGetEvaluationBootstrapHandler: evaluation.GetEvaluationBootstrapHandlerFunc(func(params evaluation.GetEvaluationBootstrapParams) middleware.Responder {
+			_ = params
+
+			return middleware.NotImplemented("operation evaluation.GetEvaluationBootstrap has not yet been implemented")
+		}),
+
 		ExportGetExportEvalCacheJSONHandler: export.GetExportEvalCacheJSONHandlerFunc(func(params export.GetExportEvalCacheJSONParams) middleware.Responder {
 			_ = params
 
@@ -225,6 +276,24 @@ func NewFlagrAPI(spec *loads.Document) *FlagrAPI {
 			return middleware.NotImplemented("operation health.GetHealth has not yet been implemented")
 		}),
 
+		OfrepOfrepEvaluateFlagHandler: ofrep.OfrepEvaluateFlagHandlerFunc(func(params ofrep.OfrepEvaluateFlagParams) middleware.Responder {
+			_ = params
+
+			return middleware.NotImplemented("operation ofrep.OfrepEvaluateFlag has not yet been implemented")
+		}),
+
+		OfrepOfrepEvaluateFlagsBulkHandler: ofrep.OfrepEvaluateFlagsBulkHandlerFunc(func(params ofrep.OfrepEvaluateFlagsBulkParams) middleware.Responder {
+			_ = params
+
+			return middleware.NotImplemented("operation ofrep.OfrepEvaluateFlagsBulk has not yet been implemented")
+		}),
+
+		DatarPostDatarFlagExperimentHandler: datar.PostDatarFlagExperimentHandlerFunc(func(params datar.PostDatarFlagExperimentParams) middleware.Responder {
+			_ = params
+
+			return middleware.NotImplemented("operation datar.PostDatarFlagExperiment has not yet been implemented")
+		}),
+
 		EvaluationPostEvaluationHandler: evaluation.PostEvaluationHandlerFunc(func(params evaluation.PostEvaluationParams) middleware.Responder {
 			_ = params
 
@@ -237,12 +306,36 @@ func NewFlagrAPI(spec *loads.Document) *FlagrAPI {
 			return middleware.NotImplemented("operation evaluation.PostEvaluationBatch has not yet been implemented")
 		}),
 
+		EvaluationPostEvaluationBootstrapHandler: evaluation.PostEvaluationBootstrapHandlerFunc(func(params evaluation.PostEvaluationBootstrapParams) middleware.Responder {
+			_ = params
+
+			return middleware.NotImplemented("operation evaluation.PostEvaluationBootstrap has not yet been implemented")
+		}),
+
+		EvaluationPostEvaluationPreviewHandler: evaluation.PostEvaluationPreviewHandlerFunc(func(params evaluation.PostEvaluationPreviewParams) middleware.Responder {
+			_ = params
+
+			return middleware.NotImplemented("operation evaluation.PostEvaluationPreview has not yet been implemented")
+		}),
+
+		EvaluationPostEvaluationSimulationHandler: evaluation.PostEvaluationSimulationHandlerFunc(func(params evaluation.PostEvaluationSimulationParams) middleware.Responder {
+			_ = params
+
+			return middleware.NotImplemented("operation evaluation.PostEvaluationSimulation has not yet been implemented")
+		}),
+
 		ExposurePostExposuresHandler: exposure.PostExposuresHandlerFunc(func(params exposure.PostExposuresParams) middleware.Responder {
 			_ = params
 
 			return middleware.NotImplemented("operation exposure.PostExposures has not yet been implemented")
 		}),
 
+		MetricPostMetricEventsHandler: metric.PostMetricEventsHandlerFunc(func(params metric.PostMetricEventsParams) middleware.Responder {
+			_ = params
+
+			return middleware.NotImplemented("operation metric.PostMetricEvents has not yet been implemented")
+		}),
+
 		ConstraintPutConstraintHandler: constraint.PutConstraintHandlerFunc(func(params constraint.PutConstraintParams) middleware.Responder {
 			_ = params
 
@@ -261,6 +354,12 @@ func NewFlagrAPI(spec *loads.Document) *FlagrAPI {
 			return middleware.NotImplemented("operation flag.PutFlag has not yet been implemented")
 		}),
 
+		MetricPutMetricHandler: metric.PutMetricHandlerFunc(func(params metric.PutMetricParams) middleware.Responder {
+			_ = params
+
+			return middleware.NotImplemented("operation metric.PutMetric has not yet been implemented")
+		}),
+
 		SegmentPutSegmentHandler: segment.PutSegmentHandlerFunc(func(params segment.PutSegmentParams) middleware.Responder {
 			_ = params
 
@@ -326,14 +425,20 @@ type FlagrAPI struct {
 	// BinProducer registers a producer for the following mime types:
 	//   - application/octet-stream
 	BinProducer runtime.Producer
+	// CsvProducer registers a producer for the following mime types:
+	//   - text/csv
+	CsvProducer runtime.Producer
 	// JSONProducer registers a producer for the following mime types:
 	//   - application/json
+	//   - application/x-ndjson
 	JSONProducer runtime.Producer
 
 	// ConstraintCreateConstraintHandler sets the operation handler for the create constraint operation
 	ConstraintCreateConstraintHandler constraint.CreateConstraintHandler
 	// FlagCreateFlagHandler sets the operation handler for the create flag operation
 	FlagCreateFlagHandler flag.CreateFlagHandler
+	// MetricCreateMetricHandler sets the operation handler for the create metric operation
+	MetricCreateMetricHandler metric.CreateMetricHandler
 	// SegmentCreateSegmentHandler sets the operation handler for the create segment operation
 	SegmentCreateSegmentHandler segment.CreateSegmentHandler
 	// TagCreateTagHandler sets the operation handler for the create tag operation
@@ -344,6 +449,8 @@ type FlagrAPI struct {
 	ConstraintDeleteConstraintHandler constraint.DeleteConstraintHandler
 	// FlagDeleteFlagHandler sets the operation handler for the delete flag operation
 	FlagDeleteFlagHandler flag.DeleteFlagHandler
+	// MetricDeleteMetricHandler sets the operation handler for the delete metric operation
+	MetricDeleteMetricHandler metric.DeleteMetricHandler
 	// SegmentDeleteSegmentHandler sets the operation handler for the delete segment operation
 	SegmentDeleteSegmentHandler segment.DeleteSegmentHandler
 	// TagDeleteTagHandler sets the operation handler for the delete tag operation
@@ -360,20 +467,32 @@ type FlagrAPI struct {
 	DistributionFindDistributionsHandler distribution.FindDistributionsHandler
 	// FlagFindFlagsHandler sets the operation handler for the find flags operation
 	FlagFindFlagsHandler flag.FindFlagsHandler
+	// MetricFindMetricsHandler sets the operation handler for the find metrics operation
+	MetricFindMetricsHandler metric.FindMetricsHandler
 	// SegmentFindSegmentsHandler sets the operation handler for the find segments operation
 	SegmentFindSegmentsHandler segment.FindSegmentsHandler
 	// TagFindTagsHandler sets the operation handler for the find tags operation
 	TagFindTagsHandler tag.FindTagsHandler
 	// VariantFindVariantsHandler sets the operation handler for the find variants operation
 	VariantFindVariantsHandler variant.FindVariantsHandler
+	// DatarGetDatarExportHandler sets the operation handler for the get datar export operation
+	DatarGetDatarExportHandler datar.GetDatarExportHandler
+	// DatarGetDatarFlagMetricsHandler sets the operation handler for the get datar flag metrics operation
+	DatarGetDatarFlagMetricsHandler datar.GetDatarFlagMetricsHandler
 	// DatarGetDatarFlagSummaryHandler sets the operation handler for the get datar flag summary operation
 	DatarGetDatarFlagSummaryHandler datar.GetDatarFlagSummaryHandler
+	// DatarGetDatarFlagTimeSeriesHandler sets the operation handler for the get datar flag time series operation
+	DatarGetDatarFlagTimeSeriesHandler datar.GetDatarFlagTimeSeriesHandler
 	// DatarGetDatarSummaryHandler sets the operation handler for the get datar summary operation
 	DatarGetDatarSummaryHandler datar.GetDatarSummaryHandler
+	// DatarGetDatarTagSummaryHandler sets the operation handler for the get datar tag summary operation
+	DatarGetDatarTagSummaryHandler datar.GetDatarTagSummaryHandler
 	// EvaluationGetEvaluationHandler sets the operation handler for the get evaluation operation
 	EvaluationGetEvaluationHandler evaluation.GetEvaluationHandler
 	// EvaluationGetEvaluationBatchHandler sets the operation handler for the get evaluation batch operation
 	EvaluationGetEvaluationBatchHandler evaluation.GetEvaluationBatchHandler
+	// EvaluationGetEvaluationBootstrapHandler sets the operation handler for the get evaluation bootstrap operation
+	EvaluationGetEvaluationBootstrapHandler evaluation.GetEvaluationBootstrapHandler
 	// ExportGetExportEvalCacheJSONHandler sets the operation handler for the get export eval cache JSON operation
 	ExportGetExportEvalCacheJSONHandler export.GetExportEvalCacheJSONHandler
 	// ExportGetExportSqliteHandler sets the operation handler for the get export sqlite operation
@@ -388,18 +507,34 @@ type FlagrAPI struct {
 	FlagGetFlagSnapshotsHandler flag.GetFlagSnapshotsHandler
 	// HealthGetHealthHandler sets the operation handler for the get health operation
 	HealthGetHealthHandler health.GetHealthHandler
+	// OfrepOfrepEvaluateFlagHandler sets the operation handler for the ofrep evaluate flag operation
+	OfrepOfrepEvaluateFlagHandler ofrep.OfrepEvaluateFlagHandler
+	// OfrepOfrepEvaluateFlagsBulkHandler sets the operation handler for the ofrep evaluate flags bulk operation
+	OfrepOfrepEvaluateFlagsBulkHandler ofrep.OfrepEvaluateFlagsBulkHandler
+	// DatarPostDatarFlagExperimentHandler sets the operation handler for the post datar flag experiment operation
+	DatarPostDatarFlagExperimentHandler datar.PostDatarFlagExperimentHandler
 	// EvaluationPostEvaluationHandler sets the operation handler for the post evaluation operation
 	EvaluationPostEvaluationHandler evaluation.PostEvaluationHandler
 	// EvaluationPostEvaluationBatchHandler sets the operation handler for the post evaluation batch operation
 	EvaluationPostEvaluationBatchHandler evaluation.PostEvaluationBatchHandler
+	// EvaluationPostEvaluationBootstrapHandler sets the operation handler for the post evaluation bootstrap operation
+	EvaluationPostEvaluationBootstrapHandler evaluation.PostEvaluationBootstrapHandler
+	// EvaluationPostEvaluationPreviewHandler sets the operation handler for the post evaluation preview operation
+	EvaluationPostEvaluationPreviewHandler evaluation.PostEvaluationPreviewHandler
+	// EvaluationPostEvaluationSimulationHandler sets the operation handler for the post evaluation simulation operation
+	EvaluationPostEvaluationSimulationHandler evaluation.PostEvaluationSimulationHandler
 	// ExposurePostExposuresHandler sets the operation handler for the post exposures operation
 	ExposurePostExposuresHandler exposure.PostExposuresHandler
+	// MetricPostMetricEventsHandler sets the operation handler for the post metric events operation
+	MetricPostMetricEventsHandler metric.PostMetricEventsHandler
 	// ConstraintPutConstraintHandler sets the operation handler for the put constraint operation
 	ConstraintPutConstraintHandler constraint.PutConstraintHandler
 	// DistributionPutDistributionsHandler sets the operation handler for the put distributions operation
 	DistributionPutDistributionsHandler distribution.PutDistributionsHandler
 	// FlagPutFlagHandler sets the operation handler for the put flag operation
 	FlagPutFlagHandler flag.PutFlagHandler
+	// MetricPutMetricHandler sets the operation handler for the put metric operation
+	MetricPutMetricHandler metric.PutMetricHandler
 	// SegmentPutSegmentHandler sets the operation handler for the put segment operation
 	SegmentPutSegmentHandler segment.PutSegmentHandler
 	// SegmentPutSegmentsReorderHandler sets the operation handler for the put segments reorder operation
@@ -486,6 +621,9 @@ func (o *FlagrAPI) Validate() error {
 	if o.BinProducer == nil {
 		unregistered = append(unregistered, "BinProducer")
 	}
+	if o.CsvProducer == nil {
+		unregistered = append(unregistered, "CsvProducer")
+	}
 	if o.JSONProducer == nil {
 		unregistered = append(unregistered, "JSONProducer")
 	}
@@ -496,6 +634,9 @@ func (o *FlagrAPI) Validate() error {
 	if o.FlagCreateFlagHandler == nil {
 		unregistered = append(unregistered, "flag.CreateFlagHandler")
 	}
+	if o.MetricCreateMetricHandler == nil {
+		unregistered = append(unregistered, "metric.CreateMetricHandler")
+	}
 	if o.SegmentCreateSegmentHandler == nil {
 		unregistered = append(unregistered, "segment.CreateSegmentHandler")
 	}
@@ -511,6 +652,9 @@ func (o *FlagrAPI) Validate() error {
 	if o.FlagDeleteFlagHandler == nil {
 		unregistered = append(unregistered, "flag.DeleteFlagHandler")
 	}
+	if o.MetricDeleteMetricHandler == nil {
+		unregistered = append(unregistered, "metric.DeleteMetricHandler")
+	}
 	if o.SegmentDeleteSegmentHandler == nil {
 		unregistered = append(unregistered, "segment.DeleteSegmentHandler")
 	}
@@ -535,6 +679,9 @@ func (o *FlagrAPI) Validate() error {
 	if o.FlagFindFlagsHandler == nil {
 		unregistered = append(unregistered, "flag.FindFlagsHandler")
 	}
+	if o.MetricFindMetricsHandler == nil {
+		unregistered = append(unregistered, "metric.FindMetricsHandler")
+	}
 	if o.SegmentFindSegmentsHandler == nil {
 		unregistered = append(unregistered, "segment.FindSegmentsHandler")
 	}
@@ -544,18 +691,33 @@ func (o *FlagrAPI) Validate() error {
 	if o.VariantFindVariantsHandler == nil {
 		unregistered = append(unregistered, "variant.FindVariantsHandler")
 	}
+	if o.DatarGetDatarExportHandler == nil {
+		unregistered = append(unregistered, "datar.GetDatarExportHandler")
+	}
+	if o.DatarGetDatarFlagMetricsHandler == nil {
+		unregistered = append(unregistered, "datar.GetDatarFlagMetricsHandler")
+	}
 	if o.DatarGetDatarFlagSummaryHandler == nil {
 		unregistered = append(unregistered, "datar.GetDatarFlagSummaryHandler")
 	}
+	if o.DatarGetDatarFlagTimeSeriesHandler == nil {
+		unregistered = append(unregistered, "datar.GetDatarFlagTimeSeriesHandler")
+	}
 	if o.DatarGetDatarSummaryHandler == nil {
 		unregistered = append(unregistered, "datar.GetDatarSummaryHandler")
 	}
+	if o.DatarGetDatarTagSummaryHandler == nil {
+		unregistered = append(unregistered, "datar.GetDatarTagSummaryHandler")
+	}
 	if o.EvaluationGetEvaluationHandler == nil {
 		unregistered = append(unregistered, "evaluation.GetEvaluationHandler")
 	}
 	if o.EvaluationGetEvaluationBatchHandler == nil {
 		unregistered = append(unregistered, "evaluation.GetEvaluationBatchHandler")
 	}
+	if o.EvaluationGetEvaluationBootstrapHandler == nil {
+		unregistered = append(unregistered, "evaluation.GetEvaluationBootstrapHandler")
+	}
 	if o.ExportGetExportEvalCacheJSONHandler == nil {
 		unregistered = append(unregistered, "export.GetExportEvalCacheJSONHandler")
 	}
@@ -577,15 +739,36 @@ func (o *FlagrAPI) Validate() error {
 	if o.HealthGetHealthHandler == nil {
 		unregistered = append(unregistered, "health.GetHealthHandler")
 	}
+	if o.OfrepOfrepEvaluateFlagHandler == nil {
+		unregistered = append(unregistered, "ofrep.OfrepEvaluateFlagHandler")
+	}
+	if o.OfrepOfrepEvaluateFlagsBulkHandler == nil {
+		unregistered = append(unregistered, "ofrep.OfrepEvaluateFlagsBulkHandler")
+	}
+	if o.DatarPostDatarFlagExperimentHandler == nil {
+		unregistered = append(unregistered, "datar.PostDatarFlagExperimentHandler")
+	}
 	if o.EvaluationPostEvaluationHandler == nil {
 		unregistered = append(unregistered, "evaluation.PostEvaluationHandler")
 	}
 	if o.EvaluationPostEvaluationBatchHandler == nil {
 		unregistered = append(unregistered, "evaluation.PostEvaluationBatchHandler")
 	}
+	if o.EvaluationPostEvaluationBootstrapHandler == nil {
+		unregistered = append(unregistered, "evaluation.PostEvaluationBootstrapHandler")
+	}
+	if o.EvaluationPostEvaluationPreviewHandler == nil {
+		unregistered = append(unregistered, "evaluation.PostEvaluationPreviewHandler")
+	}
+	if o.EvaluationPostEvaluationSimulationHandler == nil {
+		unregistered = append(unregistered, "evaluation.PostEvaluationSimulationHandler")
+	}
 	if o.ExposurePostExposuresHandler == nil {
 		unregistered = append(unregistered, "exposure.PostExposuresHandler")
 	}
+	if o.MetricPostMetricEventsHandler == nil {
+		unregistered = append(unregistered, "metric.PostMetricEventsHandler")
+	}
 	if o.ConstraintPutConstraintHandler == nil {
 		unregistered = append(unregistered, "constraint.PutConstraintHandler")
 	}
@@ -595,6 +778,9 @@ func (o *FlagrAPI) Validate() error {
 	if o.FlagPutFlagHandler == nil {
 		unregistered = append(unregistered, "flag.PutFlagHandler")
 	}
+	if o.MetricPutMetricHandler == nil {
+		unregistered = append(unregistered, "metric.PutMetricHandler")
+	}
 	if o.SegmentPutSegmentHandler == nil {
 		unregistered = append(unregistered, "segment.PutSegmentHandler")
 	}
@@ -660,8 +846,12 @@ func (o *FlagrAPI) ProducersFor(mediaTypes []string) map[string]runtime.Producer
 		switch mt {
 		case "application/octet-stream":
 			result["application/octet-stream"] = o.BinProducer
+		case "text/csv":
+			result["text/csv"] = o.CsvProducer
 		case "application/json":
 			result["application/json"] = o.JSONProducer
+		case "application/x-ndjson":
+			result["application/x-ndjson"] = o.JSONProducer
 		}
 
 		if p, ok := o.customProducers[mt]; ok {
@@ -714,6 +904,10 @@ func (o *FlagrAPI) initHandlerCache() {
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
+	o.handlers["POST"]["/metrics"] = metric.NewCreateMetric(o.context, o.MetricCreateMetricHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
 	o.handlers["POST"]["/flags/{flagID}/segments"] = segment.NewCreateSegment(o.context, o.SegmentCreateSegmentHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
@@ -734,6 +928,10 @@ func (o *FlagrAPI) initHandlerCache() {
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
+	o.handlers["DELETE"]["/metrics/{metricID}"] = metric.NewDeleteMetric(o.context, o.MetricDeleteMetricHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
 	o.handlers["DELETE"]["/flags/{flagID}/segments/{segmentID}"] = segment.NewDeleteSegment(o.context, o.SegmentDeleteSegmentHandler)
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
@@ -766,6 +964,10 @@ func (o *FlagrAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/metrics"] = metric.NewFindMetrics(o.context, o.MetricFindMetricsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/flags/{flagID}/segments"] = segment.NewFindSegments(o.context, o.SegmentFindSegmentsHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -778,14 +980,30 @@ func (o *FlagrAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/datar/export"] = datar.NewGetDatarExport(o.context, o.DatarGetDatarExportHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/datar/flags/{flagID}/metrics"] = datar.NewGetDatarFlagMetrics(o.context, o.DatarGetDatarFlagMetricsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/datar/flags/{flagID}/summary"] = datar.NewGetDatarFlagSummary(o.context, o.DatarGetDatarFlagSummaryHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/datar/flags/{flagID}/timeseries"] = datar.NewGetDatarFlagTimeSeries(o.context, o.DatarGetDatarFlagTimeSeriesHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/datar/summary"] = datar.NewGetDatarSummary(o.context, o.DatarGetDatarSummaryHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/datar/tags/{tag}/summary"] = datar.NewGetDatarTagSummary(o.context, o.DatarGetDatarTagSummaryHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/evaluation"] = evaluation.NewGetEvaluation(o.context, o.EvaluationGetEvaluationHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -794,6 +1012,10 @@ func (o *FlagrAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/evaluation/bootstrap"] = evaluation.NewGetEvaluationBootstrap(o.context, o.EvaluationGetEvaluationBootstrapHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/export/eval_cache/json"] = export.NewGetExportEvalCacheJSON(o.context, o.ExportGetExportEvalCacheJSONHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -822,6 +1044,18 @@ func (o *FlagrAPI) initHandlerCache() {
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
+	o.handlers["POST"]["/ofrep/v1/evaluate/flags/{key}"] = ofrep.NewOfrepEvaluateFlag(o.context, o.OfrepOfrepEvaluateFlagHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/ofrep/v1/evaluate/flags"] = ofrep.NewOfrepEvaluateFlagsBulk(o.context, o.OfrepOfrepEvaluateFlagsBulkHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/datar/flags/{flagID}/experiment"] = datar.NewPostDatarFlagExperiment(o.context, o.DatarPostDatarFlagExperimentHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
 	o.handlers["POST"]["/evaluation"] = evaluation.NewPostEvaluation(o.context, o.EvaluationPostEvaluationHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
@@ -830,7 +1064,23 @@ func (o *FlagrAPI) initHandlerCache() {
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
+	o.handlers["POST"]["/evaluation/bootstrap"] = evaluation.NewPostEvaluationBootstrap(o.context, o.EvaluationPostEvaluationBootstrapHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/evaluation/preview"] = evaluation.NewPostEvaluationPreview(o.context, o.EvaluationPostEvaluationPreviewHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/evaluation/simulation"] = evaluation.NewPostEvaluationSimulation(o.context, o.EvaluationPostEvaluationSimulationHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
 	o.handlers["POST"]["/exposures"] = exposure.NewPostExposures(o.context, o.ExposurePostExposuresHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/metrics/events"] = metric.NewPostMetricEvents(o.context, o.MetricPostMetricEventsHandler)
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
@@ -846,6 +1096,10 @@ func (o *FlagrAPI) initHandlerCache() {
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
+	o.handlers["PUT"]["/metrics/{metricID}"] = metric.NewPutMetric(o.context, o.MetricPutMetricHandler)
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
 	o.handlers["PUT"]["/flags/{flagID}/segments/{segmentID}"] = segment.NewPutSegment(o.context, o.SegmentPutSegmentHandler)
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)